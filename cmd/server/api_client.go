@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// apiClient calls the questionnaire server's API for the command line tools
+type apiClient struct {
+	server string
+	user   string // sent as X-Forwarded-User
+	http   *http.Client
+}
+
+// newAPIClient creates a client for the server at the given base URL
+func newAPIClient(server, user string) *apiClient {
+	return &apiClient{
+		server: strings.TrimRight(server, "/"),
+		user:   user,
+		http:   &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// do sends a request with an optional body and decodes a JSON response into out unless it is
+// nil. Error responses are returned with the server's error message.
+func (c *apiClient) do(method, path, contentType string, body []byte, out interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	
+	req, err := http.NewRequest(method, c.server+path, reader)
+	if err != nil {
+		return fmt.Errorf("invalid server URL: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.user != "" {
+		req.Header.Set("X-Forwarded-User", c.user)
+	}
+	
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiError struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiError) == nil && apiError.Error != "" {
+			return fmt.Errorf("%s", apiError.Error)
+		}
+		return fmt.Errorf("%s %s returned %s", method, path, resp.Status)
+	}
+	
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
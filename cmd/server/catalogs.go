@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+	"strings"
+	"text/tabwriter"
+)
+
+// catalogsInstallUsage is the usage of "catalogs install"
+const catalogsInstallUsage = "usage: server catalogs install [-data dir] [-replace] <catalog> | server catalogs install [-data dir] [-replace] -git url [-ref tag] [-path dir] [-checksum sha256:...]"
+
+// runCatalogsCommand implements "catalogs list", "catalogs install <id>", "catalogs install
+// -git <url>", "catalogs checksum <path>" and "catalogs diff <base> <target>" and returns the
+// process exit code
+func runCatalogsCommand(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: server catalogs list | server catalogs install [-data dir] [-replace] <catalog> | server catalogs install -git url [-ref tag] [-path dir] [-checksum sha256:...] | server catalogs checksum <path> | server catalogs diff [-data dir] [-json] <base> <target>")
+		return 2
+	}
+	
+	switch args[0] {
+	case "list":
+		catalogs, err := services.BuiltinCatalogs()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(table, "ID\tQUESTIONS\tNAME")
+		for _, catalog := range catalogs {
+			fmt.Fprintf(table, "%s\t%d\t%s\n", catalog.ID, catalog.QuestionCount, catalog.Name)
+		}
+		table.Flush()
+		return 0
+	
+	case "install":
+		flags := flag.NewFlagSet("catalogs install", flag.ContinueOnError)
+		dataDir := flags.String("data", getEnvStr("DATA_DIR", "./data"), "Data directory")
+		replace := flags.Bool("replace", false, "Overwrite existing questions with the same ID")
+		var source models.GitCatalogSource
+		flags.StringVar(&source.URL, "git", "", "Install from this Git repository instead of a built-in catalog")
+		flags.StringVar(&source.Ref, "ref", "", "Tag or branch of the Git repository (default branch if empty)")
+		flags.StringVar(&source.Path, "path", "", "Directory or file of the catalog within the Git repository")
+		flags.StringVar(&source.Checksum, "checksum", "", "Checksum the catalog files must have, from \"catalogs checksum\"")
+		if err := flags.Parse(args[1:]); err != nil {
+			return 2
+		}
+		if (source.URL == "") != (flags.NArg() == 1) || flags.NArg() > 1 {
+			fmt.Fprintln(os.Stderr, catalogsInstallUsage)
+			return 2
+		}
+		
+		store, err := storage.NewFileStorage(*dataDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create storage: %v\n", err)
+			return 1
+		}
+		
+		questionService := services.NewQuestionService(store)
+		var result *models.CatalogInstallResult
+		if source.URL != "" {
+			result, err = questionService.InstallGitCatalog(context.Background(), source, *replace)
+		} else {
+			result, err = questionService.InstallCatalog(context.Background(), flags.Arg(0), *replace)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to install catalog %s%s: %v\n", flags.Arg(0), source.URL, err)
+			return 1
+		}
+		
+		fmt.Printf("Installed %d, replaced %d and skipped %d questions of catalog %s\n",
+			len(result.Installed), len(result.Replaced), len(result.Skipped), result.CatalogID)
+		if result.Commit != "" {
+			fmt.Printf("Commit %s, checksum %s\n", result.Commit, result.Checksum)
+		}
+		return 0
+	
+	case "checksum":
+		if len(args) != 2 {
+			fmt.Fprintln(os.Stderr, "usage: server catalogs checksum <path>")
+			return 2
+		}
+		checksum, err := services.CatalogChecksum(args[1])
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		fmt.Println(checksum)
+		return 0
+	
+	case "diff":
+		return runCatalogDiff(args[1:])
+	
+	default:
+		fmt.Fprintf(os.Stderr, "unknown catalogs command %q\n", args[0])
+		return 2
+	}
+}
+
+// runCatalogDiff compares two catalog versions, each a catalog file, a directory of them or a
+// built-in catalog ID. With -data, it also estimates the impact on the reports stored there.
+func runCatalogDiff(args []string) int {
+	flags := flag.NewFlagSet("catalogs diff", flag.ContinueOnError)
+	dataDir := flags.String("data", "", "Data directory whose reports to estimate the impact on")
+	asJSON := flags.Bool("json", false, "Print the diff as JSON")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: server catalogs diff [-data dir] [-json] <base> <target>")
+		return 2
+	}
+	
+	base, err := services.LoadCatalog(flags.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	target, err := services.LoadCatalog(flags.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	
+	diff := services.DiffCatalogs(base, target)
+	if *dataDir != "" {
+		store, err := storage.NewFileStorage(*dataDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create storage: %v\n", err)
+			return 1
+		}
+		
+		ctx := context.Background()
+		reports, err := store.ListReports(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		scoring, err := store.GetScoringConfig(ctx)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if scoring == nil {
+			scoring = services.DefaultScoringConfig()
+		}
+		diff.Impact = services.EstimateCatalogImpact(reports, target, scoring)
+	}
+	
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(diff)
+		return 0
+	}
+	printCatalogDiff(diff)
+	return 0
+}
+
+// printCatalogDiff prints one line per added, removed or changed question, followed by the
+// estimated impact if there is one
+func printCatalogDiff(diff *models.CatalogDiff) {
+	for _, question := range diff.Added {
+		fmt.Printf("+ %s (%s, weight %d): %s\n", question.QuestionID, question.Category, question.Weight, question.Text)
+	}
+	for _, question := range diff.Removed {
+		fmt.Printf("- %s (%s, weight %d): %s\n", question.QuestionID, question.Category, question.Weight, question.Text)
+	}
+	for _, question := range diff.Changed {
+		var changes []string
+		if question.Weight != nil {
+			changes = append(changes, fmt.Sprintf("weight %d -> %d", question.Weight.From, question.Weight.To))
+		}
+		for _, points := range question.Points {
+			changes = append(changes, fmt.Sprintf("%s points %d -> %d", points.OptionID, points.From, points.To))
+		}
+		for _, rename := range question.RenamedOptions {
+			changes = append(changes, fmt.Sprintf("%s renamed %q -> %q", rename.OptionID, rename.From, rename.To))
+		}
+		if len(question.AddedOptions) > 0 {
+			changes = append(changes, "added options "+strings.Join(question.AddedOptions, ", "))
+		}
+		if len(question.RemovedOptions) > 0 {
+			changes = append(changes, "removed options "+strings.Join(question.RemovedOptions, ", "))
+		}
+		if len(question.Fields) > 0 {
+			changes = append(changes, "changed "+strings.Join(question.Fields, ", "))
+		}
+		fmt.Printf("~ %s: %s\n", question.QuestionID, strings.Join(changes, "; "))
+	}
+	fmt.Printf("%d added, %d removed, %d changed questions\n", len(diff.Added), len(diff.Removed), len(diff.Changed))
+	
+	impact := diff.Impact
+	if impact == nil {
+		return
+	}
+	fmt.Printf("Impact: %d of %d reports change score (average %+.1f points), %d change grade\n",
+		impact.Changed, impact.Reports, impact.AverageDelta, impact.GradeChanges)
+	for _, report := range impact.Assessments {
+		fmt.Printf("  %s (%s): %d%% -> %d%%", report.AssessmentID, report.ApplicationID, report.ScorePercent, report.NewScorePercent)
+		if report.Grade != report.NewGrade {
+			fmt.Printf(", grade %s -> %s", report.Grade, report.NewGrade)
+		}
+		fmt.Println()
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"questionnaire-app/internal/kube"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+const inspectUsage = "usage: server inspect [-kubeconfig file] [-context name] [-namespace ns] [-selector labels] [-o file] [-server url] [-user name] [-application id | -assessment id]"
+
+// runInspectCommand implements "inspect": it reads an application's workloads from a live
+// cluster and either prints the detected signals or creates a draft assessment with
+// suggested answers on the server. It returns the process exit code.
+func runInspectCommand(args []string) int {
+	flags := flag.NewFlagSet("inspect", flag.ContinueOnError)
+	kubeconfig := flags.String("kubeconfig", kube.DefaultKubeconfigPath(), "Kubeconfig file (the pod's service account if missing)")
+	kubeContext := flags.String("context", "", "Kubeconfig context (the current context if empty)")
+	namespace := flags.String("namespace", "", "Namespace of the application's workloads (the context's namespace if empty)")
+	selector := flags.String("selector", "", "Label selector of the application's workloads, e.g. app=billing")
+	output := flags.String("o", "", "Write the collected objects as JSON to this file")
+	server := flags.String("server", getEnvStr("QUESTIONNAIRE_SERVER", "http://localhost:8080"), "Base URL of the questionnaire server")
+	user := flags.String("user", getEnvStr("QUESTIONNAIRE_USER", ""), "User sent in X-Forwarded-User")
+	applicationID := flags.String("application", "", "Start a draft assessment of this application with suggested answers")
+	assessmentID := flags.String("assessment", "", "Add suggested answers to this existing assessment")
+	timeout := flags.Duration("timeout", time.Minute, "Maximum duration of the cluster inspection")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, inspectUsage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 || *applicationID != "" && *assessmentID != "" {
+		flags.Usage()
+		return 2
+	}
+	
+	config, err := kube.LoadConfig(*kubeconfig, *kubeContext)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	if *namespace == "" {
+		*namespace = config.Namespace
+	}
+	if *namespace == "" {
+		*namespace = "default"
+	}
+	
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+	
+	document, err := collectWorkloads(ctx, kube.NewClient(config), *namespace, *selector)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	
+	if *output != "" {
+		if err := os.WriteFile(*output, document, 0644); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", *output, err)
+			return 1
+		}
+	}
+	
+	// Without a target on the server only show what was detected
+	if *applicationID == "" && *assessmentID == "" {
+		signals, _, err := services.ParseClusterScan(services.ScanFormatManifests, document)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		printSignals(signals)
+		return 0
+	}
+	
+	client := newAPIClient(*server, *user)
+	if *applicationID != "" {
+		body, _ := json.Marshal(map[string]string{"applicationId": *applicationID})
+		var assessment models.Assessment
+		if err := client.do(http.MethodPost, "/api/assessments", "application/json", body, &assessment); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to start assessment: %v\n", err)
+			return 1
+		}
+		*assessmentID = assessment.ID
+		fmt.Printf("Started draft assessment %s of application %s\n", assessment.ID, *applicationID)
+	}
+	
+	var result models.PrefillResult
+	path := "/api/assessments/" + url.PathEscape(*assessmentID) + "/prefill/cluster-scan?format=" + services.ScanFormatManifests
+	if err := client.do(http.MethodPost, path, "application/json", document, &result); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to suggest answers: %v\n", err)
+		return 1
+	}
+	
+	printSignals(result.Signals)
+	fmt.Printf("\n%d suggested answers await confirmation in assessment %s\n", len(result.Suggestions), *assessmentID)
+	return 0
+}
+
+// collectWorkloads lists the inspected resources of a namespace as a Kubernetes List document.
+// Resources the cluster does not serve or the user may not list are skipped with a warning.
+func collectWorkloads(ctx context.Context, client *kube.Client, namespace, selector string) ([]byte, error) {
+	items := []map[string]interface{}{}
+	for _, resource := range kube.WorkloadResources {
+		objects, err := client.List(ctx, resource, namespace, selector)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, err
+			}
+			fmt.Fprintf(os.Stderr, "Skipping %s: %v\n", resource.Plural, err)
+			continue
+		}
+		items = append(items, objects...)
+	}
+	
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no workloads found in namespace %s matching %q", namespace, selector)
+	}
+	
+	return json.Marshal(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	})
+}
+
+// printSignals prints one line per detected signal with its evidence
+func printSignals(signals []models.Signal) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SIGNAL\tEVIDENCE")
+	for _, signal := range signals {
+		fmt.Fprintf(table, "%s\t%s\n", signal.Name, strings.Join(signal.Evidence, ", "))
+	}
+	table.Flush()
+}
@@ -1,23 +1,117 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/rand"
 	"flag"
+	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"path/filepath"
 	"questionnaire-app/internal/api"
 	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/secrets"
 	"questionnaire-app/internal/services"
 	"questionnaire-app/internal/storage"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func main() {
+	// Run a subcommand instead of the server if one is given
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "catalogs":
+			os.Exit(runCatalogsCommand(os.Args[2:]))
+		case "validate":
+			os.Exit(runValidateCommand(os.Args[2:]))
+		case "report":
+			os.Exit(runReportCommand(os.Args[2:]))
+		case "inspect":
+			os.Exit(runInspectCommand(os.Args[2:]))
+		case "migrate":
+			os.Exit(runMigrateCommand(os.Args[2:]))
+		}
+	}
+	
 	// Parse command line flags
 	port := flag.Int("port", getEnvInt("PORT", 8080), "Server port")
 	dataDir := flag.String("data", getEnvStr("DATA_DIR", "./data"), "Data directory")
+	shareSecret := flag.String("share-secret", getEnvStr("SHARE_SECRET", ""), "Secret used to sign shared report links")
+	shareMaxTTL := flag.Duration("share-max-ttl", getEnvDuration("SHARE_MAX_TTL", 30*24*time.Hour), "Longest lifetime of shared report links")
+	secretsProvider := flag.String("secrets-provider", getEnvStr("SECRETS_PROVIDER", ""), "Secret source: env, file or vault (flags/env vars if empty)")
+	secretsDir := flag.String("secrets-dir", getEnvStr("SECRETS_DIR", "/var/run/secrets/questionnaire-app"), "Directory of secret files for the file provider")
+	vaultMount := flag.String("vault-mount", getEnvStr("VAULT_MOUNT", "secret"), "Vault KV v2 mount")
+	vaultPath := flag.String("vault-path", getEnvStr("VAULT_PATH", "questionnaire-app"), "Vault secret path within the mount")
+	secretsRefresh := flag.Duration("secrets-refresh-interval", getEnvDuration("SECRETS_REFRESH_INTERVAL", 15*time.Minute), "Interval between secret refreshes and Vault token renewals")
+	reportSigningKey := flag.String("report-signing-key", getEnvStr("REPORT_SIGNING_KEY_FILE", ""), "PEM file with the Ed25519 private key used to sign reports")
+	catalogDir := flag.String("catalog-dir", getEnvStr("CATALOG_DIR", ""), "Read the question catalog from this directory, e.g. a mounted ConfigMap")
+	catalogPoll := flag.Duration("catalog-poll-interval", getEnvDuration("CATALOG_POLL_INTERVAL", 10*time.Second), "Interval between catalog directory change checks (0 disables polling)")
+	seedDir := flag.String("seed-dir", getEnvStr("SEED_DIR", ""), "Directory of JSON/YAML files with questions and applications added at startup if missing")
+	lockTTL := flag.Duration("lock-ttl", getEnvDuration("LOCK_TTL", 30*time.Second), "Lease duration of entity locks shared between replicas")
+	notificationTargets := flag.String("notification-targets", getEnvStr("NOTIFICATION_TARGETS", ""), "JSON file with webhook, Slack and email notification targets")
+	outboxInterval := flag.Duration("outbox-interval", getEnvDuration("OUTBOX_INTERVAL", 10*time.Second), "Interval between outbox delivery runs")
+	smtpAddr := flag.String("smtp-addr", getEnvStr("SMTP_ADDR", ""), "SMTP relay host:port for email notifications")
+	smtpFrom := flag.String("smtp-from", getEnvStr("SMTP_FROM", "questionnaire-app@localhost"), "Sender address of email notifications")
+	smtpUsername := flag.String("smtp-username", getEnvStr("SMTP_USERNAME", ""), "SMTP username (password from SMTP_PASSWORD or the secrets provider)")
+	digestEnabled := flag.Bool("digest", getEnvBool("DIGEST_ENABLED", false), "Email a weekly portfolio digest to recipients and subscribed users")
+	digestRecipients := flag.String("digest-recipients", getEnvStr("DIGEST_RECIPIENTS", ""), "Comma-separated email addresses receiving the full weekly digest")
+	digestWeekday := flag.String("digest-weekday", getEnvStr("DIGEST_WEEKDAY", "monday"), "Day of the week the digest is sent")
+	digestHour := flag.Int("digest-hour", getEnvInt("DIGEST_HOUR", 8), "Hour of the day (server time zone) the digest is sent")
+	weightProfiles := flag.String("weight-profiles", getEnvStr("WEIGHT_PROFILES", ""), "JSON file with question weight overrides per application class")
+	jobWorkers := flag.Int("job-workers", getEnvInt("JOB_WORKERS", 4), "Maximum number of background jobs running at once")
+	jobTimeout := flag.Duration("job-timeout", getEnvDuration("JOB_TIMEOUT", 30*time.Minute), "Maximum duration of a background job")
+	retentionRules := flag.String("retention-rules", getEnvStr("RETENTION_RULES", ""), "JSON file with retention rules (disabled if empty)")
+	retentionInterval := flag.Duration("retention-interval", getEnvDuration("RETENTION_INTERVAL", 24*time.Hour), "Interval between retention runs")
+	retentionDryRun := flag.Bool("retention-dry-run", getEnvBool("RETENTION_DRY_RUN", false), "Only log what retention rules would delete")
+	prefillRules := flag.String("prefill-rules", getEnvStr("PREFILL_RULES", ""), "JSON file mapping detected signals to suggested answers (built-in rules if empty)")
+	llmBaseURL := flag.String("llm-base-url", getEnvStr("LLM_BASE_URL", ""), "OpenAI-compatible API URL for AI-generated report summaries (disabled if empty)")
+	portfolioSummaryMaxAge := flag.Duration("portfolio-summary-max-age", getEnvDuration("PORTFOLIO_SUMMARY_MAX_AGE", time.Hour), "Rebuild the cached portfolio summary from all reports after this long (0 never)")
+	reportValidityDays := flag.Int("report-validity-days", getEnvInt("REPORT_VALIDITY_DAYS", 365), "Days after which a report is flagged as stale (0 never)")
+	consensusRule := flag.String("consensus-rule", getEnvStr("CONSENSUS_RULE", models.ConsensusLatest), "Answer scored when several assessors answer a question: latest, majority or lowest")
+	disagreementThreshold := flag.Int("disagreement-threshold", getEnvInt("DISAGREEMENT_THRESHOLD", services.DefaultDisagreementThreshold), "Percent of assessors who must agree for a question not to be flagged as disputed in reports")
+	instanceName := flag.String("instance-name", getEnvStr("INSTANCE_NAME", ""), "Name of this instance in federated portfolios (hostname if empty)")
+	federationPeers := flag.String("federation-peers", getEnvStr("FEDERATION_PEERS", ""), "JSON file with the instances whose portfolio summaries are aggregated")
+	catalogDownstreams := flag.String("catalog-downstreams", getEnvStr("CATALOG_DOWNSTREAMS", ""), "JSON file with the instances catalog releases are pushed to")
+	catalogSyncTimeout := flag.Duration("catalog-sync-timeout", getEnvDuration("CATALOG_SYNC_TIMEOUT", 30*time.Second), "Timeout of pushing a catalog release to a downstream instance")
+	federationTimeout := flag.Duration("federation-timeout", getEnvDuration("FEDERATION_TIMEOUT", 10*time.Second), "Timeout of pulling a peer instance's portfolio summary")
+	wasmPluginsDir := flag.String("wasm-plugins-dir", getEnvStr("WASM_PLUGINS_DIR", ""), "Directory of WASM scoring modules the scoring config can name (disabled if empty)")
+	reportPluginsDir := flag.String("report-plugins-dir", getEnvStr("REPORT_PLUGINS_DIR", ""), "Directory of executables run before and after each report is generated (disabled if empty)")
+	llmModel := flag.String("llm-model", getEnvStr("LLM_MODEL", "gpt-4o-mini"), "Model used for AI-generated report summaries")
+	outboundProxy := flag.String("outbound-proxy", getEnvStr("OUTBOUND_PROXY", ""), "Proxy URL for requests to integrations (HTTPS_PROXY/HTTP_PROXY/NO_PROXY if empty)")
+	outboundNoProxy := flag.String("outbound-no-proxy", getEnvStr("OUTBOUND_NO_PROXY", ""), "Comma-separated hosts and domains integrations reach without --outbound-proxy")
+	outboundCABundle := flag.String("outbound-ca-bundle", getEnvStr("OUTBOUND_CA_BUNDLE", ""), "PEM file of CAs trusted by integrations in addition to the system CAs")
+	outboundConnectTimeout := flag.Duration("outbound-connect-timeout", getEnvDuration("OUTBOUND_CONNECT_TIMEOUT", 10*time.Second), "Timeout of connecting to an integration, including the TLS handshake")
+	outboundTimeout := flag.Duration("outbound-timeout", getEnvDuration("OUTBOUND_TIMEOUT", 0), "Timeout of a request to an integration (each integration's default if 0)")
+	serverConfig := api.DefaultServerConfig()
+	flag.DurationVar(&serverConfig.ReadTimeout, "read-timeout", getEnvDuration("READ_TIMEOUT", serverConfig.ReadTimeout), "Maximum duration for reading a request")
+	flag.DurationVar(&serverConfig.WriteTimeout, "write-timeout", getEnvDuration("WRITE_TIMEOUT", serverConfig.WriteTimeout), "Maximum duration for writing a response")
+	flag.DurationVar(&serverConfig.IdleTimeout, "idle-timeout", getEnvDuration("IDLE_TIMEOUT", serverConfig.IdleTimeout), "Maximum time to keep idle connections open")
+	flag.DurationVar(&serverConfig.RouteTimeout, "route-timeout", getEnvDuration("ROUTE_TIMEOUT", serverConfig.RouteTimeout), "Handler timeout of regular API routes (0 disables)")
+	flag.DurationVar(&serverConfig.ExportTimeout, "export-timeout", getEnvDuration("EXPORT_TIMEOUT", serverConfig.ExportTimeout), "Handler timeout of export routes (0 disables)")
+	flag.DurationVar(&serverConfig.ImportTimeout, "import-timeout", getEnvDuration("IMPORT_TIMEOUT", serverConfig.ImportTimeout), "Handler timeout of import routes (0 disables)")
+	flag.Int64Var(&serverConfig.MaxBodySize, "max-body-size", int64(getEnvInt("MAX_BODY_SIZE", int(serverConfig.MaxBodySize))), "Maximum request body size in bytes of regular API routes")
+	flag.Int64Var(&serverConfig.MaxImportSize, "max-import-size", int64(getEnvInt("MAX_IMPORT_SIZE", int(serverConfig.MaxImportSize))), "Maximum request body size in bytes of import routes")
+	contentScanner := flag.String("content-scanner", getEnvStr("CONTENT_SCANNER", ""), "Scan uploads to import routes with clamav://host:port, clamav:///socket or icap://host:port/service (disabled if empty)")
+	flag.BoolVar(&serverConfig.ScoreMetrics, "score-metrics", getEnvBool("SCORE_METRICS", false), "Expose per-application and per-category scores as Prometheus gauges on /metrics")
+	websocketOrigins := flag.String("websocket-origins", getEnvStr("WEBSOCKET_ORIGINS", ""), "Comma-separated origins of pages on other hosts allowed to open WebSockets (* allows any)")
 	flag.Parse()
+	serverConfig.Port = *port
+	for _, origin := range strings.Split(*websocketOrigins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			serverConfig.WebSocketOrigins = append(serverConfig.WebSocketOrigins, origin)
+		}
+	}
+	if *contentScanner != "" {
+		scanner, err := services.NewContentScanner(*contentScanner)
+		if err != nil {
+			log.Fatalf("Invalid content scanner: %v", err)
+		}
+		serverConfig.ContentScanner = scanner
+		log.Printf("Scanning uploads with %s", *contentScanner)
+	}
 	
 	log.Printf("Starting questionnaire application on port %d with data directory %s", *port, *dataDir)
 	
@@ -27,24 +121,256 @@ func main() {
 	}
 	
 	// Initialize storage
-	store, err := storage.NewFileStorage(*dataDir)
+	fileStore, err := storage.NewFileStorage(*dataDir)
 	if err != nil {
 		log.Fatalf("Failed to create storage: %v", err)
 	}
 	
-	// Add sample data if needed
-	if err := ensureSampleData(store); err != nil {
-		log.Fatalf("Failed to add sample data: %v", err)
+	var store storage.Storage = fileStore
+	if *catalogDir != "" {
+		catalogStore, err := storage.NewCatalogStorage(fileStore, *catalogDir)
+		if err != nil {
+			log.Fatalf("Failed to load question catalog: %v", err)
+		}
+		go catalogStore.Watch(context.Background(), *catalogPoll)
+		store = catalogStore
+		log.Printf("Serving question catalog from %s", *catalogDir)
+	}
+	
+	// Add seed data if needed
+	if err := seedStorage(context.Background(), store, *seedDir); err != nil {
+		log.Fatalf("Failed to add seed data: %v", err)
+	}
+	
+	// Initialize distributed locking shared by all replicas using the data directory
+	hostname, _ := os.Hostname()
+	replica := fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	locker, err := storage.NewFileLocker(filepath.Join(*dataDir, "locks"), replica, *lockTTL)
+	if err != nil {
+		log.Fatalf("Failed to create locker: %v", err)
+	}
+	
+	// Initialize secrets
+	var provider secrets.Provider
+	if *secretsProvider != "" {
+		provider = newSecretsProvider(*secretsProvider, *secretsDir, *vaultMount, *vaultPath, *secretsRefresh)
+	}
+	
+	shareKey := shareSigningKey(secretValue(provider, "share-secret", *shareSecret, *secretsRefresh))
+	smtpPassword := secretValue(provider, "smtp-password", os.Getenv("SMTP_PASSWORD"), *secretsRefresh)
+	webhookSecret := secretValue(provider, "webhook-signing-secret", os.Getenv("WEBHOOK_SIGNING_SECRET"), *secretsRefresh)
+	
+	// Initialize the HTTP clients of integrations, which may have to go through a proxy
+	var noProxy []string
+	for _, host := range strings.Split(*outboundNoProxy, ",") {
+		if host = strings.TrimSpace(host); host != "" {
+			noProxy = append(noProxy, host)
+		}
+	}
+	outbound, err := services.NewOutboundHTTP(services.OutboundConfig{
+		Proxy:          *outboundProxy,
+		NoProxy:        noProxy,
+		CABundle:       *outboundCABundle,
+		ConnectTimeout: *outboundConnectTimeout,
+		Timeout:        *outboundTimeout,
+	})
+	if err != nil {
+		log.Fatalf("Invalid outbound HTTP configuration: %v", err)
+	}
+	if *outboundProxy != "" {
+		proxy, _ := url.Parse(*outboundProxy)
+		log.Printf("Integrations connect through proxy %s", proxy.Redacted())
+	}
+	
+	// Initialize notifications
+	var targets []models.NotificationTarget
+	if *notificationTargets != "" {
+		if targets, err = services.LoadNotificationTargets(*notificationTargets); err != nil {
+			log.Fatalf("Failed to load notification targets: %v", err)
+		}
 	}
+	notificationService := services.NewNotificationService(store, locker, services.NotificationConfig{
+		Targets:       targets,
+		SMTPAddr:      *smtpAddr,
+		SMTPFrom:      *smtpFrom,
+		SMTPUsername:  *smtpUsername,
+		SMTPPassword:  smtpPassword,
+		WebhookSecret: func() []byte { return []byte(webhookSecret()) },
+		HTTPClient:    outbound.Client(10 * time.Second),
+	})
 	
 	// Initialize services
-	assessmentService := services.NewAssessmentService(store)
+	var profiles map[string][]models.WeightOverride
+	if *weightProfiles != "" {
+		if profiles, err = services.LoadWeightProfiles(*weightProfiles); err != nil {
+			log.Fatalf("Failed to load weight profiles: %v", err)
+		}
+	}
+	if err := services.ValidateConsensusRule(*consensusRule); err != nil {
+		log.Fatalf("Invalid consensus rule: %v", err)
+	}
+	signer := reportSigner(*reportSigningKey, secretValue(provider, "report-signing-key", "", *secretsRefresh)())
+	assessmentOpts := []services.AssessmentOption{
+		services.WithNotifier(notificationService),
+		services.WithWeightProfiles(profiles),
+		services.WithReportSigner(signer),
+		services.WithReportValidity(time.Duration(*reportValidityDays) * 24 * time.Hour),
+		services.WithConsensus(*consensusRule, *disagreementThreshold),
+	}
+	if *llmBaseURL != "" {
+		assessmentOpts = append(assessmentOpts, services.WithNarrativeGenerator(&services.OpenAINarrativeGenerator{
+			BaseURL:    *llmBaseURL,
+			APIKey:     secretValue(provider, "llm-api-key", os.Getenv("LLM_API_KEY"), *secretsRefresh),
+			ModelName:  *llmModel,
+			HTTPClient: outbound.Client(60 * time.Second),
+		}))
+		log.Printf("AI-generated report summaries enabled using %s", *llmModel)
+	}
+	if *reportPluginsDir != "" {
+		hooks, err := services.LoadReportPlugins(*reportPluginsDir)
+		if err != nil {
+			log.Fatalf("Failed to load report plugins: %v", err)
+		}
+		assessmentOpts = append(assessmentOpts, services.WithReportHooks(hooks...))
+		log.Printf("Loaded %d report plugins from %s", len(hooks), *reportPluginsDir)
+	}
+	if *wasmPluginsDir != "" {
+		plugins, err := services.LoadWasmPlugins(context.Background(), *wasmPluginsDir)
+		if err != nil {
+			log.Fatalf("Failed to load WASM plugins: %v", err)
+		}
+		defer plugins.Close(context.Background())
+		assessmentOpts = append(assessmentOpts, services.WithScoringPlugins(plugins))
+		log.Printf("Loaded WASM scoring plugins from %s: %s", *wasmPluginsDir, strings.Join(plugins.Modules(), ", "))
+	}
+	assessmentService := services.NewAssessmentService(store, locker, assessmentOpts...)
+	applicationService := services.NewApplicationService(store, locker, assessmentService)
+	questionService := services.NewQuestionService(store, services.WithCatalogOutbound(outbound))
+	campaignService := services.NewCampaignService(store)
+	
+	suggestionRules := services.DefaultPrefillRules()
+	if *prefillRules != "" {
+		if suggestionRules, err = services.LoadPrefillRules(*prefillRules); err != nil {
+			log.Fatalf("Failed to load prefill rules: %v", err)
+		}
+	}
+	prefillService := services.NewPrefillService(assessmentService, suggestionRules, &services.RepositoryScanner{})
+	tackleService := services.NewTackleService(store, assessmentService)
+	portfolioService := services.NewPortfolioService(store, assessmentService, *portfolioSummaryMaxAge)
+	shareService := services.NewShareService(store, shareKey, *shareMaxTTL)
+	privacyService := services.NewPrivacyService(store, locker)
+	
+	// Initialize federation; peers present FEDERATION_TOKEN to pull this instance's summary
+	var peers []models.FederationPeer
+	if *federationPeers != "" {
+		if peers, err = services.LoadFederationPeers(*federationPeers); err != nil {
+			log.Fatalf("Failed to load federation peers: %v", err)
+		}
+	}
+	peerTokens := make(map[string]func() string)
+	if provider != nil {
+		for _, peer := range peers {
+			peerTokens[peer.Name] = secretValue(provider, "federation-peer-"+peer.Name, peer.Token, *secretsRefresh)
+		}
+	}
+	if *instanceName == "" {
+		*instanceName = hostname
+	}
+	federationService := services.NewFederationService(store, portfolioService, services.FederationConfig{
+		Instance:   *instanceName,
+		Token:      secretValue(provider, "federation-token", os.Getenv("FEDERATION_TOKEN"), *secretsRefresh),
+		Peers:      peers,
+		PeerTokens: peerTokens,
+		Timeout:    *federationTimeout,
+		HTTPClient: outbound.Client(0),
+	})
+	
+	// Initialize catalog sync; authoring instances present CATALOG_SYNC_TOKEN to push releases
+	var downstreams []models.CatalogDownstream
+	if *catalogDownstreams != "" {
+		if downstreams, err = services.LoadCatalogDownstreams(*catalogDownstreams); err != nil {
+			log.Fatalf("Failed to load catalog downstreams: %v", err)
+		}
+	}
+	downstreamTokens := make(map[string]func() string)
+	if provider != nil {
+		for _, downstream := range downstreams {
+			downstreamTokens[downstream.Name] = secretValue(provider, "catalog-downstream-"+downstream.Name, downstream.Token, *secretsRefresh)
+		}
+	}
+	catalogSyncService := services.NewCatalogSyncService(store, questionService, services.CatalogSyncConfig{
+		Instance:         *instanceName,
+		Token:            secretValue(provider, "catalog-sync-token", os.Getenv("CATALOG_SYNC_TOKEN"), *secretsRefresh),
+		Downstreams:      downstreams,
+		DownstreamTokens: downstreamTokens,
+		Timeout:          *catalogSyncTimeout,
+		HTTPClient:       outbound.Client(0),
+	})
+	
+	weekday, err := services.ParseWeekday(*digestWeekday)
+	if err != nil || *digestHour < 0 || *digestHour > 23 {
+		log.Fatalf("Invalid digest schedule %s at %d:00", *digestWeekday, *digestHour)
+	}
+	var recipients []string
+	for _, recipient := range strings.Split(*digestRecipients, ",") {
+		if recipient = strings.TrimSpace(recipient); recipient != "" {
+			recipients = append(recipients, recipient)
+		}
+	}
+	digestService := services.NewDigestService(store, locker, assessmentService, campaignService, notificationService, services.DigestConfig{
+		Recipients: recipients,
+		Weekday:    weekday,
+		Hour:       *digestHour,
+	})
+	
+	var rules []models.RetentionRule
+	if *retentionRules != "" {
+		if rules, err = services.LoadRetentionRules(*retentionRules); err != nil {
+			log.Fatalf("Failed to load retention rules: %v", err)
+		}
+	}
+	retentionService := services.NewRetentionService(store, locker, rules)
+	
+	// Initialize background jobs
+	jobService := services.NewJobService(store, locker, replica, *jobWorkers, *jobTimeout)
+	jobService.Register(services.JobRetention, retentionService.EnforceJob)
+	jobService.Register(services.JobCompleteAssessment, assessmentService.CompleteAssessmentJob)
+	jobService.Register(services.JobPrefillRepository, prefillService.PrefillFromRepositoryJob)
+	jobService.Register(services.JobRescoreReports, assessmentService.RescoreReportsJob)
+	
+	// Start background jobs
+	if len(rules) > 0 {
+		go retentionService.Run(context.Background(), *retentionInterval, *retentionDryRun)
+	}
+	go notificationService.Run(context.Background(), *outboxInterval)
+	go jobService.Run(context.Background(), *lockTTL)
+	if *digestEnabled {
+		go digestService.Run(context.Background(), 10*time.Minute)
+		log.Printf("Weekly portfolio digest enabled on %s at %d:00", weekday, *digestHour)
+	}
 	
 	// Initialize HTTP handlers
-	handler := api.NewHandler(assessmentService)
+	handler := api.NewHandler(api.Services{
+		Assessment:   assessmentService,
+		Share:        shareService,
+		Privacy:      privacyService,
+		Retention:    retentionService,
+		Notification: notificationService,
+		Job:          jobService,
+		Application:  applicationService,
+		Question:     questionService,
+		Campaign:     campaignService,
+		Prefill:      prefillService,
+		Tackle:       tackleService,
+		Portfolio:    portfolioService,
+		Digest:       digestService,
+		Stats:        services.NewStatsService(store),
+		Federation:   federationService,
+		CatalogSync:  catalogSyncService,
+	})
 	
 	// Initialize and start server
-	server := api.NewServer(handler, *port)
+	server := api.NewServer(handler, serverConfig)
 	if err := server.Start(); err != nil {
 		log.Fatalf("Server error: %v", err)
 	}
@@ -68,103 +394,162 @@ func getEnvInt(key string, fallback int) int {
 	return fallback
 }
 
-// ensureSampleData adds sample questions and applications if none exist
-func ensureSampleData(store storage.Storage) error {
-	// Check if we already have questions
-	questions, err := store.GetQuestions(nil)
+// getEnvBool gets a boolean environment variable with a fallback
+func getEnvBool(key string, fallback bool) bool {
+	if value, exists := os.LookupEnv(key); exists {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return fallback
+}
+
+// getEnvDuration gets a duration environment variable with a fallback
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if value, exists := os.LookupEnv(key); exists {
+		if durationValue, err := time.ParseDuration(value); err == nil {
+			return durationValue
+		}
+	}
+	return fallback
+}
+
+// shareSigningKey returns the configured share secret, or a random one for this process if
+// neither the secrets provider nor the flag sets one
+func shareSigningKey(secret func() string) func() []byte {
+	if secret() != "" {
+		return func() []byte { return []byte(secret()) }
+	}
+	
+	log.Println("No share secret configured; shared report links will not survive a restart")
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("Failed to generate share secret: %v", err)
+	}
+	return func() []byte { return key }
+}
+
+// reportSigner loads the report signing key from a PEM file or secret, generating a temporary
+// key if neither is configured
+func reportSigner(path, secret string) *services.ReportSigner {
+	var pemData []byte
+	switch {
+	case secret != "":
+		pemData = []byte(secret)
+	case path != "":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			log.Fatalf("Failed to read report signing key: %v", err)
+		}
+		pemData = data
+	default:
+		log.Println("No report signing key configured; report signatures will not verify after a restart")
+		signer, err := services.NewRandomReportSigner()
+		if err != nil {
+			log.Fatalf("Failed to create report signer: %v", err)
+		}
+		return signer
+	}
+	
+	signer, err := services.ParseReportSigningKey(pemData)
 	if err != nil {
-		return err
+		log.Fatalf("Failed to load report signing key: %v", err)
+	}
+	return signer
+}
+
+// secretValue returns an accessor for a secret kept fresh from the provider, or for the
+// static fallback value when no provider is configured or the provider has no value for it.
+// An empty value counts as missing.
+func secretValue(provider secrets.Provider, name, fallback string, refresh time.Duration) func() string {
+	if provider == nil {
+		return func() string { return fallback }
+	}
+	
+	secret, err := secrets.NewSecret(context.Background(), provider, name)
+	if err == nil && secret.Value() == "" {
+		err = fmt.Errorf("secret %s is empty", name)
+	}
+	if err != nil {
+		if fallback == "" {
+			log.Printf("Secret %s not available from provider: %v", name, err)
+		}
+		return func() string { return fallback }
 	}
 	
-	// If we already have questions, don't add sample data
-	if len(questions) > 0 {
+	go secret.Run(context.Background(), refresh)
+	return func() string {
+		if value := secret.Value(); value != "" {
+			return value
+		}
+		return fallback
+	}
+}
+
+// newSecretsProvider creates the configured external secret provider
+func newSecretsProvider(kind, dir, vaultMount, vaultPath string, renewInterval time.Duration) secrets.Provider {
+	switch kind {
+	case "env":
+		return secrets.EnvProvider{}
+	case "file":
+		return secrets.FileProvider{Dir: dir}
+	case "vault":
+		address, token := os.Getenv("VAULT_ADDR"), os.Getenv("VAULT_TOKEN")
+		if address == "" || token == "" {
+			log.Fatalf("VAULT_ADDR and VAULT_TOKEN are required for the vault secrets provider")
+		}
+		provider := secrets.NewVaultProvider(address, token, vaultMount, vaultPath)
+		go provider.RunTokenRenewal(context.Background(), renewInterval)
+		return provider
+	default:
+		log.Fatalf("Unknown secrets provider: %s", kind)
 		return nil
 	}
+}
+
+// seedStorage adds the seed categories, questions and applications missing from storage.
+// Without a seed directory the built-in sample data is only added to a storage without
+// questions. Categories of questions stored before categories were managed are adopted first.
+func seedStorage(ctx context.Context, store storage.Storage, seedDir string) error {
+	adopted, err := services.AdoptCategories(ctx, store)
+	if err != nil {
+		return err
+	}
+	if len(adopted) > 0 {
+		log.Printf("Defined %d categories used by existing questions: %s", len(adopted), strings.Join(adopted, ", "))
+	}
 	
-	// Add sample questions
-	sampleQuestions := []*models.Question{
-		{
-			ID:       "q1",
-			Text:     "Is the application stateless?",
-			Category: "Architecture",
-			Weight:   5,
-			Options: []models.Option{
-				{ID: "q1_a1", Text: "Yes, completely stateless", Points: 10},
-				{ID: "q1_a2", Text: "Mostly stateless with minimal state", Points: 7},
-				{ID: "q1_a3", Text: "Partially stateless", Points: 4},
-				{ID: "q1_a4", Text: "Heavily stateful", Points: 1},
-			},
-		},
-		{
-			ID:       "q2",
-			Text:     "Does the application use external configuration?",
-			Category: "Configuration",
-			Weight:   3,
-			Options: []models.Option{
-				{ID: "q2_a1", Text: "Yes, all configuration is external", Points: 10},
-				{ID: "q2_a2", Text: "Most configuration is external", Points: 7},
-				{ID: "q2_a3", Text: "Some configuration is external", Points: 4},
-				{ID: "q2_a4", Text: "No, all configuration is internal", Points: 1},
-			},
-		},
-		{
-			ID:       "q3",
-			Text:     "How is application logging handled?",
-			Category: "Observability",
-			Weight:   2,
-			Options: []models.Option{
-				{ID: "q3_a1", Text: "Logs to stdout/stderr", Points: 10},
-				{ID: "q3_a2", Text: "Logs to configurable location", Points: 7},
-				{ID: "q3_a3", Text: "Logs to fixed file location", Points: 3},
-				{ID: "q3_a4", Text: "No logging capability", Points: 0},
-			},
-		},
-		{
-			ID:       "q4",
-			Text:     "How does the application store persistent data?",
-			Category: "Persistence",
-			Weight:   4,
-			Options: []models.Option{
-				{ID: "q4_a1", Text: "Uses external databases with connection strings", Points: 10},
-				{ID: "q4_a2", Text: "Uses external storage with configurable location", Points: 7},
-				{ID: "q4_a3", Text: "Uses local filesystem with fixed paths", Points: 3},
-				{ID: "q4_a4", Text: "Embedded database or storage", Points: 1},
-			},
-		},
-		{
-			ID:       "q5",
-			Text:     "Does the application support horizontal scaling?",
-			Category: "Scalability",
-			Weight:   5,
-			Options: []models.Option{
-				{ID: "q5_a1", Text: "Designed for horizontal scaling", Points: 10},
-				{ID: "q5_a2", Text: "Can scale horizontally with minor changes", Points: 7},
-				{ID: "q5_a3", Text: "Requires significant changes to scale horizontally", Points: 3},
-				{ID: "q5_a4", Text: "Cannot scale horizontally", Points: 0},
-			},
-		},
-	}
-	
-	// Save sample questions
-	for _, question := range sampleQuestions {
-		path := filepath.Join(store.(*storage.FileStorage).BasePath, "questions", question.ID+".json")
-		content, _ := json.Marshal(question)
-		if err := os.WriteFile(path, content, 0644); err != nil {
+	var seed *models.SeedData
+	if seedDir != "" {
+		loaded, err := services.LoadSeedDir(seedDir)
+		if err != nil {
+			return err
+		}
+		seed = loaded
+	} else {
+		questions, err := store.GetQuestions(ctx)
+		if err != nil {
+			return err
+		}
+		if len(questions) > 0 {
+			return nil
+		}
+		
+		if seed, err = services.DefaultSeedData(); err != nil {
 			return err
 		}
 	}
 	
-	// Add sample application
-	sampleApp := &models.Application{
-		ID:          "app1",
-		Name:        "Sample Application",
-		Description: "A sample application for testing the assessment tool",
-		Tags: map[string]string{
-			"language": "Java",
-			"type":     "Web Application",
-		},
+	result, err := services.SeedStorage(ctx, store, seed)
+	if err != nil {
+		return err
 	}
 	
-	// Save sample application
-	return store.SaveApplication(nil, sampleApp)
+	if len(result.Questions) > 0 || len(result.Applications) > 0 {
+		log.Printf("Seeded %d questions and %d applications", len(result.Questions), len(result.Applications))
+	}
+	if result.SkippedQuestions > 0 {
+		log.Printf("Skipped %d seed questions because the question catalog is read-only", result.SkippedQuestions)
+	}
+	return nil
 }
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"text/tabwriter"
+)
+
+const migrateUsage = "usage: server migrate [-data dir] [-dry-run]"
+
+// runMigrateCommand implements "migrate": it upgrades the documents of a data directory
+// written with an older schema version in place and prints how many of each kind it checked
+// and upgraded. It returns the process exit code.
+func runMigrateCommand(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dataDir := flags.String("data", getEnvStr("DATA_DIR", "./data"), "Data directory")
+	dryRun := flags.Bool("dry-run", false, "Only report the documents that would be upgraded")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() != 0 {
+		flags.Usage()
+		return 2
+	}
+	
+	store, err := storage.NewFileStorage(*dataDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create storage: %v\n", err)
+		return 1
+	}
+	
+	result, err := store.Migrate(context.Background(), *dryRun)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to migrate %s: %v\n", *dataDir, err)
+		return 1
+	}
+	
+	kinds := make([]string, 0, len(result.Checked))
+	for kind := range result.Checked {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "KIND\tVERSION\tDOCUMENTS\tUPGRADED")
+	for _, kind := range kinds {
+		fmt.Fprintf(table, "%s\t%d\t%d\t%d\n", kind, storage.SchemaVersion(kind), result.Checked[kind], result.Upgraded[kind])
+	}
+	table.Flush()
+	if *dryRun {
+		fmt.Println("Dry run, no documents were changed")
+	}
+	return 0
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ANSI escape sequences used by the terminal report viewer
+const (
+	ansiReset  = "\033[0m"
+	ansiBold   = "\033[1m"
+	ansiRed    = "\033[31m"
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiDim    = "\033[2m"
+)
+
+// barWidth is the number of cells of a category score bar
+const barWidth = 20
+
+// runReportCommand implements "report view [-server url] [-user name] [-color mode] [-template
+// name] <assessment ID | file | ->" and returns the process exit code
+func runReportCommand(args []string) int {
+	if len(args) == 0 || args[0] != "view" {
+		fmt.Fprintln(os.Stderr, "usage: server report view [-server url] [-user name] [-color auto|always|never] [-template name] <assessment ID | report.json | ->")
+		return 2
+	}
+	
+	flags := flag.NewFlagSet("report view", flag.ContinueOnError)
+	server := flags.String("server", getEnvStr("QUESTIONNAIRE_SERVER", "http://localhost:8080"), "Base URL of the questionnaire server")
+	user := flags.String("user", getEnvStr("QUESTIONNAIRE_USER", ""), "User sent in X-Forwarded-User")
+	colorMode := flags.String("color", "auto", "Colored output: auto, always or never")
+	templateID := flags.String("template", "", "Report template: executive, technical or auditor (default the full report)")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: server report view [-server url] [-user name] [-color auto|always|never] [-template name] <assessment ID | report.json | ->")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return 2
+	}
+	
+	var color bool
+	switch *colorMode {
+	case "always":
+		color = true
+	case "never":
+	case "auto":
+		color = isTerminal(os.Stdout) && os.Getenv("NO_COLOR") == ""
+	default:
+		fmt.Fprintf(os.Stderr, "unknown color mode %q\n", *colorMode)
+		return 2
+	}
+	
+	if err := services.ValidateReportTemplate(*templateID); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	
+	report, err := loadReport(flags.Arg(0), *server, *user)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	
+	report, _ = services.ApplyReportTemplate(report, *templateID)
+	
+	printReport(os.Stdout, report, color)
+	return 0
+}
+
+// loadReport reads a report from stdin ("-"), from a JSON file or, if no such file exists,
+// from the server by assessment ID
+func loadReport(source, server, user string) (*models.Report, error) {
+	var data []byte
+	var err error
+	
+	switch {
+	case source == "-":
+		data, err = io.ReadAll(os.Stdin)
+	case fileExists(source):
+		data, err = os.ReadFile(source)
+	default:
+		data, err = fetchReport(source, server, user)
+	}
+	if err != nil {
+		return nil, err
+	}
+	
+	var report models.Report
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	return &report, nil
+}
+
+// fetchReport downloads the report of an assessment from the server
+func fetchReport(assessmentID, server, user string) ([]byte, error) {
+	var data json.RawMessage
+	if err := newAPIClient(server, user).do(http.MethodGet, "/api/assessments/"+url.PathEscape(assessmentID)+"/report", "", nil, &data); err != nil {
+		return nil, fmt.Errorf("failed to fetch report: %w", err)
+	}
+	return data, nil
+}
+
+// printReport renders a report for the terminal
+func printReport(w io.Writer, report *models.Report, color bool) {
+	paint := func(code, text string) string {
+		if !color || code == "" {
+			return text
+		}
+		return code + text + ansiReset
+	}
+	
+	fmt.Fprintln(w, paint(ansiBold, "Kubernetes Readiness Report"))
+	if report.Application != nil {
+		fmt.Fprintf(w, "Application  %s (%s)\n", report.Application.Name, report.ApplicationID)
+	} else {
+		fmt.Fprintf(w, "Application  %s\n", report.ApplicationID)
+	}
+	fmt.Fprintf(w, "Assessment   %s\n", report.AssessmentID)
+	fmt.Fprintf(w, "Generated    %s\n", report.GeneratedAt.Format(time.RFC1123))
+	if rescore := report.Rescore; rescore != nil {
+		fmt.Fprintf(w, "Version      %d, re-scored %s (catalog %s, %s scoring) from version %d: %d/%d %s\n",
+			report.Version, rescore.RescoredAt.Format(time.RFC1123), rescore.Catalog, rescore.Scoring,
+			rescore.Previous.Version, rescore.Previous.TotalScore, rescore.Previous.MaxPossibleScore, rescore.Previous.Grade)
+	}
+	if report.ValidUntil != nil {
+		validity := "Valid until  " + report.ValidUntil.Format(time.RFC1123)
+		if report.Stale {
+			validity = paint(ansiRed, validity+"  STALE, reassess the application")
+		}
+		fmt.Fprintln(w, validity)
+	}
+	if len(report.Categories) > 0 {
+		fmt.Fprintf(w, "Categories   %s only\n", strings.Join(report.Categories, ", "))
+	}
+	if spotCheck := report.SpotCheck; spotCheck != nil {
+		fmt.Fprintf(w, "Spot check   %d sampled questions, %d per category (seed %d)\n", len(spotCheck.QuestionIDs), spotCheck.PerCategory, spotCheck.Seed)
+	}
+	if details := report.Context; details != nil {
+		if details.Scope != "" {
+			fmt.Fprintf(w, "Scope        %s\n", details.Scope)
+		}
+		if len(details.Participants) > 0 {
+			fmt.Fprintf(w, "Participants %s\n", strings.Join(details.Participants, ", "))
+		}
+		if details.BusinessContext != "" {
+			fmt.Fprintf(w, "Context      %s\n", details.BusinessContext)
+		}
+	}
+	
+	percent := services.ReportPercent(report)
+	grade := report.Grade
+	if grade == "" {
+		grade = services.ReadinessGrade(report.TotalScore, report.MaxPossibleScore)
+	}
+	score := fmt.Sprintf("%d / %d (%d%%)", report.TotalScore, report.MaxPossibleScore, percent)
+	if report.WeightedRatio != nil {
+		score = fmt.Sprintf("%d / %d (%d%% weighted by category)", report.TotalScore, report.MaxPossibleScore, percent)
+	}
+	fmt.Fprintf(w, "Score        %s  grade %s", paint(ansiBold+ratioColor(percent), score), paint(ansiBold, grade))
+	if report.BandLabel != "" {
+		fmt.Fprintf(w, "  %s", report.BandLabel)
+	}
+	fmt.Fprintln(w)
+	if report.PenaltyScore != 0 {
+		fmt.Fprintf(w, "Penalties    %s\n", paint(ansiRed, fmt.Sprint(report.PenaltyScore)))
+	}
+	if report.UnansweredScore > 0 {
+		fmt.Fprintf(w, "Unanswered   %d questions worth %d points\n", len(report.Unanswered), report.UnansweredScore)
+	}
+	if len(report.Disagreements) > 0 {
+		fmt.Fprintf(w, "Disputed     %s\n", paint(ansiYellow, fmt.Sprintf("%d questions the assessors disagreed on", len(report.Disagreements))))
+	}
+	if len(report.AnswerProvenance) > 0 {
+		methods := make([]string, 0, len(report.AnswerProvenance))
+		for method := range report.AnswerProvenance {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		
+		parts := make([]string, len(methods))
+		for i, method := range methods {
+			parts[i] = fmt.Sprintf("%d %s", report.AnswerProvenance[method], method)
+		}
+		fmt.Fprintf(w, "Answers      %s\n", strings.Join(parts, ", "))
+	}
+	for _, gate := range report.FailedGates {
+		fmt.Fprintf(w, "Gate         %s %d%% below %d%%, grade capped at %s\n", paint(ansiRed, gate.Category), int(math.Round(gate.Ratio*100)), int(math.Round(gate.MinRatio*100)), gate.MaxGrade)
+	}
+	
+	// Category maxima come from the breakdown; older reports without one only show scores
+	categoryMax := make(map[string]int)
+	for _, question := range report.Breakdown {
+		if !question.Hidden {
+			categoryMax[question.Category] += question.MaxScore
+		}
+	}
+	
+	categories := make([]string, 0, len(report.CategoryScores))
+	width := len("CATEGORY")
+	for category := range report.CategoryScores {
+		categories = append(categories, category)
+		if n := utf8.RuneCountInString(category); n > width {
+			width = n
+		}
+	}
+	sort.Strings(categories)
+	
+	if len(categories) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, paint(ansiBold, padRight("CATEGORY", width)+"  SCORE"))
+		for _, category := range categories {
+			score := report.CategoryScores[category]
+			max, ok := categoryMax[category]
+			if !ok || max <= 0 {
+				fmt.Fprintf(w, "%s  %d\n", padRight(category, width), score)
+				continue
+			}
+			
+			percent := services.ScorePercent(score, max)
+			filled := percent * barWidth / 100
+			if filled > barWidth {
+				filled = barWidth
+			}
+			bar := paint(ratioColor(percent), strings.Repeat("█", filled)) + paint(ansiDim, strings.Repeat("░", barWidth-filled))
+			fmt.Fprintf(w, "%s  %s %3d%%  %d / %d\n", padRight(category, width), bar, percent, score, max)
+		}
+	}
+	
+	if len(report.Risks) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, paint(ansiBold, "RISKS"))
+		for _, risk := range report.Risks {
+			fmt.Fprintf(w, "  %s  %s: %s\n", paint(levelColor(risk.Severity), padRight(risk.Severity, 6)), risk.Category, risk.Description)
+		}
+	}
+	
+	if len(report.Recommendations) > 0 {
+		fmt.Fprintln(w)
+		heading := "RECOMMENDATIONS"
+		if progress := report.Remediation; progress != nil {
+			heading += fmt.Sprintf("  %d%% done, %d open", progress.PercentDone, progress.Open)
+		}
+		fmt.Fprintln(w, paint(ansiBold, heading))
+		for _, recommendation := range report.Recommendations {
+			fmt.Fprintf(w, "  %s  %s: %s", paint(levelColor(recommendation.Priority), padRight(recommendation.Priority, 6)), recommendation.Category, recommendation.Description)
+			if remediation := recommendation.Remediation; remediation != nil {
+				fmt.Fprintf(w, "  [%s", remediation.Status)
+				if remediation.Owner != "" {
+					fmt.Fprintf(w, ", %s", remediation.Owner)
+				}
+				if remediation.DueDate != "" {
+					fmt.Fprintf(w, ", due %s", remediation.DueDate)
+				}
+				fmt.Fprint(w, "]")
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	
+	if len(report.ModernizationPlan) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, paint(ansiBold, "MODERNIZATION PLAN"))
+		for _, step := range report.ModernizationPlan {
+			fmt.Fprintf(w, "  %d. %s %s\n", step.Order, step.Description, paint(ansiDim, "("+step.Effort+" effort)"))
+		}
+	}
+	
+	if report.Narrative != nil && report.Narrative.Text != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, paint(ansiBold, "SUMMARY"))
+		fmt.Fprintln(w, report.Narrative.Text)
+		fmt.Fprintln(w, paint(ansiDim, report.Narrative.Disclaimer))
+	}
+	
+	for _, section := range report.PluginSections {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, paint(ansiBold, strings.ToUpper(section.Title)))
+		if section.Content != "" {
+			fmt.Fprintln(w, section.Content)
+		}
+		for _, fact := range section.Facts {
+			fmt.Fprintf(w, "  %s: %s\n", fact.Label, fact.Value)
+		}
+	}
+	
+	if approval := report.Approval; approval != nil {
+		fmt.Fprintln(w)
+		heading := "APPROVAL  " + approval.ApprovedAt.Format(time.RFC1123)
+		if approval.ApprovedBy != "" {
+			heading += " by " + approval.ApprovedBy
+		}
+		fmt.Fprintln(w, paint(ansiBold, heading))
+		for _, item := range approval.Checklist {
+			fmt.Fprintf(w, "  %s %s", paint(ansiGreen, "✓"), item.Text)
+			if item.Note != "" {
+				fmt.Fprintf(w, ": %s", item.Note)
+			}
+			fmt.Fprintln(w)
+		}
+	}
+}
+
+// ratioColor colors a score percentage red below 50%, yellow below 70% and green above
+func ratioColor(percent int) string {
+	switch {
+	case percent >= 70:
+		return ansiGreen
+	case percent >= 50:
+		return ansiYellow
+	default:
+		return ansiRed
+	}
+}
+
+// levelColor colors a High, Medium or Low severity or priority
+func levelColor(level string) string {
+	switch strings.ToLower(level) {
+	case "high", "critical":
+		return ansiRed
+	case "medium":
+		return ansiYellow
+	case "low":
+		return ansiGreen
+	default:
+		return ""
+	}
+}
+
+// padRight pads text with spaces to the given number of characters
+func padRight(text string, width int) string {
+	if n := utf8.RuneCountInString(text); n < width {
+		return text + strings.Repeat(" ", width-n)
+	}
+	return text
+}
+
+// fileExists reports whether path names a regular file
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// isTerminal reports whether f is a character device such as a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+)
+
+// runValidateCommand implements "validate [-json] [-strict] <file or directory>..." and
+// returns the process exit code: 0 if the catalog is valid, 1 if it has errors (or warnings
+// with -strict) and 2 on usage errors
+func runValidateCommand(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	asJSON := flags.Bool("json", false, "Print the result as JSON")
+	strict := flags.Bool("strict", false, "Fail on warnings as well as errors")
+	flags.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: server validate [-json] [-strict] <file or directory>...")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	if flags.NArg() == 0 {
+		flags.Usage()
+		return 2
+	}
+	
+	validation, err := services.ValidateCatalogFiles(flags.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+	
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(validation)
+	} else {
+		printValidation(validation)
+	}
+	
+	if !validation.Valid || *strict && len(validation.Problems) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// printValidation prints one line per problem followed by a summary
+func printValidation(validation *models.CatalogValidation) {
+	errors, warnings := 0, 0
+	for _, problem := range validation.Problems {
+		location := problem.File
+		if problem.QuestionID != "" {
+			if location != "" {
+				location += ": "
+			}
+			location += problem.QuestionID
+		}
+		fmt.Printf("%s: %s: %s: %s\n", location, problem.Severity, problem.Field, problem.Message)
+		
+		if problem.Severity == models.SeverityError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+	
+	fmt.Printf("%d questions in %d files: %d errors, %d warnings\n", validation.Questions, validation.Files, errors, warnings)
+}
@@ -0,0 +1,241 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	
+	"github.com/gorilla/mux"
+)
+
+// GetInstanceStats returns entity counts, storage size, the oldest assessment in progress,
+// recent report generation and failing notifications
+func (h *Handler) GetInstanceStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.statsService.InstanceStats(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get instance stats: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, stats)
+}
+
+// GetRetentionPreview lists the configured retention rules and what they would currently remove
+func (h *Handler) GetRetentionPreview(w http.ResponseWriter, r *http.Request) {
+	actions, err := h.retentionService.Enforce(r.Context(), true)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to evaluate retention rules: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"rules":   h.retentionService.Rules(),
+		"actions": actions,
+	})
+}
+
+// RunRetention enforces the retention rules immediately; dryRun=true only lists the actions and
+// async=true runs them as a background job
+func (h *Handler) RunRetention(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		job, err := h.jobService.Submit(r.Context(), services.JobRetention, dryRun)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to start retention job: "+err.Error())
+			return
+		}
+		respondWithJob(w, r, job.ID)
+		return
+	}
+	
+	actions, err := h.retentionService.Enforce(r.Context(), dryRun)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to enforce retention rules: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"dryRun":  dryRun,
+		"actions": actions,
+	})
+}
+
+// RescoreReports recomputes the reports of completed assessments against a catalog and scoring
+// configuration, storing new report versions; async=true runs it as a background job
+func (h *Handler) RescoreReports(w http.ResponseWriter, r *http.Request) {
+	var request models.RescoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if len(request.AssessmentIDs) == 0 {
+		respondWithError(w, http.StatusBadRequest, "assessmentIds is required")
+		return
+	}
+	
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		job, err := h.jobService.Submit(r.Context(), services.JobRescoreReports, request)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to start rescore job: "+err.Error())
+			return
+		}
+		respondWithJob(w, r, job.ID)
+		return
+	}
+	
+	result, err := h.assessmentService.RescoreReports(r.Context(), request)
+	if errors.Is(err, services.ErrInvalidRescore) || errors.Is(err, services.ErrUnknownCatalog) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to rescore reports: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// GetScoringConfig returns the score bands and grade thresholds used for new reports
+func (h *Handler) GetScoringConfig(w http.ResponseWriter, r *http.Request) {
+	config, err := h.assessmentService.GetScoringConfig(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get scoring config: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, config)
+}
+
+// UpdateScoringConfig replaces the score bands and grade thresholds used for new reports
+func (h *Handler) UpdateScoringConfig(w http.ResponseWriter, r *http.Request) {
+	var config models.ScoringConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	updated, err := h.assessmentService.UpdateScoringConfig(r.Context(), &config)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid scoring config: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// GetEstimationConfig returns the effort ranges and hourly rate used to estimate modernization plans
+func (h *Handler) GetEstimationConfig(w http.ResponseWriter, r *http.Request) {
+	config, err := h.assessmentService.GetEstimationConfig(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get estimation config: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, config)
+}
+
+// UpdateEstimationConfig replaces the effort ranges and hourly rate used for new reports
+func (h *Handler) UpdateEstimationConfig(w http.ResponseWriter, r *http.Request) {
+	var config models.EstimationConfig
+	if err := json.NewDecoder(r.Body).Decode(&config); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	updated, err := h.assessmentService.UpdateEstimationConfig(r.Context(), &config)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid estimation config: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// GetBranding returns the logo, colors and footer applied to exported reports
+func (h *Handler) GetBranding(w http.ResponseWriter, r *http.Request) {
+	branding, err := h.assessmentService.GetBranding(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get branding: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, branding)
+}
+
+// UpdateBranding replaces the branding applied to exported reports
+func (h *Handler) UpdateBranding(w http.ResponseWriter, r *http.Request) {
+	var branding models.Branding
+	if err := json.NewDecoder(r.Body).Decode(&branding); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	updated, err := h.assessmentService.UpdateBranding(r.Context(), &branding)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid branding: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// GetReviewChecklist returns the items reviewers must tick to approve an assessment
+func (h *Handler) GetReviewChecklist(w http.ResponseWriter, r *http.Request) {
+	checklist, err := h.assessmentService.GetReviewChecklist(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get review checklist: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, checklist)
+}
+
+// UpdateReviewChecklist replaces the items reviewers must tick to approve an assessment
+func (h *Handler) UpdateReviewChecklist(w http.ResponseWriter, r *http.Request) {
+	var checklist models.ReviewChecklist
+	if err := json.NewDecoder(r.Body).Decode(&checklist); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	updated, err := h.assessmentService.UpdateReviewChecklist(r.Context(), &checklist)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid review checklist: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, updated)
+}
+
+// ReopenAssessment lifts the approval of an assessment so it can be edited again
+func (h *Handler) ReopenAssessment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	if req.Reason == "" {
+		respondWithError(w, http.StatusBadRequest, "Reason is required")
+		return
+	}
+	
+	assessment, err := h.assessmentService.ReopenAssessment(r.Context(), assessmentID, requestUser(r), req.Reason)
+	if err != nil {
+		respondWithServiceError(w, "Failed to reopen assessment", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
@@ -0,0 +1,290 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	"strings"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListApplications returns all applications. include=score adds each application's latest
+// score, which sort (name, score or -score), band and grade (comma-separated) order and filter by;
+// stale=true or false filters by whether the latest report is past its validity period.
+// createdFrom/createdTo and updatedFrom/updatedTo filter by date range, and lifecycle
+// (comma-separated, including untracked) by lifecycle state.
+func (h *Handler) ListApplications(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.ApplicationListOptions{
+		Sort:       query.Get("sort"),
+		Bands:      splitList(query.Get("band")),
+		Grades:     splitList(query.Get("grade")),
+		Lifecycles: splitList(query.Get("lifecycle")),
+	}
+	
+	for _, state := range opts.Lifecycles {
+		if !validLifecycleFilter(state) {
+			respondWithError(w, http.StatusBadRequest, "Invalid lifecycle, expected untracked or one of "+strings.Join(services.LifecycleStates(), ", "))
+			return
+		}
+	}
+	
+	if value := query.Get("stale"); value != "" {
+		stale, err := strconv.ParseBool(value)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid stale, expected true or false")
+			return
+		}
+		opts.Stale = &stale
+	}
+	
+	var err error
+	if opts.Created, err = parseTimeRange(query, "created"); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if opts.Updated, err = parseTimeRange(query, "updated"); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	
+	switch opts.Sort {
+	case "", services.SortByName, services.SortByScore, services.SortByScoreDesc:
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid sort, expected name, score or -score")
+		return
+	}
+	
+	if query.Get("include") == "score" || opts.Sort != "" || len(opts.Bands) > 0 || len(opts.Grades) > 0 || opts.Stale != nil {
+		scored, err := h.applicationService.ListScoredApplications(r.Context(), opts)
+		if err != nil {
+			respondWithServiceError(w, "Failed to list applications", err)
+			return
+		}
+		
+		respondWithList(w, r, scored, scoredApplicationLinks)
+		return
+	}
+	
+	apps, err := h.applicationService.ListApplications(r.Context(), opts)
+	if err != nil {
+		respondWithServiceError(w, "Failed to list applications", err)
+		return
+	}
+	
+	respondWithList(w, r, apps, applicationLinks)
+}
+
+// GetApplication returns an application by ID
+func (h *Handler) GetApplication(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	app, err := h.applicationService.GetApplication(r.Context(), applicationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get application: "+err.Error())
+		return
+	}
+	
+	if app == nil {
+		respondWithError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// GetApplicationScorecard returns the one-page summary of an application
+func (h *Handler) GetApplicationScorecard(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	scorecard, err := h.applicationService.GetScorecard(r.Context(), applicationID, h.requestLocale(w, r))
+	if err != nil {
+		respondWithServiceError(w, "Failed to get scorecard", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, scorecard)
+}
+
+// validLifecycleFilter reports whether state names a lifecycle state or untracked applications
+func validLifecycleFilter(state string) bool {
+	if state == models.LifecycleUntracked {
+		return true
+	}
+	for _, known := range services.LifecycleStates() {
+		if state == known {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionApplicationLifecycle moves an application to another lifecycle state, recording
+// the requesting user and an optional note in its lifecycle history
+func (h *Handler) TransitionApplicationLifecycle(w http.ResponseWriter, r *http.Request) {
+	var transition models.LifecycleTransition
+	if err := json.NewDecoder(r.Body).Decode(&transition); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	app, err := h.applicationService.TransitionLifecycle(r.Context(), mux.Vars(r)["applicationId"], transition, requestUser(r))
+	if errors.Is(err, services.ErrInvalidLifecycle) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to change lifecycle", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// ListApplicationAssessments returns an application's assessments, oldest first, optionally
+// filtered by creation, update and completion time
+func (h *Handler) ListApplicationAssessments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	var opts services.AssessmentListOptions
+	ranges := map[string]*services.TimeRange{
+		"created":   &opts.Created,
+		"updated":   &opts.Updated,
+		"completed": &opts.Completed,
+	}
+	for name, target := range ranges {
+		parsed, err := parseTimeRange(r.URL.Query(), name)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		*target = parsed
+	}
+	
+	assessments, err := h.applicationService.ListAssessments(r.Context(), applicationID, opts)
+	if err != nil {
+		respondWithServiceError(w, "Failed to list assessments", err)
+		return
+	}
+	
+	respondWithList(w, r, assessments, assessmentLinks)
+}
+
+// ImportAssessmentWorkbook loads answers collected offline in an XLSX workbook, sent as the
+// request body, as a completed assessment of the application. dryRun=true only validates the
+// workbook. Invalid workbooks are rejected with the problems of each cell.
+func (h *Handler) ImportAssessmentWorkbook(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to read request body: "+err.Error())
+		return
+	}
+	
+	report, err := h.assessmentService.ImportWorkbook(r.Context(), applicationID, data, requestUser(r), dryRun)
+	if err != nil {
+		respondWithServiceError(w, "Failed to import workbook", err)
+		return
+	}
+	
+	switch {
+	case !report.Valid:
+		respondWithJSON(w, http.StatusUnprocessableEntity, report)
+	case dryRun:
+		respondWithJSON(w, http.StatusOK, report)
+	default:
+		respondWithJSON(w, http.StatusCreated, report)
+	}
+}
+
+// DeleteApplication deletes an application; mode=block (default), cascade or orphan controls
+// what happens to its assessments and reports
+func (h *Handler) DeleteApplication(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = models.DeleteModeBlock
+	}
+	
+	result, err := h.applicationService.DeleteApplication(r.Context(), applicationID, mode)
+	if errors.Is(err, services.ErrConflict) {
+		respondWithJSON(w, http.StatusConflict, map[string]interface{}{
+			"error":  "Application has dependent assessments; use mode=cascade or mode=orphan",
+			"result": result,
+		})
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to delete application", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// splitList splits a comma-separated query parameter, dropping empty values
+func splitList(value string) []string {
+	var values []string
+	for _, part := range strings.Split(value, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			values = append(values, part)
+		}
+	}
+	return values
+}
+
+// parseTimeRange reads the <name>From and <name>To query parameters as an inclusive range. Each
+// bound is an RFC3339 time or a date; a date as the upper bound includes that whole day.
+func parseTimeRange(query url.Values, name string) (services.TimeRange, error) {
+	var timeRange services.TimeRange
+	bounds := []struct {
+		param    string
+		target   *time.Time
+		endOfDay bool
+	}{
+		{name + "From", &timeRange.From, false},
+		{name + "To", &timeRange.To, true},
+	}
+	
+	for _, bound := range bounds {
+		value := query.Get(bound.param)
+		if value == "" {
+			continue
+		}
+		
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			*bound.target = t
+			continue
+		}
+		
+		day, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return timeRange, fmt.Errorf("invalid %s, expected an RFC3339 time or a YYYY-MM-DD date", bound.param)
+		}
+		if bound.endOfDay {
+			day = day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		*bound.target = day
+	}
+	
+	if !timeRange.From.IsZero() && !timeRange.To.IsZero() && timeRange.To.Before(timeRange.From) {
+		return timeRange, fmt.Errorf("%sTo is before %sFrom", name, name)
+	}
+	return timeRange, nil
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListArchetypes returns the application archetypes with their default answers
+func (h *Handler) ListArchetypes(w http.ResponseWriter, r *http.Request) {
+	archetypes, err := h.applicationService.ListArchetypes(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to list archetypes", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, archetypes)
+}
+
+// GetArchetype returns the archetype with the ID in the URL
+func (h *Handler) GetArchetype(w http.ResponseWriter, r *http.Request) {
+	archetype, err := h.applicationService.GetArchetype(r.Context(), mux.Vars(r)["archetypeId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get archetype", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, archetype)
+}
+
+// SaveArchetype creates or replaces the archetype with the ID in the URL
+func (h *Handler) SaveArchetype(w http.ResponseWriter, r *http.Request) {
+	archetypeID := mux.Vars(r)["archetypeId"]
+	
+	var archetype models.Archetype
+	if err := json.NewDecoder(r.Body).Decode(&archetype); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	if archetype.ID == "" {
+		archetype.ID = archetypeID
+	}
+	if archetype.ID != archetypeID {
+		respondWithError(w, http.StatusBadRequest, "Archetype ID does not match the URL")
+		return
+	}
+	
+	saved, err := h.applicationService.SaveArchetype(r.Context(), &archetype)
+	if errors.Is(err, services.ErrInvalidArchetype) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to save archetype", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, saved)
+}
+
+// DeleteArchetype removes an archetype no application is tagged with
+func (h *Handler) DeleteArchetype(w http.ResponseWriter, r *http.Request) {
+	if err := h.applicationService.DeleteArchetype(r.Context(), mux.Vars(r)["archetypeId"]); err != nil {
+		respondWithServiceError(w, "Failed to delete archetype", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// badgeColors maps the default readiness grades to shields.io palette colors; custom
+// grades are shown in grey
+var badgeColors = map[string]string{
+	"A":   "#4c1",
+	"B":   "#97ca00",
+	"C":   "#dfb317",
+	"D":   "#fe7d37",
+	"F":   "#e05d44",
+	"N/A": "#9f9f9f",
+}
+
+// GetApplicationBadge renders an SVG badge with the latest readiness score of an application
+func (h *Handler) GetApplicationBadge(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	app, err := h.applicationService.GetApplication(r.Context(), applicationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get application: "+err.Error())
+		return
+	}
+	
+	if app == nil {
+		respondWithError(w, http.StatusNotFound, "Application not found")
+		return
+	}
+	
+	report, err := h.assessmentService.GetLatestReport(r.Context(), applicationID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get latest report: "+err.Error())
+		return
+	}
+	
+	grade := "N/A"
+	value := "not assessed"
+	if report != nil {
+		grade = report.Grade
+		if grade == "" {
+			grade = services.ReadinessGrade(report.TotalScore, report.MaxPossibleScore)
+		}
+		if report.MaxPossibleScore > 0 {
+			value = fmt.Sprintf("%s (%d%%)", grade, services.ReportPercent(report))
+		}
+		if report.Stale {
+			value += " stale"
+		}
+	}
+	
+	// Badges must never be cached by README renderers such as GitHub's camo proxy
+	w.Header().Set("Content-Type", "image/svg+xml")
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+	w.WriteHeader(http.StatusOK)
+	color, ok := badgeColors[grade]
+	if !ok {
+		color = badgeColors["N/A"]
+	}
+	w.Write([]byte(renderBadge("k8s readiness", value, color)))
+}
+
+// renderBadge produces a flat shields.io-style SVG badge
+func renderBadge(label, value, color string) string {
+	// Approximate Verdana 11px glyph width used by shields.io
+	labelWidth := len(label)*7 + 10
+	valueWidth := len(value)*7 + 10
+	width := labelWidth + valueWidth
+	label = html.EscapeString(label)
+	value = html.EscapeString(value)
+	
+	return fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[4]s: %[5]s">
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[3]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="14">%[4]s</text><text x="%[8]d" y="14">%[5]s</text>
+</g>
+</svg>`, width, labelWidth, valueWidth, label, value, color, labelWidth/2, labelWidth+valueWidth/2)
+}
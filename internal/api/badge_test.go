@@ -0,0 +1,49 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	
+	"github.com/gorilla/mux"
+	
+	"questionnaire-app/internal/api"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+func TestGetApplicationBadge(t *testing.T) {
+	store := questionnairetest.NewMemoryStorage()
+	store.Seed(t, questionnairetest.NewApplication("app1").Build())
+	locker := questionnairetest.NewMemoryLocker()
+	assessments := questionnairetest.NewAssessmentService(store)
+	handler := api.NewHandler(api.Services{
+		Assessment:  assessments,
+		Application: services.NewApplicationService(store, locker, assessments),
+	})
+	
+	tests := []struct {
+		applicationID string
+		wantStatus    int
+	}{
+		{"app1", http.StatusOK},
+		{"missing", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.applicationID, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/api/applications/"+tt.applicationID+"/badge.svg", nil)
+			req = mux.SetURLVars(req, map[string]string{"applicationId": tt.applicationID})
+			rec := httptest.NewRecorder()
+			
+			handler.GetApplicationBadge(rec, req)
+			
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus == http.StatusOK && !strings.Contains(rec.Body.String(), "not assessed") {
+				t.Errorf("badge of an unassessed application = %s, want not assessed", rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	
+	"github.com/gorilla/mux"
+)
+
+// Limits of a batch request
+const (
+	maxBatchOperations = 25
+	batchConcurrency   = 4
+)
+
+// batchHeaders are the request headers passed on to the operations of a batch
+var batchHeaders = []string{"X-Forwarded-User", "Accept", "Accept-Language", "Authorization"}
+
+// batchRequest lists the read operations of a batch
+type batchRequest struct {
+	Operations []batchOperation `json:"operations"`
+}
+
+// batchOperation is a GET request of a batch
+type batchOperation struct {
+	ID     string `json:"id,omitempty"`     // echoed in the result
+	Method string `json:"method,omitempty"` // only GET is supported
+	Path   string `json:"path"`             // API path with query, e.g. /api/applications?grade=A
+}
+
+// batchResponse holds the results of a batch in the order of its operations
+type batchResponse struct {
+	Results []batchResult `json:"results"`
+}
+
+// batchResult is the response to an operation of a batch. JSON responses are embedded as
+// they are; other responses, such as CSV, as a string.
+type batchResult struct {
+	ID     string          `json:"id,omitempty"`
+	Status int             `json:"status"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// batchRecorder captures the response of an operation of a batch
+type batchRecorder struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (r *batchRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *batchRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+}
+
+func (r *batchRecorder) Write(data []byte) (int, error) {
+	r.WriteHeader(http.StatusOK)
+	return r.body.Write(data)
+}
+
+// batchHandler runs the read operations of a batch through the router, a few at a time, and
+// returns their combined results, so dashboards can load related resources in one request.
+// Operations pass through the same middleware as regular requests.
+func batchHandler(router *mux.Router) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var request batchRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+			return
+		}
+		if len(request.Operations) == 0 {
+			respondWithError(w, http.StatusBadRequest, "At least one operation is required")
+			return
+		}
+		if len(request.Operations) > maxBatchOperations {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("A batch holds at most %d operations", maxBatchOperations))
+			return
+		}
+		
+		results := make([]batchResult, len(request.Operations))
+		slots := make(chan struct{}, batchConcurrency)
+		var wg sync.WaitGroup
+		for i, operation := range request.Operations {
+			wg.Add(1)
+			slots <- struct{}{}
+			go func(i int, operation batchOperation) {
+				defer func() { <-slots; wg.Done() }()
+				results[i] = runBatchOperation(router, r, operation)
+			}(i, operation)
+		}
+		wg.Wait()
+		
+		respondWithJSON(w, http.StatusOK, batchResponse{Results: results})
+	}
+}
+
+// runBatchOperation serves an operation of a batch with the headers of the batch request
+func runBatchOperation(router *mux.Router, batch *http.Request, operation batchOperation) batchResult {
+	result := batchResult{ID: operation.ID}
+	fail := func(status int, message string) batchResult {
+		result.Status = status
+		result.Body, _ = json.Marshal(map[string]string{"error": message})
+		return result
+	}
+	
+	if operation.Method != "" && !strings.EqualFold(operation.Method, http.MethodGet) {
+		return fail(http.StatusMethodNotAllowed, "Only GET operations can be batched")
+	}
+	target, err := url.Parse(operation.Path)
+	if err != nil || target.IsAbs() || target.Host != "" || !strings.HasPrefix(target.Path, "/api/") {
+		return fail(http.StatusBadRequest, "Invalid path, expected an API path such as /api/applications")
+	}
+	
+	req, err := http.NewRequestWithContext(batch.Context(), http.MethodGet, target.RequestURI(), nil)
+	if err != nil {
+		return fail(http.StatusBadRequest, "Invalid path: "+err.Error())
+	}
+	req.RequestURI = target.RequestURI()
+	req.Host = batch.Host
+	req.RemoteAddr = batch.RemoteAddr
+	for _, name := range batchHeaders {
+		if value := batch.Header.Get(name); value != "" {
+			req.Header.Set(name, value)
+		}
+	}
+	
+	// Streams only end when the client disconnects
+	var match mux.RouteMatch
+	if router.Match(req, &match) && match.Route != nil {
+		if template, err := match.Route.GetPathTemplate(); err == nil && routeClasses[template] == routeStream {
+			return fail(http.StatusBadRequest, "Event streams cannot be batched")
+		}
+	}
+	
+	recorder := &batchRecorder{header: make(http.Header)}
+	router.ServeHTTP(recorder, req)
+	result.Status = recorder.status
+	if result.Status == 0 {
+		result.Status = http.StatusOK
+	}
+	
+	body := bytes.TrimSpace(recorder.body.Bytes())
+	switch {
+	case len(body) == 0:
+	case strings.Contains(recorder.header.Get("Content-Type"), "json") && json.Valid(body):
+		result.Body = body
+	default:
+		result.Body, _ = json.Marshal(string(body))
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package api
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// bundleFile is a file of an application bundle
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// bundleManifest lists the files of an application bundle with their checksums, so recipients
+// can check nothing was changed in transit
+type bundleManifest struct {
+	ApplicationID string               `json:"applicationId"`
+	AssessmentID  string               `json:"assessmentId"`
+	GeneratedAt   time.Time            `json:"generatedAt"`
+	Files         []bundleManifestFile `json:"files"`
+}
+
+type bundleManifestFile struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// GetApplicationBundle returns a ZIP archive for handing an application off to auditors or
+// migration vendors: its latest report as JSON and as a printable branded page, the assessment
+// behind it with its history and answer evidence, and a manifest of checksums
+func (h *Handler) GetApplicationBundle(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	applicationID := vars["applicationId"]
+	
+	bundle, err := h.applicationService.GetApplicationBundle(r.Context(), applicationID)
+	if err != nil {
+		respondWithServiceError(w, "Failed to create bundle", err)
+		return
+	}
+	
+	localized, err := h.assessmentService.LocalizeReport(r.Context(), bundle.Report, h.requestLocale(w, r))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to translate report: "+err.Error())
+		return
+	}
+	branding, err := h.assessmentService.GetBranding(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get branding: "+err.Error())
+		return
+	}
+	var page bytes.Buffer
+	if err := sharedReportTemplate.Execute(&page, sharedReportView{Report: localized, Branding: branding, Logo: template.URL(branding.LogoURL)}); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to render report: "+err.Error())
+		return
+	}
+	
+	files := []bundleFile{{name: "report.html", data: page.Bytes()}}
+	documents := map[string]interface{}{
+		"application.json": bundle.Application,
+		"assessment.json":  bundle.Assessment,
+		"events.json":      bundle.Events,
+		"evidence.json":    bundle.Evidence,
+		"report.json":      bundle.Report,
+	}
+	if key := h.assessmentService.ReportSigningKey(); key != nil {
+		documents["signing-key.json"] = key
+	}
+	names := make([]string, 0, len(documents))
+	for name := range documents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data, err := json.MarshalIndent(documents[name], "", "  ")
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to encode "+name+": "+err.Error())
+			return
+		}
+		files = append(files, bundleFile{name: name, data: data})
+	}
+	
+	manifest := bundleManifest{
+		ApplicationID: applicationID,
+		AssessmentID:  bundle.Assessment.ID,
+		GeneratedAt:   time.Now(),
+	}
+	for _, file := range files {
+		sum := sha256.Sum256(file.data)
+		manifest.Files = append(manifest.Files, bundleManifestFile{Name: file.name, Size: len(file.data), SHA256: hex.EncodeToString(sum[:])})
+	}
+	data, _ := json.MarshalIndent(manifest, "", "  ")
+	files = append(files, bundleFile{name: "manifest.json", data: data})
+	
+	// The archive is built in memory so a failure can still be reported as an error response
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	for _, file := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: applicationID + "/" + file.name, Method: zip.Deflate, Modified: time.Now()})
+		if err == nil {
+			_, err = fw.Write(file.data)
+		}
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to write bundle: "+err.Error())
+			return
+		}
+	}
+	if err := zw.Close(); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to write bundle: "+err.Error())
+		return
+	}
+	
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+applicationID+`-bundle.zip"`)
+	w.WriteHeader(http.StatusOK)
+	w.Write(archive.Bytes())
+}
@@ -0,0 +1,194 @@
+package api
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// CreateCampaign creates a new campaign
+func (h *Handler) CreateCampaign(w http.ResponseWriter, r *http.Request) {
+	var campaign models.Campaign
+	if err := json.NewDecoder(r.Body).Decode(&campaign); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	campaign.CreatedBy = requestUser(r)
+	
+	created, err := h.campaignService.CreateCampaign(r.Context(), &campaign)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to create campaign: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
+// ListCampaigns returns all campaigns
+func (h *Handler) ListCampaigns(w http.ResponseWriter, r *http.Request) {
+	campaigns, err := h.campaignService.ListCampaigns(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list campaigns: "+err.Error())
+		return
+	}
+	
+	respondWithList(w, r, campaigns, campaignLinks)
+}
+
+// GetCampaign returns a campaign by ID
+func (h *Handler) GetCampaign(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	campaignID := vars["campaignId"]
+	
+	campaign, err := h.campaignService.GetCampaign(r.Context(), campaignID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get campaign: "+err.Error())
+		return
+	}
+	
+	if campaign == nil {
+		respondWithError(w, http.StatusNotFound, "Campaign not found")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, campaign)
+}
+
+// GetCampaignFeed returns campaign progress as JSON for live dashboards
+func (h *Handler) GetCampaignFeed(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	campaignID := vars["campaignId"]
+	
+	progress, err := h.campaignService.GetProgress(r.Context(), campaignID)
+	if err != nil {
+		respondWithServiceError(w, "Failed to get campaign progress", err)
+		return
+	}
+	
+	// Dashboards poll the feed, so it must always reflect the current state
+	w.Header().Set("Cache-Control", "no-cache")
+	respondWithJSON(w, http.StatusOK, progress)
+}
+
+// ExportCampaignCSV returns campaign progress as a CSV file with one row per application
+func (h *Handler) ExportCampaignCSV(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	campaignID := vars["campaignId"]
+	
+	progress, err := h.campaignService.GetProgress(r.Context(), campaignID)
+	if err != nil {
+		respondWithServiceError(w, "Failed to get campaign progress", err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="campaign-`+campaignID+`.csv"`)
+	w.WriteHeader(http.StatusOK)
+	
+	out := csv.NewWriter(w)
+	out.Write([]string{"application_id", "application", "owner", "status", "assessment_id", "score_percent", "grade", "due_date", "overdue"})
+	for _, entry := range progress.Entries {
+		score := ""
+		if entry.ScorePercent != nil {
+			score = strconv.Itoa(*entry.ScorePercent)
+		}
+		out.Write([]string{
+			entry.ApplicationID,
+			entry.ApplicationName,
+			entry.Owner,
+			entry.Status,
+			entry.AssessmentID,
+			score,
+			entry.Grade,
+			entry.DueDate,
+			strconv.FormatBool(entry.Overdue),
+		})
+	}
+	out.Flush()
+}
+
+// Intervals of live campaign dashboards
+const (
+	campaignStreamPollInterval = 2 * time.Second
+	campaignStreamPingInterval = 30 * time.Second
+)
+
+// StreamCampaign pushes campaign updates to live dashboards over a WebSocket: the current
+// progress on connect, then completed assessments, changed scores and the new progress as
+// they happen. Progress is polled from storage, so changes made through any replica are seen.
+func (h *Handler) StreamCampaign(w http.ResponseWriter, r *http.Request) {
+	campaignID := mux.Vars(r)["campaignId"]
+	
+	progress, err := h.campaignService.GetProgress(r.Context(), campaignID)
+	if err != nil {
+		respondWithServiceError(w, "Failed to get campaign progress", err)
+		return
+	}
+	
+	conn, err := upgradeWebSocket(w, r)
+	if err != nil {
+		return
+	}
+	
+	// The request context ends when the handler returns; the read loop detects disconnects
+	ctx, cancel := context.WithCancel(context.WithoutCancel(r.Context()))
+	defer cancel()
+	go func() {
+		conn.ReadLoop()
+		cancel()
+	}()
+	
+	send := func(update models.CampaignUpdate) bool {
+		data, _ := json.Marshal(update)
+		return conn.WriteText(data) == nil
+	}
+	if !send(models.CampaignUpdate{Type: models.CampaignUpdateSnapshot, CampaignID: campaignID, OccurredAt: progress.GeneratedAt, Progress: progress}) {
+		conn.Close(1011, "write failed")
+		return
+	}
+	
+	poll := time.NewTicker(campaignStreamPollInterval)
+	defer poll.Stop()
+	ping := time.NewTicker(campaignStreamPingInterval)
+	defer ping.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			conn.Close(1001, "")
+			return
+		case <-ping.C:
+			if conn.Ping() != nil {
+				conn.Close(1011, "write failed")
+				return
+			}
+		case <-poll.C:
+			current, err := h.campaignService.GetProgress(ctx, campaignID)
+			if errors.Is(err, services.ErrNotFound) {
+				conn.Close(1000, "campaign deleted")
+				return
+			}
+			if err != nil {
+				log.Printf("Failed to poll campaign %s: %v", campaignID, err)
+				continue
+			}
+			
+			for _, update := range services.CampaignUpdates(progress, current) {
+				if !send(update) {
+					conn.Close(1011, "write failed")
+					return
+				}
+			}
+			progress = current
+		}
+	}
+}
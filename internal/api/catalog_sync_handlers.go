@@ -0,0 +1,153 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+	"strings"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListCatalogReleases returns the published and received catalog releases, newest first
+func (h *Handler) ListCatalogReleases(w http.ResponseWriter, r *http.Request) {
+	releases, err := h.catalogSyncService.ListReleases(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to list catalog releases", err)
+		return
+	}
+	
+	respondWithList(w, r, releases, nil)
+}
+
+// PublishCatalogRelease saves the current question catalog as a release that can be pushed to
+// downstream instances
+func (h *Handler) PublishCatalogRelease(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Version string `json:"version"`
+		Notes   string `json:"notes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	release, err := h.catalogSyncService.PublishRelease(r.Context(), req.Version, req.Notes, requestUser(r))
+	if errors.Is(err, services.ErrInvalidReleaseVersion) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrInvalidCatalogRelease) {
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to publish catalog release", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusCreated, release)
+}
+
+// GetCatalogRelease returns a catalog release with its questions and categories
+func (h *Handler) GetCatalogRelease(w http.ResponseWriter, r *http.Request) {
+	release, err := h.catalogSyncService.GetRelease(r.Context(), mux.Vars(r)["version"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get catalog release", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, release)
+}
+
+// GetCatalogDownstreams compares the catalog release of each downstream instance with the
+// release it is pinned to
+func (h *Handler) GetCatalogDownstreams(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.catalogSyncService.Downstreams(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get catalog downstreams", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, statuses)
+}
+
+// SyncCatalogDownstreams pushes the pinned or latest catalog release to all downstream
+// instances, or to the one named by the downstream query parameter
+func (h *Handler) SyncCatalogDownstreams(w http.ResponseWriter, r *http.Request) {
+	statuses, err := h.catalogSyncService.Sync(r.Context(), r.URL.Query().Get("downstream"))
+	if err != nil {
+		respondWithServiceError(w, "Failed to sync catalog downstreams", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, statuses)
+}
+
+// authorizeCatalogSync checks the catalog sync token an authoring instance presented as a
+// bearer token, responding with an error if it is not accepted
+func (h *Handler) authorizeCatalogSync(w http.ResponseWriter, r *http.Request) bool {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch err := h.catalogSyncService.Authorize(token); {
+	case errors.Is(err, services.ErrCatalogSyncDisabled):
+		respondWithError(w, http.StatusNotFound, "Catalog sync is not enabled on this instance")
+		return false
+	case err != nil:
+		w.Header().Set("WWW-Authenticate", `Bearer realm="catalog-sync"`)
+		respondWithError(w, http.StatusUnauthorized, "Invalid catalog sync token")
+		return false
+	}
+	return true
+}
+
+// GetCatalogSyncState returns the catalog release this instance received last to an
+// authoring instance
+func (h *Handler) GetCatalogSyncState(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeCatalogSync(w, r) {
+		return
+	}
+	
+	state, err := h.catalogSyncService.State(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get catalog sync state", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, state)
+}
+
+// ReceiveCatalogRelease installs a catalog release pushed by an authoring instance
+func (h *Handler) ReceiveCatalogRelease(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeCatalogSync(w, r) {
+		return
+	}
+	
+	var release models.CatalogRelease
+	if err := json.NewDecoder(r.Body).Decode(&release); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	result, err := h.catalogSyncService.ReceiveRelease(r.Context(), &release)
+	if errors.Is(err, services.ErrInvalidReleaseVersion) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrInvalidCatalogRelease) {
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to receive catalog release", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
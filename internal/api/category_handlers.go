@@ -0,0 +1,63 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListCategories returns the question categories in display order
+func (h *Handler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	categories, err := h.questionService.ListCategories(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to list categories", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, categories)
+}
+
+// SaveCategory creates or replaces the category named in the URL
+func (h *Handler) SaveCategory(w http.ResponseWriter, r *http.Request) {
+	name := mux.Vars(r)["categoryName"]
+	
+	var category models.Category
+	if err := json.NewDecoder(r.Body).Decode(&category); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	if category.Name == "" {
+		category.Name = name
+	}
+	if category.Name != name {
+		respondWithError(w, http.StatusBadRequest, "Category name does not match the URL")
+		return
+	}
+	
+	saved, err := h.questionService.SaveCategory(r.Context(), &category)
+	if errors.Is(err, services.ErrInvalidCategory) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to save category", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, saved)
+}
+
+// DeleteCategory removes a category; categories still used by questions are rejected with 409
+func (h *Handler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	if err := h.questionService.DeleteCategory(r.Context(), mux.Vars(r)["categoryName"]); err != nil {
+		respondWithServiceError(w, "Failed to delete category", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,68 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// scanRejection is the response to an upload a content scanner flagged
+type scanRejection struct {
+	Error string               `json:"error"`
+	Scan  *services.ScanResult `json:"scan"`
+}
+
+// scanMiddleware has the bodies of import routes checked by a content scanner before their
+// handler runs. Uploads the scanner flags are rejected with 422 and never reach storage;
+// uploads that cannot be scanned are rejected with 503, so a scanner outage never lets
+// unscanned files through.
+func scanMiddleware(scanner services.ContentScanner) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		if scanner == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := mux.CurrentRoute(r)
+			if route == nil || r.Method != http.MethodPost {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if template, err := route.GetPathTemplate(); err != nil || routeClasses[template] != routeImport {
+				next.ServeHTTP(w, r)
+				return
+			}
+			
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					respondWithError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+					return
+				}
+				respondWithError(w, http.StatusBadRequest, "Failed to read request body: "+err.Error())
+				return
+			}
+			
+			result, err := scanner.Scan(r.Context(), data)
+			if err != nil {
+				log.Printf("Failed to scan upload to %s: %v", r.URL.Path, err)
+				respondWithError(w, http.StatusServiceUnavailable, "Upload could not be scanned for malware")
+				return
+			}
+			if !result.Clean {
+				log.Printf("Rejected upload to %s from user %q: %s found %s", r.URL.Path, requestUser(r), result.Scanner, result.Threat)
+				respondWithJSON(w, http.StatusUnprocessableEntity, scanRejection{Error: "Upload rejected by content scan", Scan: result})
+				return
+			}
+			
+			r.Body = io.NopCloser(bytes.NewReader(data))
+			r.ContentLength = int64(len(data))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"strconv"
+	"time"
+)
+
+// GetDigestSubscription returns the requesting user's digest subscription
+func (h *Handler) GetDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	user := requestUser(r)
+	if user == "" {
+		respondWithError(w, http.StatusBadRequest, "X-Forwarded-User is required")
+		return
+	}
+	
+	subscription, err := h.digestService.GetSubscription(r.Context(), user)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get digest subscription: "+err.Error())
+		return
+	}
+	
+	if subscription == nil {
+		respondWithError(w, http.StatusNotFound, "Not subscribed to the digest")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, subscription)
+}
+
+// UpdateDigestSubscription subscribes the requesting user to the digest or changes their preferences
+func (h *Handler) UpdateDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	var subscription models.DigestSubscription
+	if err := json.NewDecoder(r.Body).Decode(&subscription); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	subscription.User = requestUser(r)
+	
+	saved, err := h.digestService.Subscribe(r.Context(), &subscription)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to save digest subscription: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, saved)
+}
+
+// DeleteDigestSubscription unsubscribes the requesting user from the digest
+func (h *Handler) DeleteDigestSubscription(w http.ResponseWriter, r *http.Request) {
+	user := requestUser(r)
+	if user == "" {
+		respondWithError(w, http.StatusBadRequest, "X-Forwarded-User is required")
+		return
+	}
+	
+	if err := h.digestService.Unsubscribe(r.Context(), user); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to delete digest subscription: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "unsubscribed"})
+}
+
+// PreviewDigest returns the digest of the past days (7 by default) as the requesting user
+// would receive it, or the full digest for users without a subscription
+func (h *Handler) PreviewDigest(w http.ResponseWriter, r *http.Request) {
+	days := 7
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			respondWithError(w, http.StatusBadRequest, "days must be a positive integer")
+			return
+		}
+		days = parsed
+	}
+	
+	var subscription *models.DigestSubscription
+	if user := requestUser(r); user != "" {
+		var err error
+		if subscription, err = h.digestService.GetSubscription(r.Context(), user); err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get digest subscription: "+err.Error())
+			return
+		}
+	}
+	
+	end := time.Now()
+	digest, err := h.digestService.BuildDigest(r.Context(), end.AddDate(0, 0, -days), end, subscription)
+	if err != nil {
+		respondWithServiceError(w, "Failed to build digest", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, digest)
+}
+
+// ListDigestSubscriptions returns every user's digest subscription
+func (h *Handler) ListDigestSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subscriptions, err := h.digestService.ListSubscriptions(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list digest subscriptions: "+err.Error())
+		return
+	}
+	
+	respondWithList(w, r, subscriptions, nil)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// externalIDRequest sets the external ID of an application or assessment; empty clears it
+type externalIDRequest struct {
+	ExternalID string `json:"externalId"`
+}
+
+// decodeExternalID reads an external ID request; on failure an error response has been written
+func decodeExternalID(w http.ResponseWriter, r *http.Request) (string, bool) {
+	var req externalIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return "", false
+	}
+	return req.ExternalID, true
+}
+
+// respondWithExternalIDError maps invalid external IDs to 400 and the others as service errors
+func respondWithExternalIDError(w http.ResponseWriter, message string, err error) {
+	if errors.Is(err, services.ErrInvalidExternalID) {
+		respondWithError(w, http.StatusBadRequest, message+": "+err.Error())
+		return
+	}
+	respondWithServiceError(w, message, err)
+}
+
+// GetApplicationByExternalID returns the application carrying an external ID
+func (h *Handler) GetApplicationByExternalID(w http.ResponseWriter, r *http.Request) {
+	app, err := h.applicationService.GetApplicationByExternalID(r.Context(), mux.Vars(r)["externalId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get application", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// SetApplicationExternalID sets or clears the external ID of an application
+func (h *Handler) SetApplicationExternalID(w http.ResponseWriter, r *http.Request) {
+	externalID, ok := decodeExternalID(w, r)
+	if !ok {
+		return
+	}
+	
+	app, err := h.applicationService.SetExternalID(r.Context(), mux.Vars(r)["applicationId"], externalID)
+	if err != nil {
+		respondWithExternalIDError(w, "Failed to set external ID", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, app)
+}
+
+// GetAssessmentByExternalID returns the assessment carrying an external ID
+func (h *Handler) GetAssessmentByExternalID(w http.ResponseWriter, r *http.Request) {
+	assessment, err := h.assessmentService.GetAssessmentByExternalID(r.Context(), mux.Vars(r)["externalId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get assessment", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
+
+// SetAssessmentExternalID sets or clears the external ID of an assessment
+func (h *Handler) SetAssessmentExternalID(w http.ResponseWriter, r *http.Request) {
+	externalID, ok := decodeExternalID(w, r)
+	if !ok {
+		return
+	}
+	
+	assessment, err := h.assessmentService.SetExternalID(r.Context(), mux.Vars(r)["assessmentId"], externalID, requestUser(r))
+	if err != nil {
+		respondWithExternalIDError(w, "Failed to set external ID", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
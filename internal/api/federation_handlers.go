@@ -0,0 +1,43 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/services"
+	"strings"
+)
+
+// GetFederatedSummary returns this instance's summary data to a central instance presenting
+// the federation token as a bearer token
+func (h *Handler) GetFederatedSummary(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	switch err := h.federationService.Authorize(token); {
+	case errors.Is(err, services.ErrFederationDisabled):
+		respondWithError(w, http.StatusNotFound, "Federation is not enabled on this instance")
+		return
+	case err != nil:
+		w.Header().Set("WWW-Authenticate", `Bearer realm="federation"`)
+		respondWithError(w, http.StatusUnauthorized, "Invalid federation token")
+		return
+	}
+	
+	summary, err := h.federationService.Summary(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get federated summary", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, summary)
+}
+
+// GetFederatedPortfolio pulls the summaries of the configured peer instances and aggregates
+// them with this instance's
+func (h *Handler) GetFederatedPortfolio(w http.ResponseWriter, r *http.Request) {
+	portfolio, err := h.federationService.Portfolio(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get federated portfolio", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, portfolio)
+}
@@ -1,27 +1,83 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"io"
+	"log"
 	"net/http"
 	"questionnaire-app/internal/models"
 	"questionnaire-app/internal/services"
+	"strconv"
+	"time"
 	
 	"github.com/gorilla/mux"
 )
 
 // Handler manages HTTP requests
 type Handler struct {
-	assessmentService *services.AssessmentService
+	assessmentService   *services.AssessmentService
+	shareService        *services.ShareService
+	privacyService      *services.PrivacyService
+	retentionService    *services.RetentionService
+	notificationService *services.NotificationService
+	jobService          *services.JobService
+	applicationService  *services.ApplicationService
+	questionService     *services.QuestionService
+	campaignService     *services.CampaignService
+	prefillService      *services.PrefillService
+	tackleService       *services.TackleService
+	portfolioService    *services.PortfolioService
+	digestService       *services.DigestService
+	statsService        *services.StatsService
+	federationService   *services.FederationService
+	catalogSyncService  *services.CatalogSyncService
+}
+
+// Services groups the business services the API layer depends on
+type Services struct {
+	Assessment   *services.AssessmentService
+	Share        *services.ShareService
+	Privacy      *services.PrivacyService
+	Retention    *services.RetentionService
+	Notification *services.NotificationService
+	Job          *services.JobService
+	Application  *services.ApplicationService
+	Question     *services.QuestionService
+	Campaign     *services.CampaignService
+	Prefill      *services.PrefillService
+	Tackle       *services.TackleService
+	Portfolio    *services.PortfolioService
+	Digest       *services.DigestService
+	Stats        *services.StatsService
+	Federation   *services.FederationService
+	CatalogSync  *services.CatalogSyncService
 }
 
 // NewHandler creates a new API handler
-func NewHandler(assessmentService *services.AssessmentService) *Handler {
+func NewHandler(svc Services) *Handler {
 	return &Handler{
-		assessmentService: assessmentService,
+		assessmentService:   svc.Assessment,
+		shareService:        svc.Share,
+		privacyService:      svc.Privacy,
+		retentionService:    svc.Retention,
+		notificationService: svc.Notification,
+		jobService:          svc.Job,
+		applicationService:  svc.Application,
+		questionService:     svc.Question,
+		campaignService:     svc.Campaign,
+		prefillService:      svc.Prefill,
+		tackleService:       svc.Tackle,
+		portfolioService:    svc.Portfolio,
+		digestService:       svc.Digest,
+		statsService:        svc.Stats,
+		federationService:   svc.Federation,
+		catalogSyncService:  svc.CatalogSync,
 	}
 }
 
-// GetQuestions returns all assessment questions
+// GetQuestions returns all assessment questions in the requested language
 func (h *Handler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 	questions, err := h.assessmentService.GetQuestions(r.Context())
 	if err != nil {
@@ -29,13 +85,30 @@ func (h *Handler) GetQuestions(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	respondWithJSON(w, http.StatusOK, questions)
+	respondWithList(w, r, services.LocalizeQuestions(questions, h.requestLocale(w, r)), nil)
+}
+
+// GetAssessmentQuestions lists the questions that apply to an assessment's application in the
+// requested language
+func (h *Handler) GetAssessmentQuestions(w http.ResponseWriter, r *http.Request) {
+	questions, err := h.assessmentService.GetAssessmentQuestions(r.Context(), mux.Vars(r)["assessmentId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get questions", err)
+		return
+	}
+	
+	respondWithList(w, r, services.LocalizeQuestions(questions, h.requestLocale(w, r)), nil)
 }
 
 // StartAssessment creates a new assessment
 func (h *Handler) StartAssessment(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		ApplicationID string `json:"applicationId"`
+		ApplicationID   string                    `json:"applicationId"`
+		WeightOverrides []models.WeightOverride   `json:"weightOverrides"`
+		Context         *models.AssessmentContext `json:"context"`
+		SpotCheck       *models.SpotCheck         `json:"spotCheck"`
+		Categories      []string                  `json:"categories"`
+		ExternalID      string                    `json:"externalId"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -48,12 +121,36 @@ func (h *Handler) StartAssessment(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	assessment, err := h.assessmentService.StartAssessment(r.Context(), req.ApplicationID)
+	assessment, err := h.assessmentService.StartAssessment(r.Context(), req.ApplicationID, services.StartOptions{
+		StartedBy:       requestUser(r),
+		WeightOverrides: req.WeightOverrides,
+		Context:         req.Context,
+		SpotCheck:       req.SpotCheck,
+		Categories:      req.Categories,
+		ExternalID:      req.ExternalID,
+	})
+	if errors.Is(err, services.ErrInvalidSpotCheck) || errors.Is(err, services.ErrInvalidCategoryScope) || errors.Is(err, services.ErrInvalidExternalID) {
+		respondWithError(w, http.StatusBadRequest, "Failed to start assessment: "+err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrConflict) {
+		respondWithError(w, http.StatusConflict, "Failed to start assessment: "+err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to start assessment: "+err.Error())
 		return
 	}
 	
+	// Suggest answers from the application's tags; the assessment is usable without them
+	if result, err := h.prefillService.PrefillFromApplication(r.Context(), assessment.ID); err != nil {
+		log.Printf("Failed to pre-fill assessment %s from application tags: %v", assessment.ID, err)
+	} else if len(result.Suggestions) > 0 {
+		if updated, err := h.assessmentService.GetAssessment(r.Context(), assessment.ID); err == nil && updated != nil {
+			assessment = updated
+		}
+	}
+	
 	respondWithJSON(w, http.StatusCreated, assessment)
 }
 
@@ -62,6 +159,12 @@ func (h *Handler) GetAssessment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	assessmentID := vars["assessmentId"]
 	
+	// Time-travel view: rebuild the assessment as it was at a point in time
+	if at := r.URL.Query().Get("at"); at != "" {
+		h.getAssessmentAt(w, r, assessmentID, at)
+		return
+	}
+	
 	assessment, err := h.assessmentService.GetAssessment(r.Context(), assessmentID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to get assessment: "+err.Error())
@@ -76,14 +179,133 @@ func (h *Handler) GetAssessment(w http.ResponseWriter, r *http.Request) {
 	respondWithJSON(w, http.StatusOK, assessment)
 }
 
-// SaveAnswer saves an answer for a question
+// getAssessmentAt responds with the state of an assessment at an RFC3339 timestamp
+func (h *Handler) getAssessmentAt(w http.ResponseWriter, r *http.Request, assessmentID, at string) {
+	timestamp, err := time.Parse(time.RFC3339, at)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid at timestamp, expected RFC3339: "+err.Error())
+		return
+	}
+	
+	assessment, err := h.assessmentService.GetAssessmentAt(r.Context(), assessmentID, timestamp)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get assessment: "+err.Error())
+		return
+	}
+	
+	if assessment == nil {
+		respondWithError(w, http.StatusNotFound, "Assessment not found at "+at)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
+
+// GetAssessmentHistory returns the event log of an assessment
+func (h *Handler) GetAssessmentHistory(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	events, err := h.assessmentService.GetAssessmentHistory(r.Context(), assessmentID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get assessment history: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, events)
+}
+
+// GetAssessmentMetrics returns timing metrics for an assessment
+func (h *Handler) GetAssessmentMetrics(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	metrics, err := h.assessmentService.GetAssessmentMetrics(r.Context(), assessmentID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Failed to get assessment metrics: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, metrics)
+}
+
+// GetPortfolioCycleTimes returns assessment cycle time metrics across the portfolio
+func (h *Handler) GetPortfolioCycleTimes(w http.ResponseWriter, r *http.Request) {
+	metrics, err := h.assessmentService.GetPortfolioCycleTimes(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get cycle time metrics: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, metrics)
+}
+
+// GetQuestionMetrics returns the time to answer and revisits of each question, slowest first
+// or, with sort=revisits, most revisited first. category filters by category and minAnswers
+// leaves out questions answered in fewer assessments.
+func (h *Handler) GetQuestionMetrics(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	opts := services.QuestionMetricsOptions{
+		Category: query.Get("category"),
+		Sort:     query.Get("sort"),
+	}
+	if opts.Sort != "" && opts.Sort != services.QuestionMetricsByTime && opts.Sort != services.QuestionMetricsByRevisits {
+		respondWithError(w, http.StatusBadRequest, "Invalid sort, expected time or revisits")
+		return
+	}
+	if value := query.Get("minAnswers"); value != "" {
+		minAnswers, err := strconv.Atoi(value)
+		if err != nil || minAnswers < 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid minAnswers, expected a non-negative number")
+			return
+		}
+		opts.MinAnswers = minAnswers
+	}
+	
+	metrics, err := h.assessmentService.GetQuestionMetrics(r.Context(), opts)
+	if err != nil {
+		respondWithServiceError(w, "Failed to get question metrics", err)
+		return
+	}
+	
+	respondWithList(w, r, metrics, nil)
+}
+
+// SetWeightOverrides replaces the assessment-specific question weight overrides
+func (h *Handler) SetWeightOverrides(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var overrides []models.WeightOverride
+	if err := json.NewDecoder(r.Body).Decode(&overrides); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	assessment, err := h.assessmentService.SetWeightOverrides(r.Context(), assessmentID, overrides)
+	if errors.Is(err, services.ErrConflict) {
+		respondWithError(w, http.StatusConflict, "Failed to set weight overrides: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to set weight overrides: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
+
+// SaveAnswer saves an answer for a question. Explanations breaking the question's answer rules
+// are rejected with 422 and the failing fields.
 func (h *Handler) SaveAnswer(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	assessmentID := vars["assessmentId"]
 	
 	var req struct {
-		QuestionID string `json:"questionId"`
-		OptionID   string `json:"optionId"`
+		QuestionID  string   `json:"questionId"`
+		OptionID    string   `json:"optionId"`
+		Explanation string   `json:"explanation"`
+		References  []string `json:"references"`
 	}
 	
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -96,25 +318,96 @@ func (h *Handler) SaveAnswer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
-	if err := h.assessmentService.SaveAnswer(r.Context(), assessmentID, req.QuestionID, req.OptionID); err != nil {
-		respondWithError(w, http.StatusInternalServerError, "Failed to save answer: "+err.Error())
+	err := h.assessmentService.SaveAnswer(r.Context(), assessmentID, req.QuestionID, req.OptionID, req.Explanation, req.References, requestUser(r))
+	var validationErr *services.AnswerValidationError
+	if errors.As(err, &validationErr) {
+		respondWithJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":    "Failed to save answer: the answer breaks the question's answer rules",
+			"problems": validationErr.Problems,
+		})
+		return
+	}
+	if errors.Is(err, services.ErrInvalidAnswer) {
+		respondWithError(w, http.StatusBadRequest, "Failed to save answer: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to save answer", err)
 		return
 	}
 	
 	respondWithJSON(w, http.StatusOK, map[string]string{"status": "success"})
 }
 
-// CompleteAssessment finishes an assessment and generates a report
+// CopyAnswers copies the answers of selected questions or categories from another assessment
+// and returns which answers were copied and which were skipped
+func (h *Handler) CopyAnswers(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var req models.AnswerCopyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	result, err := h.assessmentService.CopyAnswers(r.Context(), assessmentID, req, requestUser(r))
+	if errors.Is(err, services.ErrInvalidAnswer) {
+		respondWithError(w, http.StatusBadRequest, "Failed to copy answers: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to copy answers", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// CompleteAssessment finishes an assessment and generates a report. With async=true the report
+// is generated by a background job and the response is 202 with the job to poll.
 func (h *Handler) CompleteAssessment(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	assessmentID := vars["assessmentId"]
 	
+	templateID, ok := requestReportTemplate(w, r)
+	if !ok {
+		return
+	}
+	
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		assessment, err := h.assessmentService.GetAssessment(r.Context(), assessmentID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to get assessment: "+err.Error())
+			return
+		}
+		
+		if assessment == nil {
+			respondWithError(w, http.StatusNotFound, "Assessment not found")
+			return
+		}
+		
+		job, err := h.jobService.Submit(r.Context(), services.JobCompleteAssessment, assessmentID)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to start report generation: "+err.Error())
+			return
+		}
+		
+		respondWithJob(w, r, job.ID)
+		return
+	}
+	
 	report, err := h.assessmentService.CompleteAssessment(r.Context(), assessmentID)
+	if errors.Is(err, services.ErrEvidenceMissing) {
+		respondWithError(w, http.StatusUnprocessableEntity, "Failed to complete assessment: "+err.Error())
+		return
+	}
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to complete assessment: "+err.Error())
 		return
 	}
 	
+	report, _ = services.ApplyReportTemplate(report, templateID)
 	respondWithJSON(w, http.StatusOK, report)
 }
 
@@ -123,6 +416,11 @@ func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	assessmentID := vars["assessmentId"]
 	
+	templateID, ok := requestReportTemplate(w, r)
+	if !ok {
+		return
+	}
+	
 	report, err := h.assessmentService.GetReport(r.Context(), assessmentID)
 	if err != nil {
 		respondWithError(w, http.StatusInternalServerError, "Failed to get report: "+err.Error())
@@ -134,11 +432,201 @@ func (h *Handler) GetReport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	
+	report, err = h.assessmentService.LocalizeReport(r.Context(), report, h.requestLocale(w, r))
+	if err != nil {
+		respondWithServiceError(w, "Failed to translate report", err)
+		return
+	}
+	
+	report, _ = services.ApplyReportTemplate(report, templateID)
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// GetReportFindings returns the report's risks and recommendations as a SARIF-like findings log
+func (h *Handler) GetReportFindings(w http.ResponseWriter, r *http.Request) {
+	findings, err := h.assessmentService.Findings(r.Context(), mux.Vars(r)["assessmentId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to export findings", err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "application/sarif+json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(findings)
+}
+
+// ListReportVersions returns the versions of a report superseded by re-scoring, oldest first
+func (h *Handler) ListReportVersions(w http.ResponseWriter, r *http.Request) {
+	versions, err := h.assessmentService.ListReportVersions(r.Context(), mux.Vars(r)["assessmentId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to list report versions", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, versions)
+}
+
+// GetReportVersion returns a version of a report, current or superseded
+func (h *Handler) GetReportVersion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	version, err := strconv.Atoi(vars["version"])
+	if err != nil || version < 1 {
+		respondWithError(w, http.StatusBadRequest, "Invalid report version")
+		return
+	}
+	
+	report, err := h.assessmentService.GetReportVersion(r.Context(), vars["assessmentId"], version)
+	if err != nil {
+		respondWithServiceError(w, "Failed to get report version", err)
+		return
+	}
+	
 	respondWithJSON(w, http.StatusOK, report)
 }
 
+// GetPlanDiagram returns the report's modernization plan as a Mermaid Gantt chart or flowchart
+// (type=gantt|flowchart). start (YYYY-MM-DD, default today) and hoursPerDay (default 8) set
+// the Gantt schedule, and markdown=true wraps the definition in a fenced code block.
+func (h *Handler) GetPlanDiagram(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	query := r.URL.Query()
+	
+	opts := services.PlanDiagramOptions{Type: query.Get("type"), Start: time.Now()}
+	if opts.Type != "" && opts.Type != services.PlanDiagramGantt && opts.Type != services.PlanDiagramFlowchart {
+		respondWithError(w, http.StatusBadRequest, "Invalid type, expected gantt or flowchart")
+		return
+	}
+	
+	if raw := query.Get("start"); raw != "" {
+		start, err := time.Parse("2006-01-02", raw)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid start date, expected YYYY-MM-DD")
+			return
+		}
+		opts.Start = start
+	}
+	
+	if raw := query.Get("hoursPerDay"); raw != "" {
+		hours, err := strconv.ParseFloat(raw, 64)
+		if err != nil || hours <= 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid hoursPerDay")
+			return
+		}
+		opts.HoursPerDay = hours
+	}
+	
+	diagram, err := h.assessmentService.PlanDiagram(r.Context(), assessmentID, opts)
+	if err != nil {
+		respondWithServiceError(w, "Failed to draw modernization plan", err)
+		return
+	}
+	
+	if markdown, _ := strconv.ParseBool(query.Get("markdown")); markdown {
+		diagram = "```mermaid\n" + diagram + "```\n"
+	}
+	
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(diagram))
+}
+
+// AddReportAnnotation attaches a reviewer annotation to a report section
+func (h *Handler) AddReportAnnotation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var annotation models.Annotation
+	if err := json.NewDecoder(r.Body).Decode(&annotation); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	if user := requestUser(r); user != "" {
+		annotation.Author = user
+	}
+	
+	if annotation.Section == "" || annotation.Text == "" {
+		respondWithError(w, http.StatusBadRequest, "Section and text are required")
+		return
+	}
+	
+	created, err := h.assessmentService.AddReportAnnotation(r.Context(), assessmentID, &annotation)
+	if errors.Is(err, services.ErrConflict) {
+		respondWithError(w, http.StatusConflict, "Failed to add annotation: "+err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrNotFound) {
+		respondWithError(w, http.StatusNotFound, "Failed to add annotation: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to add annotation: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusCreated, created)
+}
+
 // Helper functions for HTTP responses
 
+// requestUser returns the user identity asserted by the authenticating proxy, if any
+func requestUser(r *http.Request) string {
+	return r.Header.Get("X-Forwarded-User")
+}
+
+// ApproveAssessment signs off a completed assessment, locking it and its report against changes.
+// The optional body ticks the items of the review checklist.
+func (h *Handler) ApproveAssessment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var req struct {
+		Checklist []models.CheckedItem `json:"checklist"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	assessment, err := h.assessmentService.ApproveAssessment(r.Context(), assessmentID, requestUser(r), req.Checklist)
+	if errors.Is(err, services.ErrInvalidChecklist) {
+		respondWithError(w, http.StatusBadRequest, "Failed to approve assessment: "+err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrChecklistIncomplete) {
+		respondWithError(w, http.StatusUnprocessableEntity, "Failed to approve assessment: "+err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to approve assessment", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, assessment)
+}
+
+// statusClientClosedRequest is the non-standard status logged when the client went away
+// before the response was ready
+const statusClientClosedRequest = 499
+
+// respondWithServiceError maps sentinel service errors to status codes, defaulting to 500
+func respondWithServiceError(w http.ResponseWriter, message string, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, services.ErrConflict):
+		code = http.StatusConflict
+	case errors.Is(err, context.DeadlineExceeded):
+		code = http.StatusGatewayTimeout
+	case errors.Is(err, context.Canceled):
+		code = statusClientClosedRequest
+	}
+	
+	respondWithError(w, code, message+": "+err.Error())
+}
+
 func respondWithError(w http.ResponseWriter, code int, message string) {
 	respondWithJSON(w, code, map[string]string{"error": message})
 }
@@ -152,3 +640,41 @@ func respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
 		w.Write(response)
 	}
 }
+
+// VerifyReport checks the signature of a report submitted in the request body
+func (h *Handler) VerifyReport(w http.ResponseWriter, r *http.Request) {
+	var report models.Report
+	if err := json.NewDecoder(r.Body).Decode(&report); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	err := h.assessmentService.VerifyReport(&report)
+	if errors.Is(err, services.ErrSigningDisabled) {
+		respondWithError(w, http.StatusNotImplemented, err.Error())
+		return
+	}
+	
+	result := map[string]interface{}{
+		"valid":        err == nil,
+		"assessmentId": report.AssessmentID,
+	}
+	if err != nil {
+		result["error"] = err.Error()
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// GetReportSigningKey returns the public key that verifies report signatures as a JWK set
+func (h *Handler) GetReportSigningKey(w http.ResponseWriter, r *http.Request) {
+	key := h.assessmentService.ReportSigningKey()
+	if key == nil {
+		respondWithError(w, http.StatusNotFound, "Report signing is not configured")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"keys": []map[string]string{key},
+	})
+}
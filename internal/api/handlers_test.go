@@ -0,0 +1,29 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	
+	"github.com/gorilla/mux"
+	
+	"questionnaire-app/internal/api"
+	"questionnaire-app/internal/questionnairetest"
+)
+
+func TestAddReportAnnotationToUnknownReport(t *testing.T) {
+	store := questionnairetest.NewMemoryStorage()
+	handler := api.NewHandler(api.Services{Assessment: questionnairetest.NewAssessmentService(store)})
+	
+	body := `{"section": "risk", "target": "0", "text": "Accepted by the architecture board"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/assessments/missing/report/annotations", strings.NewReader(body))
+	req = mux.SetURLVars(req, map[string]string{"assessmentId": "missing"})
+	rec := httptest.NewRecorder()
+	
+	handler.AddReportAnnotation(rec, req)
+	
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body)
+	}
+}
@@ -0,0 +1,206 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"questionnaire-app/internal/models"
+	"strconv"
+	"strings"
+)
+
+// Page sizes of list responses
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// Link is a hypermedia link to a related resource
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links maps link relations such as self, next or report to their targets
+type Links map[string]Link
+
+// listEnvelope wraps a page of a list response with its total count and navigation links
+type listEnvelope struct {
+	Items  []json.RawMessage `json:"items"`
+	Total  int               `json:"total"`
+	Offset int               `json:"offset"`
+	Limit  int               `json:"limit"`
+	Links  Links             `json:"_links"`
+}
+
+// respondWithList writes one page of items, selected by the offset and limit query parameters,
+// in a list envelope. links returns the links of each item and may be nil.
+func respondWithList[T any](w http.ResponseWriter, r *http.Request, items []T, links func(T) Links) {
+	offset, limit, err := pageParams(r.URL.Query())
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	
+	total := len(items)
+	end := offset + limit
+	if offset > total {
+		offset = total
+	}
+	if end > total {
+		end = total
+	}
+	
+	envelope := listEnvelope{
+		Items:  make([]json.RawMessage, 0, end-offset),
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+		Links:  Links{"self": {Href: pageURL(r, offset, limit)}},
+	}
+	
+	for _, item := range items[offset:end] {
+		var itemLinks Links
+		if links != nil {
+			itemLinks = links(item)
+		}
+		data, err := withLinks(item, itemLinks)
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to encode response: "+err.Error())
+			return
+		}
+		envelope.Items = append(envelope.Items, data)
+	}
+	
+	var header []string
+	if end < total {
+		envelope.Links["next"] = Link{Href: pageURL(r, end, limit)}
+		header = append(header, fmt.Sprintf(`<%s>; rel="next"`, envelope.Links["next"].Href))
+	}
+	if offset > 0 {
+		prev := offset - limit
+		if prev < 0 {
+			prev = 0
+		}
+		envelope.Links["prev"] = Link{Href: pageURL(r, prev, limit)}
+		header = append(header, fmt.Sprintf(`<%s>; rel="prev"`, envelope.Links["prev"].Href))
+	}
+	if len(header) > 0 {
+		w.Header().Set("Link", strings.Join(header, ", "))
+	}
+	
+	respondWithJSON(w, http.StatusOK, envelope)
+}
+
+// pageParams reads the offset and limit query parameters
+func pageParams(query url.Values) (int, int, error) {
+	offset, limit := 0, defaultPageLimit
+	
+	if raw := query.Get("offset"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			return 0, 0, fmt.Errorf("Invalid offset")
+		}
+		offset = value
+	}
+	
+	if raw := query.Get("limit"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 1 || value > maxPageLimit {
+			return 0, 0, fmt.Errorf("Invalid limit, expected 1 to %d", maxPageLimit)
+		}
+		limit = value
+	}
+	
+	return offset, limit, nil
+}
+
+// pageURL returns the request's URL with the given page, keeping its other query parameters
+func pageURL(r *http.Request, offset, limit int) string {
+	query := r.URL.Query()
+	query.Set("offset", strconv.Itoa(offset))
+	query.Set("limit", strconv.Itoa(limit))
+	return r.URL.Path + "?" + query.Encode()
+}
+
+// withLinks encodes a resource with its links added as a _links member
+func withLinks(item interface{}, links Links) (json.RawMessage, error) {
+	data, err := json.Marshal(item)
+	if err != nil || len(links) == 0 {
+		return data, err
+	}
+	
+	encoded, err := json.Marshal(links)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Splice the links in before the object's closing brace
+	data = data[:len(data)-1]
+	if len(data) > 1 {
+		data = append(data, ',')
+	}
+	data = append(data, `"_links":`...)
+	data = append(append(data, encoded...), '}')
+	return data, nil
+}
+
+// applicationLinks returns the links of an application
+func applicationLinks(app *models.Application) Links {
+	base := "/api/applications/" + url.PathEscape(app.ID)
+	return Links{
+		"self":        {Href: base},
+		"assessments": {Href: base + "/assessments"},
+		"badge":       {Href: base + "/badge.svg"},
+	}
+}
+
+// scoredApplicationLinks returns the links of an application and of its latest report
+func scoredApplicationLinks(app *models.ScoredApplication) Links {
+	links := applicationLinks(app.Application)
+	if app.LatestScore != nil {
+		links["report"] = Link{Href: "/api/assessments/" + url.PathEscape(app.LatestScore.AssessmentID) + "/report"}
+	}
+	return links
+}
+
+// assessmentLinks returns the links of an assessment
+func assessmentLinks(assessment *models.Assessment) Links {
+	base := "/api/assessments/" + url.PathEscape(assessment.ID)
+	links := Links{
+		"self":        {Href: base},
+		"application": {Href: "/api/applications/" + url.PathEscape(assessment.ApplicationID)},
+		"answers":     {Href: base + "/answers"},
+		"events":      {Href: base + "/events"},
+	}
+	if assessment.Status != "in_progress" {
+		links["report"] = Link{Href: base + "/report"}
+	}
+	return links
+}
+
+// jobLinks returns the links of a background job
+func jobLinks(job *models.Job) Links {
+	base := "/api/jobs/" + url.PathEscape(job.ID)
+	return Links{
+		"self":   {Href: base},
+		"events": {Href: base + "/events"},
+	}
+}
+
+// campaignLinks returns the links of a campaign
+func campaignLinks(campaign *models.Campaign) Links {
+	base := "/api/campaigns/" + url.PathEscape(campaign.ID)
+	return Links{
+		"self":   {Href: base},
+		"feed":   {Href: base + "/feed"},
+		"status": {Href: base + "/status.csv"},
+	}
+}
+
+// deadLetterLinks returns the links of an undeliverable outbox message
+func deadLetterLinks(message *models.OutboxMessage) Links {
+	return Links{
+		"retry": {Href: "/api/admin/outbox/" + url.PathEscape(message.ID) + "/retry"},
+	}
+}
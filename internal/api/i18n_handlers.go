@@ -0,0 +1,91 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// requestLocale negotiates the language of a response from the lang query parameter and the
+// Accept-Language header, and announces it in Content-Language
+func (h *Handler) requestLocale(w http.ResponseWriter, r *http.Request) string {
+	w.Header().Add("Vary", "Accept-Language")
+	
+	preferred := services.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		preferred = append([]string{lang}, preferred...)
+	}
+	
+	locale, err := h.questionService.NegotiateLocale(r.Context(), preferred)
+	if err != nil {
+		log.Printf("Failed to negotiate locale, answering in %s: %v", models.SourceLocale, err)
+		locale = models.SourceLocale
+	}
+	
+	w.Header().Set("Content-Language", locale)
+	return locale
+}
+
+// ListLocales returns the locales the question catalog is available in, the source locale first
+func (h *Handler) ListLocales(w http.ResponseWriter, r *http.Request) {
+	locales, err := h.questionService.Locales(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to list locales", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]interface{}{"source": models.SourceLocale, "locales": locales})
+}
+
+// GetMessageCatalog returns the message catalog of the locale in the URL
+func (h *Handler) GetMessageCatalog(w http.ResponseWriter, r *http.Request) {
+	catalog, err := h.questionService.GetMessageCatalog(r.Context(), mux.Vars(r)["locale"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get message catalog", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, catalog)
+}
+
+// SaveMessageCatalog creates or replaces the message catalog of the locale in the URL. The body
+// holds the messages keyed by their source text.
+func (h *Handler) SaveMessageCatalog(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Messages map[string]string `json:"messages"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	catalog, err := h.questionService.SaveMessageCatalog(r.Context(), &models.MessageCatalog{
+		Locale:   mux.Vars(r)["locale"],
+		Messages: req.Messages,
+	})
+	if errors.Is(err, services.ErrInvalidLocale) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to save message catalog", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, catalog)
+}
+
+// DeleteMessageCatalog removes the message catalog of the locale in the URL
+func (h *Handler) DeleteMessageCatalog(w http.ResponseWriter, r *http.Request) {
+	if err := h.questionService.DeleteMessageCatalog(r.Context(), mux.Vars(r)["locale"]); err != nil {
+		respondWithServiceError(w, "Failed to delete message catalog", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListJobs returns all background jobs, newest first
+func (h *Handler) ListJobs(w http.ResponseWriter, r *http.Request) {
+	jobs, err := h.jobService.ListJobs(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list jobs: "+err.Error())
+		return
+	}
+	
+	respondWithList(w, r, jobs, jobLinks)
+}
+
+// GetJob returns the status and, once finished, the result of a background job
+func (h *Handler) GetJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["jobId"]
+	
+	job, err := h.jobService.GetJob(r.Context(), jobID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get job: "+err.Error())
+		return
+	}
+	
+	if job == nil {
+		respondWithError(w, http.StatusNotFound, "Job not found")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, job)
+}
+
+// jobStreamPollInterval is how often a job status stream checks for changes
+const jobStreamPollInterval = 500 * time.Millisecond
+
+// StreamJob sends the status of a job as server-sent events until it finishes
+func (h *Handler) StreamJob(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	jobID := vars["jobId"]
+	
+	// Streams outlive the server's write timeout, so extend the deadline as events are sent
+	controller := http.NewResponseController(w)
+	
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	
+	lastStatus := ""
+	ticker := time.NewTicker(jobStreamPollInterval)
+	defer ticker.Stop()
+	
+	for {
+		job, err := h.jobService.GetJob(r.Context(), jobID)
+		if err != nil || job == nil {
+			fmt.Fprintf(w, "event: error\ndata: {\"error\":\"job not found\"}\n\n")
+			controller.Flush()
+			return
+		}
+		
+		if job.Status != lastStatus {
+			lastStatus = job.Status
+			data, _ := json.Marshal(job)
+			controller.SetWriteDeadline(time.Now().Add(time.Minute))
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", job.Status, data)
+			controller.Flush()
+		}
+		
+		if job.Status == models.JobSucceeded || job.Status == models.JobFailed {
+			return
+		}
+		
+		select {
+		case <-r.Context().Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// respondWithJob acknowledges an operation accepted for background execution
+func respondWithJob(w http.ResponseWriter, r *http.Request, jobID string) {
+	location := "/api/jobs/" + jobID
+	w.Header().Set("Location", location)
+	respondWithJSON(w, http.StatusAccepted, map[string]string{
+		"jobId":     jobID,
+		"statusUrl": baseURL(r) + location,
+	})
+}
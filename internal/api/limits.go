@@ -0,0 +1,130 @@
+package api
+
+import (
+	"net/http"
+	"questionnaire-app/internal/services"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// ServerConfig holds the HTTP server's timeouts, request size limits and optional routes
+type ServerConfig struct {
+	Port          int
+	ScoreMetrics  bool          // serve per-application scores as Prometheus gauges on /metrics
+	ReadTimeout   time.Duration // reading a request, headers and body
+	WriteTimeout  time.Duration // writing a response
+	IdleTimeout   time.Duration // keep-alive connections between requests
+	RouteTimeout  time.Duration // handler time of regular routes
+	ExportTimeout time.Duration // handler time of export routes
+	ImportTimeout time.Duration // handler time of import routes
+	MaxBodySize   int64         // request body of regular routes
+	MaxImportSize int64         // request body of import routes
+	
+	ContentScanner services.ContentScanner // checks the bodies of import routes; nil disables scanning
+	
+	WebSocketOrigins []string // origins of pages on other hosts allowed to open WebSockets; "*" allows any
+}
+
+// DefaultServerConfig returns the limits used when nothing is configured
+func DefaultServerConfig() ServerConfig {
+	return ServerConfig{
+		Port:          8080,
+		ReadTimeout:   15 * time.Second,
+		WriteTimeout:  15 * time.Second,
+		IdleTimeout:   60 * time.Second,
+		RouteTimeout:  15 * time.Second,
+		ExportTimeout: 5 * time.Minute,
+		ImportTimeout: 5 * time.Minute,
+		MaxBodySize:   1 << 20,
+		MaxImportSize: 10 << 20,
+	}
+}
+
+// routeClass groups routes that share timeouts and body limits
+type routeClass int
+
+const (
+	routeDefault routeClass = iota
+	routeExport             // long-running downloads
+	routeImport             // large uploads
+	routeStream             // server-sent events, which manage their own deadlines
+)
+
+// routeClasses lists the routes that do not use the regular limits, by path template
+var routeClasses = map[string]routeClass{
+	"/api/admin/tackle/export":                             routeExport,
+	"/api/users/{userId}/data":                             routeExport,
+	"/api/campaigns/{campaignId}/status.csv":               routeExport,
+	"/api/portfolio/waves.csv":                             routeExport,
+	"/api/portfolio/risks.csv":                             routeExport,
+	"/api/portfolio/heatmap.csv":                           routeExport,
+	"/api/applications/{applicationId}/bundle.zip":         routeExport,
+	"/api/admin/questions/import":                          routeImport,
+	"/api/admin/tackle/import":                             routeImport,
+	"/api/admin/catalogs/git/install":                      routeImport,
+	"/api/catalog-sync/release":                            routeImport,
+	"/api/applications/{applicationId}/assessments/import": routeImport,
+	"/api/assessments/{assessmentId}/prefill/cluster-scan": routeImport,
+	"/api/jobs/{jobId}/events":                             routeStream,
+	"/api/campaigns/{campaignId}/live":                     routeStream,
+}
+
+// limitsFor returns the handler timeout and maximum body size of a route class; a zero
+// timeout means the handler is not limited
+func (c ServerConfig) limitsFor(class routeClass) (time.Duration, int64) {
+	switch class {
+	case routeExport:
+		return c.ExportTimeout, c.MaxBodySize
+	case routeImport:
+		return c.ImportTimeout, c.MaxImportSize
+	case routeStream:
+		return 0, c.MaxBodySize
+	default:
+		return c.RouteTimeout, c.MaxBodySize
+	}
+}
+
+// limitsMiddleware applies the matched route's handler timeout and body size limit. Routes
+// allowed to run longer than the server's read or write timeout have their connection
+// deadlines extended accordingly.
+func limitsMiddleware(config ServerConfig) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			class := routeDefault
+			if route := mux.CurrentRoute(r); route != nil {
+				if template, err := route.GetPathTemplate(); err == nil {
+					class = routeClasses[template]
+				}
+			}
+			timeout, maxBody := config.limitsFor(class)
+			
+			if maxBody > 0 {
+				if r.ContentLength > maxBody {
+					respondWithError(w, http.StatusRequestEntityTooLarge, "Request body too large")
+					return
+				}
+				r.Body = http.MaxBytesReader(w, r.Body, maxBody)
+			}
+			
+			if timeout <= 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+			
+			controller := http.NewResponseController(w)
+			deadline := time.Now().Add(timeout)
+			if timeout > config.ReadTimeout {
+				controller.SetReadDeadline(deadline)
+			}
+			if timeout > config.WriteTimeout {
+				// Leave time to write the timeout response itself
+				controller.SetWriteDeadline(deadline.Add(time.Second))
+			}
+			
+			// The timeout body is written without the handler's headers, so set the type up front
+			w.Header().Set("Content-Type", "application/json")
+			http.TimeoutHandler(next, timeout, `{"error":"Request timed out"}`).ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"net/http"
+	
+	"github.com/gorilla/mux"
+)
+
+// ListDeadLetters returns notifications that exhausted their delivery attempts
+func (h *Handler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	messages, err := h.notificationService.ListDeadLetters(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list dead letters: "+err.Error())
+		return
+	}
+	
+	respondWithList(w, r, messages, deadLetterLinks)
+}
+
+// RetryDeadLetter requeues a dead notification for delivery
+func (h *Handler) RetryDeadLetter(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	messageID := vars["messageId"]
+	
+	message, err := h.notificationService.RetryDeadLetter(r.Context(), messageID)
+	if err != nil {
+		respondWithError(w, http.StatusNotFound, "Failed to retry notification: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, message)
+}
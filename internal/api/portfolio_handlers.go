@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/csv"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GetPrioritizationMatrix places applications on a business value versus effort matrix.
+// valueThreshold (default 3) and effortThreshold in hours (default: the portfolio median)
+// separate the quadrants.
+func (h *Handler) GetPrioritizationMatrix(w http.ResponseWriter, r *http.Request) {
+	valueThreshold := services.DefaultValueThreshold
+	if raw := r.URL.Query().Get("valueThreshold"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid valueThreshold")
+			return
+		}
+		valueThreshold = value
+	}
+	
+	effortThreshold := 0.0
+	if raw := r.URL.Query().Get("effortThreshold"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid effortThreshold")
+			return
+		}
+		effortThreshold = value
+	}
+	
+	matrix, err := h.portfolioService.PrioritizationMatrix(r.Context(), valueThreshold, effortThreshold)
+	if err != nil {
+		respondWithServiceError(w, "Failed to build prioritization matrix", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, matrix)
+}
+
+// GetWavePlan groups applications into quarterly migration waves. capacityHours and
+// maxApplications limit each wave and startQuarter (YYYY-QN) sets the first wave's quarter.
+func (h *Handler) GetWavePlan(w http.ResponseWriter, r *http.Request) {
+	plan, ok := h.planWaves(w, r)
+	if !ok {
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, plan)
+}
+
+// ExportWavePlanCSV returns the migration wave plan as a CSV file with one row per application
+func (h *Handler) ExportWavePlanCSV(w http.ResponseWriter, r *http.Request) {
+	plan, ok := h.planWaves(w, r)
+	if !ok {
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="migration-waves.csv"`)
+	w.WriteHeader(http.StatusOK)
+	
+	out := csv.NewWriter(w)
+	out.Write([]string{"wave", "quarter", "application_id", "application", "quadrant", "value", "effort_hours", "depends_on"})
+	for _, wave := range plan.Waves {
+		for _, app := range wave.Applications {
+			dependsOn := append(append([]string{}, app.DependsOn...), app.ExternalDependencies...)
+			out.Write([]string{
+				strconv.Itoa(wave.Number),
+				wave.Quarter,
+				app.ApplicationID,
+				app.Name,
+				app.Quadrant,
+				strconv.FormatFloat(app.Value, 'f', -1, 64),
+				strconv.FormatFloat(app.EffortHours, 'f', -1, 64),
+				strings.Join(dependsOn, ";"),
+			})
+		}
+	}
+	for _, app := range plan.Unscheduled {
+		out.Write([]string{"", "", app.ApplicationID, app.Name, "", "", "", app.Reason})
+	}
+	out.Flush()
+}
+
+// planWaves parses the wave plan options and builds the plan, writing an error response on failure
+func (h *Handler) planWaves(w http.ResponseWriter, r *http.Request) (*models.WavePlan, bool) {
+	query := r.URL.Query()
+	opts := services.WavePlanOptions{StartQuarter: query.Get("startQuarter")}
+	
+	if raw := query.Get("capacityHours"); raw != "" {
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid capacityHours")
+			return nil, false
+		}
+		opts.CapacityHours = value
+	}
+	
+	if raw := query.Get("maxApplications"); raw != "" {
+		value, err := strconv.Atoi(raw)
+		if err != nil || value < 0 {
+			respondWithError(w, http.StatusBadRequest, "Invalid maxApplications")
+			return nil, false
+		}
+		opts.MaxApplications = value
+	}
+	
+	plan, err := h.portfolioService.PlanWaves(r.Context(), opts)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to plan migration waves: "+err.Error())
+		return nil, false
+	}
+	return plan, true
+}
+
+// GetPortfolioSummary returns per-category averages and counts by band and grade over the
+// latest report of every application
+func (h *Handler) GetPortfolioSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.portfolioService.PortfolioSummary(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get portfolio summary", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, summary)
+}
+
+// GetScoreMetrics exposes the latest application and category scores as Prometheus gauges
+func (h *Handler) GetScoreMetrics(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	if err := h.portfolioService.WriteScoreMetrics(r.Context(), &b); err != nil {
+		respondWithServiceError(w, "Failed to collect score metrics", err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(b.String()))
+}
+
+// GetPortfolioHeatmap returns the category scores of every application's latest report as an
+// application by category matrix
+func (h *Handler) GetPortfolioHeatmap(w http.ResponseWriter, r *http.Request) {
+	heatmap, err := h.portfolioService.PortfolioHeatmap(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to build heatmap", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, heatmap)
+}
+
+// ExportPortfolioHeatmapCSV returns the heatmap as a CSV file with one row per application and
+// one column per category. value selects the cells: percent (default) or score. Cells of
+// categories a report did not score are empty.
+func (h *Handler) ExportPortfolioHeatmapCSV(w http.ResponseWriter, r *http.Request) {
+	value := r.URL.Query().Get("value")
+	switch value {
+	case "":
+		value = "percent"
+	case "percent", "score":
+	default:
+		respondWithError(w, http.StatusBadRequest, "Invalid value, expected percent or score")
+		return
+	}
+	
+	heatmap, err := h.portfolioService.PortfolioHeatmap(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to build heatmap", err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="portfolio-heatmap.csv"`)
+	w.WriteHeader(http.StatusOK)
+	
+	out := csv.NewWriter(w)
+	out.Write(append([]string{"application_id", "application", "assessment_id", "reported_at", "score_percent", "grade"}, heatmap.Categories...))
+	for _, app := range heatmap.Applications {
+		row := []string{app.ApplicationID, app.ApplicationName, app.AssessmentID, app.ReportedAt.Format(time.RFC3339), strconv.Itoa(app.ScorePercent), app.Grade}
+		for _, category := range heatmap.Categories {
+			cell, ok := app.Cells[category]
+			switch {
+			case !ok:
+				row = append(row, "")
+			case value == "score":
+				row = append(row, strconv.Itoa(cell.Score))
+			case cell.Percent != nil:
+				row = append(row, strconv.Itoa(*cell.Percent))
+			default:
+				row = append(row, "")
+			}
+		}
+		out.Write(row)
+	}
+	out.Flush()
+}
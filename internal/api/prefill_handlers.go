@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"questionnaire-app/internal/services"
+	"strconv"
+	
+	"github.com/gorilla/mux"
+)
+
+// PrefillFromRepository scans the application's Git repository and suggests answers. The
+// optional body {"repository": "<url>"} overrides the repository linked to the application.
+// With async=true the scan runs as a background job.
+func (h *Handler) PrefillFromRepository(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var req struct {
+		Repository string `json:"repository"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	if async, _ := strconv.ParseBool(r.URL.Query().Get("async")); async {
+		job, err := h.jobService.Submit(r.Context(), services.JobPrefillRepository, services.PrefillRepositoryParams{
+			AssessmentID: assessmentID,
+			Repository:   req.Repository,
+		})
+		if err != nil {
+			respondWithError(w, http.StatusInternalServerError, "Failed to start repository scan: "+err.Error())
+			return
+		}
+		respondWithJob(w, r, job.ID)
+		return
+	}
+	
+	result, err := h.prefillService.PrefillFromRepository(r.Context(), assessmentID, req.Repository)
+	if errors.Is(err, services.ErrNotFound) || errors.Is(err, services.ErrConflict) {
+		respondWithServiceError(w, "Failed to pre-fill answers", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to pre-fill answers: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// ConfirmSuggestion accepts a suggested answer
+func (h *Handler) ConfirmSuggestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	questionID := vars["questionId"]
+	
+	if err := h.assessmentService.ConfirmSuggestion(r.Context(), assessmentID, questionID, requestUser(r)); err != nil {
+		respondWithServiceError(w, "Failed to confirm suggestion", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "success"})
+}
+
+// DismissSuggestion discards a suggested answer
+func (h *Handler) DismissSuggestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	questionID := vars["questionId"]
+	
+	if err := h.assessmentService.DismissSuggestion(r.Context(), assessmentID, questionID, requestUser(r)); err != nil {
+		respondWithServiceError(w, "Failed to dismiss suggestion", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "success"})
+}
+
+// PrefillFromClusterScan suggests answers from kube-score, Polaris or Popeye JSON output in
+// the request body; format=kube-score|polaris|popeye overrides format detection
+func (h *Handler) PrefillFromClusterScan(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to read request body: "+err.Error())
+		return
+	}
+	
+	result, err := h.prefillService.PrefillFromClusterScan(r.Context(), assessmentID, r.URL.Query().Get("format"), data)
+	if errors.Is(err, services.ErrNotFound) || errors.Is(err, services.ErrConflict) {
+		respondWithServiceError(w, "Failed to pre-fill answers", err)
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to pre-fill answers: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// PrefillFromApplication suggests answers from the tags of the assessment's application
+func (h *Handler) PrefillFromApplication(w http.ResponseWriter, r *http.Request) {
+	result, err := h.prefillService.PrefillFromApplication(r.Context(), mux.Vars(r)["assessmentId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to pre-fill answers", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
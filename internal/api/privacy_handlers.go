@@ -0,0 +1,47 @@
+package api
+
+import (
+	"net/http"
+	"questionnaire-app/internal/models"
+	
+	"github.com/gorilla/mux"
+)
+
+// ExportUserData returns all personal data recorded for a user
+func (h *Handler) ExportUserData(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+	
+	export, err := h.privacyService.ExportUserData(r.Context(), userID)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to export user data: "+err.Error())
+		return
+	}
+	
+	w.Header().Set("Content-Disposition", `attachment; filename="user-data.json"`)
+	respondWithJSON(w, http.StatusOK, export)
+}
+
+// EraseUserData anonymizes (default) or purges all personal data recorded for a user
+func (h *Handler) EraseUserData(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	userID := vars["userId"]
+	
+	mode := r.URL.Query().Get("mode")
+	if mode == "" {
+		mode = models.ErasureModeAnonymize
+	}
+	
+	if mode != models.ErasureModeAnonymize && mode != models.ErasureModePurge {
+		respondWithError(w, http.StatusBadRequest, "Mode must be anonymize or purge")
+		return
+	}
+	
+	result, err := h.privacyService.EraseUserData(r.Context(), userID, mode)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to erase user data: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,216 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+	"strconv"
+	
+	"github.com/gorilla/mux"
+)
+
+// ImportQuestionsCSV imports a question catalog from a CSV body with one row per option.
+// dryRun=true only validates the file. Invalid files are rejected with 422 and a per-row
+// validation report.
+func (h *Handler) ImportQuestionsCSV(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	
+	report, err := h.questionService.ImportCSV(r.Context(), r.Body, dryRun)
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Failed to import questions: "+err.Error())
+		return
+	}
+	
+	if !report.Valid {
+		respondWithJSON(w, http.StatusUnprocessableEntity, report)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, report)
+}
+
+// PreviewQuestion renders a draft question and validates it against the live catalog. The body
+// holds the draft question and optional sample answers for evaluating its visibility.
+func (h *Handler) PreviewQuestion(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Question *models.Question  `json:"question"`
+		Answers  map[string]string `json:"answers"`
+	}
+	
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Question == nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	preview, err := h.questionService.PreviewQuestion(r.Context(), req.Question, req.Answers)
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to preview question: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, preview)
+}
+
+// DiffCatalogs compares two catalog versions, each a built-in catalog, a list of questions or
+// the live catalog, and estimates the impact of the target catalog on existing reports
+func (h *Handler) DiffCatalogs(w http.ResponseWriter, r *http.Request) {
+	var request models.CatalogDiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	diff, err := h.assessmentService.DiffCatalog(r.Context(), request)
+	if errors.Is(err, services.ErrInvalidCatalogDiff) || errors.Is(err, services.ErrUnknownCatalog) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to diff catalogs: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, diff)
+}
+
+// PublishQuestion validates a question and saves it into the live catalog
+func (h *Handler) PublishQuestion(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	questionID := vars["questionId"]
+	
+	var question models.Question
+	if err := json.NewDecoder(r.Body).Decode(&question); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	if question.ID == "" {
+		question.ID = questionID
+	}
+	if question.ID != questionID {
+		respondWithError(w, http.StatusBadRequest, "Question ID does not match the URL")
+		return
+	}
+	
+	preview, err := h.questionService.PublishQuestion(r.Context(), &question)
+	if errors.Is(err, services.ErrInvalidQuestion) {
+		respondWithJSON(w, http.StatusUnprocessableEntity, preview)
+		return
+	}
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to publish question: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, preview)
+}
+
+// ReloadQuestions re-reads the question catalog without a restart. An invalid catalog is
+// rejected with 422 and the current catalog stays active.
+func (h *Handler) ReloadQuestions(w http.ResponseWriter, r *http.Request) {
+	result, err := h.questionService.ReloadCatalog(r.Context())
+	if errors.Is(err, services.ErrInvalidCatalog) {
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to reload questions", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// ListBuiltinCatalogs lists the question catalogs shipped with the application
+func (h *Handler) ListBuiltinCatalogs(w http.ResponseWriter, r *http.Request) {
+	catalogs, err := services.BuiltinCatalogs()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to list catalogs: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, catalogs)
+}
+
+// GetBuiltinCatalog returns a built-in catalog with its questions
+func (h *Handler) GetBuiltinCatalog(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	
+	catalog, err := services.BuiltinCatalog(vars["catalogId"])
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get catalog: "+err.Error())
+		return
+	}
+	if catalog == nil {
+		respondWithError(w, http.StatusNotFound, "Catalog not found")
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, catalog)
+}
+
+// InstallCatalog saves the questions of a built-in catalog into the live catalog. Existing
+// questions are kept unless replace=true.
+func (h *Handler) InstallCatalog(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+	
+	result, err := h.questionService.InstallCatalog(r.Context(), vars["catalogId"], replace)
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to install catalog", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// InstallGitCatalog installs the question catalog of a Git repository at a tag or branch,
+// checking it against the pinned checksum if one is given
+func (h *Handler) InstallGitCatalog(w http.ResponseWriter, r *http.Request) {
+	replace, _ := strconv.ParseBool(r.URL.Query().Get("replace"))
+	
+	var source models.GitCatalogSource
+	if err := json.NewDecoder(r.Body).Decode(&source); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	result, err := h.questionService.InstallGitCatalog(r.Context(), source, replace)
+	if errors.Is(err, services.ErrInvalidCatalogSource) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrCatalogFetch) {
+		respondWithError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	if errors.Is(err, services.ErrCatalogChecksum) || errors.Is(err, services.ErrInvalidGitCatalog) {
+		respondWithError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to install catalog", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	
+	"github.com/gorilla/mux"
+)
+
+// SetRecommendationRemediation marks the recommendation at an index of a report as accepted,
+// rejected or done, with an optional owner, due date and note
+func (h *Handler) SetRecommendationRemediation(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	index, err := strconv.Atoi(vars["index"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid recommendation index")
+		return
+	}
+	
+	var update models.RemediationUpdate
+	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	remediation, err := h.assessmentService.SetRemediation(r.Context(), vars["assessmentId"], index, update, requestUser(r))
+	if errors.Is(err, services.ErrInvalidRemediation) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to update remediation", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, remediation)
+}
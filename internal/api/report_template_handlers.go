@@ -0,0 +1,22 @@
+package api
+
+import (
+	"net/http"
+	"questionnaire-app/internal/services"
+)
+
+// ListReportTemplates returns the report templates that can be selected with ?template=
+func (h *Handler) ListReportTemplates(w http.ResponseWriter, r *http.Request) {
+	respondWithJSON(w, http.StatusOK, services.ReportTemplates())
+}
+
+// requestReportTemplate returns the report template named in the template query parameter,
+// responding with 400 and returning false if it is unknown
+func requestReportTemplate(w http.ResponseWriter, r *http.Request) (string, bool) {
+	templateID := r.URL.Query().Get("template")
+	if err := services.ValidateReportTemplate(templateID); err != nil {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return "", false
+	}
+	return templateID, true
+}
@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"strconv"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// GetRiskRegister lists the risks of every application's latest report with their acceptance
+// status. severity, category and status (open, accepted or expired) filter by comma-separated
+// values.
+func (h *Handler) GetRiskRegister(w http.ResponseWriter, r *http.Request) {
+	register, ok := h.riskRegister(w, r)
+	if !ok {
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, register)
+}
+
+// ExportRiskRegisterCSV returns the risk register as a CSV file with one row per risk
+func (h *Handler) ExportRiskRegisterCSV(w http.ResponseWriter, r *http.Request) {
+	register, ok := h.riskRegister(w, r)
+	if !ok {
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="risk-register.csv"`)
+	w.WriteHeader(http.StatusOK)
+	
+	out := csv.NewWriter(w)
+	out.Write([]string{"application_id", "application", "assessment_id", "risk_index", "risk_key", "category", "severity", "description", "status", "accepted_by", "accepted_at", "expires_on", "reason"})
+	for _, risk := range register.Risks {
+		row := []string{risk.ApplicationID, risk.ApplicationName, risk.AssessmentID, strconv.Itoa(risk.Index), risk.Key, risk.Category, risk.Severity, risk.Description, risk.Status, "", "", "", ""}
+		if acceptance := risk.Acceptance; acceptance != nil {
+			row[9] = acceptance.AcceptedBy
+			row[10] = acceptance.AcceptedAt.Format(time.RFC3339)
+			row[11] = acceptance.ExpiresOn
+			row[12] = acceptance.Reason
+		}
+		out.Write(row)
+	}
+	out.Flush()
+}
+
+// riskRegister parses the risk register filters and builds the register, writing an error
+// response on failure
+func (h *Handler) riskRegister(w http.ResponseWriter, r *http.Request) (*models.RiskRegister, bool) {
+	query := r.URL.Query()
+	opts := services.RiskRegisterOptions{
+		Severities: splitList(query.Get("severity")),
+		Categories: splitList(query.Get("category")),
+		Statuses:   splitList(query.Get("status")),
+	}
+	for _, status := range opts.Statuses {
+		switch status {
+		case models.RiskStatusOpen, models.RiskStatusAccepted, models.RiskStatusExpired:
+		default:
+			respondWithError(w, http.StatusBadRequest, "Invalid status, expected open, accepted or expired")
+			return nil, false
+		}
+	}
+	
+	register, err := h.portfolioService.RiskRegister(r.Context(), opts)
+	if err != nil {
+		respondWithServiceError(w, "Failed to build risk register", err)
+		return nil, false
+	}
+	return register, true
+}
+
+// AcceptReportRisk accepts the risk at an index of a report until expiresOn. The requesting
+// user is recorded as acceptedBy; without one, acceptedBy must be given.
+func (h *Handler) AcceptReportRisk(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	index, err := strconv.Atoi(vars["index"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid risk index")
+		return
+	}
+	
+	var request models.RiskAcceptanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	acceptance, err := h.assessmentService.AcceptRisk(r.Context(), vars["assessmentId"], index, request, requestUser(r))
+	if errors.Is(err, services.ErrInvalidRiskAcceptance) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to accept risk", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, acceptance)
+}
+
+// RevokeReportRiskAcceptance reopens the risk at an index of a report
+func (h *Handler) RevokeReportRiskAcceptance(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	index, err := strconv.Atoi(vars["index"])
+	if err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid risk index")
+		return
+	}
+	
+	if err := h.assessmentService.RevokeRiskAcceptance(r.Context(), vars["assessmentId"], index); err != nil {
+		respondWithServiceError(w, "Failed to revoke risk acceptance", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// GetQuestionPages returns the question catalog grouped into questionnaire pages in the
+// requested language
+func (h *Handler) GetQuestionPages(w http.ResponseWriter, r *http.Request) {
+	pages, err := h.questionService.GetPages(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to get question pages", err)
+		return
+	}
+	
+	pages, err = h.questionService.LocalizePages(r.Context(), pages, h.requestLocale(w, r))
+	if err != nil {
+		respondWithServiceError(w, "Failed to translate question pages", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, pages)
+}
+
+// GetAssessmentPages returns the questions that apply to an assessment's application grouped
+// into questionnaire pages, with the number of answered questions per page
+func (h *Handler) GetAssessmentPages(w http.ResponseWriter, r *http.Request) {
+	pages, err := h.assessmentService.GetAssessmentPages(r.Context(), mux.Vars(r)["assessmentId"])
+	if err != nil {
+		respondWithServiceError(w, "Failed to get question pages", err)
+		return
+	}
+	
+	pages, err = h.questionService.LocalizePages(r.Context(), pages, h.requestLocale(w, r))
+	if err != nil {
+		respondWithServiceError(w, "Failed to translate question pages", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, pages)
+}
+
+// ListSections returns the questionnaire sections in page order
+func (h *Handler) ListSections(w http.ResponseWriter, r *http.Request) {
+	sections, err := h.questionService.ListSections(r.Context())
+	if err != nil {
+		respondWithServiceError(w, "Failed to list sections", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, sections)
+}
+
+// SaveSection creates or replaces the section with the ID in the URL
+func (h *Handler) SaveSection(w http.ResponseWriter, r *http.Request) {
+	sectionID := mux.Vars(r)["sectionId"]
+	
+	var section models.Section
+	if err := json.NewDecoder(r.Body).Decode(&section); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		return
+	}
+	
+	if section.ID == "" {
+		section.ID = sectionID
+	}
+	if section.ID != sectionID {
+		respondWithError(w, http.StatusBadRequest, "Section ID does not match the URL")
+		return
+	}
+	
+	saved, err := h.questionService.SaveSection(r.Context(), &section)
+	if errors.Is(err, services.ErrInvalidSection) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to save section", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, saved)
+}
+
+// DeleteSection removes a section; its questions move to the page of unsectioned questions
+func (h *Handler) DeleteSection(w http.ResponseWriter, r *http.Request) {
+	if err := h.questionService.DeleteSection(r.Context(), mux.Vars(r)["sectionId"]); err != nil {
+		respondWithServiceError(w, "Failed to delete section", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
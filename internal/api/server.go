@@ -20,28 +20,157 @@ type Server struct {
 }
 
 // NewServer creates a new API server
-func NewServer(handler *Handler, port int) *Server {
+func NewServer(handler *Handler, config ServerConfig) *Server {
 	router := mux.NewRouter()
 	
 	// Register routes
 	router.HandleFunc("/api/health", healthCheckHandler).Methods("GET")
 	router.HandleFunc("/api/questions", handler.GetQuestions).Methods("GET")
+	router.HandleFunc("/api/questions/pages", handler.GetQuestionPages).Methods("GET")
+	router.HandleFunc("/api/categories", handler.ListCategories).Methods("GET")
+	router.HandleFunc("/api/sections", handler.ListSections).Methods("GET")
+	router.HandleFunc("/api/locales", handler.ListLocales).Methods("GET")
+	router.HandleFunc("/api/report-templates", handler.ListReportTemplates).Methods("GET")
+	router.HandleFunc("/api/applications", handler.ListApplications).Methods("GET")
+	router.HandleFunc("/api/archetypes", handler.ListArchetypes).Methods("GET")
+	router.HandleFunc("/api/archetypes/{archetypeId}", handler.GetArchetype).Methods("GET")
+	router.HandleFunc("/api/applications/by-external-id/{externalId}", handler.GetApplicationByExternalID).Methods("GET")
+	router.HandleFunc("/api/applications/{applicationId}", handler.GetApplication).Methods("GET")
+	router.HandleFunc("/api/applications/{applicationId}", handler.DeleteApplication).Methods("DELETE")
+	router.HandleFunc("/api/applications/{applicationId}/assessments", handler.ListApplicationAssessments).Methods("GET")
+	router.HandleFunc("/api/applications/{applicationId}/assessments/import", handler.ImportAssessmentWorkbook).Methods("POST")
+	router.HandleFunc("/api/applications/{applicationId}/lifecycle", handler.TransitionApplicationLifecycle).Methods("POST")
+	router.HandleFunc("/api/applications/{applicationId}/external-id", handler.SetApplicationExternalID).Methods("PUT")
+	router.HandleFunc("/api/applications/{applicationId}/scorecard", handler.GetApplicationScorecard).Methods("GET")
+	router.HandleFunc("/api/applications/{applicationId}/badge.svg", handler.GetApplicationBadge).Methods("GET")
+	router.HandleFunc("/api/applications/{applicationId}/bundle.zip", handler.GetApplicationBundle).Methods("GET")
 	router.HandleFunc("/api/assessments", handler.StartAssessment).Methods("POST")
+	router.HandleFunc("/api/assessments/by-external-id/{externalId}", handler.GetAssessmentByExternalID).Methods("GET")
 	router.HandleFunc("/api/assessments/{assessmentId}", handler.GetAssessment).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/external-id", handler.SetAssessmentExternalID).Methods("PUT")
+	router.HandleFunc("/api/assessments/{assessmentId}/questions", handler.GetAssessmentQuestions).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/pages", handler.GetAssessmentPages).Methods("GET")
 	router.HandleFunc("/api/assessments/{assessmentId}/answers", handler.SaveAnswer).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/answers/copy", handler.CopyAnswers).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/events", handler.GetAssessmentHistory).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/weights", handler.SetWeightOverrides).Methods("PUT")
+	router.HandleFunc("/api/assessments/{assessmentId}/metrics", handler.GetAssessmentMetrics).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop", handler.StartWorkshop).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop", handler.GetWorkshop).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop/advance", handler.MoveWorkshop).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop/votes", handler.VoteInWorkshop).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop/decide", handler.DecideWorkshopQuestion).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/workshop/close", handler.CloseWorkshop).Methods("POST")
+	router.HandleFunc("/api/metrics/cycle-times", handler.GetPortfolioCycleTimes).Methods("GET")
+	router.HandleFunc("/api/metrics/questions", handler.GetQuestionMetrics).Methods("GET")
+	router.HandleFunc("/api/portfolio/summary", handler.GetPortfolioSummary).Methods("GET")
+	router.HandleFunc("/api/portfolio/prioritization", handler.GetPrioritizationMatrix).Methods("GET")
+	router.HandleFunc("/api/portfolio/waves", handler.GetWavePlan).Methods("GET")
+	router.HandleFunc("/api/portfolio/waves.csv", handler.ExportWavePlanCSV).Methods("GET")
+	router.HandleFunc("/api/portfolio/risks", handler.GetRiskRegister).Methods("GET")
+	router.HandleFunc("/api/portfolio/risks.csv", handler.ExportRiskRegisterCSV).Methods("GET")
+	router.HandleFunc("/api/portfolio/heatmap", handler.GetPortfolioHeatmap).Methods("GET")
+	router.HandleFunc("/api/portfolio/heatmap.csv", handler.ExportPortfolioHeatmapCSV).Methods("GET")
+	router.HandleFunc("/api/federation/summary", handler.GetFederatedSummary).Methods("GET")
+	router.HandleFunc("/api/federation/portfolio", handler.GetFederatedPortfolio).Methods("GET")
+	router.HandleFunc("/api/catalog-sync/release", handler.GetCatalogSyncState).Methods("GET")
+	router.HandleFunc("/api/catalog-sync/release", handler.ReceiveCatalogRelease).Methods("POST")
 	router.HandleFunc("/api/assessments/{assessmentId}/complete", handler.CompleteAssessment).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/prefill/repository", handler.PrefillFromRepository).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/prefill/cluster-scan", handler.PrefillFromClusterScan).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/prefill/application", handler.PrefillFromApplication).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/suggestions/{questionId}/confirm", handler.ConfirmSuggestion).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/suggestions/{questionId}", handler.DismissSuggestion).Methods("DELETE")
+	router.HandleFunc("/api/assessments/{assessmentId}/approve", handler.ApproveAssessment).Methods("POST")
 	router.HandleFunc("/api/assessments/{assessmentId}/report", handler.GetReport).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/plan.mmd", handler.GetPlanDiagram).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/findings.sarif", handler.GetReportFindings).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/versions", handler.ListReportVersions).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/versions/{version}", handler.GetReportVersion).Methods("GET")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/annotations", handler.AddReportAnnotation).Methods("POST")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/recommendations/{index}/remediation", handler.SetRecommendationRemediation).Methods("PUT")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/risks/{index}/acceptance", handler.AcceptReportRisk).Methods("PUT")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/risks/{index}/acceptance", handler.RevokeReportRiskAcceptance).Methods("DELETE")
+	router.HandleFunc("/api/assessments/{assessmentId}/report/share", handler.CreateReportShareLink).Methods("POST")
+	router.HandleFunc("/api/reports/verify", handler.VerifyReport).Methods("POST")
+	router.HandleFunc("/api/reports/signing-key", handler.GetReportSigningKey).Methods("GET")
+	router.HandleFunc("/api/shared/reports/{token}", handler.GetSharedReport).Methods("GET")
+	router.HandleFunc("/api/campaigns", handler.ListCampaigns).Methods("GET")
+	router.HandleFunc("/api/campaigns", handler.CreateCampaign).Methods("POST")
+	router.HandleFunc("/api/campaigns/{campaignId}", handler.GetCampaign).Methods("GET")
+	router.HandleFunc("/api/campaigns/{campaignId}/feed", handler.GetCampaignFeed).Methods("GET")
+	router.HandleFunc("/api/campaigns/{campaignId}/live", handler.StreamCampaign).Methods("GET")
+	router.HandleFunc("/api/campaigns/{campaignId}/status.csv", handler.ExportCampaignCSV).Methods("GET")
+	router.HandleFunc("/api/digest/subscription", handler.GetDigestSubscription).Methods("GET")
+	router.HandleFunc("/api/digest/subscription", handler.UpdateDigestSubscription).Methods("PUT")
+	router.HandleFunc("/api/digest/subscription", handler.DeleteDigestSubscription).Methods("DELETE")
+	router.HandleFunc("/api/digest/preview", handler.PreviewDigest).Methods("GET")
+	router.HandleFunc("/api/users/{userId}/data", handler.ExportUserData).Methods("GET")
+	router.HandleFunc("/api/users/{userId}/data", handler.EraseUserData).Methods("DELETE")
+	router.HandleFunc("/api/jobs", handler.ListJobs).Methods("GET")
+	router.HandleFunc("/api/jobs/{jobId}", handler.GetJob).Methods("GET")
+	router.HandleFunc("/api/jobs/{jobId}/events", handler.StreamJob).Methods("GET")
+	router.HandleFunc("/api/admin/stats", handler.GetInstanceStats).Methods("GET")
+	router.HandleFunc("/api/admin/reports/rescore", handler.RescoreReports).Methods("POST")
+	router.HandleFunc("/api/admin/retention", handler.GetRetentionPreview).Methods("GET")
+	router.HandleFunc("/api/admin/retention/run", handler.RunRetention).Methods("POST")
+	router.HandleFunc("/api/admin/assessments/{assessmentId}/reopen", handler.ReopenAssessment).Methods("POST")
+	router.HandleFunc("/api/admin/scoring", handler.GetScoringConfig).Methods("GET")
+	router.HandleFunc("/api/admin/scoring", handler.UpdateScoringConfig).Methods("PUT")
+	router.HandleFunc("/api/admin/estimation", handler.GetEstimationConfig).Methods("GET")
+	router.HandleFunc("/api/admin/estimation", handler.UpdateEstimationConfig).Methods("PUT")
+	router.HandleFunc("/api/admin/branding", handler.GetBranding).Methods("GET")
+	router.HandleFunc("/api/admin/branding", handler.UpdateBranding).Methods("PUT")
+	router.HandleFunc("/api/admin/review-checklist", handler.GetReviewChecklist).Methods("GET")
+	router.HandleFunc("/api/admin/review-checklist", handler.UpdateReviewChecklist).Methods("PUT")
+	router.HandleFunc("/api/admin/questions/import", handler.ImportQuestionsCSV).Methods("POST")
+	router.HandleFunc("/api/admin/questions/reload", handler.ReloadQuestions).Methods("POST")
+	router.HandleFunc("/api/admin/questions/preview", handler.PreviewQuestion).Methods("POST")
+	router.HandleFunc("/api/admin/questions/diff", handler.DiffCatalogs).Methods("POST")
+	router.HandleFunc("/api/admin/questions/{questionId}", handler.PublishQuestion).Methods("PUT")
+	router.HandleFunc("/api/admin/categories/{categoryName}", handler.SaveCategory).Methods("PUT")
+	router.HandleFunc("/api/admin/categories/{categoryName}", handler.DeleteCategory).Methods("DELETE")
+	router.HandleFunc("/api/admin/sections/{sectionId}", handler.SaveSection).Methods("PUT")
+	router.HandleFunc("/api/admin/sections/{sectionId}", handler.DeleteSection).Methods("DELETE")
+	router.HandleFunc("/api/admin/archetypes/{archetypeId}", handler.SaveArchetype).Methods("PUT")
+	router.HandleFunc("/api/admin/archetypes/{archetypeId}", handler.DeleteArchetype).Methods("DELETE")
+	router.HandleFunc("/api/admin/translations/{locale}", handler.GetMessageCatalog).Methods("GET")
+	router.HandleFunc("/api/admin/translations/{locale}", handler.SaveMessageCatalog).Methods("PUT")
+	router.HandleFunc("/api/admin/translations/{locale}", handler.DeleteMessageCatalog).Methods("DELETE")
+	router.HandleFunc("/api/admin/catalogs", handler.ListBuiltinCatalogs).Methods("GET")
+	router.HandleFunc("/api/admin/catalogs/git/install", handler.InstallGitCatalog).Methods("POST")
+	router.HandleFunc("/api/admin/catalogs/{catalogId}", handler.GetBuiltinCatalog).Methods("GET")
+	router.HandleFunc("/api/admin/catalogs/{catalogId}/install", handler.InstallCatalog).Methods("POST")
+	router.HandleFunc("/api/admin/catalog-releases", handler.ListCatalogReleases).Methods("GET")
+	router.HandleFunc("/api/admin/catalog-releases", handler.PublishCatalogRelease).Methods("POST")
+	router.HandleFunc("/api/admin/catalog-releases/{version}", handler.GetCatalogRelease).Methods("GET")
+	router.HandleFunc("/api/admin/catalog-downstreams", handler.GetCatalogDownstreams).Methods("GET")
+	router.HandleFunc("/api/admin/catalog-downstreams/sync", handler.SyncCatalogDownstreams).Methods("POST")
+	router.HandleFunc("/api/admin/tackle/import", handler.ImportTackle).Methods("POST")
+	router.HandleFunc("/api/admin/tackle/export", handler.ExportTackle).Methods("GET")
+	router.HandleFunc("/api/admin/digest/subscriptions", handler.ListDigestSubscriptions).Methods("GET")
+	router.HandleFunc("/api/admin/outbox/dead-letters", handler.ListDeadLetters).Methods("GET")
+	router.HandleFunc("/api/admin/outbox/{messageId}/retry", handler.RetryDeadLetter).Methods("POST")
+	router.HandleFunc("/api/batch", batchHandler(router)).Methods("POST")
+	
+	// Per-application gauges create series per application and category, so they are opt-in
+	if config.ScoreMetrics {
+		router.HandleFunc("/metrics", handler.GetScoreMetrics).Methods("GET")
+	}
 	
 	// Add middleware for logging, CORS, etc.
 	router.Use(loggingMiddleware)
 	router.Use(corsMiddleware)
+	router.Use(websocketOriginMiddleware(config.WebSocketOrigins))
+	router.Use(limitsMiddleware(config))
+	router.Use(scanMiddleware(config.ContentScanner))
 	
 	server := &http.Server{
-		Addr:         fmt.Sprintf(":%d", port),
+		Addr:         fmt.Sprintf(":%d", config.Port),
 		Handler:      router,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
+		ReadTimeout:  config.ReadTimeout,
+		WriteTimeout: config.WriteTimeout,
+		IdleTimeout:  config.IdleTimeout,
 	}
 	
 	return &Server{
@@ -119,7 +248,7 @@ func corsMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// Set CORS headers
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
 		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
 		
 		// Handle preflight requests
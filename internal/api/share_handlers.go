@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// defaultShareTTL is used when a share request does not specify an expiry
+const defaultShareTTL = 7 * 24 * time.Hour
+
+// sharedReportTemplate renders a read-only report for stakeholders without accounts
+var sharedReportTemplate = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with .Branding.Name}}{{.}} - {{end}}Assessment Report {{.AssessmentID}}</title>
+{{with .Branding}}{{if or .PrimaryColor .AccentColor}}<style>
+{{with .PrimaryColor}}h1, h2, th { color: {{.}}; }
+{{end}}{{with .AccentColor}}a { color: {{.}}; }
+h1 { border-bottom: 3px solid {{.}}; }
+footer { border-top: 1px solid {{.}}; }
+{{end}}</style>
+{{end}}{{end}}</head>
+<body>
+{{with .Logo}}<img src="{{.}}" alt="{{$.Branding.Name}}" style="max-height: 64px">
+{{end}}<h1>{{with .Branding.Name}}{{.}}: {{end}}Kubernetes Readiness Report</h1>
+<p>Application: {{with .Application}}{{.Name}} ({{$.ApplicationID}}){{else}}{{.ApplicationID}}{{end}}<br>Generated: {{.GeneratedAt}}{{with .ValidUntil}}<br>Valid until: {{.}}{{end}}</p>
+{{if .Stale}}<p><strong>This report is past its validity period and may no longer reflect the application.</strong></p>
+{{end}}{{with .Categories}}<p><strong>Only these categories were assessed: {{range $i, $category := .}}{{if $i}}, {{end}}{{$category}}{{end}}.</strong></p>
+{{end}}{{with .SpotCheck}}<p><strong>Spot check: only {{len .QuestionIDs}} sampled questions ({{.PerCategory}} per category) were assessed.</strong></p>
+{{end}}{{with .Context}}<h2>Assessment Context</h2>
+<dl>
+{{with .Scope}}<dt>Scope</dt><dd>{{.}}</dd>
+{{end}}{{with .Participants}}<dt>Participants</dt><dd>{{range $i, $participant := .}}{{if $i}}, {{end}}{{$participant}}{{end}}</dd>
+{{end}}{{with .BusinessContext}}<dt>Business context</dt><dd>{{.}}</dd>
+{{end}}</dl>
+{{end}}{{if .MaxPossibleScore}}<h2>Score: {{.TotalScore}} / {{.MaxPossibleScore}}{{with .Grade}} ({{.}}){{end}}</h2>
+{{if .PenaltyScore}}<p>Penalties: {{.PenaltyScore}}</p>
+{{end}}{{with .CategoryShares}}<p>Weighted by category: {{range $category, $share := .}}{{$category}} {{$share}}% {{end}}</p>
+{{end}}{{end}}{{with .Narrative}}<h2>Summary</h2>
+<p>{{.Text}}</p>
+{{if .AIGenerated}}<p><em>{{.Disclaimer}}</em></p>
+{{end}}{{end}}{{range .PluginSections}}<h2>{{.Title}}</h2>
+{{with .Content}}<p>{{.}}</p>
+{{end}}{{with .Facts}}<table>
+{{range .}}<tr><td>{{.Label}}</td><td>{{.Value}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}{{if .CategoryScores}}<h2>Category Scores</h2>
+<table>
+{{range $category, $score := .CategoryScores}}<tr><td>{{$category}}</td><td>{{$score}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Breakdown}}<h2>Score Breakdown</h2>
+<table>
+<tr><th>Category</th><th>Question</th><th>Answer</th><th>Points</th><th>Weight</th><th>Score</th></tr>
+{{range .Breakdown}}<tr><td>{{.Category}}</td><td>{{.Text}}</td>{{if .Hidden}}<td colspan="4">not shown</td>{{else}}<td>{{.OptionText}}{{if .Explanation}}: {{.Explanation}}{{end}}{{if .Unverified}} <em>({{.Provenance}}, not verified)</em>{{end}}</td><td>{{.Points}} / {{.MaxPoints}}</td><td>{{.Weight}}</td><td>{{.Score}} / {{.MaxScore}}</td>{{end}}</tr>
+{{end}}</table>
+{{end}}{{if .Unanswered}}<h2>Unanswered Questions</h2>
+<p>{{.UnansweredScore}} of the maximum score rest on questions without a usable answer.</p>
+<table>
+{{range .Unanswered}}<tr><td>{{.Category}}</td><td>{{.Text}}</td><td>weight {{.Weight}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Disagreements}}<h2>Disputed Answers</h2>
+<p>The assessors disagreed on these questions; they should be discussed before acting on the score.</p>
+<table>
+{{range .Disagreements}}<tr><td>{{.Category}}</td><td>{{.Text}}</td><td>{{.Agreement}}% of {{.Responses}} agree</td><td>{{range $i, $option := .Options}}{{if $i}}; {{end}}{{.OptionText}} ({{.Count}}){{end}}</td></tr>
+{{end}}</table>
+{{end}}{{if .Recommendations}}<h2>Recommendations</h2>
+<ul>
+{{range .Recommendations}}<li>[{{.Priority}}] {{.Category}}: {{.Description}}</li>
+{{end}}</ul>
+{{end}}{{if .Risks}}<h2>Risks</h2>
+<ul>
+{{range .Risks}}<li>[{{.Severity}}] {{.Category}}: {{.Description}}</li>
+{{end}}</ul>
+{{end}}{{if .ModernizationPlan}}<h2>Modernization Plan</h2>
+<ol>
+{{range .ModernizationPlan}}<li>{{.Description}} (effort: {{.Effort}})</li>
+{{end}}</ol>
+{{end}}{{with .Estimate}}<p>Estimated effort: {{.MinHours}}&ndash;{{.MaxHours}} hours ({{.MinCost}}&ndash;{{.MaxCost}} {{.Currency}})</p>
+{{end}}{{if .Annotations}}<h2>Reviewer Annotations</h2>
+<ul>
+{{range .Annotations}}<li>{{.Section}} {{.Target}} &ndash; {{.Author}}: {{.Text}}</li>
+{{end}}</ul>
+{{end}}{{with .Approval}}<h2>Appendix: Approval</h2>
+<p>Approved{{with .ApprovedBy}} by {{.}}{{end}} on {{.ApprovedAt}}</p>
+{{with .Checklist}}<ul>
+{{range .}}<li>&#10003; {{.Text}}{{with .Note}}: {{.}}{{end}}</li>
+{{end}}</ul>
+{{end}}{{end}}{{with .Branding.Footer}}<footer>{{.}}</footer>
+{{end}}</body>
+</html>
+`))
+
+// sharedReportView is the data of the shared report page: the report with the organization's
+// branding. Logo is validated when the branding is saved, so it may be a data URI.
+type sharedReportView struct {
+	*models.Report
+	Branding *models.Branding
+	Logo     template.URL
+}
+
+// CreateReportShareLink issues a signed, expiring link to a read-only view of a report
+func (h *Handler) CreateReportShareLink(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	assessmentID := vars["assessmentId"]
+	
+	var req struct {
+		ExpiresInHours int    `json:"expiresInHours"`
+		Template       string `json:"template"` // report template the link shows; empty for the full report
+	}
+	
+	if r.ContentLength > 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+	
+	maxTTL := h.shareService.MaxTTL()
+	ttl := min(defaultShareTTL, maxTTL)
+	if req.ExpiresInHours != 0 {
+		// Compared in hours, since a larger count would overflow the duration
+		if req.ExpiresInHours < 0 || int64(req.ExpiresInHours) > int64(maxTTL/time.Hour) {
+			respondWithError(w, http.StatusBadRequest, fmt.Sprintf("expiresInHours must be between 1 and %d", maxTTL/time.Hour))
+			return
+		}
+		ttl = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	
+	token, expiresAt, err := h.shareService.CreateShareToken(r.Context(), assessmentID, ttl, req.Template)
+	if errors.Is(err, services.ErrInvalidReportTemplate) || errors.Is(err, services.ErrInvalidShareTTL) {
+		respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithServiceError(w, "Failed to share report", err)
+		return
+	}
+	
+	respondWithJSON(w, http.StatusCreated, map[string]string{
+		"token":     token,
+		"url":       baseURL(r) + "/api/shared/reports/" + token,
+		"expiresAt": expiresAt.Format(time.RFC3339),
+	})
+}
+
+// GetSharedReport renders a read-only report for a valid share token
+func (h *Handler) GetSharedReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	token := vars["token"]
+	
+	report, err := h.shareService.GetSharedReport(r.Context(), token)
+	if err != nil {
+		respondWithError(w, http.StatusForbidden, "Invalid share link: "+err.Error())
+		return
+	}
+	
+	if report == nil {
+		respondWithError(w, http.StatusNotFound, "Report not found")
+		return
+	}
+	h.assessmentService.MarkValidity(report)
+	if err := h.assessmentService.MarkApproval(r.Context(), report); err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get approval: "+err.Error())
+		return
+	}
+	
+	report, err = h.assessmentService.LocalizeReport(r.Context(), report, h.requestLocale(w, r))
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to translate report: "+err.Error())
+		return
+	}
+	
+	branding, err := h.assessmentService.GetBranding(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to get branding: "+err.Error())
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	sharedReportTemplate.Execute(w, sharedReportView{Report: report, Branding: branding, Logo: template.URL(branding.LogoURL)})
+}
+
+// baseURL reconstructs the externally visible scheme and host of a request
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host
+}
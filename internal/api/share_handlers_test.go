@@ -0,0 +1,81 @@
+package api_test
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	
+	"github.com/gorilla/mux"
+	
+	"questionnaire-app/internal/api"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+func TestCreateReportShareLinkLimitsLifetime(t *testing.T) {
+	store := questionnairetest.NewMemoryStorage()
+	store.Seed(t, &models.Report{AssessmentID: "a1", ApplicationID: "app1"})
+	share := services.NewShareService(store, func() []byte { return []byte("secret") }, 30*24*time.Hour)
+	handler := api.NewHandler(api.Services{Share: share})
+	
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		wantTTL    time.Duration
+	}{
+		{"default", "", http.StatusCreated, 7 * 24 * time.Hour},
+		{"maximum", `{"expiresInHours": 720}`, http.StatusCreated, 720 * time.Hour},
+		{"above maximum", `{"expiresInHours": 721}`, http.StatusBadRequest, 0},
+		{"overflowing", `{"expiresInHours": ` + strconv.Itoa(math.MaxInt64) + `}`, http.StatusBadRequest, 0},
+		{"negative", `{"expiresInHours": -1}`, http.StatusBadRequest, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/assessments/a1/report/share", strings.NewReader(tt.body))
+			req = mux.SetURLVars(req, map[string]string{"assessmentId": "a1"})
+			rec := httptest.NewRecorder()
+			
+			handler.CreateReportShareLink(rec, req)
+			
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body)
+			}
+			if tt.wantStatus != http.StatusCreated {
+				return
+			}
+			
+			var link struct {
+				ExpiresAt time.Time `json:"expiresAt"`
+			}
+			if err := json.NewDecoder(rec.Body).Decode(&link); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if ttl := time.Until(link.ExpiresAt); ttl < tt.wantTTL-time.Minute || ttl > tt.wantTTL {
+				t.Errorf("link expires in %s, want %s", ttl, tt.wantTTL)
+			}
+		})
+	}
+}
+
+func TestCreateReportShareLinkForUnknownReport(t *testing.T) {
+	store := questionnairetest.NewMemoryStorage()
+	share := services.NewShareService(store, func() []byte { return []byte("secret") }, 30*24*time.Hour)
+	handler := api.NewHandler(api.Services{Share: share})
+	
+	req := httptest.NewRequest(http.MethodPost, "/api/assessments/missing/report/share", nil)
+	req = mux.SetURLVars(req, map[string]string{"assessmentId": "missing"})
+	rec := httptest.NewRecorder()
+	
+	handler.CreateReportShareLink(rec, req)
+	
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want %d: %s", rec.Code, http.StatusNotFound, rec.Body)
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+	"strconv"
+)
+
+// ImportTackle imports questionnaires, applications and assessments from a Konveyor Tackle
+// bundle; dryRun=true only reports what would be imported. Imports creating likely duplicate
+// applications are rejected with 409 and the duplicates unless force=true.
+func (h *Handler) ImportTackle(w http.ResponseWriter, r *http.Request) {
+	dryRun, _ := strconv.ParseBool(r.URL.Query().Get("dryRun"))
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	
+	var bundle models.TackleBundle
+	if err := json.NewDecoder(r.Body).Decode(&bundle); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	
+	result, err := h.tackleService.Import(r.Context(), &bundle, dryRun, force)
+	if errors.Is(err, services.ErrDuplicateApplications) {
+		respondWithJSON(w, http.StatusConflict, result)
+		return
+	}
+	if errors.Is(err, storage.ErrCatalogReadOnly) {
+		respondWithError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to import Tackle data: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, result)
+}
+
+// ExportTackle exports the question catalog, applications and assessments as a Konveyor Tackle bundle
+func (h *Handler) ExportTackle(w http.ResponseWriter, r *http.Request) {
+	bundle, err := h.tackleService.Export(r.Context())
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "Failed to export Tackle data: "+err.Error())
+		return
+	}
+	
+	respondWithJSON(w, http.StatusOK, bundle)
+}
@@ -0,0 +1,251 @@
+package api
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+	
+	"github.com/gorilla/mux"
+)
+
+// WebSocket opcodes (RFC 6455)
+const (
+	wsText  = 0x1
+	wsClose = 0x8
+	wsPing  = 0x9
+	wsPong  = 0xA
+)
+
+// wsAcceptGUID is appended to a client's key to derive the handshake's accept key
+const wsAcceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// wsMaxReadSize limits the frames accepted from clients, which only send control frames
+const wsMaxReadSize = 64 << 10
+
+// wsConn is a server-side WebSocket connection for pushing JSON messages to dashboards. Only
+// the parts of the protocol the server needs are implemented: unfragmented text frames out,
+// and pings and closes in; other client messages are read and ignored.
+type wsConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+	
+	mu     sync.Mutex // serializes writes
+	closed bool
+}
+
+// websocketOriginMiddleware rejects WebSocket upgrades from pages of other origins. Browsers
+// do not apply CORS to WebSocket handshakes, so without this check any page a dashboard user
+// visits could open a connection with the user's cookies and proxy credentials.
+func websocketOriginMiddleware(allowed []string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if headerContains(r.Header, "Upgrade", "websocket") && !allowedOrigin(r, allowed) {
+				respondWithError(w, http.StatusForbidden, "WebSocket origin not allowed")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allowedOrigin reports whether the Origin of a request has the request's host or is one of
+// allowed. Requests without an Origin come from clients other than browsers and are allowed.
+func allowedOrigin(r *http.Request, allowed []string) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	if strings.EqualFold(u.Host, r.Host) {
+		return true
+	}
+	for _, entry := range allowed {
+		if entry == "*" || strings.EqualFold(strings.TrimSuffix(entry, "/"), origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// upgradeWebSocket completes the WebSocket handshake and takes over the connection. On failure
+// an error response has been written.
+func upgradeWebSocket(w http.ResponseWriter, r *http.Request) (*wsConn, error) {
+	if !headerContains(r.Header, "Connection", "upgrade") || !headerContains(r.Header, "Upgrade", "websocket") {
+		respondWithError(w, http.StatusUpgradeRequired, "WebSocket upgrade required")
+		return nil, errors.New("not a websocket upgrade")
+	}
+	if r.Header.Get("Sec-WebSocket-Version") != "13" {
+		w.Header().Set("Sec-WebSocket-Version", "13")
+		respondWithError(w, http.StatusBadRequest, "Unsupported WebSocket version")
+		return nil, errors.New("unsupported websocket version")
+	}
+	key := r.Header.Get("Sec-WebSocket-Key")
+	if key == "" {
+		respondWithError(w, http.StatusBadRequest, "Missing Sec-WebSocket-Key")
+		return nil, errors.New("missing websocket key")
+	}
+	
+	conn, rw, err := http.NewResponseController(w).Hijack()
+	if err != nil {
+		respondWithError(w, http.StatusInternalServerError, "WebSocket not supported")
+		return nil, fmt.Errorf("failed to hijack connection: %w", err)
+	}
+	
+	// The server's read and write timeouts no longer apply; deadlines are set per frame
+	conn.SetDeadline(time.Time{})
+	
+	sum := sha1.Sum([]byte(key + wsAcceptGUID))
+	handshake := "HTTP/1.1 101 Switching Protocols\r\n" +
+		"Upgrade: websocket\r\n" +
+		"Connection: Upgrade\r\n" +
+		"Sec-WebSocket-Accept: " + base64.StdEncoding.EncodeToString(sum[:]) + "\r\n\r\n"
+	conn.SetWriteDeadline(time.Now().Add(10 * time.Second))
+	if _, err := conn.Write([]byte(handshake)); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write handshake: %w", err)
+	}
+	
+	return &wsConn{conn: conn, reader: rw.Reader}, nil
+}
+
+// headerContains reports whether a comma-separated header contains a token, ignoring case
+func headerContains(header http.Header, name, token string) bool {
+	for _, value := range header.Values(name) {
+		for _, part := range strings.Split(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(part), token) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// WriteText sends a text message
+func (c *wsConn) WriteText(data []byte) error {
+	return c.writeFrame(wsText, data)
+}
+
+// Ping sends a ping; clients answer with a pong, which keeps proxies from closing the connection
+func (c *wsConn) Ping() error {
+	return c.writeFrame(wsPing, nil)
+}
+
+// Close sends a close frame with a status code and closes the connection
+func (c *wsConn) Close(code uint16, reason string) {
+	payload := make([]byte, 2, 2+len(reason))
+	binary.BigEndian.PutUint16(payload, code)
+	c.writeFrame(wsClose, append(payload, reason...))
+	
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.closed {
+		c.closed = true
+		c.conn.Close()
+	}
+}
+
+// writeFrame sends a single unmasked frame
+func (c *wsConn) writeFrame(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	
+	header := []byte{0x80 | opcode, 0}
+	switch length := len(payload); {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xFFFF:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+	
+	c.conn.SetWriteDeadline(time.Now().Add(time.Minute))
+	if _, err := c.conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ReadLoop reads client frames until the connection is closed, answering pings and closes.
+// It returns when the client closes the connection or the connection fails.
+func (c *wsConn) ReadLoop() error {
+	for {
+		opcode, payload, err := c.readFrame()
+		if err != nil {
+			return err
+		}
+		
+		switch opcode {
+		case wsPing:
+			if err := c.writeFrame(wsPong, payload); err != nil {
+				return err
+			}
+		case wsClose:
+			c.Close(1000, "")
+			return io.EOF
+		}
+	}
+}
+
+// readFrame reads a single frame, which clients must mask
+func (c *wsConn) readFrame() (byte, []byte, error) {
+	var header [2]byte
+	if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+		return 0, nil, err
+	}
+	opcode := header[0] & 0x0F
+	masked := header[1]&0x80 != 0
+	if !masked {
+		return 0, nil, errors.New("client frame is not masked")
+	}
+	
+	length := uint64(header[1] & 0x7F)
+	switch length {
+	case 126:
+		var extended [2]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = uint64(binary.BigEndian.Uint16(extended[:]))
+	case 127:
+		var extended [8]byte
+		if _, err := io.ReadFull(c.reader, extended[:]); err != nil {
+			return 0, nil, err
+		}
+		length = binary.BigEndian.Uint64(extended[:])
+	}
+	if length > wsMaxReadSize {
+		return 0, nil, errors.New("client frame too large")
+	}
+	
+	var mask [4]byte
+	if _, err := io.ReadFull(c.reader, mask[:]); err != nil {
+		return 0, nil, err
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(c.reader, payload); err != nil {
+		return 0, nil, err
+	}
+	for i := range payload {
+		payload[i] ^= mask[i%4]
+	}
+	return opcode, payload, nil
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+	
+	"github.com/gorilla/mux"
+)
+
+// StartWorkshop runs an assessment in workshop mode, facilitated by the requesting user
+func (h *Handler) StartWorkshop(w http.ResponseWriter, r *http.Request) {
+	view, err := h.assessmentService.StartWorkshop(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r))
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to start workshop", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusCreated, view)
+}
+
+// GetWorkshop returns the state of an assessment's workshop, including the current question
+// and its votes, as seen by the requesting user
+func (h *Handler) GetWorkshop(w http.ResponseWriter, r *http.Request) {
+	view, err := h.assessmentService.GetWorkshop(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r))
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to get workshop", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusOK, view)
+}
+
+// MoveWorkshop moves a workshop to the next, previous or a given question
+func (h *Handler) MoveWorkshop(w http.ResponseWriter, r *http.Request) {
+	var move models.WorkshopMove
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&move); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+	
+	view, err := h.assessmentService.MoveWorkshop(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r), move)
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to move workshop", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusOK, view)
+}
+
+// VoteInWorkshop records the requesting user's vote on the workshop's current question
+func (h *Handler) VoteInWorkshop(w http.ResponseWriter, r *http.Request) {
+	var vote models.WorkshopVote
+	if err := json.NewDecoder(r.Body).Decode(&vote); err != nil {
+		respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+		return
+	}
+	if vote.QuestionID == "" || vote.OptionID == "" {
+		respondWithError(w, http.StatusBadRequest, "Question ID and Option ID are required")
+		return
+	}
+	
+	view, err := h.assessmentService.VoteInWorkshop(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r), vote)
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to vote", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusOK, view)
+}
+
+// DecideWorkshopQuestion saves the facilitator's decision on the current question as the
+// assessment's answer and moves on to the next question
+func (h *Handler) DecideWorkshopQuestion(w http.ResponseWriter, r *http.Request) {
+	var request models.WorkshopDecisionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			respondWithError(w, http.StatusBadRequest, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+	
+	view, err := h.assessmentService.DecideWorkshopQuestion(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r), request)
+	var validationErr *services.AnswerValidationError
+	if errors.As(err, &validationErr) {
+		respondWithJSON(w, http.StatusUnprocessableEntity, map[string]interface{}{
+			"error":    "Failed to record decision: the answer breaks the question's answer rules",
+			"problems": validationErr.Problems,
+		})
+		return
+	}
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to record decision", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusOK, view)
+}
+
+// CloseWorkshop ends an assessment's workshop; its decisions stay saved as answers
+func (h *Handler) CloseWorkshop(w http.ResponseWriter, r *http.Request) {
+	view, err := h.assessmentService.CloseWorkshop(r.Context(), mux.Vars(r)["assessmentId"], requestUser(r))
+	if err != nil {
+		respondWithWorkshopError(w, "Failed to close workshop", err)
+		return
+	}
+	
+	h.respondWithWorkshop(w, r, http.StatusOK, view)
+}
+
+// respondWithWorkshop writes a workshop view with its current question in the request's language
+func (h *Handler) respondWithWorkshop(w http.ResponseWriter, r *http.Request, code int, view *models.WorkshopView) {
+	if view.Question != nil {
+		view.Question = services.LocalizeQuestion(view.Question, h.requestLocale(w, r))
+	}
+	respondWithJSON(w, code, view)
+}
+
+// respondWithWorkshopError maps the errors of workshop requests to status codes
+func respondWithWorkshopError(w http.ResponseWriter, message string, err error) {
+	switch {
+	case errors.Is(err, services.ErrInvalidWorkshop), errors.Is(err, services.ErrInvalidAnswer):
+		respondWithError(w, http.StatusBadRequest, message+": "+err.Error())
+	case errors.Is(err, services.ErrNotFacilitator):
+		respondWithError(w, http.StatusForbidden, message+": "+err.Error())
+	default:
+		respondWithServiceError(w, message, err)
+	}
+}
@@ -0,0 +1,88 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Client reads resources from the Kubernetes API
+type Client struct {
+	config *Config
+	http   *http.Client
+}
+
+// NewClient creates a client for the configured API server
+func NewClient(config *Config) *Client {
+	return &Client{config: config, http: config.HTTPClient()}
+}
+
+// Resource identifies a namespaced resource type of the Kubernetes API
+type Resource struct {
+	Kind       string // kind of the listed items, e.g. Deployment
+	APIVersion string // e.g. apps/v1
+	Plural     string // e.g. deployments
+}
+
+// WorkloadResources are the resources inspected to assess a deployed application
+var WorkloadResources = []Resource{
+	{Kind: "Deployment", APIVersion: "apps/v1", Plural: "deployments"},
+	{Kind: "StatefulSet", APIVersion: "apps/v1", Plural: "statefulsets"},
+	{Kind: "DaemonSet", APIVersion: "apps/v1", Plural: "daemonsets"},
+	{Kind: "CronJob", APIVersion: "batch/v1", Plural: "cronjobs"},
+	{Kind: "PersistentVolumeClaim", APIVersion: "v1", Plural: "persistentvolumeclaims"},
+	{Kind: "PodDisruptionBudget", APIVersion: "policy/v1", Plural: "poddisruptionbudgets"},
+	{Kind: "HorizontalPodAutoscaler", APIVersion: "autoscaling/v2", Plural: "horizontalpodautoscalers"},
+}
+
+// List returns the objects of a resource in a namespace matching a label selector, which
+// may be empty. Each object has its kind and apiVersion set, which list responses omit.
+func (c *Client) List(ctx context.Context, resource Resource, namespace, selector string) ([]map[string]interface{}, error) {
+	prefix := "/apis/" + resource.APIVersion
+	if resource.APIVersion == "v1" {
+		prefix = "/api/v1"
+	}
+	
+	endpoint := fmt.Sprintf("%s%s/namespaces/%s/%s", c.config.Server, prefix, url.PathEscape(namespace), resource.Plural)
+	if selector != "" {
+		endpoint += "?labelSelector=" + url.QueryEscape(selector)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	} else if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+	
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("listing %s returned %s: %s", resource.Plural, resp.Status, body)
+	}
+	
+	var list struct {
+		Items []map[string]interface{} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", resource.Plural, err)
+	}
+	
+	for _, item := range list.Items {
+		item["kind"] = resource.Kind
+		item["apiVersion"] = resource.APIVersion
+	}
+	return list.Items, nil
+}
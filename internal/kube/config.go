@@ -0,0 +1,254 @@
+package kube
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	
+	"gopkg.in/yaml.v3"
+)
+
+// In-cluster service account files mounted into every pod
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	inClusterToken    = serviceAccountDir + "/token"
+	inClusterCA       = serviceAccountDir + "/ca.crt"
+	inClusterNS       = serviceAccountDir + "/namespace"
+)
+
+// Config describes how to reach and authenticate against a Kubernetes API server
+type Config struct {
+	Server    string
+	Namespace string // default namespace of the context
+	Token     string
+	Username  string
+	Password  string
+	TLS       *tls.Config
+}
+
+// kubeconfig is the subset of the kubeconfig file format used to connect
+type kubeconfig struct {
+	CurrentContext string `yaml:"current-context"`
+	Clusters       []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string    `yaml:"token"`
+			TokenFile             string    `yaml:"tokenFile"`
+			Username              string    `yaml:"username"`
+			Password              string    `yaml:"password"`
+			ClientCertificate     string    `yaml:"client-certificate"`
+			ClientCertificateData string    `yaml:"client-certificate-data"`
+			ClientKey             string    `yaml:"client-key"`
+			ClientKeyData         string    `yaml:"client-key-data"`
+			Exec                  yaml.Node `yaml:"exec"`
+			AuthProvider          yaml.Node `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+	Contexts []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster   string `yaml:"cluster"`
+			User      string `yaml:"user"`
+			Namespace string `yaml:"namespace"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+}
+
+// DefaultKubeconfigPath returns the first file listed in KUBECONFIG, or ~/.kube/config
+func DefaultKubeconfigPath() string {
+	if env := os.Getenv("KUBECONFIG"); env != "" {
+		return filepath.SplitList(env)[0]
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".kube", "config")
+}
+
+// LoadConfig reads a context of a kubeconfig file; the current context if contextName is
+// empty. Without a kubeconfig file it falls back to the service account of the pod it runs in.
+func LoadConfig(path, contextName string) (*Config, error) {
+	if path == "" || !fileExists(path) {
+		if os.Getenv("KUBERNETES_SERVICE_HOST") != "" {
+			return InClusterConfig()
+		}
+		return nil, errors.New("no kubeconfig found and not running in a cluster")
+	}
+	
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	
+	var file kubeconfig
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	
+	if contextName == "" {
+		contextName = file.CurrentContext
+	}
+	if contextName == "" {
+		return nil, errors.New("kubeconfig has no current context")
+	}
+	
+	// Relative file references are resolved against the kubeconfig's directory
+	base := filepath.Dir(path)
+	resolve := func(name string) string {
+		if name == "" || filepath.IsAbs(name) {
+			return name
+		}
+		return filepath.Join(base, name)
+	}
+	
+	for _, ctx := range file.Contexts {
+		if ctx.Name != contextName {
+			continue
+		}
+		
+		config := &Config{Namespace: ctx.Context.Namespace, TLS: &tls.Config{}}
+		clusterFound := false
+		for _, cluster := range file.Clusters {
+			if cluster.Name != ctx.Context.Cluster {
+				continue
+			}
+			clusterFound = true
+			config.Server = strings.TrimRight(cluster.Cluster.Server, "/")
+			config.TLS.InsecureSkipVerify = cluster.Cluster.InsecureSkipTLSVerify
+			
+			ca, err := fileOrData(resolve(cluster.Cluster.CertificateAuthority), cluster.Cluster.CertificateAuthorityData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read certificate authority: %w", err)
+			}
+			if ca != nil {
+				pool := x509.NewCertPool()
+				if !pool.AppendCertsFromPEM(ca) {
+					return nil, errors.New("certificate authority contains no PEM certificates")
+				}
+				config.TLS.RootCAs = pool
+			}
+		}
+		if !clusterFound {
+			return nil, fmt.Errorf("context %s refers to unknown cluster %s", contextName, ctx.Context.Cluster)
+		}
+		
+		for _, user := range file.Users {
+			if user.Name != ctx.Context.User {
+				continue
+			}
+			if !user.User.Exec.IsZero() || !user.User.AuthProvider.IsZero() {
+				return nil, fmt.Errorf("user %s uses an exec or auth provider plugin, which is not supported; use a token or client certificate", user.Name)
+			}
+			
+			config.Token = user.User.Token
+			if user.User.TokenFile != "" {
+				token, err := os.ReadFile(resolve(user.User.TokenFile))
+				if err != nil {
+					return nil, fmt.Errorf("failed to read token file: %w", err)
+				}
+				config.Token = strings.TrimSpace(string(token))
+			}
+			config.Username = user.User.Username
+			config.Password = user.User.Password
+			
+			cert, err := fileOrData(resolve(user.User.ClientCertificate), user.User.ClientCertificateData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client certificate: %w", err)
+			}
+			key, err := fileOrData(resolve(user.User.ClientKey), user.User.ClientKeyData)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client key: %w", err)
+			}
+			if cert != nil && key != nil {
+				pair, err := tls.X509KeyPair(cert, key)
+				if err != nil {
+					return nil, fmt.Errorf("invalid client certificate: %w", err)
+				}
+				config.TLS.Certificates = []tls.Certificate{pair}
+			}
+		}
+		
+		return config, nil
+	}
+	
+	return nil, fmt.Errorf("context %s not found in kubeconfig", contextName)
+}
+
+// InClusterConfig connects with the service account of the pod the process runs in
+func InClusterConfig() (*Config, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in a cluster")
+	}
+	
+	token, err := os.ReadFile(inClusterToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account token: %w", err)
+	}
+	
+	ca, err := os.ReadFile(inClusterCA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read service account CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(ca)
+	
+	namespace, _ := os.ReadFile(inClusterNS)
+	
+	return &Config{
+		Server:    "https://" + joinHostPort(host, port),
+		Namespace: strings.TrimSpace(string(namespace)),
+		Token:     strings.TrimSpace(string(token)),
+		TLS:       &tls.Config{RootCAs: pool},
+	}, nil
+}
+
+// HTTPClient returns an HTTP client using the configuration's TLS settings
+func (c *Config) HTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = c.TLS
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}
+}
+
+// fileOrData returns inline base64 data if given, otherwise the content of the file, or nil
+// if neither is set
+func fileOrData(path, data string) ([]byte, error) {
+	if data != "" {
+		return base64.StdEncoding.DecodeString(data)
+	}
+	if path != "" {
+		return os.ReadFile(path)
+	}
+	return nil, nil
+}
+
+// joinHostPort joins a host and port, bracketing IPv6 addresses
+func joinHostPort(host, port string) string {
+	if strings.Contains(host, ":") {
+		return "[" + host + "]:" + port
+	}
+	return host + ":" + port
+}
+
+// fileExists reports whether path names an existing file
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,33 @@
+package models
+
+// AnswerCopyRequest selects the answers copied into an assessment from another one, such as the
+// shared infrastructure answers of services running on the same platform
+type AnswerCopyRequest struct {
+	SourceAssessmentID string   `json:"sourceAssessmentId"`
+	QuestionIDs        []string `json:"questionIds,omitempty"`
+	Categories         []string `json:"categories,omitempty"`
+	Overwrite          bool     `json:"overwrite,omitempty"` // replace answers already given in the target
+}
+
+// CopiedAnswer is an answer taken over from the source assessment
+type CopiedAnswer struct {
+	QuestionID string `json:"questionId"`
+	Category   string `json:"category"`
+	OptionID   string `json:"optionId"`
+	Replaced   bool   `json:"replaced,omitempty"` // the target had a different answer before
+}
+
+// SkippedAnswer is a selected question whose answer was not copied
+type SkippedAnswer struct {
+	QuestionID string `json:"questionId"`
+	Reason     string `json:"reason"` // not_answered, unknown_question, not_applicable, unknown_option, invalid_explanation or already_answered
+}
+
+// AnswerCopyResult records which answers were copied between two assessments
+type AnswerCopyResult struct {
+	AssessmentID       string          `json:"assessmentId"`
+	SourceAssessmentID string          `json:"sourceAssessmentId"`
+	CopiedBy           string          `json:"copiedBy,omitempty"`
+	Copied             []CopiedAnswer  `json:"copied"`
+	Skipped            []SkippedAnswer `json:"skipped"`
+}
@@ -1,9 +1,75 @@
 package models
 
+import "time"
+
 // Application represents an application to be assessed
 type Application struct {
-	ID          string            `json:"id"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	ExternalID       string            `json:"externalId,omitempty"` // key in another system, e.g. a CMDB sys_id; unique
+	Description      string            `json:"description"`
+	Tags             map[string]string `json:"tags"`
+	Repository       string            `json:"repository,omitempty"` // Git URL used to pre-fill answers
+	DependsOn        []string          `json:"dependsOn,omitempty"`  // applications that must migrate first
+	CreatedAt        time.Time         `json:"createdAt"`
+	UpdatedAt        time.Time         `json:"updatedAt"`
+	Lifecycle        string            `json:"lifecycle,omitempty"` // empty until the first transition
+	LifecycleHistory []LifecycleChange `json:"lifecycleHistory,omitempty"`
+	Remediations     []Remediation     `json:"remediations,omitempty"`    // decisions on report recommendations
+	RiskAcceptances  []RiskAcceptance  `json:"riskAcceptances,omitempty"` // report risks accepted until an expiry date
+}
+
+// ApplicationSnapshot is an application as it was when an assessment started. Assessments and
+// their reports keep it, so later renames or re-tagging of the application do not change them.
+type ApplicationSnapshot struct {
 	Name        string            `json:"name"`
-	Description string            `json:"description"`
-	Tags        map[string]string `json:"tags"`
+	Description string            `json:"description,omitempty"`
+	Tags        map[string]string `json:"tags,omitempty"`
+	Repository  string            `json:"repository,omitempty"`
+}
+
+// Snapshot returns a copy of the application's name, description, tags and repository
+func (a *Application) Snapshot() *ApplicationSnapshot {
+	snapshot := &ApplicationSnapshot{
+		Name:        a.Name,
+		Description: a.Description,
+		Repository:  a.Repository,
+	}
+	if len(a.Tags) > 0 {
+		snapshot.Tags = make(map[string]string, len(a.Tags))
+		for key, value := range a.Tags {
+			snapshot.Tags[key] = value
+		}
+	}
+	return snapshot
+}
+
+// LatestScore summarizes an application's most recent report
+type LatestScore struct {
+	AssessmentID string    `json:"assessmentId"`
+	GeneratedAt  time.Time `json:"generatedAt"`
+	ScorePercent int       `json:"scorePercent"`
+	Band         string    `json:"band"`
+	Grade        string    `json:"grade"`
+	Stale        bool      `json:"stale,omitempty"` // the report is past its validity period
+}
+
+// ApplicationDuplicate is an application about to be created that likely duplicates an
+// existing one, by a similar name, matching tags or the same repository
+type ApplicationDuplicate struct {
+	Name           string   `json:"name"`
+	ApplicationID  string   `json:"applicationId"` // the ID the new application gets
+	DuplicateOf    string   `json:"duplicateOf"`   // ID of the application it duplicates
+	DuplicateName  string   `json:"duplicateName"`
+	Similarity     float64  `json:"similarity"`             // of the names ignoring case and punctuation, 0 to 1
+	MatchingTags   []string `json:"matchingTags,omitempty"` // key=value
+	SameRepository bool     `json:"sameRepository,omitempty"`
+}
+
+// ScoredApplication is an application listed together with its latest score, which is nil
+// for applications without a report
+type ScoredApplication struct {
+	*Application
+	LatestScore *LatestScore         `json:"latestScore"`
+	Remediation *RemediationProgress `json:"remediation,omitempty"` // of the latest report's recommendations
 }
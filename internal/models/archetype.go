@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// ArchetypeTag is the application tag naming the archetype an application follows
+const ArchetypeTag = "archetype"
+
+// Archetype is a kind of application, such as a Spring Boot web service, with the answers
+// applications of that kind usually give. New assessments of applications tagged with the
+// archetype start with these answers as suggestions.
+type Archetype struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Answers     []ArchetypeAnswer `json:"answers"`
+	UpdatedAt   *time.Time        `json:"updatedAt,omitempty"`
+}
+
+// ArchetypeAnswer is the default answer of an archetype to a question
+type ArchetypeAnswer struct {
+	QuestionID string `json:"questionId"`
+	OptionID   string `json:"optionId"`
+}
@@ -1,10 +1,64 @@
 package models
 
+import "time"
+
 // Assessment represents a complete application assessment
 type Assessment struct {
-	ID            string            `json:"id"`
-	ApplicationID string            `json:"applicationId"`
-	CreatedAt     string            `json:"createdAt"`
-	Answers       map[string]string `json:"answers"` // questionID -> optionID
-	Status        string            `json:"status"`
+	ID                string                               `json:"id"`
+	ApplicationID     string                               `json:"applicationId"`
+	ExternalID        string                               `json:"externalId,omitempty"`  // key in another system; unique
+	Application       *ApplicationSnapshot                 `json:"application,omitempty"` // taken when the assessment started
+	CreatedAt         time.Time                            `json:"createdAt"`
+	UpdatedAt         time.Time                            `json:"updatedAt"`              // time of the latest event
+	Answers           map[string]string                    `json:"answers"`                // questionID -> optionID
+	Explanations      map[string]string                    `json:"explanations,omitempty"` // questionID -> free text for options requiring an explanation
+	References        map[string][]string                  `json:"references,omitempty"`   // questionID -> links to evidence for the answer
+	Status            string                               `json:"status"`
+	StartedBy         string                               `json:"startedBy,omitempty"`
+	Context           *AssessmentContext                   `json:"context,omitempty"`
+	SpotCheck         *SpotCheck                           `json:"spotCheck,omitempty"`  // set for triage assessments of a sample of the catalog
+	Categories        []string                             `json:"categories,omitempty"` // the only categories assessed; all if empty
+	AnsweredBy        map[string]string                    `json:"answeredBy,omitempty"` // questionID -> user
+	WeightOverrides   []WeightOverride                     `json:"weightOverrides,omitempty"`
+	StartedAt         time.Time                            `json:"startedAt"`
+	CompletedAt       *time.Time                           `json:"completedAt,omitempty"`
+	AnsweredAt        map[string]time.Time                 `json:"answeredAt,omitempty"` // questionID -> time of the latest answer
+	ApprovedBy        string                               `json:"approvedBy,omitempty"`
+	ApprovedAt        *time.Time                           `json:"approvedAt,omitempty"`
+	ApprovalChecklist []CheckedItem                        `json:"approvalChecklist,omitempty"` // review checklist items ticked at approval
+	Suggestions       map[string]AnswerSuggestion          `json:"suggestions,omitempty"`       // questionID -> unconfirmed pre-filled answer
+	CopiedFrom        map[string]string                    `json:"copiedFrom,omitempty"`        // questionID -> assessment the current answer was copied from
+	Provenance        map[string]AnswerProvenance          `json:"provenance,omitempty"`        // questionID -> how the current answer was produced
+	Responses         map[string]map[string]AnswerResponse `json:"responses,omitempty"`         // questionID -> assessor -> latest answer
+}
+
+// AssessmentContext records how an assessment was run, for readers of its report
+type AssessmentContext struct {
+	Scope           string   `json:"scope,omitempty"`           // what was and was not assessed
+	Participants    []string `json:"participants,omitempty"`    // people who took part, e.g. user names
+	BusinessContext string   `json:"businessContext,omitempty"` // why the application is being assessed
+}
+
+// SpotCheck limits a triage assessment to a sample of the catalog: PerCategory questions drawn
+// from every category with a seeded random order, so the same seed yields the same sample
+type SpotCheck struct {
+	PerCategory int      `json:"perCategory"`
+	Seed        int64    `json:"seed,omitempty"`
+	QuestionIDs []string `json:"questionIds,omitempty"` // the sample, fixed when the assessment starts
+}
+
+// WeightOverride replaces the catalog weight of a question, or of every question in a
+// category, for a single assessment. Question overrides take precedence over category overrides.
+type WeightOverride struct {
+	QuestionID string `json:"questionId,omitempty"`
+	Category   string `json:"category,omitempty"`
+	Weight     int    `json:"weight"`
+	Reason     string `json:"reason,omitempty"`
+	Source     string `json:"source,omitempty"` // e.g. "assessment" or "class:<application class>"
+}
+
+// AnswerProblem is a field of a submitted answer that failed validation
+type AnswerProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
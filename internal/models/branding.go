@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Branding customizes exported reports for the organization delivering them
+type Branding struct {
+	Name         string     `json:"name,omitempty"`         // shown in the report title, e.g. the consultancy
+	LogoURL      string     `json:"logoUrl,omitempty"`      // https URL or data:image URI
+	PrimaryColor string     `json:"primaryColor,omitempty"` // headings and table headers, #rgb or #rrggbb
+	AccentColor  string     `json:"accentColor,omitempty"`  // links and rules, #rgb or #rrggbb
+	Footer       string     `json:"footer,omitempty"`       // e.g. a confidentiality notice
+	UpdatedAt    *time.Time `json:"updatedAt,omitempty"`
+}
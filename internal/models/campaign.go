@@ -0,0 +1,59 @@
+package models
+
+import "time"
+
+// Campaign is a migration-readiness program assessing a set of applications by a due date
+type Campaign struct {
+	ID             string    `json:"id"`
+	Name           string    `json:"name"`
+	Description    string    `json:"description,omitempty"`
+	Owner          string    `json:"owner,omitempty"`
+	DueDate        string    `json:"dueDate,omitempty"` // YYYY-MM-DD
+	ApplicationIDs []string  `json:"applicationIds"`
+	CreatedAt      time.Time `json:"createdAt"`
+	CreatedBy      string    `json:"createdBy,omitempty"`
+}
+
+// CampaignProgress summarizes the assessment status of every application in a campaign
+type CampaignProgress struct {
+	CampaignID  string          `json:"campaignId"`
+	Name        string          `json:"name"`
+	DueDate     string          `json:"dueDate,omitempty"`
+	GeneratedAt time.Time       `json:"generatedAt"`
+	Total       int             `json:"total"`
+	ByStatus    map[string]int  `json:"byStatus"`
+	Overdue     int             `json:"overdue"`
+	PercentDone int             `json:"percentDone"` // completed or approved applications
+	Entries     []CampaignEntry `json:"entries"`
+}
+
+// CampaignEntry is the progress of a single application in a campaign
+type CampaignEntry struct {
+	ApplicationID   string `json:"applicationId"`
+	ApplicationName string `json:"applicationName"`
+	Owner           string `json:"owner,omitempty"`
+	Status          string `json:"status"` // not_started, in_progress, completed, approved or missing
+	AssessmentID    string `json:"assessmentId,omitempty"`
+	ScorePercent    *int   `json:"scorePercent,omitempty"`
+	Grade           string `json:"grade,omitempty"`
+	DueDate         string `json:"dueDate,omitempty"`
+	Overdue         bool   `json:"overdue"`
+}
+
+// Types of live campaign updates
+const (
+	CampaignUpdateSnapshot  = "snapshot"             // the current progress, sent when a dashboard connects
+	CampaignUpdateCompleted = "assessment-completed" // an application's latest assessment was completed
+	CampaignUpdateScore     = "score-updated"        // the score or grade of a completed application changed
+	CampaignUpdateProgress  = "progress"             // the progress after any change
+)
+
+// CampaignUpdate is a message sent to live campaign dashboards
+type CampaignUpdate struct {
+	Type       string            `json:"type"`
+	CampaignID string            `json:"campaignId"`
+	OccurredAt time.Time         `json:"occurredAt"`         // when the change was detected
+	Entry      *CampaignEntry    `json:"entry,omitempty"`    // the changed application
+	Previous   *CampaignEntry    `json:"previous,omitempty"` // the application before the change
+	Progress   *CampaignProgress `json:"progress,omitempty"` // snapshot and progress updates
+}
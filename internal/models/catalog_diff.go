@@ -0,0 +1,85 @@
+package models
+
+// CatalogSource selects a catalog version to compare: a built-in catalog, a list of questions,
+// or the live catalog if both are empty
+type CatalogSource struct {
+	Catalog   string      `json:"catalog,omitempty"` // built-in catalog ID
+	Questions []*Question `json:"questions,omitempty"`
+}
+
+// CatalogDiffRequest compares two catalog versions; the base defaults to the live catalog
+type CatalogDiffRequest struct {
+	Base   CatalogSource `json:"base"`
+	Target CatalogSource `json:"target"`
+}
+
+// CatalogDiff lists what changes from a base catalog to a target catalog
+type CatalogDiff struct {
+	Added   []CatalogQuestionRef `json:"added"`
+	Removed []CatalogQuestionRef `json:"removed"`
+	Changed []QuestionDiff       `json:"changed"`
+	Impact  *CatalogImpact       `json:"impact,omitempty"` // on existing reports, if estimated
+}
+
+// CatalogQuestionRef names a question added or removed
+type CatalogQuestionRef struct {
+	QuestionID string `json:"questionId"`
+	Category   string `json:"category"`
+	Text       string `json:"text"`
+	Weight     int    `json:"weight"`
+}
+
+// QuestionDiff lists the changes to a question present in both catalogs
+type QuestionDiff struct {
+	QuestionID     string         `json:"questionId"`
+	Category       string         `json:"category"`         // in the target catalog
+	Fields         []string       `json:"fields,omitempty"` // other changed fields, e.g. text or visibleWhen
+	Weight         *IntChange     `json:"weight,omitempty"`
+	AddedOptions   []string       `json:"addedOptions,omitempty"`
+	RemovedOptions []string       `json:"removedOptions,omitempty"`
+	RenamedOptions []OptionRename `json:"renamedOptions,omitempty"` // same ID, different text
+	Points         []OptionPoints `json:"points,omitempty"`
+}
+
+// IntChange is a number that changed between catalogs
+type IntChange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// OptionRename is an option whose text changed
+type OptionRename struct {
+	OptionID string `json:"optionId"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// OptionPoints is an option whose points changed
+type OptionPoints struct {
+	OptionID string `json:"optionId"`
+	From     int    `json:"from"`
+	To       int    `json:"to"`
+}
+
+// CatalogImpact estimates how existing reports would score under the target catalog
+type CatalogImpact struct {
+	Reports      int            `json:"reports"`      // reports estimated
+	Changed      int            `json:"changed"`      // reports whose score percentage changes
+	GradeChanges int            `json:"gradeChanges"` // reports whose grade changes
+	Skipped      int            `json:"skipped"`      // reports without a score breakdown
+	AverageDelta float64        `json:"averageDelta"` // mean change in percentage points
+	Assessments  []ReportImpact `json:"assessments"`  // reports whose score changes, biggest change first
+}
+
+// ReportImpact is the estimated score of a report under the target catalog
+type ReportImpact struct {
+	AssessmentID    string `json:"assessmentId"`
+	ApplicationID   string `json:"applicationId"`
+	ScorePercent    int    `json:"scorePercent"`
+	Grade           string `json:"grade,omitempty"`
+	NewTotalScore   int    `json:"newTotalScore"`
+	NewMaxScore     int    `json:"newMaxPossibleScore"`
+	NewScorePercent int    `json:"newScorePercent"`
+	NewGrade        string `json:"newGrade,omitempty"`
+	Delta           int    `json:"delta"` // in percentage points
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// CatalogRelease is a published version of the question catalog. Releases are never changed
+// after publishing, so an authoring instance can push the same catalog to its downstream
+// instances at any later time.
+type CatalogRelease struct {
+	Version       string      `json:"version"`
+	Notes         string      `json:"notes,omitempty"`
+	Source        string      `json:"source,omitempty"` // the instance that published the release
+	PublishedBy   string      `json:"publishedBy,omitempty"`
+	PublishedAt   time.Time   `json:"publishedAt"`
+	Checksum      string      `json:"checksum"` // sha256:<hex> of the questions and categories
+	QuestionCount int         `json:"questionCount"`
+	Questions     []*Question `json:"questions,omitempty"`  // omitted in release lists
+	Categories    []*Category `json:"categories,omitempty"` // omitted in release lists
+	ReceivedAt    *time.Time  `json:"receivedAt,omitempty"` // set on downstream instances when the release was last pushed
+}
+
+// CatalogDownstream is an instance an authoring instance pushes catalog releases to
+type CatalogDownstream struct {
+	Name    string `json:"name"`
+	URL     string `json:"url"`               // base URL of the instance, e.g. https://team-a.example.com
+	Token   string `json:"token,omitempty"`   // bearer token of the downstream's catalog sync API
+	Version string `json:"version,omitempty"` // release the downstream is pinned to; the latest release if empty
+}
+
+// CatalogSyncState is the catalog release a downstream instance received last
+type CatalogSyncState struct {
+	Version    string     `json:"version,omitempty"` // empty if no release was received
+	Checksum   string     `json:"checksum,omitempty"`
+	Source     string     `json:"source,omitempty"`
+	ReceivedAt *time.Time `json:"receivedAt,omitempty"`
+}
+
+// Statuses of a downstream instance
+const (
+	CatalogSynced      = "synced"      // the release was pushed and installed
+	CatalogInSync      = "in-sync"     // the downstream has the target release
+	CatalogOutOfSync   = "out-of-sync" // the downstream has no or another release
+	CatalogSyncFailed  = "failed"      // the push failed
+	CatalogUnavailable = "unavailable" // the downstream could not be reached
+)
+
+// CatalogDownstreamStatus is a downstream instance's catalog release compared with the
+// release it is pinned to, or the outcome of pushing that release
+type CatalogDownstreamStatus struct {
+	Name    string                `json:"name"`
+	URL     string                `json:"url"`
+	Pinned  string                `json:"pinned,omitempty"` // the pinned version; empty follows the latest release
+	Target  string                `json:"target,omitempty"` // the release the downstream should have
+	Status  string                `json:"status"`
+	Error   string                `json:"error,omitempty"`
+	Current *CatalogSyncState     `json:"current,omitempty"` // the downstream's release before a push
+	Install *CatalogInstallResult `json:"install,omitempty"` // the questions a push installed
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Category groups questions for scoring and presentation. Questions refer to a category by
+// its name, and once categories are defined a question must use one of them.
+type Category struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Order       int        `json:"order"`            // display order; categories with equal order sort by name
+	Icon        string     `json:"icon,omitempty"`   // icon name or URL for UIs
+	Weight      int        `json:"weight,omitempty"` // multiplies the weights of the category's questions; 0 leaves them unchanged
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// WeightMultiplier returns the factor applied to the weights of the category's questions
+func (c *Category) WeightMultiplier() int {
+	if c == nil || c.Weight < 1 {
+		return 1
+	}
+	return c.Weight
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// Rules deciding an assessment's answer to a question several assessors answered
+const (
+	ConsensusLatest   = "latest"   // the most recent answer
+	ConsensusMajority = "majority" // the option most assessors chose; ties go to the most recent
+	ConsensusLowest   = "lowest"   // the option worth the fewest points, the most conservative view
+)
+
+// AnswerResponse is an assessor's latest answer to a question. Assessments keep the responses
+// of every assessor; the consensus rule derives the answer that is scored from them.
+type AnswerResponse struct {
+	OptionID    string    `json:"optionId"`
+	Explanation string    `json:"explanation,omitempty"`
+	AnsweredAt  time.Time `json:"answeredAt"`
+}
+
+// AnswerConsensus is the answer a consensus rule derived from the responses to a question when
+// it differs from the response being saved
+type AnswerConsensus struct {
+	Rule        string `json:"rule"`
+	OptionID    string `json:"optionId"`
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// AnswerDisagreement flags a question whose assessors disagreed, for follow-up discussion
+type AnswerDisagreement struct {
+	QuestionID  string          `json:"questionId"`
+	Text        string          `json:"text"`
+	Category    string          `json:"category"`
+	OptionID    string          `json:"optionId"`    // the option scored
+	Responses   int             `json:"responses"`   // assessors who answered
+	Agreement   int             `json:"agreement"`   // percent of assessors who chose the most chosen option
+	PointSpread int             `json:"pointSpread"` // between the highest and lowest points chosen
+	Options     []ResponseCount `json:"options"`     // most chosen first
+}
+
+// ResponseCount is how many assessors chose an option
+type ResponseCount struct {
+	OptionID   string `json:"optionId"`
+	OptionText string `json:"optionText"`
+	Points     int    `json:"points"`
+	Count      int    `json:"count"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// DeletionResult describes what was removed when deleting an application
+type DeletionResult struct {
+	ApplicationID        string   `json:"applicationId"`
+	Mode                 string   `json:"mode"`
+	Deleted              bool     `json:"deleted"`
+	DependentAssessments []string `json:"dependentAssessments"`
+	DeletedAssessments   int      `json:"deletedAssessments"`
+	DeletedReports       int      `json:"deletedReports"`
+	OrphanedAssessments  int      `json:"orphanedAssessments"`
+}
+
+// Application deletion modes for handling dependent assessments and reports
+const (
+	DeleteModeBlock   = "block"   // refuse to delete while assessments exist
+	DeleteModeCascade = "cascade" // delete assessments, reports and event logs too
+	DeleteModeOrphan  = "orphan"  // delete only the application and keep its assessments
+)
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// DigestSubscription is a user's preference for the weekly portfolio digest email
+type DigestSubscription struct {
+	User      string            `json:"user"`
+	Email     string            `json:"email"`
+	Sections  []string          `json:"sections,omitempty"` // digest sections to include; empty includes all
+	Tags      map[string]string `json:"tags,omitempty"`     // only applications with all of these tags
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// Digest sections
+const (
+	DigestSectionCompleted    = "completed"
+	DigestSectionScoreChanges = "scoreChanges"
+	DigestSectionOverdue      = "overdue"
+)
+
+// DigestSections lists the sections of a digest in the order they are rendered
+var DigestSections = []string{DigestSectionCompleted, DigestSectionScoreChanges, DigestSectionOverdue}
+
+// Digest summarizes portfolio activity over a period
+type Digest struct {
+	PeriodStart  string               `json:"periodStart"`
+	PeriodEnd    string               `json:"periodEnd"`
+	Completed    []DigestAssessment   `json:"completed"`
+	ScoreChanges []DigestScoreChange  `json:"scoreChanges"`
+	Overdue      []DigestOverdueEntry `json:"overdue"`
+}
+
+// DigestAssessment is an assessment completed during the digest period
+type DigestAssessment struct {
+	ApplicationID   string    `json:"applicationId"`
+	ApplicationName string    `json:"applicationName"`
+	AssessmentID    string    `json:"assessmentId"`
+	CompletedAt     time.Time `json:"completedAt"`
+	ScorePercent    int       `json:"scorePercent"`
+	Grade           string    `json:"grade"`
+}
+
+// DigestScoreChange is an application whose latest score changed during the digest period
+type DigestScoreChange struct {
+	ApplicationID   string `json:"applicationId"`
+	ApplicationName string `json:"applicationName"`
+	PreviousPercent int    `json:"previousPercent"`
+	ScorePercent    int    `json:"scorePercent"`
+	Change          int    `json:"change"` // percentage points
+}
+
+// DigestOverdueEntry is an application not yet assessed for a campaign past its due date
+type DigestOverdueEntry struct {
+	CampaignID      string `json:"campaignId"`
+	CampaignName    string `json:"campaignName"`
+	ApplicationID   string `json:"applicationId"`
+	ApplicationName string `json:"applicationName"`
+	Owner           string `json:"owner,omitempty"`
+	Status          string `json:"status"`
+	DueDate         string `json:"dueDate"`
+}
+
+// DigestState records when digests were last sent, shared by all replicas
+type DigestState struct {
+	LastSentAt time.Time `json:"lastSentAt"`
+}
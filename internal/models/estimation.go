@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+// EstimationConfig converts the effort labels of modernization steps into hour and cost ranges
+type EstimationConfig struct {
+	Currency   string        `json:"currency"`
+	HourlyRate float64       `json:"hourlyRate"`
+	Efforts    []EffortRange `json:"efforts"`
+	UpdatedAt  *time.Time    `json:"updatedAt,omitempty"`
+}
+
+// EffortRange is the number of hours a modernization step with the given effort label takes
+type EffortRange struct {
+	Label    string  `json:"label"` // e.g. Low, Medium, High
+	MinHours float64 `json:"minHours"`
+	MaxHours float64 `json:"maxHours"`
+}
+
+// StepEstimate is the estimated hours and cost of a modernization step
+type StepEstimate struct {
+	MinHours float64 `json:"minHours"`
+	MaxHours float64 `json:"maxHours"`
+	MinCost  float64 `json:"minCost"`
+	MaxCost  float64 `json:"maxCost"`
+}
+
+// EffortEstimate is the total estimated effort and cost of a report's modernization plan
+type EffortEstimate struct {
+	Currency    string   `json:"currency"`
+	HourlyRate  float64  `json:"hourlyRate"`
+	MinHours    float64  `json:"minHours"`
+	MaxHours    float64  `json:"maxHours"`
+	MinCost     float64  `json:"minCost"`
+	MaxCost     float64  `json:"maxCost"`
+	Unestimated []string `json:"unestimated,omitempty"` // effort labels without a configured range
+}
+
+// RangeFor returns the configured range for an effort label
+func (c *EstimationConfig) RangeFor(label string) (EffortRange, bool) {
+	for _, effort := range c.Efforts {
+		if effort.Label == label {
+			return effort, true
+		}
+	}
+	return EffortRange{}, false
+}
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AssessmentEvent is an entry in the append-only change history of an assessment
+type AssessmentEvent struct {
+	AssessmentID    string             `json:"assessmentId"`
+	Sequence        int                `json:"sequence"`
+	Type            string             `json:"type"`
+	OccurredAt      time.Time          `json:"occurredAt"`
+	User            string             `json:"user,omitempty"`
+	Assessment      *Assessment        `json:"assessment,omitempty"`      // AssessmentStarted: initial state
+	QuestionID      string             `json:"questionId,omitempty"`      // AnswerSaved
+	OptionID        string             `json:"optionId,omitempty"`        // AnswerSaved
+	Explanation     string             `json:"explanation,omitempty"`     // AnswerSaved
+	References      []string           `json:"references,omitempty"`      // AnswerSaved: links to evidence
+	CopiedFrom      string             `json:"copiedFrom,omitempty"`      // AnswerSaved: source assessment of a copied answer
+	Provenance      *AnswerProvenance  `json:"provenance,omitempty"`      // AnswerSaved: how the answer was produced
+	Consensus       *AnswerConsensus   `json:"consensus,omitempty"`       // AnswerSaved: the answer scored instead of OptionID
+	WeightOverrides []WeightOverride   `json:"weightOverrides,omitempty"` // WeightsOverridden
+	Reason          string             `json:"reason,omitempty"`          // AssessmentReopened
+	Suggestions     []AnswerSuggestion `json:"suggestions,omitempty"`     // AnswersSuggested
+	ExternalID      string             `json:"externalId,omitempty"`      // ExternalIDSet; empty clears it
+	Checklist       []CheckedItem      `json:"checklist,omitempty"`       // AssessmentApproved: review checklist items ticked
+}
+
+// Assessment event types
+const (
+	EventAssessmentStarted   = "AssessmentStarted"
+	EventAnswerSaved         = "AnswerSaved"
+	EventAssessmentCompleted = "AssessmentCompleted"
+	EventWeightsOverridden   = "WeightsOverridden"
+	EventAssessmentApproved  = "AssessmentApproved"
+	EventAssessmentReopened  = "AssessmentReopened"
+	EventAnswersSuggested    = "AnswersSuggested"
+	EventSuggestionDismissed = "SuggestionDismissed"
+	EventExternalIDSet       = "ExternalIDSet"
+)
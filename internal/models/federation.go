@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// FederationPeer is a team-level instance a central instance pulls portfolio summaries from
+type FederationPeer struct {
+	Name  string `json:"name"`
+	URL   string `json:"url"`             // base URL of the instance, e.g. https://team-a.example.com
+	Token string `json:"token,omitempty"` // bearer token of the peer's federation API
+}
+
+// FederatedSummary is the summary data an instance shares with a central instance: its
+// portfolio summary and each assessed application's latest score, but no answers or reports
+type FederatedSummary struct {
+	Instance     string                 `json:"instance"`
+	GeneratedAt  time.Time              `json:"generatedAt"`
+	Summary      PortfolioSummary       `json:"summary"`
+	Applications []FederatedApplication `json:"applications"`
+}
+
+// FederatedApplication is an assessed application's latest score as shared with a central
+// instance
+type FederatedApplication struct {
+	ApplicationID string `json:"applicationId"`
+	Name          string `json:"name"`
+	LatestScore
+}
+
+// Statuses of an instance in the federated portfolio
+const (
+	FederationOK          = "ok"
+	FederationCached      = "cached"      // unreachable; its last pulled summary is used
+	FederationUnavailable = "unavailable" // unreachable and never pulled
+)
+
+// FederatedPortfolio aggregates the portfolio summaries of this instance and its peers
+type FederatedPortfolio struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Totals      FederatedTotals     `json:"totals"`
+	Instances   []FederatedInstance `json:"instances"`
+}
+
+// FederatedInstance is an instance's contribution to the federated portfolio
+type FederatedInstance struct {
+	Name      string            `json:"name"`
+	URL       string            `json:"url,omitempty"` // empty for this instance
+	Status    string            `json:"status"`
+	Error     string            `json:"error,omitempty"`     // why the last pull failed
+	FetchedAt *time.Time        `json:"fetchedAt,omitempty"` // when the summary was pulled
+	Summary   *FederatedSummary `json:"summary,omitempty"`
+}
+
+// FederatedTotals sums the portfolio summaries of the instances that have one. Averages are
+// weighted by the number of assessed applications of each instance.
+type FederatedTotals struct {
+	Instances           int                `json:"instances"`
+	Reporting           int                `json:"reporting"` // instances with a summary
+	Applications        int                `json:"applications"`
+	Assessed            int                `json:"assessed"`
+	Stale               int                `json:"stale"`
+	AverageScorePercent float64            `json:"averageScorePercent"`
+	Bands               map[string]int     `json:"bands"`
+	Grades              map[string]int     `json:"grades"`
+	CategoryAverages    map[string]float64 `json:"categoryAverages"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+// FindingsLog encodes a report's risks and recommendations as machine-readable findings for
+// security and governance tooling. It follows the structure of a SARIF 2.1.0 log: one run whose
+// tool lists a rule per finding, with the application as the logical location of each result.
+type FindingsLog struct {
+	Schema  string        `json:"$schema"`
+	Version string        `json:"version"`
+	Runs    []FindingsRun `json:"runs"`
+}
+
+// FindingsRun holds the findings of one report
+type FindingsRun struct {
+	Tool       FindingsTool       `json:"tool"`
+	Results    []Finding          `json:"results"`
+	Properties FindingsRunSummary `json:"properties"`
+}
+
+// FindingsTool describes the questionnaire that produced the findings
+type FindingsTool struct {
+	Driver FindingsDriver `json:"driver"`
+}
+
+// FindingsDriver names the tool and the rules its findings refer to
+type FindingsDriver struct {
+	Name  string        `json:"name"`
+	Rules []FindingRule `json:"rules"`
+}
+
+// FindingRule describes a kind of finding
+type FindingRule struct {
+	ID               string          `json:"id"`
+	ShortDescription FindingsMessage `json:"shortDescription"`
+	Properties       FindingRuleTags `json:"properties"`
+}
+
+// FindingRuleTags classifies a rule
+type FindingRuleTags struct {
+	Category string   `json:"category"`
+	Tags     []string `json:"tags"`
+}
+
+// FindingsMessage is a plain text message
+type FindingsMessage struct {
+	Text string `json:"text"`
+}
+
+// FindingsRunSummary identifies the report a run was exported from
+type FindingsRunSummary struct {
+	AssessmentID     string    `json:"assessmentId"`
+	ApplicationID    string    `json:"applicationId"`
+	GeneratedAt      time.Time `json:"generatedAt"`
+	TotalScore       int       `json:"totalScore"`
+	MaxPossibleScore int       `json:"maxPossibleScore"`
+	Grade            string    `json:"grade,omitempty"`
+	Band             string    `json:"band,omitempty"`
+}
+
+// Finding is a risk or recommendation of a report
+type Finding struct {
+	RuleID              string               `json:"ruleId"`
+	Level               string               `json:"level"` // error, warning or note
+	Message             FindingsMessage      `json:"message"`
+	Locations           []FindingLocation    `json:"locations"`
+	PartialFingerprints map[string]string    `json:"partialFingerprints"`
+	Suppressions        []FindingSuppression `json:"suppressions,omitempty"` // an accepted risk
+	Properties          FindingProperties    `json:"properties"`
+}
+
+// FindingLocation places a finding in an application rather than a source file
+type FindingLocation struct {
+	LogicalLocations []FindingLogicalLocation `json:"logicalLocations"`
+}
+
+// FindingLogicalLocation names the application a finding applies to
+type FindingLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"` // the application ID
+	Kind               string `json:"kind"`
+}
+
+// FindingSuppression records that a risk was accepted
+type FindingSuppression struct {
+	Kind          string `json:"kind"`   // always external: the acceptance is kept outside the report
+	Status        string `json:"status"` // accepted
+	Justification string `json:"justification,omitempty"`
+}
+
+// FindingProperties carries the questionnaire's own fields of a finding
+type FindingProperties struct {
+	Kind              string   `json:"kind"` // risk or recommendation
+	Category          string   `json:"category"`
+	Severity          string   `json:"severity"`                    // of a risk, or priority of a recommendation
+	Remediation       []string `json:"remediation,omitempty"`       // recommendations of a risk's category
+	RemediationStatus string   `json:"remediationStatus,omitempty"` // of a recommendation
+	Owner             string   `json:"owner,omitempty"`
+	DueDate           string   `json:"dueDate,omitempty"`
+	AcceptedBy        string   `json:"acceptedBy,omitempty"`
+	AcceptedUntil     string   `json:"acceptedUntil,omitempty"`
+	AcceptanceExpired bool     `json:"acceptanceExpired,omitempty"`
+}
@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// PortfolioHeatmap is the matrix of category scores of every application's latest report, for
+// business intelligence tools
+type PortfolioHeatmap struct {
+	GeneratedAt  time.Time    `json:"generatedAt"`
+	Categories   []string     `json:"categories"` // the columns: every category scored by a report
+	Applications []HeatmapRow `json:"applications"`
+}
+
+// HeatmapRow is an application's row of the heatmap
+type HeatmapRow struct {
+	ApplicationID   string                 `json:"applicationId"`
+	ApplicationName string                 `json:"applicationName"`
+	AssessmentID    string                 `json:"assessmentId"`
+	ReportedAt      time.Time              `json:"reportedAt"` // when the report was generated
+	ScorePercent    int                    `json:"scorePercent"`
+	Grade           string                 `json:"grade,omitempty"`
+	Cells           map[string]HeatmapCell `json:"cells"` // category -> score; missing if not scored
+}
+
+// HeatmapCell is an application's score in a category
+type HeatmapCell struct {
+	Score    int  `json:"score"`
+	MaxScore int  `json:"maxScore,omitempty"`
+	Percent  *int `json:"percent,omitempty"` // unset for reports without a breakdown to take the maximum from
+}
@@ -0,0 +1,32 @@
+package models
+
+// ImportReport describes the outcome of a question catalog import
+type ImportReport struct {
+	Valid     bool          `json:"valid"`
+	DryRun    bool          `json:"dryRun"`
+	Rows      int           `json:"rows"`
+	Questions int           `json:"questions"`
+	Imported  int           `json:"imported"`
+	Errors    []ImportError `json:"errors"`
+}
+
+// ImportError is a validation problem in a single cell or row of an import file
+type ImportError struct {
+	Sheet  string `json:"sheet,omitempty"` // worksheet of a workbook
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Cell   string `json:"cell,omitempty"` // reference of a workbook cell, e.g. C12
+	Error  string `json:"error"`
+}
+
+// AssessmentImportReport describes the outcome of importing an assessment from a workbook
+type AssessmentImportReport struct {
+	Valid         bool          `json:"valid"`
+	DryRun        bool          `json:"dryRun"`
+	Sheets        int           `json:"sheets"`                  // category sheets read
+	SkippedSheets []string      `json:"skippedSheets,omitempty"` // sheets without an answer column, e.g. instructions
+	Answers       int           `json:"answers"`
+	Unanswered    int           `json:"unanswered"`             // rows with a blank answer
+	AssessmentID  string        `json:"assessmentId,omitempty"` // the completed assessment, unless a dry run
+	Errors        []ImportError `json:"errors"`
+}
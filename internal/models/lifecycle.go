@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// Application lifecycle states, tracking what happened to an application after its assessment
+const (
+	LifecycleAssessed    = "assessed"
+	LifecycleRemediation = "remediation-in-progress"
+	LifecycleMigrated    = "migrated"
+	LifecycleRetired     = "retired"
+)
+
+// LifecycleUntracked stands for applications without a lifecycle state in counts and filters
+const LifecycleUntracked = "untracked"
+
+// LifecycleChange records a transition of an application's lifecycle state
+type LifecycleChange struct {
+	From      string    `json:"from,omitempty"` // empty for the first state
+	To        string    `json:"to"`
+	Note      string    `json:"note,omitempty"`
+	ChangedBy string    `json:"changedBy,omitempty"`
+	ChangedAt time.Time `json:"changedAt"`
+}
+
+// LifecycleTransition is a request to move an application to another lifecycle state
+type LifecycleTransition struct {
+	Status string `json:"status"`
+	Note   string `json:"note,omitempty"`
+}
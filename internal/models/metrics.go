@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+// AssessmentMetrics describes how long an assessment took
+type AssessmentMetrics struct {
+	AssessmentID      string     `json:"assessmentId"`
+	Status            string     `json:"status"`
+	StartedAt         time.Time  `json:"startedAt"`
+	CompletedAt       *time.Time `json:"completedAt,omitempty"`
+	FirstAnswerAt     *time.Time `json:"firstAnswerAt,omitempty"`
+	LastAnswerAt      *time.Time `json:"lastAnswerAt,omitempty"`
+	AnsweredQuestions int        `json:"answeredQuestions"`
+	CycleTimeHours    *float64   `json:"cycleTimeHours,omitempty"` // start to completion
+	ElapsedHours      float64    `json:"elapsedHours"`             // start to completion, or to now while in progress
+}
+
+// PortfolioCycleTimeMetrics aggregates assessment durations across the portfolio
+type PortfolioCycleTimeMetrics struct {
+	CompletedAssessments  int     `json:"completedAssessments"`
+	InProgressAssessments int     `json:"inProgressAssessments"`
+	AverageCycleTimeHours float64 `json:"averageCycleTimeHours"`
+	MedianCycleTimeHours  float64 `json:"medianCycleTimeHours"`
+	P90CycleTimeHours     float64 `json:"p90CycleTimeHours"`
+	AverageOpenAgeHours   float64 `json:"averageOpenAgeHours"` // age of assessments still in progress
+}
+
+// QuestionMetrics describes how long assessors take to answer a question and how often they
+// come back to it, across all assessments. Slow or often revisited questions may be worded
+// confusingly.
+type QuestionMetrics struct {
+	QuestionID     string  `json:"questionId"`
+	Text           string  `json:"text"`
+	Category       string  `json:"category"`
+	Assessments    int     `json:"assessments"`    // assessments in which assessors answered the question
+	TimedAnswers   int     `json:"timedAnswers"`   // answers whose time to answer is known
+	MedianSeconds  float64 `json:"medianSeconds"`  // time to answer
+	AverageSeconds float64 `json:"averageSeconds"` // time to answer
+	P90Seconds     float64 `json:"p90Seconds"`     // time to answer
+	Revisits       int     `json:"revisits"`       // answers saved again by the same assessor
+	Changes        int     `json:"changes"`        // revisits that chose another option
+	RevisitRate    float64 `json:"revisitRate"`    // percent of the assessments with a revisit
+}
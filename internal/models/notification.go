@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// NotificationTarget is a configured destination for outbound notifications
+type NotificationTarget struct {
+	Name   string              `json:"name"`
+	Type   string              `json:"type"`             // webhook, slack or email
+	URL    string              `json:"url"`              // webhook and slack incoming-webhook URL
+	To     []string            `json:"to"`               // email recipients
+	Events []string            `json:"events"`           // subscribed event types; empty subscribes to all
+	Filter *NotificationFilter `json:"filter,omitempty"` // only events matching it are queued
+}
+
+// NotificationFilter narrows a target's subscription to events about some applications or
+// scores. Every set condition must hold; events without the field a condition tests do not
+// match.
+type NotificationFilter struct {
+	ScoreBelow *int              `json:"scoreBelow,omitempty"` // score percentage below this
+	Tags       map[string]string `json:"tags,omitempty"`       // application tags that must all be set to these values
+}
+
+// OutboxMessage is a notification queued for delivery to a single target
+type OutboxMessage struct {
+	ID            string                 `json:"id"`
+	Target        string                 `json:"target"`
+	Event         string                 `json:"event"`
+	Payload       map[string]interface{} `json:"payload"`
+	Status        string                 `json:"status"`
+	Attempts      int                    `json:"attempts"`
+	CreatedAt     time.Time              `json:"createdAt"`
+	NextAttemptAt time.Time              `json:"nextAttemptAt"`
+	DeliveredAt   *time.Time             `json:"deliveredAt,omitempty"`
+	LastError     string                 `json:"lastError,omitempty"`
+}
+
+// Notification target types
+const (
+	NotificationWebhook = "webhook"
+	NotificationSlack   = "slack"
+	NotificationEmail   = "email"
+)
+
+// Outbox message statuses
+const (
+	OutboxPending   = "pending"
+	OutboxDelivered = "delivered"
+	OutboxDead      = "dead"
+)
+
+// Notification event types
+const (
+	EventTypeAssessmentCompleted = "assessment.completed"
+	EventTypePortfolioDigest     = "portfolio.digest"
+)
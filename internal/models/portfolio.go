@@ -0,0 +1,100 @@
+package models
+
+import "time"
+
+// PrioritizationMatrix places applications on a business value versus modernization effort grid
+type PrioritizationMatrix struct {
+	GeneratedAt     time.Time     `json:"generatedAt"`
+	ValueThreshold  float64       `json:"valueThreshold"`  // values at or above are high value
+	EffortThreshold float64       `json:"effortThreshold"` // estimated hours above are high effort
+	Applications    []MatrixPoint `json:"applications"`
+	Unassessed      []string      `json:"unassessed"` // applications without a report
+}
+
+// MatrixPoint is an application's position in the prioritization matrix
+type MatrixPoint struct {
+	ApplicationID string  `json:"applicationId"`
+	Name          string  `json:"name"`
+	Value         float64 `json:"value"`       // business value from 1 (low) to 5 (high)
+	ValueSource   string  `json:"valueSource"` // tag the value was read from, or "default"
+	EffortHours   float64 `json:"effortHours"` // midpoint of the estimated hour range
+	Grade         string  `json:"grade,omitempty"`
+	AssessmentID  string  `json:"assessmentId"`
+	Stale         bool    `json:"stale,omitempty"` // the report is past its validity period
+	Quadrant      string  `json:"quadrant"`
+	SuggestedWave int     `json:"suggestedWave"`
+}
+
+// Prioritization matrix quadrants, in the order their applications should be migrated
+const (
+	QuadrantQuickWin   = "quick-win"  // high value, low effort
+	QuadrantStrategic  = "strategic"  // high value, high effort
+	QuadrantFillIn     = "fill-in"    // low value, low effort
+	QuadrantReconsider = "reconsider" // low value, high effort
+)
+
+// WavePlan groups applications into quarterly migration waves
+type WavePlan struct {
+	GeneratedAt     time.Time                `json:"generatedAt"`
+	CapacityHours   float64                  `json:"capacityHours"`   // 0 means no hour limit
+	MaxApplications int                      `json:"maxApplications"` // 0 means no application limit
+	Waves           []MigrationWave          `json:"waves"`
+	Unscheduled     []UnscheduledApplication `json:"unscheduled"`
+	Unassessed      []string                 `json:"unassessed"`
+}
+
+// MigrationWave is a group of applications planned for the same quarter
+type MigrationWave struct {
+	Number       int               `json:"number"`
+	Quarter      string            `json:"quarter"` // e.g. 2027-Q1
+	EffortHours  float64           `json:"effortHours"`
+	OverCapacity bool              `json:"overCapacity,omitempty"` // a single application exceeds the capacity
+	Applications []WaveApplication `json:"applications"`
+}
+
+// WaveApplication is an application scheduled in a migration wave
+type WaveApplication struct {
+	ApplicationID        string   `json:"applicationId"`
+	Name                 string   `json:"name"`
+	Value                float64  `json:"value"`
+	EffortHours          float64  `json:"effortHours"`
+	Quadrant             string   `json:"quadrant"`
+	DependsOn            []string `json:"dependsOn,omitempty"`
+	ExternalDependencies []string `json:"externalDependencies,omitempty"` // dependencies outside the plan
+}
+
+// UnscheduledApplication is an assessed application that could not be placed in a wave
+type UnscheduledApplication struct {
+	ApplicationID string `json:"applicationId"`
+	Name          string `json:"name"`
+	Reason        string `json:"reason"`
+}
+
+// PortfolioSummary aggregates the latest report of every application
+type PortfolioSummary struct {
+	Applications        int                 `json:"applications"`
+	Assessed            int                 `json:"assessed"`
+	AverageScorePercent float64             `json:"averageScorePercent"`
+	Bands               map[string]int      `json:"bands"`            // band level -> applications
+	Grades              map[string]int      `json:"grades"`           // grade -> applications
+	CategoryAverages    map[string]float64  `json:"categoryAverages"` // category -> average score
+	Stale               int                 `json:"stale"`            // assessed applications whose latest report is past its validity period
+	Lifecycles          map[string]int      `json:"lifecycles"`       // lifecycle state -> applications, counted on every read
+	Remediation         RemediationProgress `json:"remediation"`      // latest reports' recommendations by status, counted on every read
+	BuiltAt             time.Time           `json:"builtAt"`          // last full rebuild
+	UpdatedAt           time.Time           `json:"updatedAt"`        // last incremental update
+}
+
+// PortfolioSummarySnapshot is the stored form of the portfolio summary, keeping the entry of
+// each assessed application so single reports can be applied without rereading the others
+type PortfolioSummarySnapshot struct {
+	Summary PortfolioSummary        `json:"summary"`
+	Entries map[string]SummaryEntry `json:"entries"` // application ID -> latest report
+}
+
+// SummaryEntry is an application's contribution to the portfolio summary
+type SummaryEntry struct {
+	LatestScore
+	CategoryScores  map[string]int `json:"categoryScores"`
+	Recommendations []string       `json:"recommendations,omitempty"` // keys of the report's recommendations
+}
@@ -0,0 +1,81 @@
+package models
+
+import "time"
+
+// UserDataExport collects all personal data recorded for a single user
+type UserDataExport struct {
+	UserID           string                 `json:"userId"`
+	ExportedAt       time.Time              `json:"exportedAt"`
+	Assessments      []*Assessment          `json:"assessments"` // assessments the user started, approved or took part in
+	Answers          []UserAnswer           `json:"answers"`
+	Responses        []UserAnswer           `json:"responses"` // the user's own answers to questions several assessors answered
+	Annotations      []AssessmentAnnotation `json:"annotations"`
+	Votes            []UserWorkshopVote     `json:"workshopVotes"`
+	LifecycleChanges []UserLifecycleChange  `json:"lifecycleChanges"` // application lifecycle transitions made by the user
+	Remediations     []UserRemediation      `json:"remediations"`     // remediations the user updated or owns
+	RiskAcceptances  []UserRiskAcceptance   `json:"riskAcceptances"`  // risks the user accepted
+	Campaigns        []*Campaign            `json:"campaigns"`        // campaigns the user created or owns
+	Digest           *DigestSubscription    `json:"digestSubscription,omitempty"`
+}
+
+// UserAnswer is an answer given by a user within an assessment
+type UserAnswer struct {
+	AssessmentID string `json:"assessmentId"`
+	QuestionID   string `json:"questionId"`
+	OptionID     string `json:"optionId"`
+	Explanation  string `json:"explanation,omitempty"`
+}
+
+// UserWorkshopVote is a user's vote on a question of an assessment workshop
+type UserWorkshopVote struct {
+	AssessmentID string `json:"assessmentId"`
+	QuestionID   string `json:"questionId"`
+	OptionID     string `json:"optionId"`
+}
+
+// UserLifecycleChange is a lifecycle transition together with the application it belongs to
+type UserLifecycleChange struct {
+	ApplicationID string `json:"applicationId"`
+	LifecycleChange
+}
+
+// UserRemediation is a remediation together with the application it belongs to
+type UserRemediation struct {
+	ApplicationID string `json:"applicationId"`
+	Remediation
+}
+
+// UserRiskAcceptance is a risk acceptance together with the application it belongs to
+type UserRiskAcceptance struct {
+	ApplicationID string `json:"applicationId"`
+	RiskAcceptance
+}
+
+// AssessmentAnnotation is a report annotation together with the assessment it belongs to
+type AssessmentAnnotation struct {
+	AssessmentID string `json:"assessmentId"`
+	Annotation
+}
+
+// UserDataErasure summarizes the result of anonymizing or purging a user's data
+type UserDataErasure struct {
+	UserID              string `json:"userId"`
+	Mode                string `json:"mode"`
+	AssessmentsUpdated  int    `json:"assessmentsUpdated"`
+	AssessmentsDeleted  int    `json:"assessmentsDeleted"`
+	AnnotationsUpdated  int    `json:"annotationsUpdated"`
+	AnnotationsDeleted  int    `json:"annotationsDeleted"`
+	WorkshopsUpdated    int    `json:"workshopsUpdated"`
+	ApplicationsUpdated int    `json:"applicationsUpdated"`
+	CampaignsUpdated    int    `json:"campaignsUpdated"`
+	DigestUnsubscribed  bool   `json:"digestUnsubscribed"`
+}
+
+// Erasure modes
+const (
+	ErasureModeAnonymize = "anonymize"
+	ErasureModePurge     = "purge"
+)
+
+// AnonymizedUser replaces user identities removed by an anonymization request
+const AnonymizedUser = "anonymized"
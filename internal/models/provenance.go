@@ -0,0 +1,34 @@
+package models
+
+// Ways an answer was produced
+const (
+	ProvenanceManual    = "manual"     // chosen by an assessor
+	ProvenancePrefilled = "prefilled"  // a rule's suggestion, confirmed by an assessor
+	ProvenanceAgentScan = "agent-scan" // a cluster scan's suggestion, confirmed by an assessor
+	ProvenanceImported  = "imported"   // taken over from another tool, such as Tackle
+	ProvenanceCopied    = "copied"     // copied from another assessment
+	ProvenanceWorkshop  = "workshop"   // the consensus of a workshop vote, recorded by its facilitator
+)
+
+// ProvenanceUnrecorded counts answers saved before provenance was recorded
+const ProvenanceUnrecorded = "unrecorded"
+
+// AnswerProvenance records how an answer was produced. The person who chose or confirmed it is
+// recorded in the assessment's AnsweredBy.
+type AnswerProvenance struct {
+	Method     string         `json:"method"`
+	Source     string         `json:"source,omitempty"`     // suggestion source, import tool or source assessment
+	Signal     string         `json:"signal,omitempty"`     // signal behind a confirmed suggestion
+	Confidence float64        `json:"confidence,omitempty"` // of a confirmed suggestion, or a workshop's agreement
+	Votes      map[string]int `json:"votes,omitempty"`      // workshop votes per option
+}
+
+// HumanVerified reports whether a person chose or confirmed the answer in this assessment, as
+// opposed to answers taken over from elsewhere
+func (p AnswerProvenance) HumanVerified() bool {
+	switch p.Method {
+	case ProvenanceManual, ProvenancePrefilled, ProvenanceAgentScan, ProvenanceWorkshop:
+		return true
+	}
+	return false
+}
@@ -2,16 +2,191 @@ package models
 
 // Question represents a single assessment question
 type Question struct {
-	ID       string   `json:"id"`
-	Text     string   `json:"text"`
-	Category string   `json:"category"`
-	Options  []Option `json:"options"`
-	Weight   int      `json:"weight"`
+	ID          string        `json:"id"`
+	Text        string        `json:"text"`
+	Category    string        `json:"category"`
+	Options     []Option      `json:"options"`
+	Weight      int           `json:"weight"`
+	Help        string        `json:"help,omitempty"`        // Markdown shown alongside the question
+	VisibleWhen []Condition   `json:"visibleWhen,omitempty"` // all conditions must hold for the question to be shown
+	AppliesWhen []TagRule     `json:"appliesWhen,omitempty"` // all rules must hold for the application's tags
+	AnswerRules *AnswerRules  `json:"answerRules,omitempty"` // checks on the free-text explanation of answers
+	Evidence    *EvidenceRule `json:"evidence,omitempty"`    // answers worth many points must link to evidence
+	
+	Translations map[string]QuestionTranslation `json:"translations,omitempty"` // keyed by locale, e.g. "de" or "pt-BR"
 }
 
 // Option represents a possible answer to a question
 type Option struct {
-	ID     string `json:"id"`
-	Text   string `json:"text"`
-	Points int    `json:"points"`
+	ID                  string `json:"id"`
+	Text                string `json:"text"`
+	Points              int    `json:"points"`                        // negative points penalize answers that are worse than scoring zero
+	RequiresExplanation bool   `json:"requiresExplanation,omitempty"` // e.g. "Other": the answer must explain itself in free text
+}
+
+// EvidenceRule requires answers whose option is worth at least MinPoints to carry a reference
+// link to evidence, such as a design document or a test report. Answers have no attachments, so
+// evidence is linked rather than uploaded.
+type EvidenceRule struct {
+	MinPoints int `json:"minPoints"`
+}
+
+// AnswerRules validate the free text given with an answer, such as the explanation of an
+// "Other" option. Lengths count characters; Min and Max make the text a number.
+type AnswerRules struct {
+	Pattern   string   `json:"pattern,omitempty"` // regular expression the whole text must match
+	MinLength int      `json:"minLength,omitempty"`
+	MaxLength int      `json:"maxLength,omitempty"`
+	Numeric   bool     `json:"numeric,omitempty"` // the text must be a number
+	Min       *float64 `json:"min,omitempty"`
+	Max       *float64 `json:"max,omitempty"`
+	Message   string   `json:"message,omitempty"` // shown instead of the default messages, e.g. an example
+}
+
+// Condition makes a question depend on the answer to another question
+type Condition struct {
+	QuestionID string   `json:"questionId"`
+	OptionIDs  []string `json:"optionIds"` // the question is shown if any of these options was chosen
+}
+
+// Tag rule operators
+const (
+	TagRuleIn    = "in"    // the tag is set to one of the values
+	TagRuleNotIn = "notIn" // the tag is missing or set to none of the values
+)
+
+// TagRule makes a question apply only to applications with matching tags
+type TagRule struct {
+	Tag      string   `json:"tag"`
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+// Matches reports whether the rule holds for an application's tags
+func (r TagRule) Matches(tags map[string]string) bool {
+	value, ok := tags[r.Tag]
+	found := false
+	if ok {
+		for _, v := range r.Values {
+			if v == value {
+				found = true
+				break
+			}
+		}
+	}
+
+	if r.Operator == TagRuleNotIn {
+		return !found
+	}
+	return found
+}
+
+// AppliesTo reports whether the question is relevant to an application with the given tags.
+// Questions that do not apply are not shown and not scored.
+func (q *Question) AppliesTo(tags map[string]string) bool {
+	for _, rule := range q.AppliesWhen {
+		if !rule.Matches(tags) {
+			return false
+		}
+	}
+	return true
+}
+
+// VisibleFor reports whether the question is shown given the answers so far. Hidden questions
+// are not scored.
+func (q *Question) VisibleFor(answers map[string]string) bool {
+	for _, condition := range q.VisibleWhen {
+		answer, ok := answers[condition.QuestionID]
+		if !ok {
+			return false
+		}
+
+		matched := false
+		for _, optionID := range condition.OptionIDs {
+			if optionID == answer {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// QuestionProblem is a validation problem with a question definition
+type QuestionProblem struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// QuestionPreview shows how a draft question will be presented and scored
+type QuestionPreview struct {
+	Question         *Question         `json:"question"`
+	HelpHTML         string            `json:"helpHtml,omitempty"`
+	Visible          bool              `json:"visible"`          // visibility for the sample answers in the request
+	VisibilityRules  []string          `json:"visibilityRules"`  // human readable conditions
+	MaxContribution  int               `json:"maxContribution"`  // weight times the highest option points
+	ReplacesExisting bool              `json:"replacesExisting"` // a live question with this ID exists
+	Valid            bool              `json:"valid"`
+	Problems         []QuestionProblem `json:"problems"`
+}
+
+// BuiltinCatalog is a ready-made question catalog shipped with the application
+type BuiltinCatalog struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	QuestionCount int         `json:"questionCount"`
+	Questions     []*Question `json:"questions,omitempty"`
+}
+
+// GitCatalogSource locates a question catalog in a Git repository
+type GitCatalogSource struct {
+	URL      string `json:"url"`
+	Ref      string `json:"ref,omitempty"`      // tag or branch; the default branch if empty
+	Path     string `json:"path,omitempty"`     // directory or file of the catalog within the repository
+	Checksum string `json:"checksum,omitempty"` // sha256:<hex> the catalog files must have
+}
+
+// CatalogInstallResult reports which questions of a built-in or Git catalog were installed
+type CatalogInstallResult struct {
+	CatalogID  string   `json:"catalogId"`
+	Commit     string   `json:"commit,omitempty"`   // Git catalogs: the commit installed from
+	Checksum   string   `json:"checksum,omitempty"` // Git catalogs: the checksum of the catalog files
+	Installed  []string `json:"installed"`            // questions added
+	Replaced   []string `json:"replaced"`             // existing questions overwritten
+	Skipped    []string `json:"skipped"`              // existing questions kept
+	Categories []string `json:"categories,omitempty"` // categories added for the catalog's questions
+}
+
+// CatalogReload reports the question catalog after a reload request
+type CatalogReload struct {
+	Reloaded  bool   `json:"reloaded"` // false if questions are read from storage on every request
+	Questions int    `json:"questions"`
+	Source    string `json:"source"` // "catalog-dir" or "storage"
+}
+
+// Severities of catalog validation problems
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// CatalogProblem is a problem found when validating a question catalog
+type CatalogProblem struct {
+	File       string `json:"file,omitempty"`
+	QuestionID string `json:"questionId,omitempty"`
+	Severity   string `json:"severity"`
+	Field      string `json:"field"`
+	Message    string `json:"message"`
+}
+
+// CatalogValidation is the result of validating question catalog files
+type CatalogValidation struct {
+	Files     int              `json:"files"`
+	Questions int              `json:"questions"`
+	Valid     bool             `json:"valid"` // true if there are no errors; warnings are allowed
+	Problems  []CatalogProblem `json:"problems"`
 }
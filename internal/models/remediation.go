@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// Remediation statuses of a recommendation; recommendations without a remediation are open
+const (
+	RemediationOpen     = "open"
+	RemediationAccepted = "accepted"
+	RemediationRejected = "rejected"
+	RemediationDone     = "done"
+)
+
+// Remediation tracks what an application's team decided about a recommendation. It is stored
+// with the application and keyed by the recommendation, so later reports giving the same
+// recommendation show it too.
+type Remediation struct {
+	Key          string     `json:"key"` // identifies the recommendation by category and description
+	Category     string     `json:"category"`
+	Description  string     `json:"description"`
+	Status       string     `json:"status"`
+	Owner        string     `json:"owner,omitempty"`
+	DueDate      string     `json:"dueDate,omitempty"` // YYYY-MM-DD
+	Note         string     `json:"note,omitempty"`
+	AssessmentID string     `json:"assessmentId"` // the report the status was last set on
+	UpdatedBy    string     `json:"updatedBy,omitempty"`
+	UpdatedAt    time.Time  `json:"updatedAt"`
+	CompletedAt  *time.Time `json:"completedAt,omitempty"` // when it was marked done
+}
+
+// RemediationUpdate sets the remediation status of a report recommendation
+type RemediationUpdate struct {
+	Status  string `json:"status"`
+	Owner   string `json:"owner,omitempty"`
+	DueDate string `json:"dueDate,omitempty"`
+	Note    string `json:"note,omitempty"`
+}
+
+// RemediationProgress counts the recommendations of a report by remediation status.
+// PercentDone leaves rejected recommendations out.
+type RemediationProgress struct {
+	Total       int `json:"total"`
+	Open        int `json:"open"`
+	Accepted    int `json:"accepted"`
+	Rejected    int `json:"rejected"`
+	Done        int `json:"done"`
+	PercentDone int `json:"percentDone"`
+}
+
+// Add counts a recommendation with the given remediation status
+func (p *RemediationProgress) Add(status string) {
+	p.Total++
+	switch status {
+	case RemediationAccepted:
+		p.Accepted++
+	case RemediationRejected:
+		p.Rejected++
+	case RemediationDone:
+		p.Done++
+	default:
+		p.Open++
+	}
+	
+	if relevant := p.Total - p.Rejected; relevant > 0 {
+		p.PercentDone = p.Done * 100 / relevant
+	} else {
+		p.PercentDone = 0
+	}
+}
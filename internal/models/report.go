@@ -1,35 +1,189 @@
 package models
 
+import "time"
+
 // Report represents the generated suitability report
 type Report struct {
-	AssessmentID      string             `json:"assessmentId"`
-	ApplicationID     string             `json:"applicationId"`
-	GeneratedAt       string             `json:"generatedAt"`
-	TotalScore        int                `json:"totalScore"`
-	MaxPossibleScore  int                `json:"maxPossibleScore"`
-	CategoryScores    map[string]int     `json:"categoryScores"`
-	Recommendations   []Recommendation   `json:"recommendations"`
-	Risks             []Risk             `json:"risks"`
-	ModernizationPlan []ModernizationStep `json:"modernizationPlan"`
-}
-
-// Recommendation provides guidance based on assessment answers
+	AssessmentID      string               `json:"assessmentId"`
+	ApplicationID     string               `json:"applicationId"`
+	Application       *ApplicationSnapshot `json:"application,omitempty"` // as it was when the assessment started
+	GeneratedAt       time.Time            `json:"generatedAt"`
+	Version           int                  `json:"version,omitempty"`    // 0 for the report issued at completion; re-scoring adds versions
+	Rescore           *ReportRescore       `json:"rescore,omitempty"`    // set on versions produced by re-scoring
+	Context           *AssessmentContext   `json:"context,omitempty"`    // as recorded when the assessment started
+	SpotCheck         *SpotCheck           `json:"spotCheck,omitempty"`  // the report only scores the sampled questions
+	Categories        []string             `json:"categories,omitempty"` // the report only scores these categories
+	UpdatedAt         *time.Time           `json:"updatedAt,omitempty"`  // last change after generation, e.g. an annotation
+	TotalScore        int                  `json:"totalScore"`
+	MaxPossibleScore  int                  `json:"maxPossibleScore"`
+	CategoryScores    map[string]int       `json:"categoryScores"`
+	CategoryShares    map[string]float64   `json:"categoryShares,omitempty"` // percent of the final score per category, if configured
+	WeightedRatio     *float64             `json:"weightedRatio,omitempty"`  // score ratio after category shares; bands and grades use it
+	FailedGates       []FailedGate         `json:"failedGates,omitempty"`    // category minimums missed, capping the grade
+	Band              string               `json:"band,omitempty"`
+	BandLabel         string               `json:"bandLabel,omitempty"`
+	Grade             string               `json:"grade,omitempty"`
+	Recommendations   []Recommendation     `json:"recommendations"`
+	Risks             []Risk               `json:"risks"`
+	ModernizationPlan []ModernizationStep  `json:"modernizationPlan"`
+	Annotations       []Annotation         `json:"annotations,omitempty"`
+	AppliedWeights    []AppliedWeight      `json:"appliedWeights,omitempty"`
+	Breakdown         []QuestionScore      `json:"breakdown,omitempty"`
+	Unanswered        []UnansweredQuestion `json:"unanswered,omitempty"`
+	UnansweredScore   int                  `json:"unansweredScore,omitempty"`  // part of the maximum score with no answer behind it
+	Disagreements     []AnswerDisagreement `json:"disagreements,omitempty"`    // questions the assessors disagreed on
+	PenaltyScore      int                  `json:"penaltyScore,omitempty"`     // sum of negative scores from penalty options
+	ScoringPlugins    []AppliedPlugin      `json:"scoringPlugins,omitempty"`   // WASM modules that adjusted the score
+	AnswerProvenance  map[string]int       `json:"answerProvenance,omitempty"` // provenance method -> scored answers
+	Estimate          *EffortEstimate      `json:"estimate,omitempty"`
+	Narrative         *Narrative           `json:"narrative,omitempty"`
+	PluginSections    []PluginSection      `json:"pluginSections,omitempty"` // added by report plugins
+	Signature         string               `json:"signature,omitempty"`      // detached JWS over the report without annotations
+	
+	// ValidUntil and Stale are derived from the configured validity period when a report is
+	// read, so they are neither stored nor signed
+	ValidUntil *time.Time `json:"validUntil,omitempty"`
+	Stale      bool       `json:"stale,omitempty"` // past its validity period; the application should be reassessed
+	
+	// Remediation is derived on read like the remediation of each recommendation
+	Remediation *RemediationProgress `json:"remediation,omitempty"`
+	
+	// Approval is derived on read from the assessment, which is approved after the report is
+	// issued
+	Approval *ReportApproval `json:"approval,omitempty"`
+}
+
+// Narrative is an AI-generated executive summary of a report
+type Narrative struct {
+	Text        string    `json:"text"`
+	AIGenerated bool      `json:"aiGenerated"`
+	Model       string    `json:"model"`
+	Disclaimer  string    `json:"disclaimer"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// AppliedPlugin documents a WASM scoring module that ran on a report and how it changed the
+// total score
+type AppliedPlugin struct {
+	Module      string `json:"module"`
+	Digest      string `json:"digest"` // SHA-256 of the module, so the exact code can be identified
+	ScoreBefore int    `json:"scoreBefore"`
+	ScoreAfter  int    `json:"scoreAfter"`
+}
+
+// PluginSection is a report section contributed by a report plugin, such as a cost quote
+// from a pricing service
+type PluginSection struct {
+	Plugin  string       `json:"plugin"` // name of the plugin that added the section
+	Title   string       `json:"title"`
+	Content string       `json:"content,omitempty"`
+	Facts   []PluginFact `json:"facts,omitempty"`
+}
+
+// PluginFact is a labeled value of a plugin section
+type PluginFact struct {
+	Label string `json:"label"`
+	Value string `json:"value"`
+}
+
+// QuestionScore shows how one question contributed to the total: Score is Points times
+// Weight out of MaxScore. Hidden questions are listed without a score.
+type QuestionScore struct {
+	QuestionID  string   `json:"questionId"`
+	Text        string   `json:"text"`
+	Category    string   `json:"category"`
+	OptionID    string   `json:"optionId,omitempty"`
+	OptionText  string   `json:"optionText,omitempty"`
+	Explanation string   `json:"explanation,omitempty"` // free text given with an option requiring an explanation
+	References  []string `json:"references,omitempty"`  // links to evidence given with the answer
+	Points      int      `json:"points"`                // points of the chosen option
+	MaxPoints   int      `json:"maxPoints"`             // points of the best option
+	Weight      int      `json:"weight"`                // effective weight in this report
+	Score       int      `json:"score"`
+	MaxScore    int      `json:"maxScore"`
+	Hidden      bool     `json:"hidden,omitempty"`     // not shown to the assessor, so not scored
+	Provenance  string   `json:"provenance,omitempty"` // how the answer was produced, if recorded
+	Unverified  bool     `json:"unverified,omitempty"` // no person chose or confirmed the answer in this assessment
+}
+
+// Reasons a scored question has no usable answer
+const (
+	UnansweredMissing       = "unanswered"     // the question was never answered
+	UnansweredUnknownOption = "unknown_option" // the answer names an option no longer in the catalog
+)
+
+// UnansweredQuestion is a question shown to the assessor that earned no points because it has
+// no usable answer, as opposed to an answer worth few points
+type UnansweredQuestion struct {
+	QuestionID string `json:"questionId"`
+	Text       string `json:"text"`
+	Category   string `json:"category"`
+	Weight     int    `json:"weight"`   // effective weight in this report
+	MaxScore   int    `json:"maxScore"` // weight times the best option's points
+	Reason     string `json:"reason"`
+	OptionID   string `json:"optionId,omitempty"` // the unknown option, for unknown_option
+}
+
+// AppliedWeight documents a question whose weight differed from the catalog in this report
+type AppliedWeight struct {
+	QuestionID      string `json:"questionId"`
+	Category        string `json:"category"`
+	DefaultWeight   int    `json:"defaultWeight"`
+	EffectiveWeight int    `json:"effectiveWeight"`
+	Reason          string `json:"reason,omitempty"`
+	Source          string `json:"source,omitempty"`
+}
+
+// FailedGate is a category that missed its configured minimum score ratio
+type FailedGate struct {
+	Category string  `json:"category"`
+	Ratio    float64 `json:"ratio"`
+	MinRatio float64 `json:"minRatio"`
+	MaxGrade string  `json:"maxGrade"` // the grade the report was capped at
+}
+
+// Recommendation provides guidance based on assessment answers. Key and Remediation are
+// derived from the application's remediations when a report is read, so they are neither
+// stored nor signed.
 type Recommendation struct {
-	Category    string `json:"category"`
-	Description string `json:"description"`
-	Priority    string `json:"priority"`
+	Category    string       `json:"category"`
+	Description string       `json:"description"`
+	Priority    string       `json:"priority"`
+	Key         string       `json:"key,omitempty"`
+	Remediation *Remediation `json:"remediation,omitempty"`
 }
 
-// Risk represents potential migration challenges
+// Risk represents potential migration challenges. Key and Acceptance are derived from the
+// application's risk acceptances when a report is read, so they are neither stored nor signed.
 type Risk struct {
-	Category    string `json:"category"`
-	Description string `json:"description"`
-	Severity    string `json:"severity"`
+	Category    string          `json:"category"`
+	Description string          `json:"description"`
+	Severity    string          `json:"severity"`
+	Key         string          `json:"key,omitempty"`
+	Acceptance  *RiskAcceptance `json:"acceptance,omitempty"`
 }
 
 // ModernizationStep defines a step in the adoption plan
 type ModernizationStep struct {
-	Order       int    `json:"order"`
-	Description string `json:"description"`
-	Effort      string `json:"effort"`
+	Order       int           `json:"order"`
+	Description string        `json:"description"`
+	Effort      string        `json:"effort"`
+	Estimate    *StepEstimate `json:"estimate,omitempty"`
 }
+
+// Annotation records a reviewer comment attached to a section of a report
+type Annotation struct {
+	ID        string    `json:"id"`
+	Section   string    `json:"section"` // risk, recommendation or category
+	Target    string    `json:"target"`  // item index for risks/recommendations, category name for categories
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Report sections that can be annotated
+const (
+	AnnotationSectionRisk           = "risk"
+	AnnotationSectionRecommendation = "recommendation"
+	AnnotationSectionCategory       = "category"
+)
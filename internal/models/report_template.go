@@ -0,0 +1,30 @@
+package models
+
+// Report sections that templates can include
+const (
+	ReportSectionContext         = "context"
+	ReportSectionScores          = "scores" // total, maximum, penalties, band and grade
+	ReportSectionCategoryScores  = "categoryScores"
+	ReportSectionNarrative       = "narrative"
+	ReportSectionPlugins         = "plugins" // sections added by report plugins
+	ReportSectionRecommendations = "recommendations"
+	ReportSectionRisks           = "risks"
+	ReportSectionPlan            = "modernizationPlan"
+	ReportSectionEstimate        = "estimate"
+	ReportSectionBreakdown       = "breakdown"
+	ReportSectionUnanswered      = "unanswered"
+	ReportSectionDisagreements   = "disagreements"
+	ReportSectionAppliedWeights  = "appliedWeights" // weight overrides and scoring plugins
+	ReportSectionAnnotations     = "annotations"
+	ReportSectionApproval        = "approval" // approver and ticked review checklist
+	ReportSectionSignature       = "signature"
+)
+
+// ReportTemplate selects the sections and level of detail of a report for an audience
+type ReportTemplate struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Sections    []string `json:"sections"`
+	Highlights  int      `json:"highlights,omitempty"` // if set, only this many recommendations and risks, most urgent first
+}
@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// RescoreRequest recomputes the reports of completed assessments against a catalog and scoring
+// configuration, which default to the live catalog and the current configuration
+type RescoreRequest struct {
+	AssessmentIDs []string       `json:"assessmentIds"`
+	Catalog       CatalogSource  `json:"catalog"`
+	Scoring       *ScoringConfig `json:"scoring,omitempty"`
+	Reason        string         `json:"reason,omitempty"`
+	DryRun        bool           `json:"dryRun,omitempty"` // compute the new scores without storing them
+}
+
+// ReportRescore records what a re-scored report version was computed from and links it to the
+// versions before it
+type ReportRescore struct {
+	RescoredAt time.Time        `json:"rescoredAt"`
+	Catalog    string           `json:"catalog"` // live, a built-in catalog ID or custom
+	Scoring    string           `json:"scoring"` // current or custom
+	Reason     string           `json:"reason,omitempty"`
+	Previous   ReportVersionRef `json:"previous"` // the version this one superseded
+	Original   ReportVersionRef `json:"original"` // the report issued at completion
+}
+
+// ReportVersionRef identifies and summarizes a report version
+type ReportVersionRef struct {
+	Version          int       `json:"version"`
+	GeneratedAt      time.Time `json:"generatedAt"`
+	TotalScore       int       `json:"totalScore"`
+	MaxPossibleScore int       `json:"maxPossibleScore"`
+	Grade            string    `json:"grade,omitempty"`
+}
+
+// RescoreResult lists the reports a rescore recomputed and the assessments it skipped
+type RescoreResult struct {
+	DryRun   bool             `json:"dryRun,omitempty"`
+	Rescored []RescoredReport `json:"rescored"`
+	Skipped  []RescoreSkip    `json:"skipped"`
+}
+
+// RescoredReport is the new version of a re-scored report
+type RescoredReport struct {
+	AssessmentID     string           `json:"assessmentId"`
+	ApplicationID    string           `json:"applicationId"`
+	Version          int              `json:"version"`
+	TotalScore       int              `json:"totalScore"`
+	MaxPossibleScore int              `json:"maxPossibleScore"`
+	Grade            string           `json:"grade,omitempty"`
+	Previous         ReportVersionRef `json:"previous"`
+}
+
+// RescoreSkip is an assessment a rescore left alone
+type RescoreSkip struct {
+	AssessmentID string `json:"assessmentId"`
+	Reason       string `json:"reason"`
+}
@@ -0,0 +1,28 @@
+package models
+
+// RetentionRule describes when stored entities expire and what happens to them
+type RetentionRule struct {
+	Name          string `json:"name"`
+	Entity        string `json:"entity"`           // assessment or report
+	Status        string `json:"status,omitempty"` // optional assessment status filter
+	OlderThanDays int    `json:"olderThanDays"`
+	Action        string `json:"action"` // purge or archive
+}
+
+// RetentionAction is an action taken (or, in dry-run mode, planned) by the retention engine
+type RetentionAction struct {
+	Rule     string `json:"rule"`
+	Entity   string `json:"entity"`
+	ID       string `json:"id"`
+	Action   string `json:"action"`
+	AgeDays  int    `json:"ageDays"`
+	Executed bool   `json:"executed"`
+}
+
+// Retention entities and actions
+const (
+	RetentionEntityAssessment = "assessment"
+	RetentionEntityReport     = "report"
+	RetentionActionPurge      = "purge"
+	RetentionActionArchive    = "archive"
+)
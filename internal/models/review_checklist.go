@@ -0,0 +1,29 @@
+package models
+
+import "time"
+
+// ReviewChecklist lists the items a reviewer must tick before approving an assessment
+type ReviewChecklist struct {
+	Items     []ChecklistItem `json:"items"`
+	UpdatedAt *time.Time      `json:"updatedAt,omitempty"`
+}
+
+// ChecklistItem is one check of the review checklist, e.g. "Evidence reviewed"
+type ChecklistItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text"`
+}
+
+// CheckedItem is a checklist item ticked at approval, with its text at that time
+type CheckedItem struct {
+	ID   string `json:"id"`
+	Text string `json:"text,omitempty"`
+	Note string `json:"note,omitempty"` // the reviewer's remark, e.g. who was interviewed
+}
+
+// ReportApproval records who approved the assessment of a report and the checklist they ticked
+type ReportApproval struct {
+	ApprovedBy string        `json:"approvedBy,omitempty"`
+	ApprovedAt time.Time     `json:"approvedAt"`
+	Checklist  []CheckedItem `json:"checklist,omitempty"`
+}
@@ -0,0 +1,52 @@
+package models
+
+import "time"
+
+// Risk register statuses of a risk
+const (
+	RiskStatusOpen     = "open"
+	RiskStatusAccepted = "accepted"
+	RiskStatusExpired  = "expired" // accepted, but the acceptance has run out
+)
+
+// RiskAcceptance records that a risk was knowingly accepted until an expiry date. It is stored
+// with the application and keyed by the risk, so later reports raising the same risk show it too.
+type RiskAcceptance struct {
+	Key          string    `json:"key"` // identifies the risk by category and description
+	Category     string    `json:"category"`
+	Description  string    `json:"description"`
+	AcceptedBy   string    `json:"acceptedBy"`
+	Reason       string    `json:"reason,omitempty"`
+	ExpiresOn    string    `json:"expiresOn"`    // YYYY-MM-DD; the acceptance holds through this day
+	AssessmentID string    `json:"assessmentId"` // the report the risk was accepted on
+	AcceptedAt   time.Time `json:"acceptedAt"`
+	Expired      bool      `json:"expired,omitempty"` // derived on read
+}
+
+// RiskAcceptanceRequest accepts a report risk
+type RiskAcceptanceRequest struct {
+	AcceptedBy string `json:"acceptedBy,omitempty"` // defaults to the requesting user
+	Reason     string `json:"reason,omitempty"`
+	ExpiresOn  string `json:"expiresOn"`
+}
+
+// RiskRegister lists the risks of every application's latest report
+type RiskRegister struct {
+	GeneratedAt time.Time           `json:"generatedAt"`
+	Risks       []RiskRegisterEntry `json:"risks"`
+	Counts      map[string]int      `json:"counts"` // status -> risks in the register
+}
+
+// RiskRegisterEntry is a risk of an application's latest report with its acceptance status
+type RiskRegisterEntry struct {
+	ApplicationID   string          `json:"applicationId"`
+	ApplicationName string          `json:"applicationName"`
+	AssessmentID    string          `json:"assessmentId"`
+	Index           int             `json:"index"` // position of the risk in the report
+	Key             string          `json:"key"`
+	Category        string          `json:"category"`
+	Description     string          `json:"description"`
+	Severity        string          `json:"severity"`
+	Status          string          `json:"status"`
+	Acceptance      *RiskAcceptance `json:"acceptance,omitempty"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// Scorecard is the one-page summary of an application: its details, latest score and how the
+// score developed, what its latest report left open and when it is due for reassessment
+type Scorecard struct {
+	Application                *Application         `json:"application"`
+	LatestScore                *LatestScore         `json:"latestScore"`                // nil until the first report
+	Trend                      []ScorePoint         `json:"trend"`                      // one point per report, oldest first
+	ScoreChange                *int                 `json:"scoreChange,omitempty"`      // percentage points since the previous report
+	OpenRisks                  []Risk               `json:"openRisks"`                  // not accepted, or accepted but expired
+	OutstandingRecommendations []Recommendation     `json:"outstandingRecommendations"` // open or accepted, but not done
+	Remediation                *RemediationProgress `json:"remediation,omitempty"`
+	NextAssessmentDue          *time.Time           `json:"nextAssessmentDue,omitempty"` // when the latest report goes stale; nil without a validity period
+	Overdue                    bool                 `json:"overdue,omitempty"`           // the latest report is already stale
+}
+
+// ScorePoint is the score of one of an application's reports
+type ScorePoint struct {
+	AssessmentID string    `json:"assessmentId"`
+	GeneratedAt  time.Time `json:"generatedAt"`
+	ScorePercent int       `json:"scorePercent"`
+	Band         string    `json:"band"`
+	Grade        string    `json:"grade"`
+}
@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// ScoringConfig holds the configurable thresholds used to interpret score ratios
+type ScoringConfig struct {
+	Bands          []ScoreBand        `json:"bands"`
+	Grades         []GradeThreshold   `json:"grades"`
+	CategoryShares map[string]float64 `json:"categoryShares,omitempty"` // category -> percent of the final score
+	CategoryGates  []CategoryGate     `json:"categoryGates,omitempty"`
+	Plugins        []ScoringPlugin    `json:"plugins,omitempty"` // WASM modules run in order after the built-in scoring
+	UpdatedAt      *time.Time         `json:"updatedAt,omitempty"`
+}
+
+// ScoreBand maps a score ratio range to a readiness level. Recommendations and the
+// modernization plan are chosen by level; the label is shown in reports.
+type ScoreBand struct {
+	Level    string  `json:"level"` // low, medium or high
+	Label    string  `json:"label"`
+	MinRatio float64 `json:"minRatio"`
+}
+
+// GradeThreshold is the minimum score ratio required for a letter grade
+type GradeThreshold struct {
+	Grade    string  `json:"grade"`
+	MinRatio float64 `json:"minRatio"`
+}
+
+// CategoryGate is a minimum score ratio a category must reach. Missing it caps the overall
+// grade, however high the total score is.
+type CategoryGate struct {
+	Category string  `json:"category"`
+	MinRatio float64 `json:"minRatio"`
+	MaxGrade string  `json:"maxGrade,omitempty"` // best grade while the gate is missed; the lowest grade if empty
+}
+
+// ScoringPlugin runs a WASM module of the plugins directory on every report. Config is passed
+// to the module as is, so one module can serve differently configured questionnaires.
+type ScoringPlugin struct {
+	Module string         `json:"module"` // file name without the .wasm extension
+	Config map[string]any `json:"config,omitempty"`
+}
+
+// Readiness levels of score bands
+const (
+	BandLow    = "low"
+	BandMedium = "medium"
+	BandHigh   = "high"
+)
+
+// BandFor returns the highest band whose minimum ratio the given ratio reaches
+func (c *ScoringConfig) BandFor(ratio float64) ScoreBand {
+	best := ScoreBand{Level: BandLow}
+	found := false
+	for _, band := range c.Bands {
+		if ratio >= band.MinRatio && (!found || band.MinRatio > best.MinRatio) {
+			best = band
+			found = true
+		}
+	}
+	return best
+}
+
+// LowestGrade returns the grade with the lowest minimum ratio
+func (c *ScoringConfig) LowestGrade() string {
+	lowest := GradeThreshold{Grade: "N/A", MinRatio: 2}
+	for _, grade := range c.Grades {
+		if grade.MinRatio < lowest.MinRatio {
+			lowest = grade
+		}
+	}
+	return lowest.Grade
+}
+
+// CapGrade returns grade, or limit if grade is better than limit
+func (c *ScoringConfig) CapGrade(grade, limit string) string {
+	rank := make(map[string]float64, len(c.Grades))
+	for _, threshold := range c.Grades {
+		rank[threshold.Grade] = threshold.MinRatio
+	}
+	
+	limitRatio, ok := rank[limit]
+	if !ok {
+		return grade
+	}
+	if gradeRatio, ok := rank[grade]; ok && gradeRatio <= limitRatio {
+		return grade
+	}
+	return limit
+}
+
+// GradeFor returns the highest grade whose minimum ratio the given ratio reaches
+func (c *ScoringConfig) GradeFor(ratio float64) string {
+	best := GradeThreshold{Grade: "N/A", MinRatio: -1}
+	for _, grade := range c.Grades {
+		if ratio >= grade.MinRatio && grade.MinRatio > best.MinRatio {
+			best = grade
+		}
+	}
+	return best.Grade
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// Section groups questions into a page of the questionnaire, so UIs can present a long
+// catalog as a multi-step wizard
+type Section struct {
+	ID          string     `json:"id"`
+	Title       string     `json:"title"`
+	Intro       string     `json:"intro,omitempty"` // Markdown shown above the page's questions
+	Order       int        `json:"order"`           // page order; sections with equal order sort by ID
+	QuestionIDs []string   `json:"questionIds"`     // questions of the page in display order
+	UpdatedAt   *time.Time `json:"updatedAt,omitempty"`
+}
+
+// QuestionPage is one page of the questionnaire with its questions resolved. Without defined
+// sections there is one page per category.
+type QuestionPage struct {
+	Number    int         `json:"number"`              // 1-based position of the page
+	SectionID string      `json:"sectionId,omitempty"` // empty for category pages and unsectioned questions
+	Category  string      `json:"category,omitempty"`  // set on category pages
+	Title     string      `json:"title"`
+	Intro     string      `json:"intro,omitempty"`
+	IntroHTML string      `json:"introHtml,omitempty"`
+	Questions []*Question `json:"questions"`
+	Answered  int         `json:"answered"` // questions of the page answered in the assessment, if any
+}
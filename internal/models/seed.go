@@ -0,0 +1,16 @@
+package models
+
+// SeedData is a set of categories, questions and applications loaded into storage at startup
+type SeedData struct {
+	Categories   []*Category    `json:"categories,omitempty"`
+	Questions    []*Question    `json:"questions,omitempty"`
+	Applications []*Application `json:"applications,omitempty"`
+}
+
+// SeedResult reports which seed entities were stored
+type SeedResult struct {
+	Categories       []string `json:"categories"`       // names of categories added
+	Questions        []string `json:"questions"`        // IDs of questions added
+	Applications     []string `json:"applications"`     // IDs of applications added
+	SkippedQuestions int      `json:"skippedQuestions"` // questions not added because the catalog is read-only
+}
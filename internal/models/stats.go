@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// InstanceStats summarizes the size and health of an instance for operators
+type InstanceStats struct {
+	GeneratedAt   time.Time             `json:"generatedAt"`
+	Counts        map[string]int        `json:"counts"`                     // entity -> stored records
+	Assessments   map[string]int        `json:"assessments"`                // status -> assessments
+	StorageBytes  *int64                `json:"storageBytes,omitempty"`     // omitted if the storage backend cannot tell
+	OldestOpen    *OldestOpenAssessment `json:"oldestInProgress,omitempty"` // nil without assessments in progress
+	Reports       ReportGenerationStats `json:"reports"`
+	Notifications NotificationStats     `json:"notifications"`
+}
+
+// OldestOpenAssessment is the assessment that has been in progress the longest
+type OldestOpenAssessment struct {
+	AssessmentID  string    `json:"assessmentId"`
+	ApplicationID string    `json:"applicationId"`
+	StartedAt     time.Time `json:"startedAt"`
+	AgeDays       float64   `json:"ageDays"`
+}
+
+// ReportGenerationStats counts the stored reports by when they were generated
+type ReportGenerationStats struct {
+	Total      int `json:"total"`
+	Last24h    int `json:"last24h"`
+	Last7Days  int `json:"last7Days"`
+	Last30Days int `json:"last30Days"`
+}
+
+// NotificationStats counts outbox messages, which carry webhook, Slack and email notifications.
+// Failing messages are pending after at least one failed attempt; dead ones were given up on.
+type NotificationStats struct {
+	Pending   int            `json:"pending"`
+	Failing   int            `json:"failing"`
+	Dead      int            `json:"dead"`
+	Delivered int            `json:"delivered"`
+	Failures  map[string]int `json:"failuresByTarget"` // target -> failing and dead messages
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// Signal is a fact about an application detected by an automated source, such as a
+// Dockerfile found by repository analysis
+type Signal struct {
+	Name     string   `json:"name"`
+	Evidence []string `json:"evidence,omitempty"` // e.g. file paths the signal was derived from
+}
+
+// PrefillRule proposes an answer when a signal is detected
+type PrefillRule struct {
+	Signal     string  `json:"signal"`
+	QuestionID string  `json:"questionId"`
+	OptionID   string  `json:"optionId"`
+	Confidence float64 `json:"confidence"` // 0..1; the most confident rule per question wins
+}
+
+// AnswerSuggestion is a proposed answer awaiting confirmation by an assessor
+type AnswerSuggestion struct {
+	QuestionID  string    `json:"questionId"`
+	OptionID    string    `json:"optionId"`
+	Source      string    `json:"source"` // e.g. "static-analysis"
+	Signal      string    `json:"signal"`
+	Confidence  float64   `json:"confidence"`
+	Evidence    []string  `json:"evidence,omitempty"`
+	SuggestedAt time.Time `json:"suggestedAt"`
+}
+
+// PrefillResult describes the signals detected for an assessment and the suggestions made from them
+type PrefillResult struct {
+	AssessmentID string             `json:"assessmentId"`
+	Source       string             `json:"source"`
+	Signals      []Signal           `json:"signals"`
+	Suggestions  []AnswerSuggestion `json:"suggestions"`
+}
+
+// AnswerEvidence documents how an answer of an assessment came about, for auditors: who gave
+// it and when, how it was produced and, for confirmed suggestions, what the suggestion was
+// based on
+type AnswerEvidence struct {
+	QuestionID  string            `json:"questionId"`
+	OptionID    string            `json:"optionId"`
+	Explanation string            `json:"explanation,omitempty"`
+	References  []string          `json:"references,omitempty"` // links to evidence given with the answer
+	AnsweredBy  string            `json:"answeredBy,omitempty"`
+	AnsweredAt  *time.Time        `json:"answeredAt,omitempty"`
+	Provenance  *AnswerProvenance `json:"provenance,omitempty"`
+	Evidence    []string          `json:"evidence,omitempty"` // of the confirmed suggestion, e.g. file paths
+}
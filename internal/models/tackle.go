@@ -0,0 +1,97 @@
+package models
+
+// The Tackle* types mirror the JSON documents of the Konveyor Tackle hub API (v0.3+), used to
+// move questionnaires, applications and assessments between Tackle and this application
+
+// TackleBundle holds Tackle documents for import or export
+type TackleBundle struct {
+	Questionnaires []TackleQuestionnaire `json:"questionnaires,omitempty"`
+	Applications   []TackleApplication   `json:"applications,omitempty"`
+	Assessments    []TackleAssessment    `json:"assessments,omitempty"`
+}
+
+// TackleRef references another Tackle resource
+type TackleRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name,omitempty"`
+}
+
+// TackleQuestionnaire is a Tackle questionnaire
+type TackleQuestionnaire struct {
+	ID           uint              `json:"id,omitempty"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description,omitempty"`
+	Required     bool              `json:"required"`
+	Sections     []TackleSection   `json:"sections"`
+	Thresholds   map[string]int    `json:"thresholds,omitempty"`
+	RiskMessages map[string]string `json:"riskMessages,omitempty"`
+}
+
+// TackleSection is a section of a Tackle questionnaire or assessment
+type TackleSection struct {
+	Order     uint             `json:"order"`
+	Name      string           `json:"name"`
+	Questions []TackleQuestion `json:"questions"`
+}
+
+// TackleQuestion is a question of a Tackle questionnaire or assessment
+type TackleQuestion struct {
+	Order       uint           `json:"order"`
+	Text        string         `json:"text"`
+	Explanation string         `json:"explanation,omitempty"`
+	Answers     []TackleAnswer `json:"answers"`
+}
+
+// TackleAnswer is an answer of a Tackle question; Selected marks the chosen answer in assessments
+type TackleAnswer struct {
+	Order     uint   `json:"order"`
+	Text      string `json:"text"`
+	Risk      string `json:"risk"` // green, yellow, red or unknown
+	Rationale string `json:"rationale,omitempty"`
+	Selected  bool   `json:"selected,omitempty"`
+}
+
+// TackleApplication is a Tackle application
+type TackleApplication struct {
+	ID          uint              `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Repository  *TackleRepository `json:"repository,omitempty"`
+	Tags        []TackleTag       `json:"tags,omitempty"`
+}
+
+// TackleRepository is the source repository of a Tackle application
+type TackleRepository struct {
+	Kind   string `json:"kind,omitempty"`
+	URL    string `json:"url"`
+	Branch string `json:"branch,omitempty"`
+}
+
+// TackleTag is a tag on a Tackle application
+type TackleTag struct {
+	ID     uint   `json:"id,omitempty"`
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+}
+
+// TackleAssessment is a Tackle application assessment with the selected answers
+type TackleAssessment struct {
+	ID            uint            `json:"id,omitempty"`
+	Application   *TackleRef      `json:"application,omitempty"`
+	Questionnaire TackleRef       `json:"questionnaire"`
+	Sections      []TackleSection `json:"sections"`
+	Status        string          `json:"status"` // empty, started or complete
+}
+
+// TackleImportResult summarizes a Tackle import
+type TackleImportResult struct {
+	DryRun       bool     `json:"dryRun"`
+	Questions    int      `json:"questions"`
+	Applications int      `json:"applications"`
+	Assessments  int      `json:"assessments"`
+	Warnings     []string `json:"warnings"`
+	
+	// Duplicates lists new applications that likely duplicate existing ones. Unless forced,
+	// an import with duplicates is rejected before anything is saved.
+	Duplicates []ApplicationDuplicate `json:"duplicates,omitempty"`
+}
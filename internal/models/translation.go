@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// SourceLocale is the language questions and report text are authored in
+const SourceLocale = "en"
+
+// QuestionTranslation holds a question's text in another language. Empty fields fall back to
+// the source text.
+type QuestionTranslation struct {
+	Text    string            `json:"text,omitempty"`
+	Help    string            `json:"help,omitempty"`
+	Options map[string]string `json:"options,omitempty"` // option ID to option text
+}
+
+// MessageCatalog translates text that is not part of a question into a locale, such as
+// recommendations, risks and plan steps of reports and category and section texts. Messages
+// are keyed by their source text.
+type MessageCatalog struct {
+	Locale    string            `json:"locale"`
+	Messages  map[string]string `json:"messages"`
+	UpdatedAt *time.Time        `json:"updatedAt,omitempty"`
+}
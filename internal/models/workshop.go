@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Workshop statuses
+const (
+	WorkshopOpen   = "open"
+	WorkshopClosed = "closed"
+)
+
+// Workshop runs an assessment as a facilitated session. The facilitator moves through the
+// assessment's questions one at a time, participants vote for an option from their own
+// devices and the facilitator records the consensus as the answer.
+type Workshop struct {
+	AssessmentID string                       `json:"assessmentId"`
+	Facilitator  string                       `json:"facilitator"`
+	Status       string                       `json:"status"`
+	QuestionIDs  []string                     `json:"questionIds"` // in the order they are discussed
+	Current      int                          `json:"current"`     // index of the question being voted on
+	Votes        map[string]map[string]string `json:"votes"`       // question ID -> participant -> option ID
+	Decisions    map[string]WorkshopDecision  `json:"decisions"`   // question ID -> recorded consensus
+	StartedAt    time.Time                    `json:"startedAt"`
+	ClosedAt     *time.Time                   `json:"closedAt,omitempty"`
+}
+
+// WorkshopDecision is the consensus answer recorded for a question with its vote distribution
+type WorkshopDecision struct {
+	OptionID     string         `json:"optionId"`
+	Votes        map[string]int `json:"votes"` // option ID -> votes
+	Participants int            `json:"participants"`
+	Agreement    int            `json:"agreement"` // percent of votes for the decided option
+	DecidedBy    string         `json:"decidedBy"`
+	DecidedAt    time.Time      `json:"decidedAt"`
+}
+
+// WorkshopView is the state of a workshop as shown to the facilitator and participants: the
+// current question with its vote counts, but not who voted for what
+type WorkshopView struct {
+	AssessmentID string                      `json:"assessmentId"`
+	Facilitator  string                      `json:"facilitator"`
+	Status       string                      `json:"status"`
+	Position     int                         `json:"position"` // of the current question, from 1
+	Questions    int                         `json:"questions"`
+	Decided      int                         `json:"decided"`
+	Question     *Question                   `json:"question,omitempty"`
+	Votes        map[string]int              `json:"votes"` // option ID -> votes on the current question
+	Participants int                         `json:"participants"`
+	MyVote       string                      `json:"myVote,omitempty"` // the requesting user's vote
+	Decision     *WorkshopDecision           `json:"decision,omitempty"`
+	Decisions    map[string]WorkshopDecision `json:"decisions"`
+	StartedAt    time.Time                   `json:"startedAt"`
+	ClosedAt     *time.Time                  `json:"closedAt,omitempty"`
+}
+
+// WorkshopVote is a participant's vote on the current question
+type WorkshopVote struct {
+	QuestionID string `json:"questionId,omitempty"` // rejected if it is not the current question
+	OptionID   string `json:"optionId"`
+}
+
+// WorkshopDecisionRequest records the consensus on the current question
+type WorkshopDecisionRequest struct {
+	OptionID    string `json:"optionId,omitempty"` // defaults to the option with the most votes
+	Explanation string `json:"explanation,omitempty"`
+}
+
+// WorkshopMove moves a workshop to another question
+type WorkshopMove struct {
+	QuestionID string `json:"questionId,omitempty"` // the question to discuss
+	Direction  string `json:"direction,omitempty"`  // next or previous, if no question is given
+}
@@ -0,0 +1,176 @@
+package questionnairetest
+
+import (
+	"questionnaire-app/internal/models"
+	"time"
+)
+
+// QuestionBuilder builds a question with sensible defaults: category "General" and weight 1
+type QuestionBuilder struct {
+	question models.Question
+}
+
+// NewQuestion starts building a question with the given ID
+func NewQuestion(id string) *QuestionBuilder {
+	return &QuestionBuilder{question: models.Question{
+		ID:       id,
+		Text:     "Question " + id,
+		Category: "General",
+		Weight:   1,
+	}}
+}
+
+// Text sets the question text
+func (b *QuestionBuilder) Text(text string) *QuestionBuilder {
+	b.question.Text = text
+	return b
+}
+
+// Category sets the question category
+func (b *QuestionBuilder) Category(category string) *QuestionBuilder {
+	b.question.Category = category
+	return b
+}
+
+// Weight sets the question weight
+func (b *QuestionBuilder) Weight(weight int) *QuestionBuilder {
+	b.question.Weight = weight
+	return b
+}
+
+// Help sets the Markdown help text
+func (b *QuestionBuilder) Help(help string) *QuestionBuilder {
+	b.question.Help = help
+	return b
+}
+
+// Option appends an answer option
+func (b *QuestionBuilder) Option(id, text string, points int) *QuestionBuilder {
+	b.question.Options = append(b.question.Options, models.Option{ID: id, Text: text, Points: points})
+	return b
+}
+
+// OtherOption appends an answer option that must be explained in free text when chosen
+func (b *QuestionBuilder) OtherOption(id, text string, points int) *QuestionBuilder {
+	b.question.Options = append(b.question.Options, models.Option{ID: id, Text: text, Points: points, RequiresExplanation: true})
+	return b
+}
+
+// VisibleWhen shows the question only if another question was answered with one of the options
+func (b *QuestionBuilder) VisibleWhen(questionID string, optionIDs ...string) *QuestionBuilder {
+	b.question.VisibleWhen = append(b.question.VisibleWhen, models.Condition{
+		QuestionID: questionID,
+		OptionIDs:  optionIDs,
+	})
+	return b
+}
+
+// Build returns the question; each call returns a new copy
+func (b *QuestionBuilder) Build() *models.Question {
+	return clone(&b.question)
+}
+
+// ApplicationBuilder builds an application named after its ID
+type ApplicationBuilder struct {
+	app models.Application
+}
+
+// NewApplication starts building an application with the given ID
+func NewApplication(id string) *ApplicationBuilder {
+	return &ApplicationBuilder{app: models.Application{
+		ID:   id,
+		Name: id,
+		Tags: map[string]string{},
+	}}
+}
+
+// Name sets the application name
+func (b *ApplicationBuilder) Name(name string) *ApplicationBuilder {
+	b.app.Name = name
+	return b
+}
+
+// Description sets the application description
+func (b *ApplicationBuilder) Description(description string) *ApplicationBuilder {
+	b.app.Description = description
+	return b
+}
+
+// Tag sets a tag
+func (b *ApplicationBuilder) Tag(key, value string) *ApplicationBuilder {
+	b.app.Tags[key] = value
+	return b
+}
+
+// Repository sets the Git URL used to pre-fill answers
+func (b *ApplicationBuilder) Repository(url string) *ApplicationBuilder {
+	b.app.Repository = url
+	return b
+}
+
+// DependsOn adds applications that must migrate first
+func (b *ApplicationBuilder) DependsOn(ids ...string) *ApplicationBuilder {
+	b.app.DependsOn = append(b.app.DependsOn, ids...)
+	return b
+}
+
+// Build returns the application; each call returns a new copy
+func (b *ApplicationBuilder) Build() *models.Application {
+	return clone(&b.app)
+}
+
+// AssessmentBuilder builds an in-progress assessment created now
+type AssessmentBuilder struct {
+	assessment models.Assessment
+}
+
+// NewAssessment starts building an assessment of an application
+func NewAssessment(id, applicationID string) *AssessmentBuilder {
+	return (&AssessmentBuilder{assessment: models.Assessment{
+		ID:            id,
+		ApplicationID: applicationID,
+		Answers:       map[string]string{},
+		Status:        "in_progress",
+	}}).CreatedAt(time.Now())
+}
+
+// Answer records an answer without an event; use the assessment service to record answers
+// through the event log
+func (b *AssessmentBuilder) Answer(questionID, optionID string) *AssessmentBuilder {
+	b.assessment.Answers[questionID] = optionID
+	return b
+}
+
+// Explain records the free-text explanation of an answer without an event
+func (b *AssessmentBuilder) Explain(questionID, explanation string) *AssessmentBuilder {
+	if b.assessment.Explanations == nil {
+		b.assessment.Explanations = map[string]string{}
+	}
+	b.assessment.Explanations[questionID] = explanation
+	return b
+}
+
+// Status sets the assessment status
+func (b *AssessmentBuilder) Status(status string) *AssessmentBuilder {
+	b.assessment.Status = status
+	return b
+}
+
+// StartedBy sets the user who started the assessment
+func (b *AssessmentBuilder) StartedBy(user string) *AssessmentBuilder {
+	b.assessment.StartedBy = user
+	return b
+}
+
+// CreatedAt sets the creation time
+func (b *AssessmentBuilder) CreatedAt(at time.Time) *AssessmentBuilder {
+	b.assessment.CreatedAt = at
+	b.assessment.UpdatedAt = at
+	b.assessment.StartedAt = at
+	return b
+}
+
+// Build returns the assessment; each call returns a new copy
+func (b *AssessmentBuilder) Build() *models.Assessment {
+	return clone(&b.assessment)
+}
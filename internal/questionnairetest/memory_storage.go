@@ -0,0 +1,832 @@
+package questionnairetest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"sync"
+	"testing"
+)
+
+// MemoryStorage is an in-memory storage.Storage that passes the storagetest conformance
+// suite. Entities are copied on the way in and out, so callers can never change stored state
+// through a pointer they kept, just as with the file storage.
+type MemoryStorage struct {
+	mu          sync.RWMutex
+	apps        map[string]*models.Application
+	archetypes  map[string]*models.Archetype
+	questions   map[string]*models.Question
+	categories  map[string]*models.Category
+	sections    map[string]*models.Section
+	messages    map[string]*models.MessageCatalog
+	assessments map[string]*models.Assessment
+	events      map[string][]*models.AssessmentEvent
+	reports     map[string]*models.Report
+	archived    map[string]*models.Report
+	versions    map[string]map[int]*models.Report // assessment -> version -> superseded report
+	outbox      map[string]*models.OutboxMessage
+	jobs        map[string]*models.Job
+	campaigns   map[string]*models.Campaign
+	releases    map[string]*models.CatalogRelease
+	scoring     *models.ScoringConfig
+	estimation  *models.EstimationConfig
+	branding    *models.Branding
+	checklist   *models.ReviewChecklist
+	summary     *models.PortfolioSummarySnapshot
+	digests     map[string]*models.DigestSubscription
+	digestState *models.DigestState
+	workshops   map[string]*models.Workshop
+}
+
+var _ storage.Storage = (*MemoryStorage)(nil)
+
+// NewMemoryStorage creates an empty in-memory storage
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		apps:        make(map[string]*models.Application),
+		archetypes:  make(map[string]*models.Archetype),
+		questions:   make(map[string]*models.Question),
+		categories:  make(map[string]*models.Category),
+		sections:    make(map[string]*models.Section),
+		messages:    make(map[string]*models.MessageCatalog),
+		assessments: make(map[string]*models.Assessment),
+		events:      make(map[string][]*models.AssessmentEvent),
+		reports:     make(map[string]*models.Report),
+		archived:    make(map[string]*models.Report),
+		versions:    make(map[string]map[int]*models.Report),
+		outbox:      make(map[string]*models.OutboxMessage),
+		jobs:        make(map[string]*models.Job),
+		campaigns:   make(map[string]*models.Campaign),
+		releases:    make(map[string]*models.CatalogRelease),
+		digests:     make(map[string]*models.DigestSubscription),
+		workshops:   make(map[string]*models.Workshop),
+	}
+}
+
+// Seed stores applications, questions, assessments, reports and campaigns, failing the test
+// on entities of any other type
+func (s *MemoryStorage) Seed(t testing.TB, entities ...interface{}) {
+	t.Helper()
+	ctx := context.Background()
+	
+	for _, entity := range entities {
+		var err error
+		switch e := entity.(type) {
+		case *models.Application:
+			err = s.SaveApplication(ctx, e)
+		case *models.Question:
+			err = s.SaveQuestion(ctx, e)
+		case *models.Assessment:
+			err = s.CreateAssessment(ctx, e)
+		case *models.Report:
+			err = s.SaveReport(ctx, e)
+		case *models.Campaign:
+			err = s.SaveCampaign(ctx, e)
+		default:
+			err = fmt.Errorf("cannot seed %T", entity)
+		}
+		if err != nil {
+			t.Fatalf("failed to seed storage: %v", err)
+		}
+	}
+}
+
+// clone deep-copies an entity through its JSON encoding, which is what the file storage keeps
+func clone[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("questionnairetest: cannot copy %T: %v", value, err))
+	}
+	var copied T
+	if err := json.Unmarshal(data, &copied); err != nil {
+		panic(fmt.Sprintf("questionnairetest: cannot copy %T: %v", value, err))
+	}
+	return &copied
+}
+
+// cloneAll copies the values of a map, ordered by key like the file storage's directory listings
+func cloneAll[T any](values map[string]*T) []*T {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	copies := make([]*T, 0, len(keys))
+	for _, key := range keys {
+		copies = append(copies, clone(values[key]))
+	}
+	return copies
+}
+
+// GetApplication retrieves an application by ID
+func (s *MemoryStorage) GetApplication(ctx context.Context, id string) (*models.Application, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.apps[id]), nil
+}
+
+// ListApplications returns all applications
+func (s *MemoryStorage) ListApplications(ctx context.Context) ([]*models.Application, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.apps), nil
+}
+
+// SaveApplication stores an application
+func (s *MemoryStorage) SaveApplication(ctx context.Context, app *models.Application) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apps[app.ID] = clone(app)
+	return nil
+}
+
+// DeleteApplication removes an application
+func (s *MemoryStorage) DeleteApplication(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.apps, id)
+	return nil
+}
+
+// GetQuestions returns all questions
+func (s *MemoryStorage) GetQuestions(ctx context.Context) ([]*models.Question, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.questions), nil
+}
+
+// GetQuestion retrieves a question by ID
+func (s *MemoryStorage) GetQuestion(ctx context.Context, id string) (*models.Question, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.questions[id]), nil
+}
+
+// SaveQuestion creates or replaces a question
+func (s *MemoryStorage) SaveQuestion(ctx context.Context, question *models.Question) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.questions[question.ID] = clone(question)
+	return nil
+}
+
+// CreateAssessment stores a new assessment
+func (s *MemoryStorage) CreateAssessment(ctx context.Context, assessment *models.Assessment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.assessments[assessment.ID] = clone(assessment)
+	return nil
+}
+
+// GetAssessment retrieves an assessment by ID
+func (s *MemoryStorage) GetAssessment(ctx context.Context, id string) (*models.Assessment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.assessments[id]), nil
+}
+
+// UpdateAssessment replaces an existing assessment
+func (s *MemoryStorage) UpdateAssessment(ctx context.Context, assessment *models.Assessment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.assessments[assessment.ID]; !ok {
+		return fmt.Errorf("assessment %s not found", assessment.ID)
+	}
+	s.assessments[assessment.ID] = clone(assessment)
+	return nil
+}
+
+// ListAssessments returns the assessments of an application, or all if applicationID is empty
+func (s *MemoryStorage) ListAssessments(ctx context.Context, applicationID string) ([]*models.Assessment, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	var assessments []*models.Assessment
+	for _, assessment := range cloneAll(s.assessments) {
+		if applicationID == "" || assessment.ApplicationID == applicationID {
+			assessments = append(assessments, assessment)
+		}
+	}
+	return assessments, nil
+}
+
+// DeleteAssessment removes an assessment
+func (s *MemoryStorage) DeleteAssessment(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.assessments, id)
+	return nil
+}
+
+// AppendEvent appends an event to the assessment's event log
+func (s *MemoryStorage) AppendEvent(ctx context.Context, event *models.AssessmentEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[event.AssessmentID] = append(s.events[event.AssessmentID], clone(event))
+	return nil
+}
+
+// ListEvents returns the events of an assessment in the order they were appended
+func (s *MemoryStorage) ListEvents(ctx context.Context, assessmentID string) ([]*models.AssessmentEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	var events []*models.AssessmentEvent
+	for _, event := range s.events[assessmentID] {
+		events = append(events, clone(event))
+	}
+	return events, nil
+}
+
+// ReplaceEvents rewrites an assessment's event log
+func (s *MemoryStorage) ReplaceEvents(ctx context.Context, assessmentID string, events []*models.AssessmentEvent) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	replaced := make([]*models.AssessmentEvent, 0, len(events))
+	for _, event := range events {
+		replaced = append(replaced, clone(event))
+	}
+	s.events[assessmentID] = replaced
+	return nil
+}
+
+// DeleteEvents removes an assessment's event log
+func (s *MemoryStorage) DeleteEvents(ctx context.Context, assessmentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.events, assessmentID)
+	return nil
+}
+
+// SaveReport stores a report
+func (s *MemoryStorage) SaveReport(ctx context.Context, report *models.Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.reports[report.AssessmentID] = clone(report)
+	return nil
+}
+
+// GetReport retrieves the report of an assessment
+func (s *MemoryStorage) GetReport(ctx context.Context, assessmentID string) (*models.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.reports[assessmentID]), nil
+}
+
+// DeleteReport removes a report and its superseded versions
+func (s *MemoryStorage) DeleteReport(ctx context.Context, assessmentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.reports, assessmentID)
+	delete(s.versions, assessmentID)
+	return nil
+}
+
+// ListReports returns all reports that are not archived
+func (s *MemoryStorage) ListReports(ctx context.Context) ([]*models.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.reports), nil
+}
+
+// ArchiveReport moves a report out of the active reports
+func (s *MemoryStorage) ArchiveReport(ctx context.Context, assessmentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	report, ok := s.reports[assessmentID]
+	if !ok {
+		return fmt.Errorf("report %s not found", assessmentID)
+	}
+	s.archived[assessmentID] = report
+	delete(s.reports, assessmentID)
+	return nil
+}
+
+// SaveReportVersion keeps a superseded version of a report
+func (s *MemoryStorage) SaveReportVersion(ctx context.Context, report *models.Report) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.versions[report.AssessmentID] == nil {
+		s.versions[report.AssessmentID] = make(map[int]*models.Report)
+	}
+	s.versions[report.AssessmentID][report.Version] = clone(report)
+	return nil
+}
+
+// ListReportVersions returns the superseded versions of an assessment's report, oldest first
+func (s *MemoryStorage) ListReportVersions(ctx context.Context, assessmentID string) ([]*models.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	versions := []*models.Report{}
+	for _, report := range s.versions[assessmentID] {
+		versions = append(versions, clone(report))
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+// Archived returns an archived report, or nil; the file storage keeps these in reports/archive
+func (s *MemoryStorage) Archived(assessmentID string) *models.Report {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.archived[assessmentID])
+}
+
+// SaveOutboxMessage creates or updates an outbox message
+func (s *MemoryStorage) SaveOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.outbox[message.ID] = clone(message)
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by ID
+func (s *MemoryStorage) GetOutboxMessage(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.outbox[id]), nil
+}
+
+// ListOutboxMessages returns the outbox messages with a status, or all if status is empty
+func (s *MemoryStorage) ListOutboxMessages(ctx context.Context, status string) ([]*models.OutboxMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	var messages []*models.OutboxMessage
+	for _, message := range cloneAll(s.outbox) {
+		if status == "" || message.Status == status {
+			messages = append(messages, message)
+		}
+	}
+	return messages, nil
+}
+
+// DeleteOutboxMessage removes an outbox message
+func (s *MemoryStorage) DeleteOutboxMessage(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.outbox, id)
+	return nil
+}
+
+// SaveJob creates or updates a job
+func (s *MemoryStorage) SaveJob(ctx context.Context, job *models.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs[job.ID] = clone(job)
+	return nil
+}
+
+// GetJob retrieves a job by ID
+func (s *MemoryStorage) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.jobs[id]), nil
+}
+
+// ListJobs returns all jobs
+func (s *MemoryStorage) ListJobs(ctx context.Context) ([]*models.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.jobs), nil
+}
+
+// SaveCampaign saves a campaign
+func (s *MemoryStorage) SaveCampaign(ctx context.Context, campaign *models.Campaign) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.campaigns[campaign.ID] = clone(campaign)
+	return nil
+}
+
+// GetCampaign retrieves a campaign by ID
+func (s *MemoryStorage) GetCampaign(ctx context.Context, id string) (*models.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.campaigns[id]), nil
+}
+
+// ListCampaigns returns all campaigns
+func (s *MemoryStorage) ListCampaigns(ctx context.Context) ([]*models.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.campaigns), nil
+}
+
+// SaveCatalogRelease saves a catalog release
+func (s *MemoryStorage) SaveCatalogRelease(ctx context.Context, release *models.CatalogRelease) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.releases[release.Version] = clone(release)
+	return nil
+}
+
+// GetCatalogRelease retrieves a catalog release by version
+func (s *MemoryStorage) GetCatalogRelease(ctx context.Context, version string) (*models.CatalogRelease, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.releases[version]), nil
+}
+
+// ListCatalogReleases returns all catalog releases
+func (s *MemoryStorage) ListCatalogReleases(ctx context.Context) ([]*models.CatalogRelease, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.releases), nil
+}
+
+// GetScoringConfig retrieves the stored scoring configuration, or nil if none was saved
+func (s *MemoryStorage) GetScoringConfig(ctx context.Context) (*models.ScoringConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.scoring), nil
+}
+
+// SaveScoringConfig stores the scoring configuration
+func (s *MemoryStorage) SaveScoringConfig(ctx context.Context, config *models.ScoringConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.scoring = clone(config)
+	return nil
+}
+
+// GetEstimationConfig retrieves the stored estimation model, or nil if none was saved
+func (s *MemoryStorage) GetEstimationConfig(ctx context.Context) (*models.EstimationConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.estimation), nil
+}
+
+// SaveEstimationConfig stores the estimation model
+func (s *MemoryStorage) SaveEstimationConfig(ctx context.Context, config *models.EstimationConfig) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.estimation = clone(config)
+	return nil
+}
+
+// GetBranding retrieves the stored report branding, or nil if none was saved
+func (s *MemoryStorage) GetBranding(ctx context.Context) (*models.Branding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.branding), nil
+}
+
+// SaveBranding stores the report branding
+func (s *MemoryStorage) SaveBranding(ctx context.Context, branding *models.Branding) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.branding = clone(branding)
+	return nil
+}
+
+// GetReviewChecklist retrieves the stored review checklist, or nil if none was saved
+func (s *MemoryStorage) GetReviewChecklist(ctx context.Context) (*models.ReviewChecklist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.checklist), nil
+}
+
+// SaveReviewChecklist stores the review checklist
+func (s *MemoryStorage) SaveReviewChecklist(ctx context.Context, checklist *models.ReviewChecklist) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checklist = clone(checklist)
+	return nil
+}
+
+// GetPortfolioSummary retrieves the materialized portfolio summary, or nil
+func (s *MemoryStorage) GetPortfolioSummary(ctx context.Context) (*models.PortfolioSummarySnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.summary), nil
+}
+
+// SavePortfolioSummary stores the materialized portfolio summary
+func (s *MemoryStorage) SavePortfolioSummary(ctx context.Context, snapshot *models.PortfolioSummarySnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary = clone(snapshot)
+	return nil
+}
+
+// DeletePortfolioSummary invalidates the materialized portfolio summary
+func (s *MemoryStorage) DeletePortfolioSummary(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.summary = nil
+	return nil
+}
+
+// GetDigestSubscription retrieves a user's digest subscription
+func (s *MemoryStorage) GetDigestSubscription(ctx context.Context, user string) (*models.DigestSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.digests[user]), nil
+}
+
+// ListDigestSubscriptions returns all digest subscriptions
+func (s *MemoryStorage) ListDigestSubscriptions(ctx context.Context) ([]*models.DigestSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.digests), nil
+}
+
+// SaveDigestSubscription creates or replaces a user's digest subscription
+func (s *MemoryStorage) SaveDigestSubscription(ctx context.Context, subscription *models.DigestSubscription) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digests[subscription.User] = clone(subscription)
+	return nil
+}
+
+// DeleteDigestSubscription removes a user's digest subscription
+func (s *MemoryStorage) DeleteDigestSubscription(ctx context.Context, user string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.digests, user)
+	return nil
+}
+
+// GetDigestState retrieves the digest schedule state, or nil
+func (s *MemoryStorage) GetDigestState(ctx context.Context) (*models.DigestState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.digestState), nil
+}
+
+// SaveDigestState stores the digest schedule state
+func (s *MemoryStorage) SaveDigestState(ctx context.Context, state *models.DigestState) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.digestState = clone(state)
+	return nil
+}
+
+// GetCategory retrieves a category by name
+func (s *MemoryStorage) GetCategory(ctx context.Context, name string) (*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.categories[name]), nil
+}
+
+// ListCategories returns all categories
+func (s *MemoryStorage) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.categories), nil
+}
+
+// SaveCategory creates or replaces a category
+func (s *MemoryStorage) SaveCategory(ctx context.Context, category *models.Category) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.categories[category.Name] = clone(category)
+	return nil
+}
+
+// DeleteCategory removes a category
+func (s *MemoryStorage) DeleteCategory(ctx context.Context, name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.categories, name)
+	return nil
+}
+
+// GetSection retrieves a section by ID
+func (s *MemoryStorage) GetSection(ctx context.Context, id string) (*models.Section, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.sections[id]), nil
+}
+
+// ListSections returns all sections
+func (s *MemoryStorage) ListSections(ctx context.Context) ([]*models.Section, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.sections), nil
+}
+
+// SaveSection creates or replaces a section
+func (s *MemoryStorage) SaveSection(ctx context.Context, section *models.Section) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sections[section.ID] = clone(section)
+	return nil
+}
+
+// DeleteSection removes a section
+func (s *MemoryStorage) DeleteSection(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.sections, id)
+	return nil
+}
+
+// GetArchetype retrieves an archetype by ID
+func (s *MemoryStorage) GetArchetype(ctx context.Context, id string) (*models.Archetype, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.archetypes[id]), nil
+}
+
+// ListArchetypes returns all archetypes
+func (s *MemoryStorage) ListArchetypes(ctx context.Context) ([]*models.Archetype, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.archetypes), nil
+}
+
+// SaveArchetype creates or replaces an archetype
+func (s *MemoryStorage) SaveArchetype(ctx context.Context, archetype *models.Archetype) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.archetypes[archetype.ID] = clone(archetype)
+	return nil
+}
+
+// DeleteArchetype removes an archetype
+func (s *MemoryStorage) DeleteArchetype(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.archetypes, id)
+	return nil
+}
+
+// GetMessageCatalog retrieves the message catalog of a locale
+func (s *MemoryStorage) GetMessageCatalog(ctx context.Context, locale string) (*models.MessageCatalog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.messages[locale]), nil
+}
+
+// ListMessageCatalogs returns the message catalogs of all locales
+func (s *MemoryStorage) ListMessageCatalogs(ctx context.Context) ([]*models.MessageCatalog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.messages), nil
+}
+
+// SaveMessageCatalog creates or replaces the message catalog of a locale
+func (s *MemoryStorage) SaveMessageCatalog(ctx context.Context, catalog *models.MessageCatalog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages[catalog.Locale] = clone(catalog)
+	return nil
+}
+
+// DeleteMessageCatalog removes the message catalog of a locale
+func (s *MemoryStorage) DeleteMessageCatalog(ctx context.Context, locale string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.messages, locale)
+	return nil
+}
+
+// GetWorkshop retrieves the workshop of an assessment
+func (s *MemoryStorage) GetWorkshop(ctx context.Context, assessmentID string) (*models.Workshop, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return clone(s.workshops[assessmentID]), nil
+}
+
+// ListWorkshops returns all workshops
+func (s *MemoryStorage) ListWorkshops(ctx context.Context) ([]*models.Workshop, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return cloneAll(s.workshops), nil
+}
+
+// SaveWorkshop creates or replaces the workshop of an assessment
+func (s *MemoryStorage) SaveWorkshop(ctx context.Context, workshop *models.Workshop) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workshops[workshop.AssessmentID] = clone(workshop)
+	return nil
+}
+
+// DeleteWorkshop removes the workshop of an assessment
+func (s *MemoryStorage) DeleteWorkshop(ctx context.Context, assessmentID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.workshops, assessmentID)
+	return nil
+}
@@ -0,0 +1,15 @@
+package questionnairetest_test
+
+import (
+	"testing"
+	
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/storage"
+	"questionnaire-app/internal/storage/storagetest"
+)
+
+func TestMemoryStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		return questionnairetest.NewMemoryStorage()
+	})
+}
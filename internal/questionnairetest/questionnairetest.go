@@ -0,0 +1,117 @@
+// Package questionnairetest provides in-memory fakes and model builders for testing code that
+// embeds the questionnaire services, without a data directory or HTTP server:
+//
+//	store := questionnairetest.NewMemoryStorage()
+//	store.Seed(t,
+//		questionnairetest.NewApplication("app1").Build(),
+//		questionnairetest.NewQuestion("q1").Option("q1_yes", "Yes", 10).Option("q1_no", "No", 0).Build(),
+//	)
+//	notifier := &questionnairetest.RecordingNotifier{}
+//	assessments := questionnairetest.NewAssessmentService(store, services.WithNotifier(notifier))
+//
+// The assessment service returned is the real implementation, so tests observe the same
+// scoring, event sourcing and validation as the server.
+package questionnairetest
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+	"sync"
+)
+
+// NewAssessmentService creates an assessment service on the given storage with an in-process locker
+func NewAssessmentService(store *MemoryStorage, opts ...services.AssessmentOption) *services.AssessmentService {
+	return services.NewAssessmentService(store, NewMemoryLocker(), opts...)
+}
+
+// Notification is a notification captured by a RecordingNotifier
+type Notification struct {
+	Event   string
+	Payload map[string]interface{}
+}
+
+// RecordingNotifier is a services.Notifier that records notifications instead of sending them.
+// Err, if set, is returned from every Notify call.
+type RecordingNotifier struct {
+	Err error
+	
+	mu            sync.Mutex
+	notifications []Notification
+}
+
+// Notify records a notification
+func (n *RecordingNotifier) Notify(ctx context.Context, event string, payload map[string]interface{}) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	
+	n.notifications = append(n.notifications, Notification{Event: event, Payload: payload})
+	return n.Err
+}
+
+// Notifications returns the recorded notifications in the order they were sent
+func (n *RecordingNotifier) Notifications() []Notification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	
+	return append([]Notification(nil), n.notifications...)
+}
+
+// Events returns the recorded notification event types, e.g. models.EventTypeAssessmentCompleted
+func (n *RecordingNotifier) Events() []string {
+	var events []string
+	for _, notification := range n.Notifications() {
+		events = append(events, notification.Event)
+	}
+	return events
+}
+
+// MemoryLocker is an in-process storage.Locker
+type MemoryLocker struct {
+	mu    sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewMemoryLocker creates an in-process locker
+func NewMemoryLocker() *MemoryLocker {
+	return &MemoryLocker{locks: make(map[string]chan struct{})}
+}
+
+// Lock blocks until the lock for key is held or the context is done. Like the file locker, it
+// fails with storage.ErrLockTimeout once the context is done.
+func (l *MemoryLocker) Lock(ctx context.Context, key string) (func(), error) {
+	_, unlock, err := l.Hold(ctx, key)
+	return unlock, err
+}
+
+// Hold acquires the lock for key like Lock. In-process locks are never lost, so the returned
+// context is only cancelled when the lock is released.
+func (l *MemoryLocker) Hold(ctx context.Context, key string) (context.Context, func(), error) {
+	l.mu.Lock()
+	lock, ok := l.locks[key]
+	if !ok {
+		lock = make(chan struct{}, 1)
+		l.locks[key] = lock
+	}
+	l.mu.Unlock()
+	
+	select {
+	case lock <- struct{}{}:
+		held, release := context.WithCancel(context.WithoutCancel(ctx))
+		var once sync.Once
+		return held, func() {
+			once.Do(func() {
+				release()
+				<-lock
+			})
+		}, nil
+	case <-ctx.Done():
+		return nil, nil, fmt.Errorf("%w %s: %v", storage.ErrLockTimeout, key, ctx.Err())
+	}
+}
+
+var (
+	_ services.Notifier = (*RecordingNotifier)(nil)
+	_ storage.Locker    = (*MemoryLocker)(nil)
+)
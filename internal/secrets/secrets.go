@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Provider resolves named secrets from an external source
+type Provider interface {
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// EnvProvider reads secrets from environment variables, e.g. share-secret from SHARE_SECRET
+type EnvProvider struct{}
+
+// GetSecret returns the environment variable matching the secret name
+func (p EnvProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+	value, exists := os.LookupEnv(key)
+	if !exists {
+		return "", fmt.Errorf("secret %s not set in environment variable %s", name, key)
+	}
+	return value, nil
+}
+
+// FileProvider reads secrets from files in a directory, one file per secret. This covers
+// Kubernetes secret volumes, the Secrets Store CSI driver for cloud secret managers and Vault Agent.
+type FileProvider struct {
+	Dir string
+}
+
+// GetSecret returns the trimmed contents of the file named after the secret
+func (p FileProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(p.Dir, name))
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Secret is a cached secret value that can be refreshed from its provider
+type Secret struct {
+	name     string
+	provider Provider
+	
+	mu    sync.RWMutex
+	value string
+}
+
+// NewSecret fetches a secret from a provider and caches it
+func NewSecret(ctx context.Context, provider Provider, name string) (*Secret, error) {
+	s := &Secret{name: name, provider: provider}
+	if err := s.Refresh(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Value returns the cached secret value
+func (s *Secret) Value() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.value
+}
+
+// Refresh re-reads the secret from its provider
+func (s *Secret) Refresh(ctx context.Context) error {
+	value, err := s.provider.GetSecret(ctx, s.name)
+	if err != nil {
+		return fmt.Errorf("failed to fetch secret %s: %w", s.name, err)
+	}
+	
+	s.mu.Lock()
+	s.value = value
+	s.mu.Unlock()
+	return nil
+}
+
+// Run refreshes the secret periodically until the context is cancelled, keeping the
+// last known value when the provider is temporarily unavailable
+func (s *Secret) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Secret refresh failed, keeping cached value: %v", err)
+			}
+		}
+	}
+}
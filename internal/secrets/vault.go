@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// VaultProvider reads secrets from a HashiCorp Vault KV version 2 secrets engine
+type VaultProvider struct {
+	Address string // e.g. https://vault.example.com:8200
+	Token   string
+	Mount   string // KV mount, e.g. secret
+	Path    string // secret path within the mount, e.g. questionnaire-app
+	Client  *http.Client
+}
+
+// NewVaultProvider creates a Vault provider using a token for authentication
+func NewVaultProvider(address, token, mount, path string) *VaultProvider {
+	return &VaultProvider{
+		Address: strings.TrimRight(address, "/"),
+		Token:   token,
+		Mount:   mount,
+		Path:    path,
+		Client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetSecret returns a key from the configured KV secret
+func (p *VaultProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.Address, p.Mount, p.Path)
+	
+	var body struct {
+		Data struct {
+			Data map[string]interface{} `json:"data"`
+		} `json:"data"`
+	}
+	if err := p.do(ctx, http.MethodGet, url, &body); err != nil {
+		return "", err
+	}
+	
+	value, ok := body.Data.Data[name]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in vault secret %s/%s", name, p.Mount, p.Path)
+	}
+	
+	return fmt.Sprint(value), nil
+}
+
+// RenewToken extends the lease of the Vault token
+func (p *VaultProvider) RenewToken(ctx context.Context) error {
+	return p.do(ctx, http.MethodPost, p.Address+"/v1/auth/token/renew-self", nil)
+}
+
+// RunTokenRenewal renews the Vault token periodically until the context is cancelled
+func (p *VaultProvider) RunTokenRenewal(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.RenewToken(ctx); err != nil {
+				log.Printf("Vault token renewal failed: %v", err)
+			}
+		}
+	}
+}
+
+// do performs an authenticated Vault API request and decodes the JSON response into out
+func (p *VaultProvider) do(ctx context.Context, method, url string, out interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.Token)
+	
+	resp, err := p.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault returned status %d for %s", resp.StatusCode, url)
+	}
+	
+	if out == nil {
+		return nil
+	}
+	
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	
+	return nil
+}
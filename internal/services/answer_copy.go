@@ -0,0 +1,196 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// Reasons a selected answer is not copied
+const (
+	copySkipNotAnswered        = "not_answered"
+	copySkipUnknownQuestion    = "unknown_question"
+	copySkipNotApplicable      = "not_applicable"
+	copySkipUnknownOption      = "unknown_option"
+	copySkipInvalidExplanation = "invalid_explanation"
+	copySkipAlreadyAnswered    = "already_answered"
+)
+
+// CopyAnswers copies the answers of the selected questions and categories from another
+// assessment. Each copied answer is recorded as an answer of the copying user that names its
+// source assessment. Answers the target already has are kept unless Overwrite is set, and
+// answers that are no longer valid for the target's application are skipped with a reason.
+func (s *AssessmentService) CopyAnswers(ctx context.Context, assessmentID string, req models.AnswerCopyRequest, copiedBy string) (*models.AnswerCopyResult, error) {
+	if req.SourceAssessmentID == "" {
+		return nil, fmt.Errorf("%w: sourceAssessmentId is required", ErrInvalidAnswer)
+	}
+	if req.SourceAssessmentID == assessmentID {
+		return nil, fmt.Errorf("%w: cannot copy answers from an assessment into itself", ErrInvalidAnswer)
+	}
+	if len(req.QuestionIDs) == 0 && len(req.Categories) == 0 {
+		return nil, fmt.Errorf("%w: select questionIds or categories to copy", ErrInvalidAnswer)
+	}
+	
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	if assessment.Status == "approved" {
+		return nil, ErrAssessmentApproved
+	}
+	
+	source, err := s.storage.GetAssessment(ctx, req.SourceAssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get source assessment: %w", err)
+	}
+	
+	if source == nil {
+		return nil, fmt.Errorf("source assessment %w", ErrNotFound)
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list questions: %w", err)
+	}
+	
+	tags, err := s.assessmentTags(ctx, assessment)
+	if err != nil {
+		return nil, err
+	}
+	
+	result := &models.AnswerCopyResult{
+		AssessmentID:       assessmentID,
+		SourceAssessmentID: source.ID,
+		CopiedBy:           copiedBy,
+		Copied:             []models.CopiedAnswer{},
+		Skipped:            []models.SkippedAnswer{},
+	}
+	
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	
+	var events []*models.AssessmentEvent
+	for _, questionID := range selectedQuestions(req, questions) {
+		question := byID[questionID]
+		optionID, answered := source.Answers[questionID]
+		skip := ""
+		switch {
+		case question == nil:
+			skip = copySkipUnknownQuestion
+		case !answered:
+			skip = copySkipNotAnswered
+		case !question.AppliesTo(tags) || !inScope(assessment, question):
+			skip = copySkipNotApplicable
+		}
+		
+		var option *models.Option
+		if skip == "" {
+			option = findOption(question.Options, optionID)
+			if option == nil {
+				skip = copySkipUnknownOption
+			}
+		}
+		
+		explanation := source.Explanations[questionID]
+		if skip == "" && option.RequiresExplanation != (explanation != "") {
+			skip = copySkipInvalidExplanation
+		}
+		if skip == "" && explanation != "" && len(checkAnswerRules(question.AnswerRules, explanation)) > 0 {
+			skip = copySkipInvalidExplanation
+		}
+		
+		current, hasAnswer := assessment.Answers[questionID]
+		if skip == "" && hasAnswer && !req.Overwrite {
+			skip = copySkipAlreadyAnswered
+		}
+		
+		if skip != "" {
+			result.Skipped = append(result.Skipped, models.SkippedAnswer{QuestionID: questionID, Reason: skip})
+			continue
+		}
+		
+		result.Copied = append(result.Copied, models.CopiedAnswer{
+			QuestionID: questionID,
+			Category:   question.Category,
+			OptionID:   optionID,
+			Replaced:   hasAnswer && current != optionID,
+		})
+		events = append(events, &models.AssessmentEvent{
+			Type:        models.EventAnswerSaved,
+			User:        copiedBy,
+			QuestionID:  questionID,
+			OptionID:    optionID,
+			Explanation: explanation,
+			References:  source.References[questionID],
+			CopiedFrom:  source.ID,
+			Provenance:  &models.AnswerProvenance{Method: models.ProvenanceCopied, Source: source.ID},
+		})
+	}
+	
+	if len(events) == 0 {
+		return result, nil
+	}
+	
+	state := assessment
+	for _, event := range events {
+		if state, err = s.appendEvent(ctx, state, event); err != nil {
+			return nil, err
+		}
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	return result, nil
+}
+
+// selectedQuestions returns the sorted IDs of the questions named in a copy request and of the
+// questions in its categories
+func selectedQuestions(req models.AnswerCopyRequest, questions []*models.Question) []string {
+	selected := make(map[string]bool)
+	for _, questionID := range req.QuestionIDs {
+		selected[questionID] = true
+	}
+	
+	categories := make(map[string]bool)
+	for _, category := range req.Categories {
+		categories[category] = true
+	}
+	for _, question := range questions {
+		if categories[question.Category] {
+			selected[question.ID] = true
+		}
+	}
+	
+	ids := make([]string, 0, len(selected))
+	for questionID := range selected {
+		ids = append(ids, questionID)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// findOption returns the option with an ID, or nil
+func findOption(options []models.Option, optionID string) *models.Option {
+	for i := range options {
+		if options[i].ID == optionID {
+			return &options[i]
+		}
+	}
+	return nil
+}
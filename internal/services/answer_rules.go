@@ -0,0 +1,115 @@
+package services
+
+import (
+	"fmt"
+	"questionnaire-app/internal/models"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// AnswerValidationError lists the fields of an answer that break the question's answer rules.
+// It wraps ErrInvalidAnswer.
+type AnswerValidationError struct {
+	QuestionID string
+	Problems   []models.AnswerProblem
+}
+
+func (e *AnswerValidationError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Field + " " + problem.Message
+	}
+	return fmt.Sprintf("%v: question %s: %s", ErrInvalidAnswer, e.QuestionID, strings.Join(messages, "; "))
+}
+
+func (e *AnswerValidationError) Unwrap() error {
+	return ErrInvalidAnswer
+}
+
+// checkAnswerRules validates the free text of an answer against a question's rules
+func checkAnswerRules(rules *models.AnswerRules, text string) []models.AnswerProblem {
+	if rules == nil {
+		return nil
+	}
+	
+	var problems []models.AnswerProblem
+	add := func(message string) {
+		if rules.Message != "" {
+			message = rules.Message
+		}
+		problems = append(problems, models.AnswerProblem{Field: "explanation", Message: message})
+	}
+	
+	length := utf8.RuneCountInString(text)
+	if rules.MinLength > 0 && length < rules.MinLength {
+		add(fmt.Sprintf("must be at least %d characters long", rules.MinLength))
+	}
+	if rules.MaxLength > 0 && length > rules.MaxLength {
+		add(fmt.Sprintf("must be at most %d characters long", rules.MaxLength))
+	}
+	
+	if rules.Pattern != "" {
+		pattern, err := regexp.Compile(`^(?:` + rules.Pattern + `)$`)
+		if err == nil && !pattern.MatchString(text) {
+			add("does not have the expected format")
+		}
+	}
+	
+	if rules.Numeric || rules.Min != nil || rules.Max != nil {
+		number, err := strconv.ParseFloat(text, 64)
+		switch {
+		case err != nil:
+			add("must be a number")
+		case rules.Min != nil && number < *rules.Min:
+			add(fmt.Sprintf("must be at least %s", strconv.FormatFloat(*rules.Min, 'f', -1, 64)))
+		case rules.Max != nil && number > *rules.Max:
+			add(fmt.Sprintf("must be at most %s", strconv.FormatFloat(*rules.Max, 'f', -1, 64)))
+		}
+	}
+	
+	// A custom message replaces every default message, so report it once
+	if rules.Message != "" && len(problems) > 1 {
+		problems = problems[:1]
+	}
+	return problems
+}
+
+// validateAnswerRules checks that a question's answer rules can be applied
+func validateAnswerRules(question *models.Question) []models.QuestionProblem {
+	rules := question.AnswerRules
+	if rules == nil {
+		return nil
+	}
+	
+	var problems []models.QuestionProblem
+	add := func(message string) {
+		problems = append(problems, models.QuestionProblem{Field: "answerRules", Message: message})
+	}
+	
+	explained := false
+	for _, option := range question.Options {
+		explained = explained || option.RequiresExplanation
+	}
+	if !explained {
+		add("no option takes an explanation to apply the rules to")
+	}
+	
+	if rules.MinLength < 0 || rules.MaxLength < 0 {
+		add("lengths must not be negative")
+	}
+	if rules.MaxLength > 0 && rules.MinLength > rules.MaxLength {
+		add("minLength must not exceed maxLength")
+	}
+	if rules.Min != nil && rules.Max != nil && *rules.Min > *rules.Max {
+		add("min must not exceed max")
+	}
+	if rules.Pattern != "" {
+		if _, err := regexp.Compile(rules.Pattern); err != nil {
+			add(fmt.Sprintf("invalid pattern: %v", err))
+		}
+	}
+	
+	return problems
+}
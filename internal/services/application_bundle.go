@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// ApplicationBundle is what an application's hand-off archive holds: its latest completed
+// assessment with the assessment's history, report and answer evidence
+type ApplicationBundle struct {
+	Application *models.Application
+	Assessment  *models.Assessment
+	Events      []*models.AssessmentEvent
+	Report      *models.Report
+	Evidence    []models.AnswerEvidence
+}
+
+// GetApplicationBundle collects an application's latest report and the assessment behind it
+// for handing off to auditors or migration vendors. Applications without a report are
+// ErrNotFound.
+func (s *ApplicationService) GetApplicationBundle(ctx context.Context, applicationID string) (*ApplicationBundle, error) {
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	report, err := s.assessments.GetLatestReport(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return nil, fmt.Errorf("report of application %s %w", applicationID, ErrNotFound)
+	}
+	
+	assessment, err := s.storage.GetAssessment(ctx, report.AssessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	events, err := s.storage.ListEvents(ctx, assessment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	
+	return &ApplicationBundle{
+		Application: app,
+		Assessment:  assessment,
+		Events:      events,
+		Report:      report,
+		Evidence:    answerEvidence(assessment, events),
+	}, nil
+}
+
+// answerEvidence documents each answer of an assessment. Suggestions are removed from the
+// assessment once confirmed, so their evidence is taken from the event log.
+func answerEvidence(assessment *models.Assessment, events []*models.AssessmentEvent) []models.AnswerEvidence {
+	suggested := make(map[string]models.AnswerSuggestion)
+	for _, event := range events {
+		if event.Type == models.EventAnswersSuggested {
+			for _, suggestion := range event.Suggestions {
+				suggested[suggestion.QuestionID] = suggestion
+			}
+		}
+	}
+	
+	questionIDs := make([]string, 0, len(assessment.Answers))
+	for questionID := range assessment.Answers {
+		questionIDs = append(questionIDs, questionID)
+	}
+	sort.Strings(questionIDs)
+	
+	evidence := make([]models.AnswerEvidence, 0, len(questionIDs))
+	for _, questionID := range questionIDs {
+		entry := models.AnswerEvidence{
+			QuestionID:  questionID,
+			OptionID:    assessment.Answers[questionID],
+			Explanation: assessment.Explanations[questionID],
+			References:  assessment.References[questionID],
+			AnsweredBy:  assessment.AnsweredBy[questionID],
+		}
+		if answeredAt, ok := assessment.AnsweredAt[questionID]; ok {
+			entry.AnsweredAt = &answeredAt
+		}
+		if provenance, ok := assessment.Provenance[questionID]; ok {
+			entry.Provenance = &provenance
+			suggestion, ok := suggested[questionID]
+			confirmed := provenance.Method == models.ProvenancePrefilled || provenance.Method == models.ProvenanceAgentScan
+			if ok && confirmed && suggestion.OptionID == entry.OptionID {
+				entry.Evidence = suggestion.Evidence
+			}
+		}
+		evidence = append(evidence, entry)
+	}
+	return evidence
+}
@@ -0,0 +1,151 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"strings"
+)
+
+// ErrDuplicateApplications is returned when applications to be created likely duplicate
+// existing ones and creating them was not forced
+var ErrDuplicateApplications = fmt.Errorf("%w: likely duplicate applications", ErrConflict)
+
+// Name similarities above which applications are likely duplicates: on their own, or when the
+// tags of the application with fewer tags all match
+const (
+	duplicateNameSimilarity   = 0.9
+	duplicateTaggedSimilarity = 0.75
+)
+
+var nameNumbers = regexp.MustCompile(`[0-9]+`)
+
+// findDuplicateApplications checks applications about to be saved against the stored ones and
+// each other. Applications whose ID is already stored are updates and never duplicates.
+func findDuplicateApplications(ctx context.Context, repo storage.ApplicationRepository, apps []*models.Application) ([]models.ApplicationDuplicate, error) {
+	stored, err := repo.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	candidates := make([]*models.Application, 0, len(stored)+len(apps))
+	storedIDs := make(map[string]bool, len(stored))
+	for _, app := range stored {
+		candidates = append(candidates, app)
+		storedIDs[app.ID] = true
+	}
+	
+	duplicates := []models.ApplicationDuplicate{}
+	for _, app := range apps {
+		if storedIDs[app.ID] {
+			continue
+		}
+		if duplicate := findDuplicate(app, candidates); duplicate != nil {
+			duplicates = append(duplicates, *duplicate)
+		}
+		candidates = append(candidates, app)
+	}
+	return duplicates, nil
+}
+
+// findDuplicate returns the first candidate that app likely duplicates, or nil
+func findDuplicate(app *models.Application, candidates []*models.Application) *models.ApplicationDuplicate {
+	for _, candidate := range candidates {
+		if candidate.ID == app.ID {
+			continue
+		}
+		
+		similarity := nameSimilarity(app.Name, candidate.Name)
+		tags := matchingTags(app.Tags, candidate.Tags)
+		sameRepository := app.Repository != "" && normalizeRepository(app.Repository) == normalizeRepository(candidate.Repository)
+		allTags := len(tags) > 0 && len(tags) == min(len(app.Tags), len(candidate.Tags))
+		if !sameRepository && similarity < duplicateNameSimilarity && (similarity < duplicateTaggedSimilarity || !allTags) {
+			continue
+		}
+		
+		return &models.ApplicationDuplicate{
+			Name:           app.Name,
+			ApplicationID:  app.ID,
+			DuplicateOf:    candidate.ID,
+			DuplicateName:  candidate.Name,
+			Similarity:     math.Round(similarity*100) / 100,
+			MatchingTags:   tags,
+			SameRepository: sameRepository,
+		}
+	}
+	return nil
+}
+
+// nameSimilarity compares application names ignoring case, spaces and punctuation, from 0 for
+// unrelated names to 1 for equal ones. Names with different numbers, such as portal-v1 and
+// portal-v2, are told apart.
+func nameSimilarity(a, b string) float64 {
+	a, b = normalizeName(a), normalizeName(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if strings.Join(nameNumbers.FindAllString(a, -1), ",") != strings.Join(nameNumbers.FindAllString(b, -1), ",") {
+		return 0
+	}
+	
+	ra, rb := []rune(a), []rune(b)
+	return 1 - float64(editDistance(ra, rb))/float64(max(len(ra), len(rb)))
+}
+
+// normalizeName lowercases a name and drops everything but letters and digits
+func normalizeName(name string) string {
+	return nonSlugChars.ReplaceAllString(strings.ToLower(name), "")
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b []rune) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// matchingTags lists the tags two applications share with the same value, as key=value
+func matchingTags(a, b map[string]string) []string {
+	var tags []string
+	for _, key := range sortedTagKeys(a) {
+		if value, ok := b[key]; ok && value == a[key] {
+			tags = append(tags, key+"="+value)
+		}
+	}
+	return tags
+}
+
+// normalizeRepository reduces a Git URL to host and path, so https and SSH URLs of the same
+// repository compare equal
+func normalizeRepository(repository string) string {
+	repository = strings.ToLower(strings.TrimSpace(repository))
+	_, rest, isURL := strings.Cut(repository, "://")
+	if isURL {
+		repository = rest
+	}
+	if _, rest, ok := strings.Cut(repository, "@"); ok {
+		repository = rest
+	}
+	if !isURL {
+		// scp-like SSH syntax, e.g. git@github.com:org/repo.git
+		repository = strings.Replace(repository, ":", "/", 1)
+	}
+	return strings.TrimSuffix(strings.TrimSuffix(repository, "/"), ".git")
+}
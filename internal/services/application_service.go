@@ -0,0 +1,360 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"time"
+)
+
+// ApplicationService handles the business logic for applications
+type ApplicationService struct {
+	storage     storage.Storage
+	locker      storage.Locker
+	assessments *AssessmentService
+}
+
+// NewApplicationService creates a new application service; assessments provides the latest
+// reports used to list applications by score
+func NewApplicationService(storage storage.Storage, locker storage.Locker, assessments *AssessmentService) *ApplicationService {
+	return &ApplicationService{
+		storage:     storage,
+		locker:      locker,
+		assessments: assessments,
+	}
+}
+
+// ListApplications returns the applications created and updated within the ranges of opts and
+// in one of its lifecycle states; the sort and score filters of opts only apply to ListScoredApplications
+func (s *ApplicationService) ListApplications(ctx context.Context, opts ApplicationListOptions) ([]*models.Application, error) {
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	filtered := []*models.Application{}
+	for _, app := range apps {
+		if opts.Created.Contains(app.CreatedAt) && opts.Updated.Contains(app.UpdatedAt) && opts.matchesLifecycle(app) {
+			filtered = append(filtered, app)
+		}
+	}
+	return filtered, nil
+}
+
+// GetApplication retrieves an application by ID
+func (s *ApplicationService) GetApplication(ctx context.Context, id string) (*models.Application, error) {
+	return s.storage.GetApplication(ctx, id)
+}
+
+// Application list sort orders
+const (
+	SortByName      = "name"
+	SortByScore     = "score"  // least ready first, unassessed applications last
+	SortByScoreDesc = "-score" // most ready first, unassessed applications last
+)
+
+// BandUnassessed filters for applications without a report
+const BandUnassessed = "unassessed"
+
+// TimeRange is an inclusive range of times; a zero bound leaves that end of the range open
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Contains reports whether t lies within the range. A zero t, such as the creation time of an
+// entity stored before timestamps were recorded, only lies within an unbounded range.
+func (r TimeRange) Contains(t time.Time) bool {
+	if r.From.IsZero() && r.To.IsZero() {
+		return true
+	}
+	if t.IsZero() {
+		return false
+	}
+	return !t.Before(r.From) && (r.To.IsZero() || !t.After(r.To))
+}
+
+// ApplicationListOptions filters and orders application lists
+type ApplicationListOptions struct {
+	Sort    string
+	Bands   []string // score band levels, or BandUnassessed
+	Grades  []string
+	Stale   *bool // only applications whose latest report is (or is not) stale
+	Created TimeRange
+	Updated TimeRange
+	
+	Lifecycles []string // lifecycle states, or LifecycleUntracked
+}
+
+// matchesLifecycle reports whether an application is in one of the requested lifecycle states
+func (o ApplicationListOptions) matchesLifecycle(app *models.Application) bool {
+	if len(o.Lifecycles) == 0 {
+		return true
+	}
+	state := lifecycleOf(app)
+	for _, wanted := range o.Lifecycles {
+		if wanted == state {
+			return true
+		}
+	}
+	return false
+}
+
+// ListScoredApplications returns applications with the score of their latest report, filtered
+// by band, grade and staleness and sorted as requested
+func (s *ApplicationService) ListScoredApplications(ctx context.Context, opts ApplicationListOptions) ([]*models.ScoredApplication, error) {
+	apps, err := s.ListApplications(ctx, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	scoring, err := s.assessments.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	scored := []*models.ScoredApplication{}
+	for _, app := range apps {
+		report, err := s.assessments.GetLatestReport(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
+		
+		entry := &models.ScoredApplication{Application: app}
+		if report != nil {
+			entry.LatestScore = latestScore(report, scoring)
+			entry.LatestScore.Stale = report.Stale
+			entry.Remediation = remediationProgress(recommendationKeys(report), app)
+		}
+		if matchesScoreFilters(entry.LatestScore, opts) {
+			scored = append(scored, entry)
+		}
+	}
+	
+	switch opts.Sort {
+	case SortByScore, SortByScoreDesc:
+		descending := opts.Sort == SortByScoreDesc
+		sort.SliceStable(scored, func(i, j int) bool {
+			a, b := scored[i].LatestScore, scored[j].LatestScore
+			if a == nil || b == nil {
+				return a != nil
+			}
+			if descending {
+				return a.ScorePercent > b.ScorePercent
+			}
+			return a.ScorePercent < b.ScorePercent
+		})
+	case SortByName:
+		sort.SliceStable(scored, func(i, j int) bool { return scored[i].Name < scored[j].Name })
+	case "":
+	default:
+		return nil, fmt.Errorf("unknown sort order %q", opts.Sort)
+	}
+	
+	return scored, nil
+}
+
+// latestScore summarizes a report, deriving the band and grade for reports issued before they
+// were recorded
+func latestScore(report *models.Report, scoring *models.ScoringConfig) *models.LatestScore {
+	ratio := ReportRatio(report)
+	score := &models.LatestScore{
+		AssessmentID: report.AssessmentID,
+		GeneratedAt:  report.GeneratedAt,
+		ScorePercent: ReportPercent(report),
+		Band:         report.Band,
+		Grade:        report.Grade,
+	}
+	if score.Band == "" {
+		score.Band = scoring.BandFor(ratio).Level
+	}
+	if score.Grade == "" {
+		score.Grade = scoring.GradeFor(ratio)
+	}
+	return score
+}
+
+// matchesScoreFilters reports whether a latest score passes the band, grade and staleness filters
+func matchesScoreFilters(score *models.LatestScore, opts ApplicationListOptions) bool {
+	if len(opts.Bands) > 0 {
+		band := BandUnassessed
+		if score != nil {
+			band = score.Band
+		}
+		if !containsString(opts.Bands, band) {
+			return false
+		}
+	}
+	
+	if len(opts.Grades) > 0 && (score == nil || !containsString(opts.Grades, score.Grade)) {
+		return false
+	}
+	
+	if opts.Stale != nil && (score == nil || score.Stale != *opts.Stale) {
+		return false
+	}
+	return true
+}
+
+// stampApplication sets the timestamps of an application about to be saved, keeping the
+// creation time, lifecycle, remediations, risk acceptances and external ID of the stored
+// version if there is one
+func stampApplication(app, existing *models.Application, now time.Time) {
+	app.CreatedAt = now
+	if existing != nil && !existing.CreatedAt.IsZero() {
+		app.CreatedAt = existing.CreatedAt
+	}
+	if existing != nil && app.Lifecycle == "" {
+		app.Lifecycle = existing.Lifecycle
+		app.LifecycleHistory = existing.LifecycleHistory
+	}
+	if existing != nil && app.Remediations == nil {
+		app.Remediations = existing.Remediations
+	}
+	if existing != nil && app.RiskAcceptances == nil {
+		app.RiskAcceptances = existing.RiskAcceptances
+	}
+	if existing != nil && app.ExternalID == "" {
+		app.ExternalID = existing.ExternalID
+	}
+	app.UpdatedAt = now
+}
+
+// AssessmentListOptions filters assessment lists by time; Completed only matches completed
+// assessments when bounded
+type AssessmentListOptions struct {
+	Created   TimeRange
+	Updated   TimeRange
+	Completed TimeRange
+}
+
+// matches reports whether an assessment passes the filters
+func (o AssessmentListOptions) matches(assessment *models.Assessment) bool {
+	var completedAt time.Time
+	if assessment.CompletedAt != nil {
+		completedAt = *assessment.CompletedAt
+	}
+	return o.Created.Contains(assessment.CreatedAt) && o.Updated.Contains(assessment.UpdatedAt) && o.Completed.Contains(completedAt)
+}
+
+// ListAssessments returns an application's assessments matching opts, oldest first
+func (s *ApplicationService) ListAssessments(ctx context.Context, applicationID string, opts AssessmentListOptions) ([]*models.Assessment, error) {
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	stored, err := s.storage.ListAssessments(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	assessments := []*models.Assessment{}
+	for _, assessment := range stored {
+		if opts.matches(assessment) {
+			assessments = append(assessments, assessment)
+		}
+	}
+	sort.SliceStable(assessments, func(i, j int) bool { return assessments[i].CreatedAt.Before(assessments[j].CreatedAt) })
+	return assessments, nil
+}
+
+// DeleteApplication deletes an application, handling its assessments and reports according
+// to mode. In block mode an application with assessments is not deleted and ErrConflict is
+// returned together with the list of dependents.
+func (s *ApplicationService) DeleteApplication(ctx context.Context, id, mode string) (*models.DeletionResult, error) {
+	switch mode {
+	case models.DeleteModeBlock, models.DeleteModeCascade, models.DeleteModeOrphan:
+	default:
+		return nil, fmt.Errorf("unknown deletion mode: %s", mode)
+	}
+	
+	app, err := s.storage.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	assessments, err := s.storage.ListAssessments(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	result := &models.DeletionResult{
+		ApplicationID:        id,
+		Mode:                 mode,
+		DependentAssessments: []string{},
+	}
+	for _, assessment := range assessments {
+		result.DependentAssessments = append(result.DependentAssessments, assessment.ID)
+	}
+	
+	if len(assessments) > 0 && mode == models.DeleteModeBlock {
+		return result, fmt.Errorf("%w: application has %d assessments", ErrConflict, len(assessments))
+	}
+	
+	if mode == models.DeleteModeCascade {
+		for _, assessment := range assessments {
+			if err := s.deleteAssessment(ctx, assessment.ID, result); err != nil {
+				return result, err
+			}
+		}
+	} else {
+		result.OrphanedAssessments = len(assessments)
+	}
+	
+	if err := s.storage.DeleteApplication(ctx, id); err != nil {
+		return result, fmt.Errorf("failed to delete application: %w", err)
+	}
+	result.Deleted = true
+	invalidatePortfolioSummary(ctx, s.storage)
+	
+	return result, nil
+}
+
+// deleteAssessment removes an assessment with its report and event log
+func (s *ApplicationService) deleteAssessment(ctx context.Context, assessmentID string, result *models.DeletionResult) error {
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(lockCtx, "assessment-"+assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to lock assessment: %w", err)
+	}
+	defer unlock()
+	
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get report: %w", err)
+	}
+	
+	if report != nil {
+		if err := s.storage.DeleteReport(ctx, assessmentID); err != nil {
+			return fmt.Errorf("failed to delete report: %w", err)
+		}
+		result.DeletedReports++
+	}
+	
+	if err := s.storage.DeleteEvents(ctx, assessmentID); err != nil {
+		return fmt.Errorf("failed to delete events: %w", err)
+	}
+	
+	if err := s.storage.DeleteWorkshop(ctx, assessmentID); err != nil {
+		return fmt.Errorf("failed to delete workshop: %w", err)
+	}
+	
+	if err := s.storage.DeleteAssessment(ctx, assessmentID); err != nil {
+		return fmt.Errorf("failed to delete assessment: %w", err)
+	}
+	result.DeletedAssessments++
+	
+	return nil
+}
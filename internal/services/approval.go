@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+)
+
+// ApproveAssessment signs off a completed assessment. Every item of the review checklist must be
+// ticked; the ticked items are recorded with the approval. Approved assessments and their
+// reports reject further changes until an admin reopens them.
+func (s *AssessmentService) ApproveAssessment(ctx context.Context, assessmentID, approvedBy string, ticked []models.CheckedItem) (*models.Assessment, error) {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	switch assessment.Status {
+	case "approved":
+		return assessment, nil
+	case "completed":
+	default:
+		return nil, fmt.Errorf("%w: only completed assessments can be approved", ErrConflict)
+	}
+	
+	checklist, err := s.GetReviewChecklist(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checked, err := checkReviewChecklist(checklist, ticked)
+	if err != nil {
+		return nil, err
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:      models.EventAssessmentApproved,
+		User:      approvedBy,
+		Checklist: checked,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	// The application's latest report may now be an older one
+	invalidatePortfolioSummary(ctx, s.storage)
+	
+	return state, nil
+}
+
+// ReopenAssessment lifts the approval of an assessment so it can be edited again. The
+// assessment returns to in progress and its report is regenerated when it is completed again.
+func (s *AssessmentService) ReopenAssessment(ctx context.Context, assessmentID, reopenedBy, reason string) (*models.Assessment, error) {
+	if reason == "" {
+		return nil, errors.New("a reason is required to reopen an assessment")
+	}
+	
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	if assessment.Status != "approved" {
+		return nil, fmt.Errorf("%w: only approved assessments can be reopened", ErrConflict)
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:   models.EventAssessmentReopened,
+		User:   reopenedBy,
+		Reason: reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	return state, nil
+}
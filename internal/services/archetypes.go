@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidArchetype is returned when saving an archetype that fails validation
+var ErrInvalidArchetype = errors.New("archetype is invalid")
+
+// archetypeIDPattern restricts archetype IDs to slugs, since they name files and are used as tag values
+var archetypeIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// SourceArchetype is the source of suggestions made from an application's archetype
+const SourceArchetype = "archetype"
+
+// ListArchetypes returns the application archetypes sorted by ID
+func (s *ApplicationService) ListArchetypes(ctx context.Context) ([]*models.Archetype, error) {
+	archetypes, err := s.storage.ListArchetypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archetypes: %w", err)
+	}
+	
+	sort.Slice(archetypes, func(i, j int) bool { return archetypes[i].ID < archetypes[j].ID })
+	if archetypes == nil {
+		archetypes = []*models.Archetype{}
+	}
+	return archetypes, nil
+}
+
+// GetArchetype returns an archetype by ID
+func (s *ApplicationService) GetArchetype(ctx context.Context, id string) (*models.Archetype, error) {
+	archetype, err := s.storage.GetArchetype(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archetype: %w", err)
+	}
+	if archetype == nil {
+		return nil, fmt.Errorf("archetype %w", ErrNotFound)
+	}
+	return archetype, nil
+}
+
+// SaveArchetype creates or replaces an archetype. Its answers must name existing options that
+// do not require an explanation, since suggestions are confirmed without one.
+func (s *ApplicationService) SaveArchetype(ctx context.Context, archetype *models.Archetype) (*models.Archetype, error) {
+	if !archetypeIDPattern.MatchString(archetype.ID) {
+		return nil, fmt.Errorf("%w: id must consist of lowercase letters, digits, dashes and underscores", ErrInvalidArchetype)
+	}
+	archetype.Name = strings.TrimSpace(archetype.Name)
+	if archetype.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidArchetype)
+	}
+	if archetype.Answers == nil {
+		archetype.Answers = []models.ArchetypeAnswer{}
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	
+	answered := make(map[string]bool, len(archetype.Answers))
+	for _, answer := range archetype.Answers {
+		question := byID[answer.QuestionID]
+		if question == nil {
+			return nil, fmt.Errorf("%w: unknown question %s", ErrInvalidArchetype, answer.QuestionID)
+		}
+		if answered[answer.QuestionID] {
+			return nil, fmt.Errorf("%w: question %s is answered twice", ErrInvalidArchetype, answer.QuestionID)
+		}
+		answered[answer.QuestionID] = true
+		
+		option := findOption(question.Options, answer.OptionID)
+		if option == nil {
+			return nil, fmt.Errorf("%w: unknown option %s for question %s", ErrInvalidArchetype, answer.OptionID, answer.QuestionID)
+		}
+		if option.RequiresExplanation {
+			return nil, fmt.Errorf("%w: option %s requires an explanation and cannot be a default answer", ErrInvalidArchetype, answer.OptionID)
+		}
+	}
+	
+	now := time.Now().UTC()
+	archetype.UpdatedAt = &now
+	if err := s.storage.SaveArchetype(ctx, archetype); err != nil {
+		return nil, fmt.Errorf("failed to save archetype: %w", err)
+	}
+	
+	return archetype, nil
+}
+
+// DeleteArchetype removes an archetype no application is tagged with
+func (s *ApplicationService) DeleteArchetype(ctx context.Context, id string) error {
+	if _, err := s.GetArchetype(ctx, id); err != nil {
+		return err
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list applications: %w", err)
+	}
+	var used []string
+	for _, app := range apps {
+		if app.Tags[models.ArchetypeTag] == id {
+			used = append(used, app.ID)
+		}
+	}
+	if len(used) > 0 {
+		sort.Strings(used)
+		return fmt.Errorf("%w: archetype is used by applications %s", ErrConflict, strings.Join(used, ", "))
+	}
+	
+	if err := s.storage.DeleteArchetype(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete archetype: %w", err)
+	}
+	return nil
+}
+
+// archetypeSuggestions returns the default answers of an application's archetype as
+// suggestions by question ID, or nil if the application has no known archetype. Answers to
+// questions that do not apply to the application or whose option was removed are left out.
+func archetypeSuggestions(ctx context.Context, repo storage.Storage, app *models.Application) (map[string]models.AnswerSuggestion, error) {
+	archetypeID := app.Tags[models.ArchetypeTag]
+	if archetypeID == "" {
+		return nil, nil
+	}
+	
+	archetype, err := repo.GetArchetype(ctx, archetypeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archetype: %w", err)
+	}
+	if archetype == nil || len(archetype.Answers) == 0 {
+		return nil, nil
+	}
+	
+	now := time.Now()
+	suggestions := make(map[string]models.AnswerSuggestion)
+	for _, answer := range archetype.Answers {
+		question, err := repo.GetQuestion(ctx, answer.QuestionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get question: %w", err)
+		}
+		if question == nil || !question.AppliesTo(app.Tags) || findOption(question.Options, answer.OptionID) == nil {
+			continue
+		}
+		
+		suggestions[answer.QuestionID] = models.AnswerSuggestion{
+			QuestionID:  answer.QuestionID,
+			OptionID:    answer.OptionID,
+			Source:      SourceArchetype,
+			Signal:      models.ArchetypeTag + ":" + archetypeID,
+			Confidence:  1,
+			SuggestedAt: now,
+		}
+	}
+	
+	if len(suggestions) == 0 {
+		return nil, nil
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,251 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+)
+
+// workbookUser attributes workbook imports when the request names no user
+const workbookUser = "xlsx-import"
+
+// Columns of the category sheets of an assessment workbook, matched case-insensitively
+const (
+	workbookQuestionID  = "question id"
+	workbookQuestion    = "question"
+	workbookAnswer      = "answer"
+	workbookExplanation = "explanation"
+)
+
+// workbookImport collects the answers of an assessment workbook
+type workbookImport struct {
+	tags       map[string]string // of the application
+	byID       map[string]*models.Question
+	byText     map[string]*models.Question // by lower-case text
+	categories map[string]bool             // lower-case category names
+	rows       map[string]string           // question -> cell of the first row naming it
+	answers    map[string]importedAnswer
+	report     *models.AssessmentImportReport
+}
+
+// importedAnswer is a validated answer of a workbook with the cell it came from
+type importedAnswer struct {
+	optionID    string
+	explanation string
+	cell        string
+}
+
+// ImportWorkbook loads answers collected offline in an XLSX workbook as a completed assessment
+// of an application. Each category has a sheet named after it with a header row naming the
+// Question ID or Question, Answer and Explanation columns; answers name an option by ID or
+// text. Sheets without an Answer column, such as instructions, are skipped. Nothing is
+// imported unless every row is valid; problems are reported by cell.
+func (s *AssessmentService) ImportWorkbook(ctx context.Context, applicationID string, data []byte, user string, dryRun bool) (*models.AssessmentImportReport, error) {
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	report := &models.AssessmentImportReport{DryRun: dryRun, Errors: []models.ImportError{}}
+	sheets, err := readWorkbook(data)
+	if err != nil {
+		report.Errors = append(report.Errors, models.ImportError{Error: err.Error()})
+		return report, nil
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	workbook := &workbookImport{
+		tags:       app.Tags,
+		byID:       make(map[string]*models.Question, len(questions)),
+		byText:     make(map[string]*models.Question, len(questions)),
+		categories: make(map[string]bool),
+		rows:       make(map[string]string),
+		answers:    make(map[string]importedAnswer),
+		report:     report,
+	}
+	for _, question := range questions {
+		workbook.byID[question.ID] = question
+		workbook.byText[strings.ToLower(strings.TrimSpace(question.Text))] = question
+		workbook.categories[strings.ToLower(question.Category)] = true
+	}
+	for _, sheet := range sheets {
+		workbook.readSheet(sheet)
+	}
+	answers := workbook.answers
+	
+	if len(answers) == 0 && len(report.Errors) == 0 {
+		report.Errors = append(report.Errors, models.ImportError{Error: "the workbook has no answers"})
+	}
+	report.Answers = len(answers)
+	report.Valid = len(report.Errors) == 0
+	if !report.Valid || dryRun {
+		return report, nil
+	}
+	
+	if user == "" {
+		user = workbookUser
+	}
+	assessment, err := s.StartAssessment(ctx, applicationID, StartOptions{StartedBy: user})
+	if err != nil {
+		return nil, err
+	}
+	
+	questionIDs := make([]string, 0, len(answers))
+	for questionID := range answers {
+		questionIDs = append(questionIDs, questionID)
+	}
+	sort.Strings(questionIDs)
+	
+	for _, questionID := range questionIDs {
+		answer := answers[questionID]
+		provenance := models.AnswerProvenance{Method: models.ProvenanceImported, Source: "xlsx"}
+		if err := s.saveAnswer(ctx, assessment.ID, questionID, answer.optionID, answer.explanation, nil, user, provenance); err != nil {
+			return nil, fmt.Errorf("failed to save answer from cell %s: %w", answer.cell, err)
+		}
+	}
+	if _, err := s.CompleteAssessment(ctx, assessment.ID); err != nil {
+		return nil, err
+	}
+	
+	report.AssessmentID = assessment.ID
+	return report, nil
+}
+
+// readSheet validates the answers of a category sheet and adds them to the import
+func (w *workbookImport) readSheet(sheet workbookSheet) {
+	report := w.report
+	if len(sheet.Rows) == 0 {
+		report.SkippedSheets = append(report.SkippedSheets, sheet.Name)
+		return
+	}
+	
+	header := sheet.Rows[0]
+	columns := make(map[string]int)
+	for i, name := range header.Cells {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, seen := columns[name]; !seen && name != "" {
+			columns[name] = i
+		}
+	}
+	if _, ok := columns[workbookAnswer]; !ok {
+		report.SkippedSheets = append(report.SkippedSheets, sheet.Name)
+		return
+	}
+	
+	report.Sheets++
+	addError := func(row, column int, message string) {
+		importError := models.ImportError{Sheet: sheet.Name, Row: row, Error: message}
+		if column >= 0 {
+			importError.Column = strings.TrimSpace(header.Cell(column))
+			importError.Cell = cellName(column, row)
+		}
+		report.Errors = append(report.Errors, importError)
+	}
+	column := func(name string) int {
+		if i, ok := columns[name]; ok {
+			return i
+		}
+		return -1
+	}
+	idColumn, textColumn := column(workbookQuestionID), column(workbookQuestion)
+	answerColumn, explanationColumn := column(workbookAnswer), column(workbookExplanation)
+	
+	if !w.categories[strings.ToLower(strings.TrimSpace(sheet.Name))] {
+		addError(header.Number, -1, fmt.Sprintf("no question category is named %q", sheet.Name))
+		return
+	}
+	if idColumn < 0 && textColumn < 0 {
+		addError(header.Number, -1, "a Question ID or Question column is required")
+		return
+	}
+	
+	for _, row := range sheet.Rows[1:] {
+		id := strings.TrimSpace(row.Cell(idColumn))
+		text := strings.TrimSpace(row.Cell(textColumn))
+		answer := strings.TrimSpace(row.Cell(answerColumn))
+		explanation := strings.TrimSpace(row.Cell(explanationColumn))
+		if id == "" && text == "" && answer == "" && explanation == "" {
+			continue
+		}
+		
+		var question *models.Question
+		switch {
+		case id != "":
+			if question = w.byID[id]; question == nil {
+				addError(row.Number, idColumn, fmt.Sprintf("unknown question %s", id))
+				continue
+			}
+		case text != "":
+			if question = w.byText[strings.ToLower(text)]; question == nil {
+				addError(row.Number, textColumn, "no question has this text")
+				continue
+			}
+		default:
+			addError(row.Number, max(idColumn, textColumn), "the question is missing")
+			continue
+		}
+		questionColumn := idColumn
+		if id == "" {
+			questionColumn = textColumn
+		}
+		
+		if !strings.EqualFold(question.Category, strings.TrimSpace(sheet.Name)) {
+			addError(row.Number, questionColumn, fmt.Sprintf("question %s belongs on the %s sheet", question.ID, question.Category))
+			continue
+		}
+		if !question.AppliesTo(w.tags) {
+			addError(row.Number, questionColumn, fmt.Sprintf("question %s does not apply to the application", question.ID))
+			continue
+		}
+		cell := sheet.Name + "!" + cellName(answerColumn, row.Number)
+		if previous, ok := w.rows[question.ID]; ok {
+			addError(row.Number, questionColumn, fmt.Sprintf("question %s is already answered in cell %s", question.ID, previous))
+			continue
+		}
+		w.rows[question.ID] = cell
+		if answer == "" {
+			report.Unanswered++
+			continue
+		}
+		
+		option := findOption(question.Options, answer)
+		if option == nil {
+			for i := range question.Options {
+				if strings.EqualFold(strings.TrimSpace(question.Options[i].Text), answer) {
+					option = &question.Options[i]
+					break
+				}
+			}
+		}
+		if option == nil {
+			addError(row.Number, answerColumn, fmt.Sprintf("question %s has no option %q", question.ID, answer))
+			continue
+		}
+		
+		switch {
+		case option.RequiresExplanation && explanation == "":
+			addError(row.Number, max(explanationColumn, answerColumn), fmt.Sprintf("option %s requires an explanation", option.ID))
+			continue
+		case !option.RequiresExplanation && explanation != "":
+			addError(row.Number, explanationColumn, fmt.Sprintf("option %s does not take an explanation", option.ID))
+			continue
+		case explanation != "":
+			if problems := checkAnswerRules(question.AnswerRules, explanation); len(problems) > 0 {
+				for _, problem := range problems {
+					addError(row.Number, explanationColumn, problem.Message)
+				}
+				continue
+			}
+		}
+		
+		w.answers[question.ID] = importedAnswer{optionID: option.ID, explanation: explanation, cell: cell}
+	}
+}
@@ -2,10 +2,14 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
 	"questionnaire-app/internal/models"
 	"questionnaire-app/internal/storage"
+	"strconv"
+	"strings"
 	"time"
 	
 	"github.com/google/uuid"
@@ -13,23 +17,168 @@ import (
 
 // AssessmentService handles the business logic for assessments
 type AssessmentService struct {
-	storage storage.Storage
+	storage  storage.Storage
+	locker   storage.Locker
+	notifier Notifier
+	signer   *ReportSigner
+	narrator NarrativeGenerator
+	
+	// reportHooks extend report generation; see ReportHook
+	reportHooks []ReportHook
+	
+	// scoringPlugins runs the WASM modules the scoring configuration names
+	scoringPlugins *WasmPlugins
+	
+	// reportValidity is how long a report reflects the application; zero if reports never go stale
+	reportValidity time.Duration
+	
+	// weightProfiles holds default weight overrides per application class
+	weightProfiles map[string][]models.WeightOverride
+	
+	// consensusRule derives the answer from the responses of several assessors. Reports flag questions where fewer than disagreementThreshold
+	// percent of the assessors agree.
+	consensusRule         string
+	disagreementThreshold int
+}
+
+// StartOptions holds the optional parameters of a new assessment
+type StartOptions struct {
+	StartedBy       string
+	WeightOverrides []models.WeightOverride
+	Context         *models.AssessmentContext
+	SpotCheck       *models.SpotCheck // sample the catalog instead of asking every question
+	Categories      []string          // limit the assessment to these categories
+	ExternalID      string            // key of the assessment in another system; must be unique
+}
+
+// Notifier queues outbound notifications about assessment events
+type Notifier interface {
+	Notify(ctx context.Context, event string, payload map[string]interface{}) error
 }
 
-// NewAssessmentService creates a new assessment service
-func NewAssessmentService(storage storage.Storage) *AssessmentService {
-	return &AssessmentService{
-		storage: storage,
+// AssessmentOption configures optional collaborators of an AssessmentService
+type AssessmentOption func(*AssessmentService)
+
+// WithNotifier sends notifications about assessment events through n
+func WithNotifier(n Notifier) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.notifier = n
+	}
+}
+
+// WithWeightProfiles applies default weight overrides to assessments of applications whose
+// "class" tag matches a profile name
+func WithWeightProfiles(profiles map[string][]models.WeightOverride) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.weightProfiles = profiles
 	}
 }
 
+// WithReportSigner signs generated reports
+func WithReportSigner(signer *ReportSigner) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.signer = signer
+	}
+}
+
+// WithReportValidity marks reports older than validity as stale; zero disables staleness
+func WithReportValidity(validity time.Duration) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.reportValidity = validity
+	}
+}
+
+// lockTimeout bounds how long an operation waits for another replica to release an entity
+const lockTimeout = 10 * time.Second
+
+// NewAssessmentService creates a new assessment service; locker serializes updates to the
+// same assessment across replicas
+func NewAssessmentService(storage storage.Storage, locker storage.Locker, opts ...AssessmentOption) *AssessmentService {
+	s := &AssessmentService{
+		storage:               storage,
+		locker:                locker,
+		consensusRule:         models.ConsensusLatest,
+		disagreementThreshold: DefaultDisagreementThreshold,
+		reportHooks:           append([]ReportHook(nil), registeredReportHooks...),
+	}
+	
+	for _, opt := range opts {
+		opt(s)
+	}
+	
+	return s
+}
+
+// lockAssessment acquires the lock guarding updates to an assessment and its report
+func (s *AssessmentService) lockAssessment(ctx context.Context, assessmentID string) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(ctx, "assessment-"+assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock assessment: %w", err)
+	}
+	return unlock, nil
+}
+
 // GetQuestions fetches all available questions
 func (s *AssessmentService) GetQuestions(ctx context.Context) ([]*models.Question, error) {
 	return s.storage.GetQuestions(ctx)
 }
 
-// StartAssessment creates a new assessment for an application
-func (s *AssessmentService) StartAssessment(ctx context.Context, applicationID string) (*models.Assessment, error) {
+// GetAssessmentQuestions returns the questions that apply to the assessment's application
+// according to its tags, limited to the sample of a spot check
+func (s *AssessmentService) GetAssessmentQuestions(ctx context.Context, assessmentID string) ([]*models.Question, error) {
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	tags, err := s.assessmentTags(ctx, assessment)
+	if err != nil {
+		return nil, err
+	}
+	
+	applicable := []*models.Question{}
+	for _, question := range questions {
+		if question.AppliesTo(tags) && inScope(assessment, question) {
+			applicable = append(applicable, question)
+		}
+	}
+	return applicable, nil
+}
+
+// assessmentTags returns the application tags an assessment applies questions by: those of its
+// snapshot, or for assessments started before snapshots were taken, the application's current
+// tags. Without either there are none.
+func (s *AssessmentService) assessmentTags(ctx context.Context, assessment *models.Assessment) (map[string]string, error) {
+	if assessment.Application != nil {
+		return assessment.Application.Tags, nil
+	}
+	
+	app, err := s.storage.GetApplication(ctx, assessment.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	
+	if app == nil {
+		return nil, nil
+	}
+	return app.Tags, nil
+}
+
+// StartAssessment creates a new assessment for an application. If the application is tagged
+// with an archetype, the archetype's default answers are suggested for confirmation.
+func (s *AssessmentService) StartAssessment(ctx context.Context, applicationID string, opts StartOptions) (*models.Assessment, error) {
 	// Validate application exists
 	app, err := s.storage.GetApplication(ctx, applicationID)
 	if err != nil {
@@ -40,16 +189,83 @@ func (s *AssessmentService) StartAssessment(ctx context.Context, applicationID s
 		return nil, errors.New("application not found")
 	}
 	
+	if err := validateWeightOverrides(opts.WeightOverrides); err != nil {
+		return nil, err
+	}
+	
+	externalID, err := normalizeExternalID(opts.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	if externalID != "" {
+		unlock, err := lockExternalIDs(ctx, s.locker)
+		if err != nil {
+			return nil, err
+		}
+		defer unlock()
+		if err := s.checkAssessmentExternalID(ctx, externalID); err != nil {
+			return nil, err
+		}
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	categories, err := scopeCategories(opts.Categories, questions, app.Tags)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Spot checks only sample the categories in scope
+	var spotCheck *models.SpotCheck
+	if opts.SpotCheck != nil {
+		if spotCheck, err = sampleQuestions(opts.SpotCheck, applicationID, questionsInCategories(questions, categories), app.Tags); err != nil {
+			return nil, err
+		}
+	}
+	
+	suggestions, err := archetypeSuggestions(ctx, s.storage, app)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Create new assessment
+	now := time.Now()
 	assessment := &models.Assessment{
-		ID:            uuid.NewString(),
-		ApplicationID: applicationID,
-		CreatedAt:     time.Now().Format(time.RFC3339),
-		Answers:       make(map[string]string),
-		Status:        "in_progress",
+		ID:              uuid.NewString(),
+		ApplicationID:   applicationID,
+		ExternalID:      externalID,
+		Application:     app.Snapshot(),
+		CreatedAt:       now,
+		UpdatedAt:       now,
+		StartedAt:       now,
+		Answers:         make(map[string]string),
+		Status:          "in_progress",
+		StartedBy:       opts.StartedBy,
+		Context:         normalizeContext(opts.Context),
+		SpotCheck:       spotCheck,
+		Categories:      categories,
+		WeightOverrides: s.initialWeightOverrides(app, opts.WeightOverrides),
+		Suggestions:     suggestions,
+	}
+	
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	for questionID := range suggestions {
+		if question := byID[questionID]; question == nil || !inScope(assessment, question) {
+			delete(suggestions, questionID)
+		}
+	}
+	
+	// Record the start of the assessment's event log, then its current state
+	if err := s.storage.AppendEvent(ctx, startedEvent(assessment, opts.StartedBy)); err != nil {
+		return nil, fmt.Errorf("failed to record assessment start: %w", err)
 	}
 	
-	// Save assessment
 	if err := s.storage.CreateAssessment(ctx, assessment); err != nil {
 		return nil, fmt.Errorf("failed to create assessment: %w", err)
 	}
@@ -57,13 +273,52 @@ func (s *AssessmentService) StartAssessment(ctx context.Context, applicationID s
 	return assessment, nil
 }
 
+// normalizeContext trims the context of a new assessment, dropping blank participants, and
+// returns nil if nothing is left
+func normalizeContext(given *models.AssessmentContext) *models.AssessmentContext {
+	if given == nil {
+		return nil
+	}
+	
+	normalized := &models.AssessmentContext{
+		Scope:           strings.TrimSpace(given.Scope),
+		BusinessContext: strings.TrimSpace(given.BusinessContext),
+	}
+	for _, participant := range given.Participants {
+		if participant = strings.TrimSpace(participant); participant != "" {
+			normalized.Participants = append(normalized.Participants, participant)
+		}
+	}
+	
+	if normalized.Scope == "" && normalized.BusinessContext == "" && len(normalized.Participants) == 0 {
+		return nil
+	}
+	return normalized
+}
+
 // GetAssessment retrieves an assessment by ID
 func (s *AssessmentService) GetAssessment(ctx context.Context, id string) (*models.Assessment, error) {
 	return s.storage.GetAssessment(ctx, id)
 }
 
-// SaveAnswer records an answer for a specific question
-func (s *AssessmentService) SaveAnswer(ctx context.Context, assessmentID, questionID, optionID string) error {
+// SaveAnswer records an answer for a specific question and the user who gave it. explanation
+// is the free text required by options such as "Other" and must be empty for other options.
+// references link to evidence for the answer; questions requiring evidence need at least one
+// for options worth their minimum points.
+func (s *AssessmentService) SaveAnswer(ctx context.Context, assessmentID, questionID, optionID, explanation string, references []string, answeredBy string) error {
+	return s.saveAnswer(ctx, assessmentID, questionID, optionID, explanation, references, answeredBy, models.AnswerProvenance{Method: models.ProvenanceManual})
+}
+
+// saveAnswer records an answer like SaveAnswer along with how it was produced. Only manual
+// answers must come with their evidence; answers from other sources are checked for it when
+// the assessment is completed.
+func (s *AssessmentService) saveAnswer(ctx context.Context, assessmentID, questionID, optionID, explanation string, references []string, answeredBy string, provenance models.AnswerProvenance) error {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
 	// Get assessment
 	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
 	if err != nil {
@@ -74,6 +329,10 @@ func (s *AssessmentService) SaveAnswer(ctx context.Context, assessmentID, questi
 		return errors.New("assessment not found")
 	}
 	
+	if assessment.Status == "approved" {
+		return ErrAssessmentApproved
+	}
+	
 	// Validate question exists
 	question, err := s.storage.GetQuestion(ctx, questionID)
 	if err != nil {
@@ -84,24 +343,68 @@ func (s *AssessmentService) SaveAnswer(ctx context.Context, assessmentID, questi
 		return errors.New("question not found")
 	}
 	
+	tags, err := s.assessmentTags(ctx, assessment)
+	if err != nil {
+		return err
+	}
+	
+	if !question.AppliesTo(tags) {
+		return fmt.Errorf("%w: question %s does not apply to the application", ErrInvalidAnswer, questionID)
+	}
+	if !inScope(assessment, question) {
+		return fmt.Errorf("%w: question %s is not part of the assessment's categories or spot check", ErrInvalidAnswer, questionID)
+	}
+	
 	// Validate option exists
-	optionValid := false
-	for _, option := range question.Options {
-		if option.ID == optionID {
-			optionValid = true
-			break
+	selected := findOption(question.Options, optionID)
+	if selected == nil {
+		return errors.New("option not found for question")
+	}
+	
+	explanation = strings.TrimSpace(explanation)
+	if selected.RequiresExplanation && explanation == "" {
+		return fmt.Errorf("%w: option %s requires an explanation", ErrInvalidAnswer, optionID)
+	}
+	if !selected.RequiresExplanation && explanation != "" {
+		return fmt.Errorf("%w: option %s does not take an explanation", ErrInvalidAnswer, optionID)
+	}
+	if explanation != "" {
+		if problems := checkAnswerRules(question.AnswerRules, explanation); len(problems) > 0 {
+			return &AnswerValidationError{QuestionID: questionID, Problems: problems}
 		}
 	}
 	
-	if !optionValid {
-		return errors.New("option not found for question")
+	references, err = normalizeReferences(references)
+	if err != nil {
+		return err
 	}
+	if provenance.Method == models.ProvenanceManual && requiresEvidence(question, selected) && len(references) == 0 {
+		return fmt.Errorf("%w: option %s of question %s requires evidence: add at least one reference link", ErrInvalidAnswer, optionID, questionID)
+	}
+	
+	// Several assessors may answer the same question; the consensus rule decides which
+	// answer is scored
+	response := models.AnswerResponse{OptionID: optionID, Explanation: explanation, AnsweredAt: time.Now()}
+	consensus := s.answerConsensus(assessment, question, answeredBy, response, &provenance)
 	
-	// Save answer
-	assessment.Answers[questionID] = optionID
+	// Record the answer and store the state rebuilt from the event log
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:        models.EventAnswerSaved,
+		OccurredAt:  response.AnsweredAt,
+		User:        answeredBy,
+		QuestionID:  questionID,
+		OptionID:    optionID,
+		Explanation: explanation,
+		References:  references,
+		Provenance:  &provenance,
+		Consensus:   consensus,
+	})
+	if err != nil {
+		return err
+	}
 	
 	// Update assessment
-	if err := s.storage.UpdateAssessment(ctx, assessment); err != nil {
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
 		return fmt.Errorf("failed to update assessment: %w", err)
 	}
 	
@@ -110,6 +413,12 @@ func (s *AssessmentService) SaveAnswer(ctx context.Context, assessmentID, questi
 
 // CompleteAssessment marks an assessment as complete and generates a report
 func (s *AssessmentService) CompleteAssessment(ctx context.Context, assessmentID string) (*models.Report, error) {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
 	// Get assessment
 	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
 	if err != nil {
@@ -120,52 +429,297 @@ func (s *AssessmentService) CompleteAssessment(ctx context.Context, assessmentID
 		return nil, errors.New("assessment not found")
 	}
 	
+	// Another replica may have completed the assessment while we waited for the lock; approved
+	// assessments always keep their issued report
+	if assessment.Status == "completed" || assessment.Status == "approved" {
+		report, err := s.storage.GetReport(ctx, assessmentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		if report != nil {
+			s.MarkValidity(report)
+			if err := s.markTracking(ctx, report); err != nil {
+				return nil, err
+			}
+			return report, nil
+		}
+	}
+	
 	// Get all questions to calculate score
 	questions, err := s.storage.GetQuestions(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get questions: %w", err)
 	}
 	
+	// Answers to questions requiring evidence must link to it, whoever gave them
+	tags, err := s.assessmentTags(ctx, assessment)
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingEvidence(assessment, questions, tags); len(missing) > 0 {
+		return nil, fmt.Errorf("%w: the answers to %s need a reference link to evidence", ErrEvidenceMissing, strings.Join(missing, ", "))
+	}
+	
 	// Mark assessment as complete
-	assessment.Status = "completed"
+	assessment, err = s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type: models.EventAssessmentCompleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
 	if err := s.storage.UpdateAssessment(ctx, assessment); err != nil {
 		return nil, fmt.Errorf("failed to update assessment: %w", err)
 	}
 	
 	// Generate report
-	report, err := s.generateReport(ctx, assessment, questions)
+	report, err := s.generateReport(ctx, assessment, questions, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate report: %w", err)
 	}
 	
+	if s.signer != nil {
+		if err := s.signer.Sign(report); err != nil {
+			return nil, fmt.Errorf("failed to sign report: %w", err)
+		}
+	}
+	
 	// Save report
 	if err := s.storage.SaveReport(ctx, report); err != nil {
 		return nil, fmt.Errorf("failed to save report: %w", err)
 	}
+	s.refreshPortfolioSummary(ctx, report)
 	
+	if tags == nil {
+		tags = map[string]string{}
+	}
+	s.notify(ctx, models.EventTypeAssessmentCompleted, map[string]interface{}{
+		"assessmentId":     report.AssessmentID,
+		"applicationId":    report.ApplicationID,
+		"totalScore":       report.TotalScore,
+		"maxPossibleScore": report.MaxPossibleScore,
+		"scorePercent":     ReportPercent(report),
+		"grade":            report.Grade,
+		"tags":             tags, // the application's tags when the assessment started, for target filters
+	})
+	
+	// The report is saved already, so it is returned even without its tracking status
+	s.MarkValidity(report)
+	if err := s.markTracking(ctx, report); err != nil {
+		log.Printf("Failed to add tracking status to report %s: %v", report.AssessmentID, err)
+	}
 	return report, nil
 }
 
+// notify queues a notification if a notifier is configured. Failures are logged rather than
+// returned because the change being notified about has already been persisted.
+func (s *AssessmentService) notify(ctx context.Context, event string, payload map[string]interface{}) {
+	if s.notifier == nil {
+		return
+	}
+	
+	if err := s.notifier.Notify(ctx, event, payload); err != nil {
+		log.Printf("Failed to queue %s notification: %v", event, err)
+	}
+}
+
+// JobCompleteAssessment is the background job type completing an assessment and generating its report
+const JobCompleteAssessment = "complete-assessment"
+
+// CompleteAssessmentJob adapts CompleteAssessment to the job runner; params is the assessment ID
+func (s *AssessmentService) CompleteAssessmentJob(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var assessmentID string
+	if err := json.Unmarshal(params, &assessmentID); err != nil {
+		return nil, fmt.Errorf("invalid job parameters: %w", err)
+	}
+	return s.CompleteAssessment(ctx, assessmentID)
+}
+
 // GetReport retrieves a report by assessment ID
 func (s *AssessmentService) GetReport(ctx context.Context, assessmentID string) (*models.Report, error) {
-	return s.storage.GetReport(ctx, assessmentID)
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil || report == nil {
+		return report, err
+	}
+	
+	s.MarkValidity(report)
+	if err := s.markTracking(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// ReportStale reports whether a report generated at generatedAt is past its validity period
+func (s *AssessmentService) ReportStale(generatedAt time.Time) bool {
+	return s.reportValidity > 0 && time.Now().After(generatedAt.Add(s.reportValidity))
+}
+
+// MarkValidity sets when a report goes stale and whether it already is. Both are derived on
+// every read so a changed validity period applies to existing reports.
+func (s *AssessmentService) MarkValidity(report *models.Report) {
+	report.ValidUntil = nil
+	report.Stale = false
+	if s.reportValidity <= 0 {
+		return
+	}
+	
+	validUntil := report.GeneratedAt.Add(s.reportValidity)
+	report.ValidUntil = &validUntil
+	report.Stale = s.ReportStale(report.GeneratedAt)
 }
 
-// generateReport creates a suitability report based on assessment answers
-func (s *AssessmentService) generateReport(ctx context.Context, 
-                                          assessment *models.Assessment, 
-                                          questions []*models.Question) (*models.Report, error) {
+// ErrSigningDisabled is returned when verifying reports without a configured signer
+var ErrSigningDisabled = errors.New("report signing is not configured")
+
+// VerifyReport checks that a report carries a valid signature by this server
+func (s *AssessmentService) VerifyReport(report *models.Report) error {
+	if s.signer == nil {
+		return ErrSigningDisabled
+	}
+	return s.signer.Verify(report)
+}
+
+// ReportSigningKey returns the public key used to sign reports as a JWK, or nil if reports are not signed
+func (s *AssessmentService) ReportSigningKey() map[string]string {
+	if s.signer == nil {
+		return nil
+	}
+	return s.signer.PublicJWK()
+}
+
+// GetLatestReport returns the most recently generated report for an application
+func (s *AssessmentService) GetLatestReport(ctx context.Context, applicationID string) (*models.Report, error) {
+	assessments, err := s.storage.ListAssessments(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	var latest *models.Report
+	for _, assessment := range assessments {
+		if assessment.Status != "completed" && assessment.Status != "approved" {
+			continue
+		}
+		
+		report, err := s.storage.GetReport(ctx, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		
+		if report != nil && (latest == nil || report.GeneratedAt.After(latest.GeneratedAt)) {
+			latest = report
+		}
+	}
+	
+	if latest != nil {
+		s.MarkValidity(latest)
+	}
+	return latest, nil
+}
+
+// AddReportAnnotation attaches a reviewer annotation to a section of a report
+func (s *AssessmentService) AddReportAnnotation(ctx context.Context, assessmentID string, annotation *models.Annotation) (*models.Annotation, error) {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	
+	if report == nil {
+		return nil, fmt.Errorf("report %w", ErrNotFound)
+	}
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment != nil && assessment.Status == "approved" {
+		return nil, ErrAssessmentApproved
+	}
+	
+	if err := validateAnnotationTarget(report, annotation); err != nil {
+		return nil, err
+	}
+	
+	now := time.Now()
+	annotation.ID = uuid.NewString()
+	annotation.CreatedAt = now
+	report.Annotations = append(report.Annotations, *annotation)
+	report.UpdatedAt = &now
+	
+	if err := s.storage.SaveReport(ctx, report); err != nil {
+		return nil, fmt.Errorf("failed to save report: %w", err)
+	}
+	
+	return annotation, nil
+}
+
+// validateAnnotationTarget checks that an annotation refers to an existing report item
+func validateAnnotationTarget(report *models.Report, annotation *models.Annotation) error {
+	if annotation.Text == "" {
+		return errors.New("annotation text is required")
+	}
+	
+	switch annotation.Section {
+	case models.AnnotationSectionRisk, models.AnnotationSectionRecommendation:
+		count := len(report.Risks)
+		if annotation.Section == models.AnnotationSectionRecommendation {
+			count = len(report.Recommendations)
+		}
+		
+		index, err := strconv.Atoi(annotation.Target)
+		if err != nil || index < 0 || index >= count {
+			return fmt.Errorf("invalid %s index: %s", annotation.Section, annotation.Target)
+		}
+	case models.AnnotationSectionCategory:
+		if _, ok := report.CategoryScores[annotation.Target]; !ok {
+			return fmt.Errorf("category not found in report: %s", annotation.Target)
+		}
+	default:
+		return fmt.Errorf("unknown report section: %s", annotation.Section)
+	}
+	
+	return nil
+}
+
+// generateReport creates a suitability report based on assessment answers, classifying the
+// score with the given scoring configuration or, if nil, the current one
+func (s *AssessmentService) generateReport(ctx context.Context,
+	assessment *models.Assessment,
+	questions []*models.Question,
+	config *models.ScoringConfig) (*models.Report, error) {
 	// Initialize report
 	report := &models.Report{
-		AssessmentID:     assessment.ID,
-		ApplicationID:    assessment.ApplicationID,
-		GeneratedAt:      time.Now().Format(time.RFC3339),
-		CategoryScores:   make(map[string]int),
-		Recommendations:  []models.Recommendation{},
-		Risks:            []models.Risk{},
+		AssessmentID:      assessment.ID,
+		ApplicationID:     assessment.ApplicationID,
+		GeneratedAt:       time.Now(),
+		Context:           assessment.Context,
+		Application:       assessment.Application,
+		SpotCheck:         assessment.SpotCheck,
+		Categories:        assessment.Categories,
+		CategoryScores:    make(map[string]int),
+		Recommendations:   []models.Recommendation{},
+		Risks:             []models.Risk{},
 		ModernizationPlan: []models.ModernizationStep{},
 	}
 	
+	s.runReportHooks(ctx, ReportStageBefore, assessment, report)
+	
+	tags, err := s.assessmentTags(ctx, assessment)
+	if err != nil {
+		return nil, err
+	}
+	
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return nil, err
+	}
+	
 	// Calculate scores
 	totalScore := 0
 	maxScore := 0
@@ -173,34 +727,168 @@ func (s *AssessmentService) generateReport(ctx context.Context,
 	categoryMaxScores := make(map[string]int)
 	
 	for _, question := range questions {
+		// Questions hidden by their visibility conditions, not applying to the application's
+		// tags or left out of the assessment's categories or spot check are not scored
+		if !question.AppliesTo(tags) || !question.VisibleFor(assessment.Answers) || !inScope(assessment, question) {
+			report.Breakdown = append(report.Breakdown, models.QuestionScore{
+				QuestionID: question.ID,
+				Text:       question.Text,
+				Category:   question.Category,
+				MaxPoints:  maxOptionPoints(question.Options),
+				Weight:     question.Weight,
+				Hidden:     true,
+			})
+			continue
+		}
+		
 		optionID, answered := assessment.Answers[question.ID]
 		
+		// Apply assessment-specific weights, or else the category's weight, and document them in
+		// the report
+		weight := question.Weight
+		if override := effectiveWeightOverride(question, assessment.WeightOverrides); override != nil {
+			weight = override.Weight
+			report.AppliedWeights = append(report.AppliedWeights, models.AppliedWeight{
+				QuestionID:      question.ID,
+				Category:        question.Category,
+				DefaultWeight:   question.Weight,
+				EffectiveWeight: weight,
+				Reason:          override.Reason,
+				Source:          override.Source,
+			})
+		} else if multiplier := categories[question.Category].WeightMultiplier(); multiplier != 1 {
+			weight = question.Weight * multiplier
+			report.AppliedWeights = append(report.AppliedWeights, models.AppliedWeight{
+				QuestionID:      question.ID,
+				Category:        question.Category,
+				DefaultWeight:   question.Weight,
+				EffectiveWeight: weight,
+				Reason:          fmt.Sprintf("category weight %d", multiplier),
+				Source:          "category",
+			})
+		}
+		
+		breakdown := models.QuestionScore{
+			QuestionID: question.ID,
+			Text:       question.Text,
+			Category:   question.Category,
+			MaxPoints:  maxOptionPoints(question.Options),
+			Weight:     weight,
+		}
+		breakdown.MaxScore = weight * breakdown.MaxPoints
+		
 		// Add to max possible score
-		maxScore += question.Weight * maxOptionPoints(question.Options)
-		categoryMaxScores[question.Category] += question.Weight * maxOptionPoints(question.Options)
+		maxScore += breakdown.MaxScore
+		categoryMaxScores[question.Category] += breakdown.MaxScore
 		
+		matched := false
 		if answered {
 			// Find selected option
 			for _, option := range question.Options {
 				if option.ID == optionID {
-					score := option.Points * question.Weight
+					score := option.Points * weight
 					totalScore += score
 					categoryScores[question.Category] += score
+					matched = true
+					if score < 0 {
+						report.PenaltyScore += score
+					}
+					
+					breakdown.OptionID = option.ID
+					breakdown.OptionText = option.Text
+					breakdown.Explanation = assessment.Explanations[question.ID]
+					breakdown.References = assessment.References[question.ID]
+					breakdown.Points = option.Points
+					breakdown.Score = score
 					break
 				}
 			}
 		}
+		if matched {
+			method := models.ProvenanceUnrecorded
+			if provenance, ok := assessment.Provenance[question.ID]; ok {
+				method = provenance.Method
+				breakdown.Provenance = provenance.Method
+				breakdown.Unverified = !provenance.HumanVerified()
+			}
+			if report.AnswerProvenance == nil {
+				report.AnswerProvenance = make(map[string]int)
+			}
+			report.AnswerProvenance[method]++
+		}
+		report.Breakdown = append(report.Breakdown, breakdown)
+		
+		// Document questions whose share of the maximum rests on missing data
+		if !matched {
+			unanswered := models.UnansweredQuestion{
+				QuestionID: question.ID,
+				Text:       question.Text,
+				Category:   question.Category,
+				Weight:     weight,
+				MaxScore:   breakdown.MaxScore,
+				Reason:     models.UnansweredMissing,
+			}
+			if answered {
+				unanswered.Reason = models.UnansweredUnknownOption
+				unanswered.OptionID = optionID
+			}
+			report.Unanswered = append(report.Unanswered, unanswered)
+			report.UnansweredScore += unanswered.MaxScore
+		}
+		
+		if disagreement := answerDisagreement(question, optionID, assessment.Responses[question.ID], s.disagreementThreshold); disagreement != nil {
+			report.Disagreements = append(report.Disagreements, *disagreement)
+		}
 	}
 	
 	report.TotalScore = totalScore
 	report.MaxPossibleScore = maxScore
 	report.CategoryScores = categoryScores
 	
+	// Classify the score using the configured bands and grades
+	if config == nil {
+		if config, err = s.GetScoringConfig(ctx); err != nil {
+			return nil, err
+		}
+	}
+	
+	// Let the configured WASM modules adjust the scores before they are classified; they
+	// change the category scores in place
+	added, err := s.runScoringPlugins(ctx, config, assessment, tags, report, categoryMaxScores)
+	if err != nil {
+		return nil, err
+	}
+	totalScore = report.TotalScore
+	
+	ratio := scoreRatio(totalScore, maxScore)
+	if weighted, shares := weightCategories(config.CategoryShares, categoryScores, categoryMaxScores); weighted != nil {
+		ratio = *weighted
+		report.WeightedRatio = weighted
+		report.CategoryShares = shares
+	}
+	band := config.BandFor(ratio)
+	report.Band = band.Level
+	report.BandLabel = band.Label
+	report.Grade = config.GradeFor(ratio)
+	
 	// Add recommendations based on scores (simplified)
-	generateRecommendations(report, totalScore, maxScore, categoryScores, categoryMaxScores)
+	generateRecommendations(report, band.Level, categoryScores, categoryMaxScores)
+	applyCategoryGates(report, config, categoryScores, categoryMaxScores)
+	report.Recommendations = append(report.Recommendations, added.Recommendations...)
+	report.Risks = append(report.Risks, added.Risks...)
 	
 	// Add modernization plan
-	report.ModernizationPlan = createModernizationPlan(totalScore, maxScore)
+	report.ModernizationPlan = createModernizationPlan(band.Level)
+	
+	// Estimate the plan's effort and cost with the configured model
+	estimation, err := s.GetEstimationConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	estimatePlan(report, estimation)
+	
+	s.addNarrative(ctx, report, assessment, questions)
+	s.runReportHooks(ctx, ReportStageAfter, assessment, report)
 	
 	return report, nil
 }
@@ -217,11 +905,9 @@ func maxOptionPoints(options []models.Option) int {
 }
 
 // generateRecommendations adds recommendations and risks to the report
-func generateRecommendations(report *models.Report, totalScore, maxScore int, categoryScores, categoryMaxScores map[string]int) {
-	overallRatio := float64(totalScore) / float64(maxScore)
-	
+func generateRecommendations(report *models.Report, band string, categoryScores, categoryMaxScores map[string]int) {
 	// Overall recommendation
-	if overallRatio < 0.5 {
+	if band == models.BandLow {
 		report.Recommendations = append(report.Recommendations, models.Recommendation{
 			Category:    "General",
 			Description: "Application requires significant modifications for Kubernetes deployment",
@@ -233,7 +919,7 @@ func generateRecommendations(report *models.Report, totalScore, maxScore int, ca
 			Description: "Application architecture not suitable for containerization",
 			Severity:    "High",
 		})
-	} else if overallRatio < 0.7 {
+	} else if band == models.BandMedium {
 		report.Recommendations = append(report.Recommendations, models.Recommendation{
 			Category:    "General",
 			Description: "Application needs moderate changes to be suitable for Kubernetes",
@@ -285,9 +971,8 @@ func generateRecommendations(report *models.Report, totalScore, maxScore int, ca
 	}
 }
 
-// createModernizationPlan creates a step-by-step plan based on scores
-func createModernizationPlan(totalScore, maxScore int) []models.ModernizationStep {
-	ratio := float64(totalScore) / float64(maxScore)
+// createModernizationPlan creates a step-by-step plan based on the score band
+func createModernizationPlan(band string) []models.ModernizationStep {
 	plan := []models.ModernizationStep{}
 	
 	// Common steps for all applications
@@ -298,7 +983,7 @@ func createModernizationPlan(totalScore, maxScore int) []models.ModernizationSte
 	})
 	
 	// Add different steps based on score
-	if ratio < 0.5 {
+	if band == models.BandLow {
 		plan = append(plan, []models.ModernizationStep{
 			{
 				Order:       2,
@@ -316,7 +1001,7 @@ func createModernizationPlan(totalScore, maxScore int) []models.ModernizationSte
 				Effort:      "Medium",
 			},
 		}...)
-	} else if ratio < 0.7 {
+	} else if band == models.BandMedium {
 		plan = append(plan, []models.ModernizationStep{
 			{
 				Order:       2,
@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"questionnaire-app/internal/models"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// colorPattern matches the hex colors accepted for report branding
+var colorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// logoDataPrefixes are the data URIs accepted as logos, so a logo can be embedded in the report
+var logoDataPrefixes = []string{"data:image/png;base64,", "data:image/jpeg;base64,", "data:image/gif;base64,", "data:image/svg+xml;base64,", "data:image/webp;base64,"}
+
+// maxFooterLength limits the footer to a line or two of text
+const maxFooterLength = 500
+
+// GetBranding returns the branding applied to exported reports; empty if none was configured
+func (s *AssessmentService) GetBranding(ctx context.Context) (*models.Branding, error) {
+	branding, err := s.storage.GetBranding(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branding: %w", err)
+	}
+	
+	if branding == nil {
+		return &models.Branding{}, nil
+	}
+	return branding, nil
+}
+
+// UpdateBranding validates and stores the branding of exported reports
+func (s *AssessmentService) UpdateBranding(ctx context.Context, branding *models.Branding) (*models.Branding, error) {
+	branding.Name = strings.TrimSpace(branding.Name)
+	branding.Footer = strings.TrimSpace(branding.Footer)
+	if err := validateBranding(branding); err != nil {
+		return nil, err
+	}
+	
+	now := time.Now()
+	branding.UpdatedAt = &now
+	if err := s.storage.SaveBranding(ctx, branding); err != nil {
+		return nil, fmt.Errorf("failed to save branding: %w", err)
+	}
+	
+	return branding, nil
+}
+
+// validateBranding checks colors and the logo source, which end up in the report's CSS and markup
+func validateBranding(branding *models.Branding) error {
+	for name, color := range map[string]string{"primaryColor": branding.PrimaryColor, "accentColor": branding.AccentColor} {
+		if color != "" && !colorPattern.MatchString(color) {
+			return fmt.Errorf("%s must be a hex color such as #1a73e8", name)
+		}
+	}
+	
+	if branding.LogoURL != "" && !validLogoURL(branding.LogoURL) {
+		return errors.New("logoUrl must be an https URL or a base64 data:image URI")
+	}
+	
+	if len(branding.Footer) > maxFooterLength {
+		return fmt.Errorf("footer must not exceed %d characters", maxFooterLength)
+	}
+	
+	return nil
+}
+
+// validLogoURL reports whether a logo is an absolute https URL or an embedded image
+func validLogoURL(logo string) bool {
+	for _, prefix := range logoDataPrefixes {
+		if strings.HasPrefix(logo, prefix) {
+			return true
+		}
+	}
+	
+	parsed, err := url.Parse(logo)
+	return err == nil && parsed.Scheme == "https" && parsed.Host != ""
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"questionnaire-app/internal/models"
+	"reflect"
+	"time"
+)
+
+// CampaignUpdates compares two progress reports of a campaign and returns the updates live
+// dashboards need to get from the first to the second: completed assessments, changed scores
+// and, after any change, the new progress
+func CampaignUpdates(previous, current *models.CampaignProgress) []models.CampaignUpdate {
+	now := time.Now()
+	update := func(kind string) models.CampaignUpdate {
+		return models.CampaignUpdate{Type: kind, CampaignID: current.CampaignID, OccurredAt: now}
+	}
+	
+	before := make(map[string]models.CampaignEntry, len(previous.Entries))
+	for _, entry := range previous.Entries {
+		before[entry.ApplicationID] = entry
+	}
+	
+	var updates []models.CampaignUpdate
+	for i := range current.Entries {
+		entry := current.Entries[i]
+		prev, ok := before[entry.ApplicationID]
+		if !ok || !campaignEntryDone(entry) {
+			continue
+		}
+		
+		switch {
+		case !campaignEntryDone(prev) || prev.AssessmentID != entry.AssessmentID:
+			completed := update(models.CampaignUpdateCompleted)
+			completed.Entry, completed.Previous = &entry, &prev
+			updates = append(updates, completed)
+		case !sameScore(prev.ScorePercent, entry.ScorePercent) || prev.Grade != entry.Grade:
+			scored := update(models.CampaignUpdateScore)
+			scored.Entry, scored.Previous = &entry, &prev
+			updates = append(updates, scored)
+		}
+	}
+	
+	if len(updates) > 0 || !reflect.DeepEqual(previous.Entries, current.Entries) || previous.Name != current.Name || previous.DueDate != current.DueDate {
+		progress := update(models.CampaignUpdateProgress)
+		progress.Progress = current
+		updates = append(updates, progress)
+	}
+	return updates
+}
+
+// campaignEntryDone reports whether an application's latest assessment is completed
+func campaignEntryDone(entry models.CampaignEntry) bool {
+	return entry.Status == "completed" || entry.Status == "approved"
+}
+
+// sameScore compares two optional scores
+func sameScore(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"time"
+	
+	"github.com/google/uuid"
+)
+
+// Campaign entry statuses in addition to the assessment statuses
+const (
+	CampaignStatusNotStarted = "not_started"
+	CampaignStatusMissing    = "missing" // the application was deleted
+)
+
+// CampaignService tracks migration-readiness programs across applications
+type CampaignService struct {
+	storage storage.Storage
+}
+
+// NewCampaignService creates a new campaign service
+func NewCampaignService(storage storage.Storage) *CampaignService {
+	return &CampaignService{storage: storage}
+}
+
+// CreateCampaign validates and stores a new campaign
+func (s *CampaignService) CreateCampaign(ctx context.Context, campaign *models.Campaign) (*models.Campaign, error) {
+	if campaign.Name == "" {
+		return nil, errors.New("campaign name is required")
+	}
+	
+	if campaign.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", campaign.DueDate); err != nil {
+			return nil, errors.New("due date must be formatted as YYYY-MM-DD")
+		}
+	}
+	
+	for _, applicationID := range campaign.ApplicationIDs {
+		app, err := s.storage.GetApplication(ctx, applicationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application: %w", err)
+		}
+		if app == nil {
+			return nil, fmt.Errorf("application %s not found", applicationID)
+		}
+	}
+	
+	if campaign.ApplicationIDs == nil {
+		campaign.ApplicationIDs = []string{}
+	}
+	campaign.ID = uuid.NewString()
+	campaign.CreatedAt = time.Now()
+	
+	if err := s.storage.SaveCampaign(ctx, campaign); err != nil {
+		return nil, fmt.Errorf("failed to save campaign: %w", err)
+	}
+	
+	return campaign, nil
+}
+
+// ListCampaigns returns all campaigns ordered by name
+func (s *CampaignService) ListCampaigns(ctx context.Context) ([]*models.Campaign, error) {
+	campaigns, err := s.storage.ListCampaigns(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	if campaigns == nil {
+		campaigns = []*models.Campaign{}
+	}
+	sort.Slice(campaigns, func(i, j int) bool { return campaigns[i].Name < campaigns[j].Name })
+	return campaigns, nil
+}
+
+// GetCampaign retrieves a campaign by ID
+func (s *CampaignService) GetCampaign(ctx context.Context, id string) (*models.Campaign, error) {
+	return s.storage.GetCampaign(ctx, id)
+}
+
+// GetProgress reports the status of the latest assessment of each application in a campaign
+func (s *CampaignService) GetProgress(ctx context.Context, campaignID string) (*models.CampaignProgress, error) {
+	campaign, err := s.storage.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get campaign: %w", err)
+	}
+	
+	if campaign == nil {
+		return nil, fmt.Errorf("campaign %w", ErrNotFound)
+	}
+	
+	config, err := s.storage.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring config: %w", err)
+	}
+	if config == nil {
+		config = DefaultScoringConfig()
+	}
+	
+	now := time.Now()
+	overdue := false
+	if campaign.DueDate != "" {
+		if due, err := time.Parse("2006-01-02", campaign.DueDate); err == nil {
+			overdue = now.After(due.AddDate(0, 0, 1))
+		}
+	}
+	
+	progress := &models.CampaignProgress{
+		CampaignID:  campaign.ID,
+		Name:        campaign.Name,
+		DueDate:     campaign.DueDate,
+		GeneratedAt: now,
+		ByStatus:    make(map[string]int),
+		Entries:     []models.CampaignEntry{},
+	}
+	
+	done := 0
+	for _, applicationID := range campaign.ApplicationIDs {
+		entry, err := s.campaignEntry(ctx, campaign, applicationID, config)
+		if err != nil {
+			return nil, err
+		}
+		
+		isDone := entry.Status == "completed" || entry.Status == "approved"
+		if isDone {
+			done++
+		}
+		entry.Overdue = overdue && !isDone && entry.Status != CampaignStatusMissing
+		if entry.Overdue {
+			progress.Overdue++
+		}
+		
+		progress.ByStatus[entry.Status]++
+		progress.Entries = append(progress.Entries, *entry)
+	}
+	
+	progress.Total = len(progress.Entries)
+	if progress.Total > 0 {
+		progress.PercentDone = done * 100 / progress.Total
+	}
+	
+	return progress, nil
+}
+
+// campaignEntry builds the progress entry of an application from its latest assessment
+func (s *CampaignService) campaignEntry(ctx context.Context, campaign *models.Campaign, applicationID string, config *models.ScoringConfig) (*models.CampaignEntry, error) {
+	entry := &models.CampaignEntry{
+		ApplicationID: applicationID,
+		Owner:         campaign.Owner,
+		DueDate:       campaign.DueDate,
+		Status:        CampaignStatusNotStarted,
+	}
+	
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	
+	if app == nil {
+		entry.Status = CampaignStatusMissing
+		return entry, nil
+	}
+	
+	entry.ApplicationName = app.Name
+	if owner := app.Tags["owner"]; owner != "" {
+		entry.Owner = owner
+	}
+	
+	assessments, err := s.storage.ListAssessments(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	var latest *models.Assessment
+	for _, assessment := range assessments {
+		if latest == nil || assessment.CreatedAt.After(latest.CreatedAt) {
+			latest = assessment
+		}
+	}
+	
+	if latest == nil {
+		return entry, nil
+	}
+	
+	entry.AssessmentID = latest.ID
+	entry.Status = latest.Status
+	
+	if latest.Status == "completed" || latest.Status == "approved" {
+		report, err := s.storage.GetReport(ctx, latest.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		
+		if report != nil && report.MaxPossibleScore > 0 {
+			percent := ReportPercent(report)
+			entry.ScorePercent = &percent
+			entry.Grade = report.Grade
+			if entry.Grade == "" {
+				entry.Grade = config.GradeFor(ReportRatio(report))
+			}
+		}
+	}
+	
+	return entry, nil
+}
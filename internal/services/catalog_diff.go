@@ -0,0 +1,347 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// ErrInvalidCatalogDiff is returned when a catalog diff request lacks its target catalog
+var ErrInvalidCatalogDiff = errors.New("invalid catalog diff")
+
+// ErrUnknownCatalog is returned when a catalog source names a built-in catalog that does not exist
+var ErrUnknownCatalog = errors.New("unknown catalog")
+
+// DiffCatalog compares two catalog versions and estimates how existing reports would score
+// under the target catalog
+func (s *AssessmentService) DiffCatalog(ctx context.Context, request models.CatalogDiffRequest) (*models.CatalogDiff, error) {
+	if request.Target.Catalog == "" && len(request.Target.Questions) == 0 {
+		return nil, fmt.Errorf("%w: target catalog is required", ErrInvalidCatalogDiff)
+	}
+	
+	base, err := s.catalogQuestions(ctx, request.Base)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.catalogQuestions(ctx, request.Target)
+	if err != nil {
+		return nil, err
+	}
+	
+	reports, err := s.storage.ListReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	scoring, err := s.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	diff := DiffCatalogs(base, target)
+	diff.Impact = EstimateCatalogImpact(reports, target, scoring)
+	return diff, nil
+}
+
+// catalogQuestions resolves a catalog source to its questions
+func (s *AssessmentService) catalogQuestions(ctx context.Context, source models.CatalogSource) ([]*models.Question, error) {
+	switch {
+	case source.Catalog != "":
+		catalog, err := BuiltinCatalog(source.Catalog)
+		if err != nil {
+			return nil, err
+		}
+		if catalog == nil {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownCatalog, source.Catalog)
+		}
+		return catalog.Questions, nil
+	case len(source.Questions) > 0:
+		return source.Questions, nil
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	return questions, nil
+}
+
+// LoadCatalog reads the questions of a catalog version for comparison: the catalog files at a
+// path, or else the built-in catalog with that ID
+func LoadCatalog(source string) ([]*models.Question, error) {
+	if _, err := os.Stat(source); err != nil {
+		catalog, builtinErr := BuiltinCatalog(source)
+		if builtinErr != nil {
+			return nil, builtinErr
+		}
+		if catalog == nil {
+			return nil, fmt.Errorf("%s is neither a catalog file or directory nor a built-in catalog", source)
+		}
+		return catalog.Questions, nil
+	}
+	
+	files, err := catalogFiles([]string{source})
+	if err != nil {
+		return nil, err
+	}
+	
+	var questions []*models.Question
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		parsed, err := parseQuestionFile(file, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		questions = append(questions, parsed...)
+	}
+	return questions, nil
+}
+
+// DiffCatalogs lists the questions added to, removed from and changed in target compared to
+// base, each sorted by question ID
+func DiffCatalogs(base, target []*models.Question) *models.CatalogDiff {
+	diff := &models.CatalogDiff{
+		Added:   []models.CatalogQuestionRef{},
+		Removed: []models.CatalogQuestionRef{},
+		Changed: []models.QuestionDiff{},
+	}
+	
+	baseByID := questionsByID(base)
+	targetByID := questionsByID(target)
+	for _, id := range sortedQuestionIDs(targetByID) {
+		question := targetByID[id]
+		previous, ok := baseByID[id]
+		if !ok {
+			diff.Added = append(diff.Added, questionRef(question))
+			continue
+		}
+		if changes, changed := diffQuestion(previous, question); changed {
+			diff.Changed = append(diff.Changed, changes)
+		}
+	}
+	for _, id := range sortedQuestionIDs(baseByID) {
+		if _, ok := targetByID[id]; !ok {
+			diff.Removed = append(diff.Removed, questionRef(baseByID[id]))
+		}
+	}
+	return diff
+}
+
+// diffQuestion compares two versions of a question and reports whether anything changed
+func diffQuestion(base, target *models.Question) (models.QuestionDiff, bool) {
+	diff := models.QuestionDiff{QuestionID: target.ID, Category: target.Category}
+	
+	fields := []struct {
+		name          string
+		before, after interface{}
+	}{
+		{"text", base.Text, target.Text},
+		{"category", base.Category, target.Category},
+		{"help", base.Help, target.Help},
+		{"visibleWhen", base.VisibleWhen, target.VisibleWhen},
+		{"appliesWhen", base.AppliesWhen, target.AppliesWhen},
+		{"answerRules", base.AnswerRules, target.AnswerRules},
+		{"evidence", base.Evidence, target.Evidence},
+		{"translations", base.Translations, target.Translations},
+	}
+	for _, field := range fields {
+		if !sameJSON(field.before, field.after) {
+			diff.Fields = append(diff.Fields, field.name)
+		}
+	}
+	
+	if base.Weight != target.Weight {
+		diff.Weight = &models.IntChange{From: base.Weight, To: target.Weight}
+	}
+	
+	baseOptions := make(map[string]models.Option, len(base.Options))
+	for _, option := range base.Options {
+		baseOptions[option.ID] = option
+	}
+	targetOptions := make(map[string]bool, len(target.Options))
+	for _, option := range target.Options {
+		targetOptions[option.ID] = true
+		previous, ok := baseOptions[option.ID]
+		if !ok {
+			diff.AddedOptions = append(diff.AddedOptions, option.ID)
+			continue
+		}
+		if previous.Text != option.Text {
+			diff.RenamedOptions = append(diff.RenamedOptions, models.OptionRename{OptionID: option.ID, From: previous.Text, To: option.Text})
+		}
+		if previous.Points != option.Points {
+			diff.Points = append(diff.Points, models.OptionPoints{OptionID: option.ID, From: previous.Points, To: option.Points})
+		}
+		if previous.RequiresExplanation != option.RequiresExplanation && !containsString(diff.Fields, "options") {
+			diff.Fields = append(diff.Fields, "options")
+		}
+	}
+	for _, option := range base.Options {
+		if !targetOptions[option.ID] {
+			diff.RemovedOptions = append(diff.RemovedOptions, option.ID)
+		}
+	}
+	
+	changed := len(diff.Fields) > 0 || diff.Weight != nil || len(diff.AddedOptions) > 0 ||
+		len(diff.RemovedOptions) > 0 || len(diff.RenamedOptions) > 0 || len(diff.Points) > 0
+	return diff, changed
+}
+
+// EstimateCatalogImpact re-scores the breakdown of each report with the target catalog's
+// weights and option points. Weights overridden in a report are kept, answers whose question
+// or option is gone score nothing, removed questions drop out of the maximum and new questions
+// count as unanswered where they apply. Category shares and gates are not re-applied, so the
+// estimate compares plain score percentages.
+func EstimateCatalogImpact(reports []*models.Report, target []*models.Question, scoring *models.ScoringConfig) *models.CatalogImpact {
+	impact := &models.CatalogImpact{Assessments: []models.ReportImpact{}}
+	targetByID := questionsByID(target)
+	
+	totalDelta := 0
+	for _, report := range reports {
+		if len(report.Breakdown) == 0 {
+			impact.Skipped++
+			continue
+		}
+		impact.Reports++
+		
+		estimate := rescoreReport(report, target, targetByID)
+		estimate.Grade = report.Grade
+		if scoring != nil {
+			estimate.NewGrade = scoring.GradeFor(scoreRatio(estimate.NewTotalScore, estimate.NewMaxScore))
+		}
+		totalDelta += estimate.Delta
+		if estimate.Delta != 0 {
+			impact.Changed++
+			impact.Assessments = append(impact.Assessments, estimate)
+		}
+		if estimate.Grade != "" && estimate.NewGrade != "" && estimate.Grade != estimate.NewGrade {
+			impact.GradeChanges++
+		}
+	}
+	
+	if impact.Reports > 0 {
+		impact.AverageDelta = math.Round(float64(totalDelta)/float64(impact.Reports)*10) / 10
+	}
+	sort.SliceStable(impact.Assessments, func(i, j int) bool {
+		a, b := impact.Assessments[i], impact.Assessments[j]
+		if abs(a.Delta) != abs(b.Delta) {
+			return abs(a.Delta) > abs(b.Delta)
+		}
+		return a.AssessmentID < b.AssessmentID
+	})
+	return impact
+}
+
+// rescoreReport estimates a report's score under the target catalog
+func rescoreReport(report *models.Report, target []*models.Question, targetByID map[string]*models.Question) models.ReportImpact {
+	overridden := make(map[string]bool, len(report.AppliedWeights))
+	for _, applied := range report.AppliedWeights {
+		overridden[applied.QuestionID] = true
+	}
+	
+	total, max := 0, 0
+	inBreakdown := make(map[string]bool, len(report.Breakdown))
+	for _, entry := range report.Breakdown {
+		inBreakdown[entry.QuestionID] = true
+		question := targetByID[entry.QuestionID]
+		if entry.Hidden || question == nil {
+			continue
+		}
+		
+		weight := question.Weight
+		if overridden[entry.QuestionID] {
+			weight = entry.Weight
+		}
+		max += weight * maxOptionPoints(question.Options)
+		if entry.OptionID == "" {
+			continue
+		}
+		for _, option := range question.Options {
+			if option.ID == entry.OptionID {
+				total += weight * option.Points
+				break
+			}
+		}
+	}
+	
+	// New questions apply unless the application's recorded tags or the report's categories
+	// rule them out; spot checks never asked them
+	var tags map[string]string
+	if report.Application != nil {
+		tags = report.Application.Tags
+	}
+	for _, question := range target {
+		if inBreakdown[question.ID] || report.SpotCheck != nil {
+			continue
+		}
+		if len(report.Categories) > 0 && !containsString(report.Categories, question.Category) {
+			continue
+		}
+		if report.Application != nil && !question.AppliesTo(tags) {
+			continue
+		}
+		max += question.Weight * maxOptionPoints(question.Options)
+	}
+	
+	estimate := models.ReportImpact{
+		AssessmentID:    report.AssessmentID,
+		ApplicationID:   report.ApplicationID,
+		ScorePercent:    ScorePercent(report.TotalScore, report.MaxPossibleScore),
+		NewTotalScore:   total,
+		NewMaxScore:     max,
+		NewScorePercent: ScorePercent(total, max),
+	}
+	estimate.Delta = estimate.NewScorePercent - estimate.ScorePercent
+	return estimate
+}
+
+// questionsByID indexes questions by ID
+func questionsByID(questions []*models.Question) map[string]*models.Question {
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	return byID
+}
+
+// sortedQuestionIDs returns the IDs of an index in order
+func sortedQuestionIDs(byID map[string]*models.Question) []string {
+	ids := make([]string, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// questionRef summarizes a question for a diff
+func questionRef(question *models.Question) models.CatalogQuestionRef {
+	return models.CatalogQuestionRef{
+		QuestionID: question.ID,
+		Category:   question.Category,
+		Text:       question.Text,
+		Weight:     question.Weight,
+	}
+}
+
+// sameJSON reports whether two values encode to the same JSON
+func sameJSON(a, b interface{}) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(encodedA) == string(encodedB)
+}
+
+// abs returns the absolute value of n
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
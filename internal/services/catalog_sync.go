@@ -0,0 +1,491 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned when publishing, pushing and receiving catalog releases
+var (
+	ErrCatalogSyncDisabled     = errors.New("catalog sync is not enabled")
+	ErrCatalogSyncUnauthorized = errors.New("invalid catalog sync token")
+	
+	// ErrInvalidReleaseVersion is returned for release versions that are not allowed
+	ErrInvalidReleaseVersion = errors.New("invalid release version")
+	
+	// ErrInvalidCatalogRelease is returned when a release's catalog fails validation or does
+	// not match its checksum
+	ErrInvalidCatalogRelease = errors.New("invalid catalog release")
+)
+
+// maxCatalogSyncResponseSize limits the size of a downstream's response to a push
+const maxCatalogSyncResponseSize = 1 << 20
+
+// releaseVersionPattern restricts release versions to names like 2026.1 or v1.2.0-rc1
+var releaseVersionPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// CatalogSyncConfig configures publishing catalog releases to and receiving them from other
+// instances
+type CatalogSyncConfig struct {
+	Instance         string                     // name of this instance, recorded as the source of its releases
+	Token            func() string              // token an authoring instance must present; receiving releases is disabled if empty
+	Downstreams      []models.CatalogDownstream // instances to push releases to
+	DownstreamTokens map[string]func() string   // downstream name -> token, overriding the token of the downstreams file
+	Timeout          time.Duration              // of each request to a downstream
+	HTTPClient       *http.Client
+}
+
+// CatalogSyncService publishes versions of the question catalog as releases and pushes them
+// from a central authoring instance to downstream instances, each pinned to a release or
+// following the latest one.
+type CatalogSyncService struct {
+	storage   storage.Storage
+	questions *QuestionService
+	config    CatalogSyncConfig
+	
+	mu sync.Mutex // serializes receiving releases
+}
+
+// NewCatalogSyncService creates a new catalog sync service
+func NewCatalogSyncService(storage storage.Storage, questions *QuestionService, config CatalogSyncConfig) *CatalogSyncService {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &CatalogSyncService{
+		storage:   storage,
+		questions: questions,
+		config:    config,
+	}
+}
+
+// LoadCatalogDownstreams reads the instances to push catalog releases to from a JSON file
+func LoadCatalogDownstreams(path string) ([]models.CatalogDownstream, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog downstreams: %w", err)
+	}
+	
+	var downstreams []models.CatalogDownstream
+	if err := json.Unmarshal(data, &downstreams); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal catalog downstreams: %w", err)
+	}
+	
+	seen := make(map[string]bool, len(downstreams))
+	for i := range downstreams {
+		downstream := &downstreams[i]
+		downstream.Name = strings.TrimSpace(downstream.Name)
+		if downstream.Name == "" {
+			return nil, fmt.Errorf("catalog downstream %d requires a name", i)
+		}
+		if seen[downstream.Name] {
+			return nil, fmt.Errorf("duplicate catalog downstream %q", downstream.Name)
+		}
+		seen[downstream.Name] = true
+		
+		parsed, err := url.Parse(downstream.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("catalog downstream %q requires an http(s) url", downstream.Name)
+		}
+		downstream.URL = strings.TrimRight(downstream.URL, "/")
+		if downstream.Version != "" && !releaseVersionPattern.MatchString(downstream.Version) {
+			return nil, fmt.Errorf("catalog downstream %q is pinned to invalid version %q", downstream.Name, downstream.Version)
+		}
+	}
+	
+	return downstreams, nil
+}
+
+// PublishRelease saves the current question catalog and its categories as a release. The
+// catalog must pass validation, and a version can only be published once.
+func (s *CatalogSyncService) PublishRelease(ctx context.Context, version, notes, publishedBy string) (*models.CatalogRelease, error) {
+	if !releaseVersionPattern.MatchString(version) {
+		return nil, fmt.Errorf("%w %q: use letters, digits, dots, dashes and underscores", ErrInvalidReleaseVersion, version)
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	existing, err := s.storage.GetCatalogRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog release: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("%w: release %s already exists", ErrConflict, version)
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("%w: the catalog has no questions", ErrInvalidCatalogRelease)
+	}
+	if validation := ValidateCatalog(questions); !validation.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCatalogRelease, validationErrors(validation))
+	}
+	categories, err := s.storage.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	sort.Slice(questions, func(i, j int) bool { return questions[i].ID < questions[j].ID })
+	sort.Slice(categories, func(i, j int) bool { return categories[i].Name < categories[j].Name })
+	
+	checksum, err := releaseChecksum(questions, categories)
+	if err != nil {
+		return nil, err
+	}
+	release := &models.CatalogRelease{
+		Version:       version,
+		Notes:         strings.TrimSpace(notes),
+		Source:        s.config.Instance,
+		PublishedBy:   publishedBy,
+		PublishedAt:   time.Now().UTC(),
+		Checksum:      checksum,
+		QuestionCount: len(questions),
+		Questions:     questions,
+		Categories:    categories,
+	}
+	if err := s.storage.SaveCatalogRelease(ctx, release); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// ListReleases returns the published and received releases without their questions, newest
+// first
+func (s *CatalogSyncService) ListReleases(ctx context.Context) ([]*models.CatalogRelease, error) {
+	releases, err := s.storage.ListCatalogReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog releases: %w", err)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return releases[i].PublishedAt.After(releases[j].PublishedAt)
+	})
+	for _, release := range releases {
+		release.Questions = nil
+		release.Categories = nil
+	}
+	return releases, nil
+}
+
+// GetRelease returns a release with its questions and categories
+func (s *CatalogSyncService) GetRelease(ctx context.Context, version string) (*models.CatalogRelease, error) {
+	if !releaseVersionPattern.MatchString(version) {
+		return nil, fmt.Errorf("%w: release %s", ErrNotFound, version)
+	}
+	release, err := s.storage.GetCatalogRelease(ctx, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog release: %w", err)
+	}
+	if release == nil {
+		return nil, fmt.Errorf("%w: release %s", ErrNotFound, version)
+	}
+	return release, nil
+}
+
+// Authorize checks the token an authoring instance presented to push a release
+func (s *CatalogSyncService) Authorize(token string) error {
+	expected := ""
+	if s.config.Token != nil {
+		expected = s.config.Token()
+	}
+	if expected == "" {
+		return ErrCatalogSyncDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return ErrCatalogSyncUnauthorized
+	}
+	return nil
+}
+
+// State returns the release this instance received last
+func (s *CatalogSyncService) State(ctx context.Context) (*models.CatalogSyncState, error) {
+	releases, err := s.storage.ListCatalogReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog releases: %w", err)
+	}
+	
+	var current *models.CatalogRelease
+	for _, release := range releases {
+		if release.ReceivedAt == nil {
+			continue
+		}
+		if current == nil || release.ReceivedAt.After(*current.ReceivedAt) {
+			current = release
+		}
+	}
+	if current == nil {
+		return &models.CatalogSyncState{}, nil
+	}
+	return &models.CatalogSyncState{
+		Version:    current.Version,
+		Checksum:   current.Checksum,
+		Source:     current.Source,
+		ReceivedAt: current.ReceivedAt,
+	}, nil
+}
+
+// ReceiveRelease installs a release pushed by an authoring instance. The release's questions
+// replace local ones with the same IDs and its categories are saved; questions that are not
+// part of the release are kept. A version received before must have the same checksum, so
+// a republished version cannot silently change downstream catalogs.
+func (s *CatalogSyncService) ReceiveRelease(ctx context.Context, release *models.CatalogRelease) (*models.CatalogInstallResult, error) {
+	if !releaseVersionPattern.MatchString(release.Version) {
+		return nil, fmt.Errorf("%w %q", ErrInvalidReleaseVersion, release.Version)
+	}
+	if len(release.Questions) == 0 {
+		return nil, fmt.Errorf("%w: release %s has no questions", ErrInvalidCatalogRelease, release.Version)
+	}
+	for _, question := range release.Questions {
+		if question == nil {
+			return nil, fmt.Errorf("%w: release %s has an empty question", ErrInvalidCatalogRelease, release.Version)
+		}
+		// Question IDs name files, so they are checked before anything of the release is saved
+		if !questionIDPattern.MatchString(question.ID) {
+			return nil, fmt.Errorf("%w: release %s has invalid question ID %q", ErrInvalidCatalogRelease, release.Version, question.ID)
+		}
+	}
+	for _, category := range release.Categories {
+		if category == nil || category.Name == "" {
+			return nil, fmt.Errorf("%w: release %s has a category without a name", ErrInvalidCatalogRelease, release.Version)
+		}
+	}
+	checksum, err := releaseChecksum(release.Questions, release.Categories)
+	if err != nil {
+		return nil, err
+	}
+	if checksum != release.Checksum {
+		return nil, fmt.Errorf("%w: checksum mismatch, expected %s, got %s", ErrInvalidCatalogRelease, release.Checksum, checksum)
+	}
+	if validation := ValidateCatalog(release.Questions); !validation.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCatalogRelease, validationErrors(validation))
+	}
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	existing, err := s.storage.GetCatalogRelease(ctx, release.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get catalog release: %w", err)
+	}
+	if existing != nil && existing.Checksum != release.Checksum {
+		return nil, fmt.Errorf("%w: release %s was received before with checksum %s", ErrConflict, release.Version, existing.Checksum)
+	}
+	
+	// Questions go first, so a read-only catalog fails before anything is saved; the release's
+	// categories then replace the placeholders added for categories that were missing
+	result := &models.CatalogInstallResult{CatalogID: release.Version, Checksum: release.Checksum}
+	if err := s.questions.installQuestions(ctx, result, release.Questions, true); err != nil {
+		return nil, err
+	}
+	for _, category := range release.Categories {
+		if err := s.storage.SaveCategory(ctx, category); err != nil {
+			return nil, fmt.Errorf("failed to save category: %w", err)
+		}
+	}
+	
+	release.QuestionCount = len(release.Questions)
+	receivedAt := time.Now().UTC()
+	release.ReceivedAt = &receivedAt
+	if err := s.storage.SaveCatalogRelease(ctx, release); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Downstreams compares the release each downstream has with the release it is pinned to
+func (s *CatalogSyncService) Downstreams(ctx context.Context) ([]models.CatalogDownstreamStatus, error) {
+	targets, err := s.releaseTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	statuses := make([]models.CatalogDownstreamStatus, len(s.config.Downstreams))
+	var wg sync.WaitGroup
+	for i, downstream := range s.config.Downstreams {
+		wg.Add(1)
+		go func(i int, downstream models.CatalogDownstream) {
+			defer wg.Done()
+			status, target := s.downstreamStatus(downstream, targets)
+			var state models.CatalogSyncState
+			if err := s.request(ctx, downstream, http.MethodGet, nil, &state); err != nil {
+				status.Status = models.CatalogUnavailable
+				status.Error = err.Error()
+			} else {
+				status.Current = &state
+				status.Status = models.CatalogOutOfSync
+				if target != nil && state.Version == target.Version && state.Checksum == target.Checksum {
+					status.Status = models.CatalogInSync
+				}
+			}
+			statuses[i] = status
+		}(i, downstream)
+	}
+	wg.Wait()
+	return statuses, nil
+}
+
+// Sync pushes its pinned release, or the latest release, to each downstream, or only to the
+// named one. Downstreams are pushed to in parallel; a failed push does not stop the others.
+func (s *CatalogSyncService) Sync(ctx context.Context, name string) ([]models.CatalogDownstreamStatus, error) {
+	downstreams := s.config.Downstreams
+	if name != "" {
+		downstreams = nil
+		for _, downstream := range s.config.Downstreams {
+			if downstream.Name == name {
+				downstreams = append(downstreams, downstream)
+			}
+		}
+		if len(downstreams) == 0 {
+			return nil, fmt.Errorf("%w: catalog downstream %q", ErrNotFound, name)
+		}
+	}
+	targets, err := s.releaseTargets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	statuses := make([]models.CatalogDownstreamStatus, len(downstreams))
+	var wg sync.WaitGroup
+	for i, downstream := range downstreams {
+		wg.Add(1)
+		go func(i int, downstream models.CatalogDownstream) {
+			defer wg.Done()
+			status, target := s.downstreamStatus(downstream, targets)
+			if target == nil {
+				status.Status = models.CatalogSyncFailed
+				statuses[i] = status
+				return
+			}
+			
+			body, err := json.Marshal(target)
+			if err == nil {
+				var install models.CatalogInstallResult
+				if err = s.request(ctx, downstream, http.MethodPost, body, &install); err == nil {
+					status.Status = models.CatalogSynced
+					status.Install = &install
+				}
+			}
+			if err != nil {
+				status.Status = models.CatalogSyncFailed
+				status.Error = err.Error()
+			}
+			statuses[i] = status
+		}(i, downstream)
+	}
+	wg.Wait()
+	return statuses, nil
+}
+
+// releaseTargets indexes the releases by version, with the latest one under the empty version
+func (s *CatalogSyncService) releaseTargets(ctx context.Context) (map[string]*models.CatalogRelease, error) {
+	releases, err := s.storage.ListCatalogReleases(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list catalog releases: %w", err)
+	}
+	
+	targets := make(map[string]*models.CatalogRelease, len(releases)+1)
+	for _, release := range releases {
+		targets[release.Version] = release
+		if latest := targets[""]; latest == nil || release.PublishedAt.After(latest.PublishedAt) {
+			targets[""] = release
+		}
+	}
+	return targets, nil
+}
+
+// downstreamStatus starts the status of a downstream with the release it should have. If
+// there is none, the release is nil and the status carries the reason.
+func (s *CatalogSyncService) downstreamStatus(downstream models.CatalogDownstream, targets map[string]*models.CatalogRelease) (models.CatalogDownstreamStatus, *models.CatalogRelease) {
+	status := models.CatalogDownstreamStatus{Name: downstream.Name, URL: downstream.URL, Pinned: downstream.Version}
+	target := targets[downstream.Version]
+	switch {
+	case target != nil:
+		status.Target = target.Version
+	case downstream.Version != "":
+		status.Error = fmt.Sprintf("pinned release %s is not published", downstream.Version)
+	default:
+		status.Error = "no release is published"
+	}
+	return status, target
+}
+
+// request calls a downstream's catalog sync API, decoding its JSON response into out
+func (s *CatalogSyncService) request(ctx context.Context, downstream models.CatalogDownstream, method string, body []byte, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	
+	req, err := http.NewRequestWithContext(ctx, method, downstream.URL+"/api/catalog-sync/release", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	token := downstream.Token
+	if downstreamToken, ok := s.config.DownstreamTokens[downstream.Name]; ok {
+		token = downstreamToken()
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCatalogSyncResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiError) == nil && apiError.Error != "" {
+			return fmt.Errorf("unexpected status %d: %s", resp.StatusCode, apiError.Error)
+		}
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// releaseChecksum hashes the JSON encoding of a release's questions and categories
+func releaseChecksum(questions []*models.Question, categories []*models.Category) (string, error) {
+	data, err := json.Marshal(struct {
+		Questions  []*models.Question `json:"questions"`
+		Categories []*models.Category `json:"categories"`
+	}{questions, categories})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal catalog release: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return checksumPrefix + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,55 @@
+package services_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+// signedRelease builds a release of questions with the checksum an authoring instance sends
+func signedRelease(t *testing.T, version string, questions ...*models.Question) *models.CatalogRelease {
+	t.Helper()
+	data, err := json.Marshal(struct {
+		Questions  []*models.Question `json:"questions"`
+		Categories []*models.Category `json:"categories"`
+	}{questions, nil})
+	if err != nil {
+		t.Fatalf("failed to encode release: %v", err)
+	}
+	sum := sha256.Sum256(data)
+	return &models.CatalogRelease{Version: version, Questions: questions, Checksum: "sha256:" + hex.EncodeToString(sum[:])}
+}
+
+func TestReceiveReleaseRejectsQuestionIDsOutsideTheCatalog(t *testing.T) {
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	catalogSync := services.NewCatalogSyncService(store, services.NewQuestionService(store), services.CatalogSyncConfig{})
+	
+	release := signedRelease(t, "1.0.0",
+		questionnairetest.NewQuestion("q1").Option("yes", "Yes", 10).Option("no", "No", 0).Build(),
+		questionnairetest.NewQuestion("../applications/pwn").Option("yes", "Yes", 10).Option("no", "No", 0).Build(),
+	)
+	if _, err := catalogSync.ReceiveRelease(ctx, release); !errors.Is(err, services.ErrInvalidCatalogRelease) {
+		t.Fatalf("ReceiveRelease: err = %v, want ErrInvalidCatalogRelease", err)
+	}
+	
+	questions, err := store.GetQuestions(ctx)
+	if err != nil {
+		t.Fatalf("GetQuestions: %v", err)
+	}
+	if len(questions) != 0 {
+		t.Errorf("questions = %+v, want none installed from a rejected release", questions)
+	}
+	
+	valid := signedRelease(t, "1.0.1", questionnairetest.NewQuestion("q1").Option("yes", "Yes", 10).Option("no", "No", 0).Build())
+	if _, err := catalogSync.ReceiveRelease(ctx, valid); err != nil {
+		t.Fatalf("ReceiveRelease of a valid release: %v", err)
+	}
+}
@@ -0,0 +1,358 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+)
+
+// catalogEntry is a question together with the file it was read from
+type catalogEntry struct {
+	file     string
+	question *models.Question
+}
+
+// ValidateCatalogFiles reads the question files at paths, descending into directories, and
+// validates them as one catalog. Files may hold a single question, an array of questions or an
+// object with a "questions" list, such as seed files and built-in catalogs, in JSON or YAML.
+// Files that cannot be parsed are reported as problems.
+func ValidateCatalogFiles(paths []string) (*models.CatalogValidation, error) {
+	files, err := catalogFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	
+	var entries []catalogEntry
+	var problems []models.CatalogProblem
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		
+		questions, err := parseQuestionFile(file, data)
+		if err != nil {
+			problems = append(problems, models.CatalogProblem{
+				File:     file,
+				Severity: models.SeverityError,
+				Field:    "file",
+				Message:  err.Error(),
+			})
+			continue
+		}
+		for _, question := range questions {
+			entries = append(entries, catalogEntry{file: file, question: question})
+		}
+	}
+	
+	validation := validateCatalogEntries(entries)
+	validation.Files = len(files)
+	validation.Problems = append(problems, validation.Problems...)
+	validation.Valid = validation.Valid && len(problems) == 0
+	return validation, nil
+}
+
+// catalogFiles lists the JSON and YAML files at paths, descending into directories and
+// skipping hidden entries inside them
+func catalogFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if file != path && strings.HasPrefix(entry.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			
+			switch strings.ToLower(filepath.Ext(file)) {
+			case ".json", ".yaml", ".yml":
+				if file == path || !strings.HasPrefix(entry.Name(), ".") {
+					files = append(files, file)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+	return files, nil
+}
+
+// ValidateCatalog validates questions as one catalog
+func ValidateCatalog(questions []*models.Question) *models.CatalogValidation {
+	entries := make([]catalogEntry, 0, len(questions))
+	for _, question := range questions {
+		entries = append(entries, catalogEntry{question: question})
+	}
+	return validateCatalogEntries(entries)
+}
+
+// validationErrors joins the error-severity problems of a validation into one message
+func validationErrors(validation *models.CatalogValidation) string {
+	var messages []string
+	for _, problem := range validation.Problems {
+		if problem.Severity == models.SeverityError {
+			messages = append(messages, fmt.Sprintf("%s %s: %s", problem.QuestionID, problem.Field, problem.Message))
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// parseQuestionFile decodes a single question, an array of questions or an object with a
+// "questions" list
+func parseQuestionFile(name string, data []byte) ([]*models.Question, error) {
+	data, err := yamlToJSON(name, data)
+	if err != nil {
+		return nil, err
+	}
+	
+	if strings.HasPrefix(strings.TrimSpace(string(data)), "[") {
+		var questions []*models.Question
+		if err := json.Unmarshal(data, &questions); err != nil {
+			return nil, err
+		}
+		return questions, nil
+	}
+	
+	var document struct {
+		Questions []*models.Question `json:"questions"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	if document.Questions != nil {
+		return document.Questions, nil
+	}
+	
+	var question models.Question
+	if err := json.Unmarshal(data, &question); err != nil {
+		return nil, err
+	}
+	return []*models.Question{&question}, nil
+}
+
+// validateCatalogEntries checks each question on its own and then the catalog as a whole:
+// duplicate IDs, conditions that can never be met and inconsistent scoring
+func validateCatalogEntries(entries []catalogEntry) *models.CatalogValidation {
+	validation := &models.CatalogValidation{
+		Questions: len(entries),
+		Problems:  []models.CatalogProblem{},
+	}
+	add := func(entry catalogEntry, severity, field, message string) {
+		validation.Problems = append(validation.Problems, models.CatalogProblem{
+			File:       entry.file,
+			QuestionID: entry.question.ID,
+			Severity:   severity,
+			Field:      field,
+			Message:    message,
+		})
+	}
+	
+	byID := make(map[string]*models.Question)
+	for _, entry := range entries {
+		question := entry.question
+		for _, problem := range ValidateQuestion(question) {
+			add(entry, models.SeverityError, problem.Field, problem.Message)
+		}
+		
+		if question.ID != "" {
+			if _, exists := byID[question.ID]; exists {
+				add(entry, models.SeverityError, "question_id", "duplicate question ID")
+			} else {
+				byID[question.ID] = question
+			}
+		}
+		
+		optionIDs := make(map[string]bool)
+		for _, option := range question.Options {
+			if option.ID == "" {
+				add(entry, models.SeverityError, "option_id", "an option has no ID")
+			} else if optionIDs[option.ID] {
+				add(entry, models.SeverityError, "option_id", fmt.Sprintf("duplicate option ID %s", option.ID))
+			}
+			optionIDs[option.ID] = true
+		}
+	}
+	
+	for _, entry := range entries {
+		for _, problem := range conditionProblems(entry.question, byID) {
+			add(entry, problem.Severity, "visibleWhen", problem.Message)
+		}
+	}
+	
+	reachable := reachableQuestions(byID)
+	for _, entry := range entries {
+		if question := entry.question; question.ID != "" && byID[question.ID] == question && !reachable[question.ID] {
+			add(entry, models.SeverityError, "visibleWhen", "the question can never be shown; its conditions cannot be met or form a cycle")
+		}
+	}
+	
+	scale := catalogPointScale(entries)
+	for _, entry := range entries {
+		for _, problem := range scoringProblems(entry.question, scale) {
+			add(entry, models.SeverityWarning, "points", problem)
+		}
+	}
+	
+	validation.Valid = true
+	for _, problem := range validation.Problems {
+		if problem.Severity == models.SeverityError {
+			validation.Valid = false
+		}
+	}
+	return validation
+}
+
+// conditionProblems reports conditions on unknown questions or options and conditions on the
+// same question that no single answer can satisfy together
+func conditionProblems(question *models.Question, byID map[string]*models.Question) []models.CatalogProblem {
+	var problems []models.CatalogProblem
+	add := func(severity, message string) {
+		problems = append(problems, models.CatalogProblem{Severity: severity, Message: message})
+	}
+	
+	allowed := make(map[string]map[string]bool) // questionID -> options satisfying every condition on it
+	for _, condition := range question.VisibleWhen {
+		target, ok := byID[condition.QuestionID]
+		if !ok {
+			add(models.SeverityError, fmt.Sprintf("condition on unknown question %s", condition.QuestionID))
+			continue
+		}
+		
+		options := make(map[string]bool)
+		for _, optionID := range condition.OptionIDs {
+			if !hasOption(target, optionID) {
+				add(models.SeverityWarning, fmt.Sprintf("condition on %s lists unknown option %s", condition.QuestionID, optionID))
+				continue
+			}
+			if previous, constrained := allowed[condition.QuestionID]; !constrained || previous[optionID] {
+				options[optionID] = true
+			}
+		}
+		allowed[condition.QuestionID] = options
+	}
+	
+	for questionID, options := range allowed {
+		if len(options) == 0 {
+			add(models.SeverityError, fmt.Sprintf("no answer to %s satisfies the conditions", questionID))
+		}
+	}
+	
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Message < problems[j].Message })
+	return problems
+}
+
+// reachableQuestions returns the questions that can be shown for some set of answers. A
+// question is reachable if every question it depends on is reachable and can be answered with
+// an option satisfying all its conditions; questions in dependency cycles are never reachable.
+func reachableQuestions(byID map[string]*models.Question) map[string]bool {
+	reachable := make(map[string]bool)
+	for changed := true; changed; {
+		changed = false
+		for id, question := range byID {
+			if !reachable[id] && conditionsSatisfiable(question, byID, reachable) {
+				reachable[id] = true
+				changed = true
+			}
+		}
+	}
+	return reachable
+}
+
+// conditionsSatisfiable reports whether a question's conditions can all hold given the
+// questions already known to be reachable
+func conditionsSatisfiable(question *models.Question, byID map[string]*models.Question, reachable map[string]bool) bool {
+	for _, condition := range question.VisibleWhen {
+		target, ok := byID[condition.QuestionID]
+		if !ok || !reachable[condition.QuestionID] {
+			return false
+		}
+		
+		satisfiable := false
+		for _, option := range target.Options {
+			if optionSatisfies(question, condition.QuestionID, option.ID) {
+				satisfiable = true
+				break
+			}
+		}
+		if !satisfiable {
+			return false
+		}
+	}
+	return true
+}
+
+// optionSatisfies reports whether answering questionID with optionID meets every condition of
+// the question on questionID
+func optionSatisfies(question *models.Question, questionID, optionID string) bool {
+	for _, condition := range question.VisibleWhen {
+		if condition.QuestionID == questionID && !containsString(condition.OptionIDs, optionID) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasOption reports whether a question has an option with the given ID
+func hasOption(question *models.Question, optionID string) bool {
+	for _, option := range question.Options {
+		if option.ID == optionID {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogPointScale returns the most common maximum of option points, which is the point scale
+// the catalog is written in, or 0 if every question uses the same maximum
+func catalogPointScale(entries []catalogEntry) int {
+	counts := make(map[int]int)
+	for _, entry := range entries {
+		if max := maxOptionPoints(entry.question.Options); max > 0 {
+			counts[max]++
+		}
+	}
+	if len(counts) < 2 {
+		return 0
+	}
+	
+	scale, count := 0, 0
+	for max, n := range counts {
+		if n > count || n == count && max > scale {
+			scale, count = max, n
+		}
+	}
+	return scale
+}
+
+// scoringProblems warns about options that cannot change the score and about a point scale
+// that differs from the rest of the catalog, which skews the question's effective weight
+func scoringProblems(question *models.Question, scale int) []string {
+	if len(question.Options) < 2 {
+		return nil
+	}
+	
+	var problems []string
+	distinct := make(map[int]bool)
+	for _, option := range question.Options {
+		distinct[option.Points] = true
+	}
+	if len(distinct) == 1 {
+		problems = append(problems, "all options award the same points, so the answer never changes the score")
+	}
+	
+	if max := maxOptionPoints(question.Options); scale > 0 && max > 0 && max != scale {
+		problems = append(problems, fmt.Sprintf("the best option awards %d points while most questions use a scale of %d; use the weight to change the question's importance", max, scale))
+	}
+	return problems
+}
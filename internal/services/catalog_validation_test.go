@@ -0,0 +1,36 @@
+package services_test
+
+import (
+	"testing"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+func TestValidateCatalogRejectsQuestionIDsOutsideTheCatalog(t *testing.T) {
+	tests := []struct {
+		id    string
+		valid bool
+	}{
+		{"q1", true},
+		{"12f-codebase", true},
+		{"Security.TLS_1", true},
+		{"../applications/pwn", false},
+		{"nested/q1", false},
+		{`nested\q1`, false},
+		{"..", false},
+		{".hidden", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			question := questionnairetest.NewQuestion(tt.id).Category("Security").Option("yes", "Yes", 10).Option("no", "No", 0).Build()
+			
+			validation := services.ValidateCatalog([]*models.Question{question})
+			
+			if validation.Valid != tt.valid {
+				t.Errorf("Valid = %v, want %v: %+v", validation.Valid, tt.valid, validation.Problems)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+//go:embed catalogs/*.json
+var builtinCatalogs embed.FS
+
+// BuiltinCatalogs returns the catalogs shipped with the application, sorted by ID, without
+// their questions
+func BuiltinCatalogs() ([]*models.BuiltinCatalog, error) {
+	files, err := builtinCatalogs.ReadDir("catalogs")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in catalogs: %w", err)
+	}
+	
+	catalogs := []*models.BuiltinCatalog{}
+	for _, file := range files {
+		catalog, err := readBuiltinCatalog(file.Name())
+		if err != nil {
+			return nil, err
+		}
+		catalog.Questions = nil
+		catalogs = append(catalogs, catalog)
+	}
+	
+	sort.Slice(catalogs, func(i, j int) bool { return catalogs[i].ID < catalogs[j].ID })
+	return catalogs, nil
+}
+
+// BuiltinCatalog returns a built-in catalog with its questions, or nil if there is no catalog
+// with that ID
+func BuiltinCatalog(id string) (*models.BuiltinCatalog, error) {
+	catalog, err := readBuiltinCatalog(id + ".json")
+	if errors.Is(err, fs.ErrNotExist) || errors.Is(err, fs.ErrInvalid) {
+		return nil, nil
+	}
+	return catalog, err
+}
+
+// readBuiltinCatalog decodes an embedded catalog file
+func readBuiltinCatalog(name string) (*models.BuiltinCatalog, error) {
+	data, err := builtinCatalogs.ReadFile(path.Join("catalogs", name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in catalog %s: %w", name, err)
+	}
+	
+	var catalog models.BuiltinCatalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to parse built-in catalog %s: %w", name, err)
+	}
+	catalog.QuestionCount = len(catalog.Questions)
+	return &catalog, nil
+}
+
+// InstallCatalog saves the questions of a built-in catalog into the live catalog as a starting
+// point. Questions that already exist are kept unless replace is set.
+func (s *QuestionService) InstallCatalog(ctx context.Context, id string, replace bool) (*models.CatalogInstallResult, error) {
+	catalog, err := BuiltinCatalog(id)
+	if err != nil {
+		return nil, err
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("catalog %w", ErrNotFound)
+	}
+	
+	result := &models.CatalogInstallResult{CatalogID: id}
+	return result, s.installQuestions(ctx, result, catalog.Questions, replace)
+}
+
+// installQuestions saves catalog questions into the live catalog, recording them in result.
+// Questions that already exist are kept unless replace is set.
+func (s *QuestionService) installQuestions(ctx context.Context, result *models.CatalogInstallResult, questions []*models.Question, replace bool) error {
+	result.Installed = []string{}
+	result.Replaced = []string{}
+	result.Skipped = []string{}
+	for _, question := range questions {
+		existing, err := s.storage.GetQuestion(ctx, question.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get question: %w", err)
+		}
+		if existing != nil && !replace {
+			result.Skipped = append(result.Skipped, question.ID)
+			continue
+		}
+		
+		if err := s.storage.SaveQuestion(ctx, question); err != nil {
+			return fmt.Errorf("failed to save question: %w", err)
+		}
+		if existing != nil {
+			result.Replaced = append(result.Replaced, question.ID)
+		} else {
+			result.Installed = append(result.Installed, question.ID)
+		}
+	}
+	
+	// Catalogs bring their own categories once categories are managed
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return err
+	}
+	if categories != nil {
+		if result.Categories, err = addMissingCategories(ctx, s.storage, questions, categories); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
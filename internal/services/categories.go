@@ -0,0 +1,178 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidCategory is returned when saving a category that fails validation
+var ErrInvalidCategory = errors.New("category is invalid")
+
+// ListCategories returns the question categories in display order
+func (s *QuestionService) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	categories, err := s.storage.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	
+	sortCategories(categories)
+	if categories == nil {
+		categories = []*models.Category{}
+	}
+	return categories, nil
+}
+
+// SaveCategory creates or replaces a category. Questions refer to categories by name, so a
+// category cannot be renamed; create the new one and move the questions instead.
+func (s *QuestionService) SaveCategory(ctx context.Context, category *models.Category) (*models.Category, error) {
+	category.Name = strings.TrimSpace(category.Name)
+	if category.Name == "" {
+		return nil, fmt.Errorf("%w: name is required", ErrInvalidCategory)
+	}
+	if category.Weight < 0 {
+		return nil, fmt.Errorf("%w: weight must not be negative", ErrInvalidCategory)
+	}
+	
+	now := time.Now().UTC()
+	category.UpdatedAt = &now
+	if err := s.storage.SaveCategory(ctx, category); err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+	
+	return category, nil
+}
+
+// DeleteCategory removes a category that no question uses
+func (s *QuestionService) DeleteCategory(ctx context.Context, name string) error {
+	category, err := s.storage.GetCategory(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to get category: %w", err)
+	}
+	if category == nil {
+		return fmt.Errorf("category %w", ErrNotFound)
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get questions: %w", err)
+	}
+	var used []string
+	for _, question := range questions {
+		if question.Category == name {
+			used = append(used, question.ID)
+		}
+	}
+	if len(used) > 0 {
+		return fmt.Errorf("%w: category is used by questions %s", ErrConflict, strings.Join(used, ", "))
+	}
+	
+	if err := s.storage.DeleteCategory(ctx, name); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+// categoryIndex returns the defined categories by name, or nil while none are defined
+func categoryIndex(ctx context.Context, repo storage.CategoryRepository) (map[string]*models.Category, error) {
+	categories, err := repo.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	
+	byName := make(map[string]*models.Category, len(categories))
+	for _, category := range categories {
+		byName[category.Name] = category
+	}
+	return byName, nil
+}
+
+// categoryProblem reports a question whose category is not defined. Catalogs without any
+// defined categories accept every category so they keep working until categories are set up.
+func categoryProblem(question *models.Question, categories map[string]*models.Category) *models.QuestionProblem {
+	if categories == nil || question.Category == "" || categories[question.Category] != nil {
+		return nil
+	}
+	
+	names := make([]string, 0, len(categories))
+	for name := range categories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return &models.QuestionProblem{
+		Field:   "category",
+		Message: fmt.Sprintf("unknown category %q; use one of %s or define it first", question.Category, strings.Join(names, ", ")),
+	}
+}
+
+// AdoptCategories defines a category for every category name used by the stored questions when
+// no categories are defined yet, so catalogs from before categories were managed keep their
+// scores. It returns the names of the categories added.
+func AdoptCategories(ctx context.Context, store storage.Storage) ([]string, error) {
+	existing, err := store.ListCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	if len(existing) > 0 {
+		return nil, nil
+	}
+	
+	questions, err := store.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	return addMissingCategories(ctx, store, questions, nil)
+}
+
+// addMissingCategories defines the categories of questions that are not in categories yet,
+// ordered after the existing ones in the order the questions use them. Added categories are
+// also put into categories unless it is nil.
+func addMissingCategories(ctx context.Context, repo storage.CategoryRepository, questions []*models.Question, categories map[string]*models.Category) ([]string, error) {
+	order := 0
+	for _, category := range categories {
+		if category.Order > order {
+			order = category.Order
+		}
+	}
+	
+	added := []string{}
+	seen := make(map[string]bool)
+	now := time.Now().UTC()
+	for _, question := range questions {
+		if question.Category == "" || categories[question.Category] != nil || seen[question.Category] {
+			continue
+		}
+		seen[question.Category] = true
+		order++
+		
+		category := &models.Category{Name: question.Category, Order: order, UpdatedAt: &now}
+		if err := repo.SaveCategory(ctx, category); err != nil {
+			return added, fmt.Errorf("failed to save category: %w", err)
+		}
+		if categories != nil {
+			categories[category.Name] = category
+		}
+		added = append(added, category.Name)
+	}
+	
+	return added, nil
+}
+
+// sortCategories sorts categories by display order, then by name
+func sortCategories(categories []*models.Category) {
+	sort.Slice(categories, func(i, j int) bool {
+		if categories[i].Order != categories[j].Order {
+			return categories[i].Order < categories[j].Order
+		}
+		return categories[i].Name < categories[j].Name
+	})
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+)
+
+// ErrInvalidCategoryScope is returned when an assessment is started for categories it cannot assess
+var ErrInvalidCategoryScope = errors.New("category scope is invalid")
+
+// scopeCategories validates the categories an assessment is limited to and returns them sorted
+// without duplicates, or nil for a full assessment. Each category needs a question that applies
+// to the application.
+func scopeCategories(requested []string, questions []*models.Question, tags map[string]string) ([]string, error) {
+	applicable := make(map[string]bool)
+	for _, question := range questions {
+		if question.AppliesTo(tags) {
+			applicable[question.Category] = true
+		}
+	}
+	
+	seen := make(map[string]bool, len(requested))
+	var scoped []string
+	for _, category := range requested {
+		category = strings.TrimSpace(category)
+		if category == "" || seen[category] {
+			continue
+		}
+		if !applicable[category] {
+			return nil, fmt.Errorf("%w: category %s has no questions for the application", ErrInvalidCategoryScope, category)
+		}
+		seen[category] = true
+		scoped = append(scoped, category)
+	}
+	sort.Strings(scoped)
+	return scoped, nil
+}
+
+// inScope reports whether a question is part of an assessment: it must be in one of the
+// assessment's categories, if limited to some, and sampled by its spot check, if any
+func inScope(assessment *models.Assessment, question *models.Question) bool {
+	if len(assessment.Categories) > 0 && !containsString(assessment.Categories, question.Category) {
+		return false
+	}
+	return inSpotCheck(assessment, question.ID)
+}
+
+// questionsInCategories returns the questions of the given categories, or all of them if none
+// are given
+func questionsInCategories(questions []*models.Question, categories []string) []*models.Question {
+	if len(categories) == 0 {
+		return questions
+	}
+	
+	scoped := []*models.Question{}
+	for _, question := range questions {
+		if containsString(categories, question.Category) {
+			scoped = append(scoped, question)
+		}
+	}
+	return scoped
+}
@@ -0,0 +1,426 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+)
+
+// Signals detected from cluster scan results
+const (
+	SignalKubernetesWorkload    = "cluster:kubernetes-workload"
+	SignalStatefulSet           = "cluster:statefulset"
+	SignalProbesConfigured      = "cluster:probes-configured"
+	SignalProbesMissing         = "cluster:probes-missing"
+	SignalResourcesConfigured   = "cluster:resources-configured"
+	SignalResourcesMissing      = "cluster:resources-missing"
+	SignalReadOnlyRootFS        = "cluster:read-only-root-filesystem"
+	SignalDisruptionBudget      = "cluster:disruption-budget"
+	SignalHorizontalAutoscaling = "cluster:horizontal-autoscaling"
+	SignalPersistentVolumes     = "cluster:persistent-volume-claims"
+	SignalHostPathVolumes       = "cluster:host-path-volumes"
+	SignalEnvironmentConfig     = "cluster:environment-config"
+	SignalMountedConfig         = "cluster:mounted-config"
+)
+
+// Supported cluster scan formats
+const (
+	ScanFormatKubeScore = "kube-score"
+	ScanFormatPolaris   = "polaris"
+	ScanFormatPopeye    = "popeye"
+	ScanFormatManifests = "kubernetes" // Kubernetes objects, e.g. from `kubectl get -o json` or the inspect command
+)
+
+// signalSet collects signals with at most maxEvidencePerHit pieces of evidence each
+type signalSet map[string][]string
+
+func (s signalSet) add(signal, evidence string) {
+	if len(s[signal]) < maxEvidencePerHit {
+		s[signal] = append(s[signal], evidence)
+	}
+}
+
+func (s signalSet) signals() []models.Signal {
+	signals := make([]models.Signal, 0, len(s))
+	for name, evidence := range s {
+		signals = append(signals, models.Signal{Name: name, Evidence: evidence})
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Name < signals[j].Name })
+	return signals
+}
+
+// ParseClusterScan extracts signals from kube-score, Polaris or Popeye JSON output. The format
+// is detected from the document if empty.
+func ParseClusterScan(format string, data []byte) ([]models.Signal, string, error) {
+	if format == "" {
+		format = detectScanFormat(data)
+	}
+	
+	var signals []models.Signal
+	var err error
+	switch format {
+	case ScanFormatKubeScore:
+		signals, err = parseKubeScore(data)
+	case ScanFormatPolaris:
+		signals, err = parsePolaris(data)
+	case ScanFormatPopeye:
+		signals, err = parsePopeye(data)
+	case ScanFormatManifests:
+		signals, err = parseManifests(data)
+	default:
+		return nil, "", errors.New("unrecognized cluster scan format; use kube-score, polaris, popeye or kubernetes JSON")
+	}
+	if err != nil {
+		return nil, format, fmt.Errorf("failed to parse %s output: %w", format, err)
+	}
+	
+	return signals, format, nil
+}
+
+// detectScanFormat guesses the tool that produced a scan document
+func detectScanFormat(data []byte) string {
+	trimmed := strings.TrimSpace(string(data))
+	switch {
+	case strings.HasPrefix(trimmed, "["):
+		return ScanFormatKubeScore
+	case strings.Contains(trimmed, `"popeye"`):
+		return ScanFormatPopeye
+	case strings.Contains(trimmed, `"Results"`):
+		return ScanFormatPolaris
+	case strings.Contains(trimmed, `"apiVersion"`):
+		return ScanFormatManifests
+	}
+	return ""
+}
+
+// parseKubeScore reads `kube-score score -o json` output. Grades of 10 are passing checks.
+func parseKubeScore(data []byte) ([]models.Signal, error) {
+	var objects []struct {
+		ObjectName string `json:"object_name"`
+		TypeMeta   struct {
+			Kind string `json:"kind"`
+		} `json:"type_meta"`
+		Checks []struct {
+			Check struct {
+				ID string `json:"id"`
+			} `json:"check"`
+			Grade   int  `json:"grade"`
+			Skipped bool `json:"skipped"`
+		} `json:"checks"`
+	}
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+	
+	set := signalSet{}
+	for _, object := range objects {
+		ref := object.TypeMeta.Kind + "/" + object.ObjectName
+		classifyKind(set, object.TypeMeta.Kind, ref)
+		
+		for _, check := range object.Checks {
+			if check.Skipped {
+				continue
+			}
+			passed := check.Grade >= 10
+			evidence := ref + ": " + check.Check.ID
+			
+			switch check.Check.ID {
+			case "pod-probes", "container-liveness-probe", "container-readiness-probe":
+				set.add(pick(passed, SignalProbesConfigured, SignalProbesMissing), evidence)
+			case "container-resources", "container-resource-requests-equal-limits":
+				set.add(pick(passed, SignalResourcesConfigured, SignalResourcesMissing), evidence)
+			case "container-security-context-readonlyrootfilesystem":
+				if passed {
+					set.add(SignalReadOnlyRootFS, evidence)
+				}
+			case "deployment-has-poddisruptionbudget", "statefulset-has-poddisruptionbudget":
+				if passed {
+					set.add(SignalDisruptionBudget, evidence)
+				}
+			case "horizontalpodautoscaler-has-target":
+				if passed {
+					set.add(SignalHorizontalAutoscaling, evidence)
+				}
+			}
+		}
+	}
+	
+	return set.signals(), nil
+}
+
+// polarisResult is a check result in Polaris audit output
+type polarisResult struct {
+	ID      string `json:"ID"`
+	Success bool   `json:"Success"`
+}
+
+// parsePolaris reads `polaris audit --format json` output
+func parsePolaris(data []byte) ([]models.Signal, error) {
+	var audit struct {
+		Results []struct {
+			Name      string                   `json:"Name"`
+			Namespace string                   `json:"Namespace"`
+			Kind      string                   `json:"Kind"`
+			Results   map[string]polarisResult `json:"Results"`
+			PodResult *struct {
+				Results          map[string]polarisResult `json:"Results"`
+				ContainerResults []struct {
+					Name    string                   `json:"Name"`
+					Results map[string]polarisResult `json:"Results"`
+				} `json:"ContainerResults"`
+			} `json:"PodResult"`
+		} `json:"Results"`
+	}
+	if err := json.Unmarshal(data, &audit); err != nil {
+		return nil, err
+	}
+	
+	set := signalSet{}
+	for _, workload := range audit.Results {
+		ref := workload.Kind + "/" + workload.Namespace + "/" + workload.Name
+		classifyKind(set, workload.Kind, ref)
+		
+		results := []map[string]polarisResult{workload.Results}
+		if workload.PodResult != nil {
+			results = append(results, workload.PodResult.Results)
+			for _, container := range workload.PodResult.ContainerResults {
+				results = append(results, container.Results)
+			}
+		}
+		
+		for _, checks := range results {
+			for id, result := range checks {
+				evidence := ref + ": " + id
+				switch id {
+				case "livenessProbeMissing", "readinessProbeMissing":
+					set.add(pick(result.Success, SignalProbesConfigured, SignalProbesMissing), evidence)
+				case "cpuRequestsMissing", "cpuLimitsMissing", "memoryRequestsMissing", "memoryLimitsMissing":
+					set.add(pick(result.Success, SignalResourcesConfigured, SignalResourcesMissing), evidence)
+				case "notReadOnlyRootFilesystem":
+					if result.Success {
+						set.add(SignalReadOnlyRootFS, evidence)
+					}
+				case "missingPodDisruptionBudget":
+					if result.Success {
+						set.add(SignalDisruptionBudget, evidence)
+					}
+				}
+			}
+		}
+	}
+	
+	return set.signals(), nil
+}
+
+// parsePopeye reads `popeye -o json` output. Popeye only reports issues, so passing checks
+// cannot be detected.
+func parsePopeye(data []byte) ([]models.Signal, error) {
+	var report struct {
+		Popeye struct {
+			Sanitizers []struct {
+				Sanitizer string `json:"sanitizer"`
+				Issues    map[string][]struct {
+					Level   int    `json:"level"`
+					Message string `json:"message"`
+				} `json:"issues"`
+			} `json:"sanitizers"`
+		} `json:"popeye"`
+	}
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, err
+	}
+	
+	set := signalSet{}
+	for _, sanitizer := range report.Popeye.Sanitizers {
+		kind := ""
+		switch sanitizer.Sanitizer {
+		case "deployments":
+			kind = "Deployment"
+		case "daemonsets":
+			kind = "DaemonSet"
+		case "statefulsets":
+			kind = "StatefulSet"
+		case "horizontalpodautoscalers":
+			kind = "HorizontalPodAutoscaler"
+		}
+		
+		for resource, issues := range sanitizer.Issues {
+			ref := sanitizer.Sanitizer + "/" + resource
+			if kind != "" {
+				classifyKind(set, kind, ref)
+			}
+			
+			for _, issue := range issues {
+				message := strings.ToLower(issue.Message)
+				evidence := ref + ": " + issue.Message
+				switch {
+				case strings.Contains(message, "probe"):
+					set.add(SignalProbesMissing, evidence)
+				case strings.Contains(message, "resource") && (strings.Contains(message, "limit") || strings.Contains(message, "request")):
+					set.add(SignalResourcesMissing, evidence)
+				}
+			}
+		}
+	}
+	
+	return set.signals(), nil
+}
+
+// manifest is the part of a Kubernetes object inspected for signals
+type manifest struct {
+	Kind     string `json:"kind"`
+	Metadata struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"metadata"`
+	Spec struct {
+		podSpec
+		Template    *struct{ Spec podSpec `json:"spec"` } `json:"template"`
+		JobTemplate *struct {
+			Spec struct {
+				Template struct{ Spec podSpec `json:"spec"` } `json:"template"`
+			} `json:"spec"`
+		} `json:"jobTemplate"`
+		VolumeClaimTemplates []json.RawMessage `json:"volumeClaimTemplates"`
+	} `json:"spec"`
+	Items []json.RawMessage `json:"items"` // kind List
+}
+
+// podSpec is the part of a pod specification inspected for signals
+type podSpec struct {
+	Containers []struct {
+		Name           string            `json:"name"`
+		LivenessProbe  json.RawMessage   `json:"livenessProbe"`
+		ReadinessProbe json.RawMessage   `json:"readinessProbe"`
+		Env            []json.RawMessage `json:"env"`
+		EnvFrom        []json.RawMessage `json:"envFrom"`
+		Resources      struct {
+			Limits   map[string]string `json:"limits"`
+			Requests map[string]string `json:"requests"`
+		} `json:"resources"`
+		SecurityContext *struct {
+			ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem"`
+		} `json:"securityContext"`
+	} `json:"containers"`
+	Volumes []struct {
+		Name                  string          `json:"name"`
+		PersistentVolumeClaim json.RawMessage `json:"persistentVolumeClaim"`
+		HostPath              json.RawMessage `json:"hostPath"`
+		ConfigMap             json.RawMessage `json:"configMap"`
+		Secret                json.RawMessage `json:"secret"`
+	} `json:"volumes"`
+}
+
+// parseManifests reads Kubernetes objects, either a single object or a List, and inspects the
+// pod templates of workloads for probes, resource limits, volumes and configuration sources
+func parseManifests(data []byte) ([]models.Signal, error) {
+	var root manifest
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+	
+	objects := []manifest{root}
+	if len(root.Items) > 0 {
+		objects = objects[:0]
+		for _, raw := range root.Items {
+			var object manifest
+			if err := json.Unmarshal(raw, &object); err != nil {
+				return nil, err
+			}
+			objects = append(objects, object)
+		}
+	}
+	
+	set := signalSet{}
+	for _, object := range objects {
+		ref := object.Kind + "/" + object.Metadata.Name
+		if object.Metadata.Namespace != "" {
+			ref = object.Kind + "/" + object.Metadata.Namespace + "/" + object.Metadata.Name
+		}
+		classifyKind(set, object.Kind, ref)
+		
+		var spec *podSpec
+		switch object.Kind {
+		case "Pod":
+			spec = &object.Spec.podSpec
+		case "CronJob":
+			if object.Spec.JobTemplate != nil {
+				spec = &object.Spec.JobTemplate.Spec.Template.Spec
+			}
+		case "PersistentVolumeClaim":
+			set.add(SignalPersistentVolumes, ref)
+		default:
+			if object.Spec.Template != nil {
+				spec = &object.Spec.Template.Spec
+			}
+		}
+		if len(object.Spec.VolumeClaimTemplates) > 0 {
+			set.add(SignalPersistentVolumes, ref+": volumeClaimTemplates")
+		}
+		if spec != nil {
+			inspectPodSpec(set, spec, ref)
+		}
+	}
+	
+	return set.signals(), nil
+}
+
+// inspectPodSpec records the signals of a workload's pod template
+func inspectPodSpec(set signalSet, spec *podSpec, ref string) {
+	for _, container := range spec.Containers {
+		evidence := ref + ": container " + container.Name
+		
+		probed := len(container.LivenessProbe) > 0 && len(container.ReadinessProbe) > 0
+		set.add(pick(probed, SignalProbesConfigured, SignalProbesMissing), evidence)
+		
+		limited := container.Resources.Limits["cpu"] != "" && container.Resources.Limits["memory"] != ""
+		set.add(pick(limited, SignalResourcesConfigured, SignalResourcesMissing), evidence)
+		
+		if container.SecurityContext != nil && container.SecurityContext.ReadOnlyRootFilesystem != nil && *container.SecurityContext.ReadOnlyRootFilesystem {
+			set.add(SignalReadOnlyRootFS, evidence)
+		}
+		
+		if len(container.Env) > 0 || len(container.EnvFrom) > 0 {
+			set.add(SignalEnvironmentConfig, evidence)
+		}
+	}
+	
+	for _, volume := range spec.Volumes {
+		evidence := ref + ": volume " + volume.Name
+		switch {
+		case len(volume.PersistentVolumeClaim) > 0:
+			set.add(SignalPersistentVolumes, evidence)
+		case len(volume.HostPath) > 0:
+			set.add(SignalHostPathVolumes, evidence)
+		case len(volume.ConfigMap) > 0 || len(volume.Secret) > 0:
+			set.add(SignalMountedConfig, evidence)
+		}
+	}
+}
+
+// classifyKind records signals implied by the kind of a scanned object
+func classifyKind(set signalSet, kind, ref string) {
+	switch kind {
+	case "Deployment", "StatefulSet", "DaemonSet", "Pod", "CronJob", "Job":
+		set.add(SignalKubernetesWorkload, ref)
+	}
+	switch kind {
+	case "StatefulSet":
+		set.add(SignalStatefulSet, ref)
+	case "HorizontalPodAutoscaler":
+		set.add(SignalHorizontalAutoscaling, ref)
+	case "PodDisruptionBudget":
+		set.add(SignalDisruptionBudget, ref)
+	}
+}
+
+// pick returns a if cond holds and b otherwise
+func pick(cond bool, a, b string) string {
+	if cond {
+		return a
+	}
+	return b
+}
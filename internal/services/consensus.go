@@ -0,0 +1,182 @@
+package services
+
+import (
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// DefaultDisagreementThreshold is the share of assessors, in percent, who must choose the same
+// option for a question not to be flagged as disputed
+const DefaultDisagreementThreshold = 60
+
+// WithConsensus sets the rule deriving an assessment's answer from the responses of several
+// assessors, and flags questions in reports where fewer than threshold percent of the
+// assessors agree
+func WithConsensus(rule string, threshold int) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.consensusRule = rule
+		s.disagreementThreshold = threshold
+	}
+}
+
+// ValidateConsensusRule checks that a consensus rule is known
+func ValidateConsensusRule(rule string) error {
+	switch rule {
+	case models.ConsensusLatest, models.ConsensusMajority, models.ConsensusLowest:
+		return nil
+	}
+	return fmt.Errorf("unknown consensus rule %q, expected %s, %s or %s", rule, models.ConsensusLatest, models.ConsensusMajority, models.ConsensusLowest)
+}
+
+// countsAsResponse reports whether an answer is an assessor's own response to a question.
+// Imported, copied and workshop answers replace the responses instead. Answers saved before
+// provenance was recorded were chosen by assessors.
+func countsAsResponse(provenance *models.AnswerProvenance) bool {
+	if provenance == nil {
+		return true
+	}
+	switch provenance.Method {
+	case models.ProvenanceManual, models.ProvenancePrefilled, models.ProvenanceAgentScan:
+		return true
+	}
+	return false
+}
+
+// answerConsensus applies the consensus rule to the responses to a question including a new
+// response, and returns the answer to score if it differs from the new response
+func (s *AssessmentService) answerConsensus(assessment *models.Assessment, question *models.Question, user string, response models.AnswerResponse, provenance *models.AnswerProvenance) *models.AnswerConsensus {
+	if s.consensusRule == models.ConsensusLatest || user == "" || !countsAsResponse(provenance) {
+		return nil
+	}
+	
+	responses := make(map[string]models.AnswerResponse, len(assessment.Responses[question.ID])+1)
+	for assessor, previous := range assessment.Responses[question.ID] {
+		responses[assessor] = previous
+	}
+	responses[user] = response
+	
+	chosen, ok := consensusResponse(s.consensusRule, question, responses)
+	if !ok || chosen.OptionID == response.OptionID {
+		return nil
+	}
+	return &models.AnswerConsensus{Rule: s.consensusRule, OptionID: chosen.OptionID, Explanation: chosen.Explanation}
+}
+
+// consensusResponse picks the response a rule selects among the responses naming options of
+// the question. Ties go to the most recent response.
+func consensusResponse(rule string, question *models.Question, responses map[string]models.AnswerResponse) (models.AnswerResponse, bool) {
+	counts := make(map[string]int)
+	latest := make(map[string]models.AnswerResponse) // option -> its most recent response
+	for _, response := range responses {
+		if findOption(question.Options, response.OptionID) == nil {
+			continue
+		}
+		counts[response.OptionID]++
+		if previous, ok := latest[response.OptionID]; !ok || answeredAfter(response, previous) {
+			latest[response.OptionID] = response
+		}
+	}
+	
+	var chosen models.AnswerResponse
+	found := false
+	for optionID, response := range latest {
+		if !found {
+			chosen, found = response, true
+			continue
+		}
+		
+		var better, tied bool
+		switch rule {
+		case models.ConsensusMajority:
+			better = counts[optionID] > counts[chosen.OptionID]
+			tied = counts[optionID] == counts[chosen.OptionID]
+		case models.ConsensusLowest:
+			points := findOption(question.Options, optionID).Points
+			chosenPoints := findOption(question.Options, chosen.OptionID).Points
+			better = points < chosenPoints
+			tied = points == chosenPoints
+		default:
+			tied = true
+		}
+		if better || (tied && answeredAfter(response, chosen)) {
+			chosen = response
+		}
+	}
+	return chosen, found
+}
+
+// answeredAfter reports whether a response was given after another
+func answeredAfter(a, b models.AnswerResponse) bool {
+	return a.AnsweredAt.After(b.AnsweredAt)
+}
+
+// answerDisagreement flags a question when fewer than threshold percent of its assessors chose
+// the same option. Questions with a single response are never flagged.
+func answerDisagreement(question *models.Question, optionID string, responses map[string]models.AnswerResponse, threshold int) *models.AnswerDisagreement {
+	counts := make(map[string]int)
+	total := 0
+	for _, response := range responses {
+		if findOption(question.Options, response.OptionID) != nil {
+			counts[response.OptionID]++
+			total++
+		}
+	}
+	if total < 2 {
+		return nil
+	}
+	
+	disagreement := &models.AnswerDisagreement{
+		QuestionID: question.ID,
+		Text:       question.Text,
+		Category:   question.Category,
+		OptionID:   optionID,
+		Responses:  total,
+	}
+	lowest, highest := 0, 0
+	for _, option := range question.Options {
+		count := counts[option.ID]
+		if count == 0 {
+			continue
+		}
+		if len(disagreement.Options) == 0 || option.Points < lowest {
+			lowest = option.Points
+		}
+		if len(disagreement.Options) == 0 || option.Points > highest {
+			highest = option.Points
+		}
+		disagreement.Options = append(disagreement.Options, models.ResponseCount{
+			OptionID:   option.ID,
+			OptionText: option.Text,
+			Points:     option.Points,
+			Count:      count,
+		})
+	}
+	sort.SliceStable(disagreement.Options, func(i, j int) bool {
+		return disagreement.Options[i].Count > disagreement.Options[j].Count
+	})
+	
+	top := disagreement.Options[0].Count
+	if top*100 >= threshold*total {
+		return nil
+	}
+	disagreement.Agreement = top * 100 / total
+	disagreement.PointSpread = highest - lowest
+	return disagreement
+}
+
+// copyResponses returns a deep copy of a response map, preserving nil
+func copyResponses(m map[string]map[string]models.AnswerResponse) map[string]map[string]models.AnswerResponse {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string]map[string]models.AnswerResponse, len(m))
+	for questionID, responses := range m {
+		copied[questionID] = make(map[string]models.AnswerResponse, len(responses))
+		for assessor, response := range responses {
+			copied[questionID][assessor] = response
+		}
+	}
+	return copied
+}
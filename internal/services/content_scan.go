@@ -0,0 +1,214 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clamdChunkSize is the size of the chunks an upload is streamed to clamd in; clamd rejects
+// streams longer than its StreamMaxLength setting
+const clamdChunkSize = 64 << 10
+
+// contentScanDialTimeout limits connecting to a scanner; the scan itself is limited by the
+// request's context
+const contentScanDialTimeout = 10 * time.Second
+
+// ScanResult is the verdict of a content scanner on an upload
+type ScanResult struct {
+	Scanner string `json:"scanner"`
+	Clean   bool   `json:"clean"`
+	Threat  string `json:"threat,omitempty"` // signature or reason reported by the scanner
+}
+
+// ContentScanner checks uploads for malware before they are processed. An error means the
+// upload could not be scanned, not that it is infected.
+type ContentScanner interface {
+	Scan(ctx context.Context, data []byte) (*ScanResult, error)
+}
+
+// NewContentScanner creates the scanner configured by a URL: clamav://host:3310 or
+// clamav:///path/to/clamd.sock for clamd, or icap://host:1344/service for an ICAP server
+func NewContentScanner(rawURL string) (ContentScanner, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content scanner url: %w", err)
+	}
+	
+	switch parsed.Scheme {
+	case "clamav":
+		if parsed.Host != "" {
+			return &ClamAVScanner{Network: "tcp", Address: parsed.Host}, nil
+		}
+		if parsed.Path == "" {
+			return nil, fmt.Errorf("content scanner %q requires a host or socket path", rawURL)
+		}
+		return &ClamAVScanner{Network: "unix", Address: parsed.Path}, nil
+	case "icap":
+		if parsed.Host == "" || strings.Trim(parsed.Path, "/") == "" {
+			return nil, fmt.Errorf("content scanner %q requires a host and service, e.g. icap://host:1344/avscan", rawURL)
+		}
+		host := parsed.Host
+		if parsed.Port() == "" {
+			host = net.JoinHostPort(parsed.Hostname(), "1344")
+		}
+		return &ICAPScanner{Address: host, Service: strings.Trim(parsed.Path, "/")}, nil
+	default:
+		return nil, fmt.Errorf("unsupported content scanner %q, expected clamav:// or icap://", rawURL)
+	}
+}
+
+// dialScanner connects to a scanner and applies the context's deadline to the connection
+func dialScanner(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := net.Dialer{Timeout: contentScanDialTimeout}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	return conn, nil
+}
+
+// ClamAVScanner scans uploads with clamd's INSTREAM command
+type ClamAVScanner struct {
+	Network string // tcp or unix
+	Address string
+}
+
+// Scan streams the upload to clamd and parses its verdict
+func (s *ClamAVScanner) Scan(ctx context.Context, data []byte) (*ScanResult, error) {
+	conn, err := dialScanner(ctx, s.Network, s.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	defer conn.Close()
+	
+	writer := bufio.NewWriter(conn)
+	writer.WriteString("zINSTREAM\x00")
+	var size [4]byte
+	for offset := 0; offset < len(data); offset += clamdChunkSize {
+		chunk := data[offset:min(offset+clamdChunkSize, len(data))]
+		binary.BigEndian.PutUint32(size[:], uint32(len(chunk)))
+		writer.Write(size[:])
+		writer.Write(chunk)
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	writer.Write(size[:])
+	if err := writer.Flush(); err != nil {
+		return nil, fmt.Errorf("failed to send upload to clamd: %w", err)
+	}
+	
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return nil, fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return parseClamdReply(strings.TrimRight(reply, "\x00\n"))
+}
+
+// parseClamdReply parses a reply such as "stream: OK" or "stream: Eicar-Signature FOUND"
+func parseClamdReply(reply string) (*ScanResult, error) {
+	result := &ScanResult{Scanner: "clamav"}
+	verdict := strings.TrimSpace(strings.TrimPrefix(reply, "stream:"))
+	switch {
+	case verdict == "OK":
+		result.Clean = true
+	case strings.HasSuffix(verdict, " FOUND"):
+		result.Threat = strings.TrimSuffix(verdict, " FOUND")
+	default:
+		return nil, fmt.Errorf("clamd could not scan the upload: %s", reply)
+	}
+	return result, nil
+}
+
+// ICAPScanner scans uploads with an ICAP server's RESPMOD service (RFC 3507), as offered by
+// most antivirus gateways. The server answers 204 for clean content and a replacement response
+// for blocked content.
+type ICAPScanner struct {
+	Address string // host:port
+	Service string // e.g. avscan
+}
+
+// Scan sends the upload to the ICAP service as the body of an HTTP response
+func (s *ICAPScanner) Scan(ctx context.Context, data []byte) (*ScanResult, error) {
+	conn, err := dialScanner(ctx, "tcp", s.Address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to ICAP server: %w", err)
+	}
+	defer conn.Close()
+	
+	httpHeader := "HTTP/1.1 200 OK\r\n" +
+		"Content-Type: application/octet-stream\r\n" +
+		"Content-Length: " + strconv.Itoa(len(data)) + "\r\n\r\n"
+	var request bytes.Buffer
+	fmt.Fprintf(&request, "RESPMOD icap://%s/%s ICAP/1.0\r\n", s.Address, s.Service)
+	fmt.Fprintf(&request, "Host: %s\r\n", s.Address)
+	request.WriteString("Allow: 204\r\n")
+	fmt.Fprintf(&request, "Encapsulated: res-hdr=0, res-body=%d\r\n\r\n", len(httpHeader))
+	request.WriteString(httpHeader)
+	if len(data) > 0 {
+		fmt.Fprintf(&request, "%x\r\n", len(data))
+		request.Write(data)
+		request.WriteString("\r\n")
+	}
+	request.WriteString("0\r\n\r\n")
+	if _, err := conn.Write(request.Bytes()); err != nil {
+		return nil, fmt.Errorf("failed to send upload to ICAP server: %w", err)
+	}
+	
+	reader := textproto.NewReader(bufio.NewReader(conn))
+	status, err := reader.ReadLine()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ICAP reply: %w", err)
+	}
+	header, err := reader.ReadMIMEHeader()
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("failed to read ICAP reply headers: %w", err)
+	}
+	return parseICAPReply(status, header)
+}
+
+// parseICAPReply turns an ICAP status line and headers into a verdict
+func parseICAPReply(status string, header textproto.MIMEHeader) (*ScanResult, error) {
+	result := &ScanResult{Scanner: "icap"}
+	fields := strings.Fields(status)
+	if len(fields) < 2 || !strings.HasPrefix(fields[0], "ICAP/") {
+		return nil, fmt.Errorf("invalid ICAP reply: %q", status)
+	}
+	
+	switch fields[1] {
+	case "204":
+		result.Clean = true
+	case "200":
+		result.Threat = icapThreat(header)
+	default:
+		return nil, fmt.Errorf("ICAP server could not scan the upload: %s", status)
+	}
+	return result, nil
+}
+
+// icapThreat extracts the threat name from the headers antivirus gateways use to report it
+func icapThreat(header textproto.MIMEHeader) string {
+	// X-Infection-Found: Type=0; Resolution=2; Threat=Eicar-Test-Signature;
+	for _, part := range strings.Split(header.Get("X-Infection-Found"), ";") {
+		if name, value, ok := strings.Cut(strings.TrimSpace(part), "="); ok && strings.EqualFold(name, "Threat") {
+			return value
+		}
+	}
+	for _, name := range []string{"X-Virus-ID", "X-Violations-Found"} {
+		if value := strings.TrimSpace(header.Get(name)); value != "" {
+			return value
+		}
+	}
+	return "blocked by ICAP service"
+}
@@ -0,0 +1,437 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/mail"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strings"
+	"time"
+)
+
+// digestLock ensures only one replica sends each digest
+const digestLock = "portfolio-digest"
+
+// DigestConfig configures the weekly portfolio digest
+type DigestConfig struct {
+	Recipients []string     // receive every section for all applications
+	Weekday    time.Weekday // day the digest is sent
+	Hour       int          // hour of the day in the server's time zone
+}
+
+// DigestService emails a weekly digest of completed assessments, score changes and overdue
+// campaign assessments to configured recipients and subscribed users
+type DigestService struct {
+	storage     storage.Storage
+	locker      storage.Locker
+	assessments *AssessmentService
+	campaigns   *CampaignService
+	notifier    *NotificationService
+	config      DigestConfig
+}
+
+// NewDigestService creates a new digest service
+func NewDigestService(storage storage.Storage, locker storage.Locker, assessments *AssessmentService, campaigns *CampaignService, notifier *NotificationService, config DigestConfig) *DigestService {
+	return &DigestService{
+		storage:     storage,
+		locker:      locker,
+		assessments: assessments,
+		campaigns:   campaigns,
+		notifier:    notifier,
+		config:      config,
+	}
+}
+
+// ParseWeekday parses an English weekday name such as "monday"
+func ParseWeekday(name string) (time.Weekday, error) {
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		if strings.EqualFold(day.String(), name) {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown weekday: %s", name)
+}
+
+// GetSubscription returns a user's digest subscription, or nil if the user is not subscribed
+func (s *DigestService) GetSubscription(ctx context.Context, user string) (*models.DigestSubscription, error) {
+	subscription, err := s.storage.GetDigestSubscription(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscription: %w", err)
+	}
+	return subscription, nil
+}
+
+// ListSubscriptions returns all digest subscriptions ordered by user
+func (s *DigestService) ListSubscriptions(ctx context.Context) ([]*models.DigestSubscription, error) {
+	subscriptions, err := s.storage.ListDigestSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	
+	if subscriptions == nil {
+		subscriptions = []*models.DigestSubscription{}
+	}
+	sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].User < subscriptions[j].User })
+	return subscriptions, nil
+}
+
+// Subscribe validates and stores a user's digest preferences, replacing earlier ones
+func (s *DigestService) Subscribe(ctx context.Context, subscription *models.DigestSubscription) (*models.DigestSubscription, error) {
+	if subscription.User == "" {
+		return nil, errors.New("a user identity is required to subscribe")
+	}
+	
+	address, err := mail.ParseAddress(subscription.Email)
+	if err != nil {
+		return nil, fmt.Errorf("invalid email address: %w", err)
+	}
+	subscription.Email = address.Address
+	
+	for _, section := range subscription.Sections {
+		if !containsString(models.DigestSections, section) {
+			return nil, fmt.Errorf("unknown digest section: %s", section)
+		}
+	}
+	
+	subscription.UpdatedAt = time.Now()
+	if err := s.storage.SaveDigestSubscription(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("failed to save subscription: %w", err)
+	}
+	
+	return subscription, nil
+}
+
+// Unsubscribe removes a user's digest subscription
+func (s *DigestService) Unsubscribe(ctx context.Context, user string) error {
+	if err := s.storage.DeleteDigestSubscription(ctx, user); err != nil {
+		return fmt.Errorf("failed to delete subscription: %w", err)
+	}
+	return nil
+}
+
+// BuildDigest summarizes the period from start to end. A subscription limits the digest to its
+// sections and tagged applications; with nil everything is included.
+func (s *DigestService) BuildDigest(ctx context.Context, start, end time.Time, subscription *models.DigestSubscription) (*models.Digest, error) {
+	digest := &models.Digest{
+		PeriodStart:  start.Format(time.RFC3339),
+		PeriodEnd:    end.Format(time.RFC3339),
+		Completed:    []models.DigestAssessment{},
+		ScoreChanges: []models.DigestScoreChange{},
+		Overdue:      []models.DigestOverdueEntry{},
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	names := make(map[string]string)
+	for _, app := range apps {
+		if subscription == nil || matchesTags(app.Tags, subscription.Tags) {
+			names[app.ID] = app.Name
+		}
+	}
+	
+	if includesSection(subscription, models.DigestSectionCompleted) || includesSection(subscription, models.DigestSectionScoreChanges) {
+		if err := s.addAssessmentActivity(ctx, digest, start, end, names, subscription); err != nil {
+			return nil, err
+		}
+	}
+	
+	if includesSection(subscription, models.DigestSectionOverdue) {
+		if err := s.addOverdue(ctx, digest, names); err != nil {
+			return nil, err
+		}
+	}
+	
+	return digest, nil
+}
+
+// addAssessmentActivity adds assessments completed in the period and the score changes they caused
+func (s *DigestService) addAssessmentActivity(ctx context.Context, digest *models.Digest, start, end time.Time, names map[string]string, subscription *models.DigestSubscription) error {
+	scoring, err := s.assessments.GetScoringConfig(ctx)
+	if err != nil {
+		return err
+	}
+	
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	// Reports of completed assessments per application, oldest first
+	reports := make(map[string][]*models.Report)
+	for _, assessment := range assessments {
+		if _, ok := names[assessment.ApplicationID]; !ok {
+			continue
+		}
+		if assessment.Status != "completed" && assessment.Status != "approved" {
+			continue
+		}
+		
+		report, err := s.storage.GetReport(ctx, assessment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to get report: %w", err)
+		}
+		if report == nil || !report.GeneratedAt.Before(end) {
+			continue
+		}
+		reports[assessment.ApplicationID] = append(reports[assessment.ApplicationID], report)
+		
+		completedAt := report.GeneratedAt
+		if assessment.CompletedAt != nil {
+			completedAt = *assessment.CompletedAt
+		}
+		if includesSection(subscription, models.DigestSectionCompleted) && !completedAt.Before(start) && completedAt.Before(end) {
+			score := latestScore(report, scoring)
+			digest.Completed = append(digest.Completed, models.DigestAssessment{
+				ApplicationID:   assessment.ApplicationID,
+				ApplicationName: names[assessment.ApplicationID],
+				AssessmentID:    assessment.ID,
+				CompletedAt:     completedAt,
+				ScorePercent:    score.ScorePercent,
+				Grade:           score.Grade,
+			})
+		}
+	}
+	
+	if includesSection(subscription, models.DigestSectionScoreChanges) {
+		for applicationID, appReports := range reports {
+			sort.Slice(appReports, func(i, j int) bool { return appReports[i].GeneratedAt.Before(appReports[j].GeneratedAt) })
+			
+			// Compare the latest score with the latest one from before the period
+			current := appReports[len(appReports)-1]
+			var previous *models.Report
+			for _, report := range appReports {
+				if report.GeneratedAt.Before(start) {
+					previous = report
+				}
+			}
+			if previous == nil || current.GeneratedAt.Before(start) {
+				continue
+			}
+			
+			before := ReportPercent(previous)
+			after := ReportPercent(current)
+			if before != after {
+				digest.ScoreChanges = append(digest.ScoreChanges, models.DigestScoreChange{
+					ApplicationID:   applicationID,
+					ApplicationName: names[applicationID],
+					PreviousPercent: before,
+					ScorePercent:    after,
+					Change:          after - before,
+				})
+			}
+		}
+	}
+	
+	sort.Slice(digest.Completed, func(i, j int) bool {
+		return digest.Completed[i].CompletedAt.Before(digest.Completed[j].CompletedAt)
+	})
+	sort.Slice(digest.ScoreChanges, func(i, j int) bool {
+		return digest.ScoreChanges[i].ApplicationName < digest.ScoreChanges[j].ApplicationName
+	})
+	return nil
+}
+
+// addOverdue adds campaign applications that are not assessed by the campaign's due date
+func (s *DigestService) addOverdue(ctx context.Context, digest *models.Digest, names map[string]string) error {
+	campaigns, err := s.campaigns.ListCampaigns(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	
+	for _, campaign := range campaigns {
+		progress, err := s.campaigns.GetProgress(ctx, campaign.ID)
+		if err != nil {
+			return err
+		}
+		
+		for _, entry := range progress.Entries {
+			if _, ok := names[entry.ApplicationID]; !ok || !entry.Overdue {
+				continue
+			}
+			digest.Overdue = append(digest.Overdue, models.DigestOverdueEntry{
+				CampaignID:      campaign.ID,
+				CampaignName:    campaign.Name,
+				ApplicationID:   entry.ApplicationID,
+				ApplicationName: entry.ApplicationName,
+				Owner:           entry.Owner,
+				Status:          entry.Status,
+				DueDate:         entry.DueDate,
+			})
+		}
+	}
+	
+	return nil
+}
+
+// Run sends the digest whenever its weekly send time has passed until the context is cancelled
+func (s *DigestService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		if err := s.SendDue(ctx, time.Now()); err != nil {
+			log.Printf("Portfolio digest failed: %v", err)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue queues the digest for every recipient if the latest scheduled send time has not been
+// served yet. Each digest covers the time since the previous one, or the past week for the first.
+func (s *DigestService) SendDue(ctx context.Context, now time.Time) error {
+	lockCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(lockCtx, digestLock)
+	if err != nil {
+		// Another replica is sending the digest
+		return nil
+	}
+	defer unlock()
+	
+	// Read the state under the lock in case another replica just sent the digest
+	state, err := s.storage.GetDigestState(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get digest state: %w", err)
+	}
+	
+	scheduled := s.lastScheduled(now)
+	start := scheduled.AddDate(0, 0, -7)
+	if state != nil {
+		if !state.LastSentAt.Before(scheduled) {
+			return nil
+		}
+		if !state.LastSentAt.IsZero() {
+			start = state.LastSentAt
+		}
+	}
+	
+	if err := s.send(ctx, start, now); err != nil {
+		return err
+	}
+	
+	if err := s.storage.SaveDigestState(ctx, &models.DigestState{LastSentAt: now}); err != nil {
+		return fmt.Errorf("failed to save digest state: %w", err)
+	}
+	return nil
+}
+
+// send queues the digest of a period to the configured recipients and every subscriber.
+// Recipients whose digest would be empty get no email.
+func (s *DigestService) send(ctx context.Context, start, end time.Time) error {
+	subscriptions, err := s.storage.ListDigestSubscriptions(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list subscriptions: %w", err)
+	}
+	
+	var full *models.Digest
+	if len(s.config.Recipients) > 0 {
+		if full, err = s.BuildDigest(ctx, start, end, nil); err != nil {
+			return err
+		}
+	}
+	
+	subject := "Portfolio digest for the week of " + start.Format("January 2, 2006")
+	queued := 0
+	queue := func(to string, digest *models.Digest) error {
+		if digestEmpty(digest) {
+			return nil
+		}
+		queued++
+		return s.notifier.QueueEmail(ctx, models.EventTypePortfolioDigest, to, subject, RenderDigest(digest))
+	}
+	
+	for _, to := range s.config.Recipients {
+		if err := queue(to, full); err != nil {
+			return err
+		}
+	}
+	
+	for _, subscription := range subscriptions {
+		digest, err := s.BuildDigest(ctx, start, end, subscription)
+		if err != nil {
+			return err
+		}
+		if err := queue(subscription.Email, digest); err != nil {
+			return err
+		}
+	}
+	
+	log.Printf("Queued %d portfolio digests covering %s to %s", queued, start.Format(time.RFC3339), end.Format(time.RFC3339))
+	return nil
+}
+
+// lastScheduled returns the latest weekly send time at or before now
+func (s *DigestService) lastScheduled(now time.Time) time.Time {
+	daysSince := (int(now.Weekday()) - int(s.config.Weekday) + 7) % 7
+	scheduled := time.Date(now.Year(), now.Month(), now.Day()-daysSince, s.config.Hour, 0, 0, 0, now.Location())
+	if scheduled.After(now) {
+		scheduled = scheduled.AddDate(0, 0, -7)
+	}
+	return scheduled
+}
+
+// RenderDigest renders a digest as the plain-text body of an email
+func RenderDigest(digest *models.Digest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Kubernetes readiness portfolio digest\n%s to %s\n", digest.PeriodStart, digest.PeriodEnd)
+	
+	if len(digest.Completed) > 0 {
+		fmt.Fprintf(&b, "\nCompleted assessments (%d)\n", len(digest.Completed))
+		for _, entry := range digest.Completed {
+			fmt.Fprintf(&b, "- %s: %d%% (grade %s), assessment %s\n", entry.ApplicationName, entry.ScorePercent, entry.Grade, entry.AssessmentID)
+		}
+	}
+	
+	if len(digest.ScoreChanges) > 0 {
+		fmt.Fprintf(&b, "\nScore changes (%d)\n", len(digest.ScoreChanges))
+		for _, entry := range digest.ScoreChanges {
+			fmt.Fprintf(&b, "- %s: %d%% -> %d%% (%+d)\n", entry.ApplicationName, entry.PreviousPercent, entry.ScorePercent, entry.Change)
+		}
+	}
+	
+	if len(digest.Overdue) > 0 {
+		fmt.Fprintf(&b, "\nOverdue assessments (%d)\n", len(digest.Overdue))
+		for _, entry := range digest.Overdue {
+			owner := ""
+			if entry.Owner != "" {
+				owner = ", owner " + entry.Owner
+			}
+			fmt.Fprintf(&b, "- %s in %s: %s, due %s%s\n", entry.ApplicationName, entry.CampaignName, entry.Status, entry.DueDate, owner)
+		}
+	}
+	
+	return b.String()
+}
+
+// digestEmpty reports whether a digest has nothing to report
+func digestEmpty(digest *models.Digest) bool {
+	return len(digest.Completed) == 0 && len(digest.ScoreChanges) == 0 && len(digest.Overdue) == 0
+}
+
+// includesSection reports whether a subscription includes a digest section
+func includesSection(subscription *models.DigestSubscription, section string) bool {
+	return subscription == nil || len(subscription.Sections) == 0 || containsString(subscription.Sections, section)
+}
+
+// matchesTags reports whether tags contain every required key with its value
+func matchesTags(tags, required map[string]string) bool {
+	for key, value := range required {
+		if tags[key] != value {
+			return false
+		}
+	}
+	return true
+}
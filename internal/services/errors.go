@@ -0,0 +1,19 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Sentinel errors returned (wrapped) by services so the API layer can map them to status codes
+var (
+	ErrNotFound = errors.New("not found")
+	ErrConflict = errors.New("conflict")
+	
+	// ErrInvalidAnswer is returned when an answer cannot be recorded as given, such as an answer
+	// to a question that does not apply to the application or one missing a required explanation
+	ErrInvalidAnswer = errors.New("invalid answer")
+	
+	// ErrAssessmentApproved is returned when changing an approved assessment or its report
+	ErrAssessmentApproved = fmt.Errorf("%w: assessment is approved and must be reopened by an admin before it can be changed", ErrConflict)
+)
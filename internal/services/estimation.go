@@ -0,0 +1,134 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"questionnaire-app/internal/models"
+	"time"
+)
+
+// DefaultEstimationConfig returns the built-in effort ranges and hourly rate
+func DefaultEstimationConfig() *models.EstimationConfig {
+	return &models.EstimationConfig{
+		Currency:   "USD",
+		HourlyRate: 100,
+		Efforts: []models.EffortRange{
+			{Label: "Low", MinHours: 8, MaxHours: 40},
+			{Label: "Medium", MinHours: 40, MaxHours: 160},
+			{Label: "High", MinHours: 160, MaxHours: 480},
+		},
+	}
+}
+
+// GetEstimationConfig returns the active estimation model
+func (s *AssessmentService) GetEstimationConfig(ctx context.Context) (*models.EstimationConfig, error) {
+	config, err := s.storage.GetEstimationConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get estimation config: %w", err)
+	}
+	
+	if config == nil {
+		return DefaultEstimationConfig(), nil
+	}
+	return config, nil
+}
+
+// UpdateEstimationConfig validates and stores a new estimation model. It applies to reports
+// generated from now on.
+func (s *AssessmentService) UpdateEstimationConfig(ctx context.Context, config *models.EstimationConfig) (*models.EstimationConfig, error) {
+	if err := validateEstimationConfig(config); err != nil {
+		return nil, err
+	}
+	
+	now := time.Now()
+	config.UpdatedAt = &now
+	if err := s.storage.SaveEstimationConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save estimation config: %w", err)
+	}
+	
+	return config, nil
+}
+
+// estimatePlan adds hour and cost ranges to each modernization step and the total to the report
+func estimatePlan(report *models.Report, config *models.EstimationConfig) {
+	estimate := &models.EffortEstimate{
+		Currency:   config.Currency,
+		HourlyRate: config.HourlyRate,
+	}
+	
+	for i := range report.ModernizationPlan {
+		step := &report.ModernizationPlan[i]
+		effort, ok := config.RangeFor(step.Effort)
+		if !ok {
+			if !containsString(estimate.Unestimated, step.Effort) {
+				estimate.Unestimated = append(estimate.Unestimated, step.Effort)
+			}
+			continue
+		}
+		
+		step.Estimate = &models.StepEstimate{
+			MinHours: effort.MinHours,
+			MaxHours: effort.MaxHours,
+			MinCost:  roundCost(effort.MinHours * config.HourlyRate),
+			MaxCost:  roundCost(effort.MaxHours * config.HourlyRate),
+		}
+		estimate.MinHours += effort.MinHours
+		estimate.MaxHours += effort.MaxHours
+	}
+	
+	estimate.MinCost = roundCost(estimate.MinHours * config.HourlyRate)
+	estimate.MaxCost = roundCost(estimate.MaxHours * config.HourlyRate)
+	report.Estimate = estimate
+}
+
+// withEstimate returns the report itself if it carries an estimate, otherwise a copy estimated
+// with the given model so reports issued before estimates were recorded can still be planned
+func withEstimate(report *models.Report, config *models.EstimationConfig) *models.Report {
+	if report.Estimate != nil {
+		return report
+	}
+	
+	copied := *report
+	copied.ModernizationPlan = append([]models.ModernizationStep(nil), report.ModernizationPlan...)
+	estimatePlan(&copied, config)
+	return &copied
+}
+
+// roundCost rounds a cost to cents
+func roundCost(cost float64) float64 {
+	return math.Round(cost*100) / 100
+}
+
+// validateEstimationConfig checks that the rate is positive and every effort range is ordered
+func validateEstimationConfig(config *models.EstimationConfig) error {
+	if config.Currency == "" {
+		return errors.New("currency is required")
+	}
+	
+	if config.HourlyRate < 0 {
+		return errors.New("hourly rate must not be negative")
+	}
+	
+	if len(config.Efforts) == 0 {
+		return errors.New("at least one effort range is required")
+	}
+	
+	labels := make(map[string]bool)
+	for _, effort := range config.Efforts {
+		if effort.Label == "" {
+			return errors.New("effort label is required")
+		}
+		if labels[effort.Label] {
+			return fmt.Errorf("duplicate effort label: %s", effort.Label)
+		}
+		labels[effort.Label] = true
+		
+		if effort.MinHours < 0 || effort.MaxHours < effort.MinHours {
+			return fmt.Errorf("effort %s needs 0 <= minHours <= maxHours", effort.Label)
+		}
+	}
+	
+	return nil
+}
@@ -0,0 +1,319 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"time"
+)
+
+// GetAssessmentHistory returns the event log of an assessment
+func (s *AssessmentService) GetAssessmentHistory(ctx context.Context, assessmentID string) ([]*models.AssessmentEvent, error) {
+	events, err := s.storage.ListEvents(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	
+	if events == nil {
+		events = []*models.AssessmentEvent{}
+	}
+	return events, nil
+}
+
+// GetAssessmentAt rebuilds the state of an assessment as it was at the given time
+func (s *AssessmentService) GetAssessmentAt(ctx context.Context, assessmentID string, at time.Time) (*models.Assessment, error) {
+	events, err := s.storage.ListEvents(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	
+	return replayEvents(events, at), nil
+}
+
+// appendEvent records a new event for an assessment and returns the state rebuilt from the
+// full event log. Callers must hold the assessment lock so sequence numbers stay unique.
+func (s *AssessmentService) appendEvent(ctx context.Context, assessment *models.Assessment, event *models.AssessmentEvent) (*models.Assessment, error) {
+	events, err := s.storage.ListEvents(ctx, assessment.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+	
+	// Assessments created before event sourcing are seeded from their stored state
+	if len(events) == 0 && event.Type != models.EventAssessmentStarted {
+		seed := startedEvent(assessment, assessment.StartedBy)
+		if err := s.storage.AppendEvent(ctx, seed); err != nil {
+			return nil, fmt.Errorf("failed to append event: %w", err)
+		}
+		events = append(events, seed)
+	}
+	
+	event.AssessmentID = assessment.ID
+	event.Sequence = len(events) + 1
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+	
+	if err := s.storage.AppendEvent(ctx, event); err != nil {
+		return nil, fmt.Errorf("failed to append event: %w", err)
+	}
+	events = append(events, event)
+	
+	state := replayEvents(events, time.Time{})
+	if state == nil {
+		return nil, errors.New("event log does not start with AssessmentStarted")
+	}
+	return state, nil
+}
+
+// startedEvent creates the first event of an assessment's log
+func startedEvent(assessment *models.Assessment, user string) *models.AssessmentEvent {
+	initial := *assessment
+	initial.Answers = copyStringMap(assessment.Answers)
+	initial.Explanations = copyStringMap(assessment.Explanations)
+	initial.References = copyReferences(assessment.References)
+	initial.AnsweredBy = copyStringMap(assessment.AnsweredBy)
+	initial.AnsweredAt = copyTimes(assessment.AnsweredAt)
+	initial.Suggestions = copySuggestions(assessment.Suggestions)
+	initial.CopiedFrom = copyStringMap(assessment.CopiedFrom)
+	initial.Provenance = copyProvenance(assessment.Provenance)
+	initial.Responses = copyResponses(assessment.Responses)
+	
+	return &models.AssessmentEvent{
+		AssessmentID: assessment.ID,
+		Sequence:     1,
+		Type:         models.EventAssessmentStarted,
+		OccurredAt:   time.Now(),
+		User:         user,
+		Assessment:   &initial,
+	}
+}
+
+// replayEvents folds an event log into assessment state, ignoring events after until
+// unless until is zero. It returns nil if no assessment existed at that time. The state's
+// UpdatedAt is the time of the last event applied.
+func replayEvents(events []*models.AssessmentEvent, until time.Time) *models.Assessment {
+	var state *models.Assessment
+	
+	for _, event := range events {
+		occurredAt := event.OccurredAt
+		if !until.IsZero() && occurredAt.After(until) {
+			break
+		}
+		
+		switch event.Type {
+		case models.EventAssessmentStarted:
+			if event.Assessment == nil {
+				continue
+			}
+			initial := *event.Assessment
+			initial.Answers = copyStringMap(event.Assessment.Answers)
+			initial.Explanations = copyStringMap(event.Assessment.Explanations)
+			initial.References = copyReferences(event.Assessment.References)
+			initial.AnsweredBy = copyStringMap(event.Assessment.AnsweredBy)
+			initial.AnsweredAt = copyTimes(event.Assessment.AnsweredAt)
+			initial.Suggestions = copySuggestions(event.Assessment.Suggestions)
+			initial.CopiedFrom = copyStringMap(event.Assessment.CopiedFrom)
+			initial.Provenance = copyProvenance(event.Assessment.Provenance)
+			initial.Responses = copyResponses(event.Assessment.Responses)
+			if initial.StartedAt.IsZero() {
+				initial.StartedAt = event.OccurredAt
+			}
+			if initial.Answers == nil {
+				initial.Answers = make(map[string]string)
+			}
+			state = &initial
+		case models.EventAnswerSaved:
+			if state == nil {
+				continue
+			}
+			optionID, explanation := event.OptionID, event.Explanation
+			if event.Consensus != nil {
+				optionID, explanation = event.Consensus.OptionID, event.Consensus.Explanation
+			}
+			state.Answers[event.QuestionID] = optionID
+			if explanation != "" {
+				if state.Explanations == nil {
+					state.Explanations = make(map[string]string)
+				}
+				state.Explanations[event.QuestionID] = explanation
+			} else {
+				delete(state.Explanations, event.QuestionID)
+			}
+			if len(event.References) > 0 {
+				if state.References == nil {
+					state.References = make(map[string][]string)
+				}
+				state.References[event.QuestionID] = event.References
+			} else {
+				delete(state.References, event.QuestionID)
+			}
+			if state.AnsweredAt == nil {
+				state.AnsweredAt = make(map[string]time.Time)
+			}
+			state.AnsweredAt[event.QuestionID] = event.OccurredAt
+			if event.User != "" {
+				if state.AnsweredBy == nil {
+					state.AnsweredBy = make(map[string]string)
+				}
+				state.AnsweredBy[event.QuestionID] = event.User
+			} else {
+				delete(state.AnsweredBy, event.QuestionID)
+			}
+			if event.CopiedFrom != "" {
+				if state.CopiedFrom == nil {
+					state.CopiedFrom = make(map[string]string)
+				}
+				state.CopiedFrom[event.QuestionID] = event.CopiedFrom
+			} else {
+				delete(state.CopiedFrom, event.QuestionID)
+			}
+			if event.Provenance != nil {
+				if state.Provenance == nil {
+					state.Provenance = make(map[string]models.AnswerProvenance)
+				}
+				state.Provenance[event.QuestionID] = *event.Provenance
+			} else {
+				// Answers saved before provenance was recorded
+				delete(state.Provenance, event.QuestionID)
+			}
+			if !countsAsResponse(event.Provenance) {
+				delete(state.Responses, event.QuestionID)
+			} else if event.User != "" {
+				if state.Responses == nil {
+					state.Responses = make(map[string]map[string]models.AnswerResponse)
+				}
+				if state.Responses[event.QuestionID] == nil {
+					state.Responses[event.QuestionID] = make(map[string]models.AnswerResponse)
+				}
+				state.Responses[event.QuestionID][event.User] = models.AnswerResponse{
+					OptionID:    event.OptionID,
+					Explanation: event.Explanation,
+					AnsweredAt:  event.OccurredAt,
+				}
+			}
+			delete(state.Suggestions, event.QuestionID)
+		case models.EventWeightsOverridden:
+			if state == nil {
+				continue
+			}
+			state.WeightOverrides = event.WeightOverrides
+		case models.EventExternalIDSet:
+			if state == nil {
+				continue
+			}
+			state.ExternalID = event.ExternalID
+		case models.EventAssessmentCompleted:
+			if state == nil {
+				continue
+			}
+			state.Status = "completed"
+			completedAt := occurredAt
+			state.CompletedAt = &completedAt
+		case models.EventAnswersSuggested:
+			if state == nil {
+				continue
+			}
+			if state.Suggestions == nil {
+				state.Suggestions = make(map[string]models.AnswerSuggestion)
+			}
+			for _, suggestion := range event.Suggestions {
+				state.Suggestions[suggestion.QuestionID] = suggestion
+			}
+		case models.EventSuggestionDismissed:
+			if state == nil {
+				continue
+			}
+			delete(state.Suggestions, event.QuestionID)
+		case models.EventAssessmentApproved:
+			if state == nil {
+				continue
+			}
+			state.Status = "approved"
+			state.ApprovedBy = event.User
+			approvedAt := occurredAt
+			state.ApprovedAt = &approvedAt
+			state.ApprovalChecklist = event.Checklist
+		case models.EventAssessmentReopened:
+			if state == nil {
+				continue
+			}
+			state.Status = "in_progress"
+			state.CompletedAt = nil
+			state.ApprovedBy = ""
+			state.ApprovedAt = nil
+			state.ApprovalChecklist = nil
+		}
+		
+		if state != nil && !occurredAt.IsZero() {
+			state.UpdatedAt = occurredAt
+		}
+	}
+	
+	return state
+}
+
+// copyStringMap returns a shallow copy of a string map, preserving nil
+func copyStringMap(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string]string, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// copyTimes returns a copy of a map of timestamps, preserving nil
+func copyTimes(m map[string]time.Time) map[string]time.Time {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string]time.Time, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// copyReferences returns a copy of a reference map, preserving nil
+func copyReferences(m map[string][]string) map[string][]string {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string][]string, len(m))
+	for k, v := range m {
+		copied[k] = append([]string(nil), v...)
+	}
+	return copied
+}
+
+// copySuggestions returns a shallow copy of a suggestion map, preserving nil
+func copySuggestions(m map[string]models.AnswerSuggestion) map[string]models.AnswerSuggestion {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string]models.AnswerSuggestion, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
+
+// copyProvenance returns a shallow copy of a provenance map, preserving nil
+func copyProvenance(m map[string]models.AnswerProvenance) map[string]models.AnswerProvenance {
+	if m == nil {
+		return nil
+	}
+	
+	copied := make(map[string]models.AnswerProvenance, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+)
+
+// maxReferences and maxReferenceLength bound the evidence links of one answer
+const (
+	maxReferences      = 10
+	maxReferenceLength = 2000
+)
+
+// ErrEvidenceMissing is returned when an assessment cannot be completed because answers of
+// questions requiring evidence have no reference link
+var ErrEvidenceMissing = errors.New("evidence required")
+
+// normalizeReferences trims and deduplicates the evidence links of an answer and checks that
+// each is an absolute http or https URL
+func normalizeReferences(references []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, reference := range references {
+		reference = strings.TrimSpace(reference)
+		if reference == "" || seen[reference] {
+			continue
+		}
+		if len(reference) > maxReferenceLength {
+			return nil, fmt.Errorf("%w: reference links must not exceed %d characters", ErrInvalidAnswer, maxReferenceLength)
+		}
+		parsed, err := url.Parse(reference)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: reference %q is not an http or https link", ErrInvalidAnswer, reference)
+		}
+		seen[reference] = true
+		normalized = append(normalized, reference)
+	}
+	if len(normalized) > maxReferences {
+		return nil, fmt.Errorf("%w: an answer takes at most %d reference links", ErrInvalidAnswer, maxReferences)
+	}
+	return normalized, nil
+}
+
+// requiresEvidence reports whether choosing an option of a question needs a reference link
+func requiresEvidence(question *models.Question, option *models.Option) bool {
+	return question.Evidence != nil && option != nil && option.Points >= question.Evidence.MinPoints
+}
+
+// missingEvidence returns the sorted IDs of the scored questions whose answer requires evidence
+// but has no reference link
+func missingEvidence(assessment *models.Assessment, questions []*models.Question, tags map[string]string) []string {
+	var missing []string
+	for _, question := range questions {
+		if question.Evidence == nil || !question.AppliesTo(tags) || !question.VisibleFor(assessment.Answers) || !inScope(assessment, question) {
+			continue
+		}
+		optionID, answered := assessment.Answers[question.ID]
+		if !answered {
+			continue
+		}
+		if requiresEvidence(question, findOption(question.Options, optionID)) && len(assessment.References[question.ID]) == 0 {
+			missing = append(missing, question.ID)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// validateEvidenceRule checks that a question's evidence rule can apply to one of its options
+func validateEvidenceRule(question *models.Question) []models.QuestionProblem {
+	if question.Evidence == nil {
+		return nil
+	}
+	for _, option := range question.Options {
+		if option.Points >= question.Evidence.MinPoints {
+			return nil
+		}
+	}
+	return []models.QuestionProblem{{Field: "evidence", Message: fmt.Sprintf("no option is worth %d points or more", question.Evidence.MinPoints)}}
+}
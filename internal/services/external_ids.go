@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// ErrInvalidExternalID is returned for external IDs that cannot be looked up by URL
+var ErrInvalidExternalID = errors.New("invalid external ID")
+
+// maxExternalIDLength limits external IDs, which are keys of other systems such as a CMDB
+// sys_id or a service catalog slug
+const maxExternalIDLength = 200
+
+// externalIDLock serializes external ID assignments, so two entities never get the same one
+const externalIDLock = "external-ids"
+
+// normalizeExternalID trims an external ID and checks that it fits in a URL path segment. An
+// empty ID clears the external ID.
+func normalizeExternalID(externalID string) (string, error) {
+	externalID = strings.TrimSpace(externalID)
+	if len(externalID) > maxExternalIDLength {
+		return "", fmt.Errorf("%w: longer than %d characters", ErrInvalidExternalID, maxExternalIDLength)
+	}
+	if strings.ContainsFunc(externalID, func(r rune) bool { return r == '/' || unicode.IsSpace(r) || unicode.IsControl(r) }) {
+		return "", fmt.Errorf("%w: %q contains a slash or whitespace", ErrInvalidExternalID, externalID)
+	}
+	return externalID, nil
+}
+
+// lockExternalIDs takes the lease serializing external ID assignments
+func lockExternalIDs(ctx context.Context, locker storage.Locker) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := locker.Lock(ctx, externalIDLock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock external IDs: %w", err)
+	}
+	return unlock, nil
+}
+
+// findApplicationByExternalID returns the application with an external ID, or nil
+func findApplicationByExternalID(ctx context.Context, repo storage.ApplicationRepository, externalID string) (*models.Application, error) {
+	apps, err := repo.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		if app.ExternalID == externalID {
+			return app, nil
+		}
+	}
+	return nil, nil
+}
+
+// findAssessmentByExternalID returns the assessment with an external ID, or nil
+func findAssessmentByExternalID(ctx context.Context, repo storage.AssessmentRepository, externalID string) (*models.Assessment, error) {
+	assessments, err := repo.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	for _, assessment := range assessments {
+		if assessment.ExternalID == externalID {
+			return assessment, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetApplicationByExternalID returns the application carrying an external ID
+func (s *ApplicationService) GetApplicationByExternalID(ctx context.Context, externalID string) (*models.Application, error) {
+	app, err := findApplicationByExternalID(ctx, s.storage, strings.TrimSpace(externalID))
+	if err != nil {
+		return nil, err
+	}
+	if app == nil || externalID == "" {
+		return nil, fmt.Errorf("application with external ID %q %w", externalID, ErrNotFound)
+	}
+	return app, nil
+}
+
+// SetExternalID sets or, if empty, clears the external ID of an application. IDs already
+// carried by another application are ErrConflict.
+func (s *ApplicationService) SetExternalID(ctx context.Context, id, externalID string) (*models.Application, error) {
+	externalID, err := normalizeExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+	
+	unlockIDs, err := lockExternalIDs(ctx, s.locker)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockIDs()
+	
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	unlock, err := s.locker.Lock(lockCtx, "application-"+id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock application: %w", err)
+	}
+	defer unlock()
+	
+	app, err := s.storage.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	if app.ExternalID == externalID {
+		return app, nil
+	}
+	
+	if externalID != "" {
+		owner, err := findApplicationByExternalID(ctx, s.storage, externalID)
+		if err != nil {
+			return nil, err
+		}
+		if owner != nil {
+			return nil, fmt.Errorf("%w: external ID %q belongs to application %s", ErrConflict, externalID, owner.ID)
+		}
+	}
+	
+	app.ExternalID = externalID
+	app.UpdatedAt = time.Now()
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to save application: %w", err)
+	}
+	return app, nil
+}
+
+// GetAssessmentByExternalID returns the assessment carrying an external ID
+func (s *AssessmentService) GetAssessmentByExternalID(ctx context.Context, externalID string) (*models.Assessment, error) {
+	assessment, err := findAssessmentByExternalID(ctx, s.storage, strings.TrimSpace(externalID))
+	if err != nil {
+		return nil, err
+	}
+	if assessment == nil || externalID == "" {
+		return nil, fmt.Errorf("assessment with external ID %q %w", externalID, ErrNotFound)
+	}
+	return assessment, nil
+}
+
+// SetExternalID sets or, if empty, clears the external ID of an assessment. The external ID
+// links the assessment to other systems rather than changing its content, so approved
+// assessments accept it too. IDs already carried by another assessment are ErrConflict.
+func (s *AssessmentService) SetExternalID(ctx context.Context, assessmentID, externalID, user string) (*models.Assessment, error) {
+	externalID, err := normalizeExternalID(externalID)
+	if err != nil {
+		return nil, err
+	}
+	
+	unlockIDs, err := lockExternalIDs(ctx, s.locker)
+	if err != nil {
+		return nil, err
+	}
+	defer unlockIDs()
+	
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	if assessment.ExternalID == externalID {
+		return assessment, nil
+	}
+	if err := s.checkAssessmentExternalID(ctx, externalID); err != nil {
+		return nil, err
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:       models.EventExternalIDSet,
+		User:       user,
+		ExternalID: externalID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	return state, nil
+}
+
+// checkAssessmentExternalID returns ErrConflict if an assessment already carries externalID
+func (s *AssessmentService) checkAssessmentExternalID(ctx context.Context, externalID string) error {
+	if externalID == "" {
+		return nil
+	}
+	owner, err := findAssessmentByExternalID(ctx, s.storage, externalID)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		return fmt.Errorf("%w: external ID %q belongs to assessment %s", ErrConflict, externalID, owner.ID)
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned when a peer's request for this instance's summary is refused
+var (
+	ErrFederationDisabled     = errors.New("federation is not enabled")
+	ErrFederationUnauthorized = errors.New("invalid federation token")
+)
+
+// maxFederatedSummarySize limits the size of a summary pulled from a peer
+const maxFederatedSummarySize = 16 << 20
+
+// FederationConfig configures sharing summaries with and pulling them from other instances
+type FederationConfig struct {
+	Instance   string                   // name of this instance in federated portfolios
+	Token      func() string            // token peers must present; serving summaries is disabled if empty
+	Peers      []models.FederationPeer  // instances to pull summaries from
+	PeerTokens map[string]func() string // peer name -> token, overriding the token of the peer file
+	Timeout    time.Duration            // of each pull
+	HTTPClient *http.Client
+}
+
+// FederationService lets a central instance aggregate the portfolio summaries of team-level
+// instances. Only summary data is shared: counts, averages and the latest score of each
+// application, never answers or reports.
+type FederationService struct {
+	storage   storage.Storage
+	portfolio *PortfolioService
+	config    FederationConfig
+	
+	mu     sync.Mutex
+	pulled map[string]models.FederatedInstance // peer name -> last successful pull
+}
+
+// NewFederationService creates a new federation service
+func NewFederationService(storage storage.Storage, portfolio *PortfolioService, config FederationConfig) *FederationService {
+	if config.HTTPClient == nil {
+		config.HTTPClient = http.DefaultClient
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	return &FederationService{
+		storage:   storage,
+		portfolio: portfolio,
+		config:    config,
+		pulled:    make(map[string]models.FederatedInstance),
+	}
+}
+
+// LoadFederationPeers reads the instances to pull summaries from from a JSON file
+func LoadFederationPeers(path string) ([]models.FederationPeer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read federation peers: %w", err)
+	}
+	
+	var peers []models.FederationPeer
+	if err := json.Unmarshal(data, &peers); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal federation peers: %w", err)
+	}
+	
+	seen := make(map[string]bool, len(peers))
+	for i := range peers {
+		peer := &peers[i]
+		peer.Name = strings.TrimSpace(peer.Name)
+		if peer.Name == "" {
+			return nil, fmt.Errorf("federation peer %d requires a name", i)
+		}
+		if seen[peer.Name] {
+			return nil, fmt.Errorf("duplicate federation peer %q", peer.Name)
+		}
+		seen[peer.Name] = true
+		
+		parsed, err := url.Parse(peer.URL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("federation peer %q requires an http(s) url", peer.Name)
+		}
+		peer.URL = strings.TrimRight(peer.URL, "/")
+	}
+	
+	return peers, nil
+}
+
+// Authorize checks the token a peer presented for this instance's summary
+func (s *FederationService) Authorize(token string) error {
+	expected := ""
+	if s.config.Token != nil {
+		expected = s.config.Token()
+	}
+	if expected == "" {
+		return ErrFederationDisabled
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(expected)) != 1 {
+		return ErrFederationUnauthorized
+	}
+	return nil
+}
+
+// Summary returns the summary data this instance shares with a central instance
+func (s *FederationService) Summary(ctx context.Context) (*models.FederatedSummary, error) {
+	summary, err := s.portfolio.PortfolioSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.portfolio.portfolioSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	federated := &models.FederatedSummary{
+		Instance:     s.config.Instance,
+		GeneratedAt:  time.Now(),
+		Summary:      *summary,
+		Applications: []models.FederatedApplication{},
+	}
+	for _, app := range apps {
+		entry, ok := snapshot.Entries[app.ID]
+		if !ok {
+			continue
+		}
+		score := entry.LatestScore
+		score.Stale = s.portfolio.assessments.ReportStale(score.GeneratedAt)
+		federated.Applications = append(federated.Applications, models.FederatedApplication{
+			ApplicationID: app.ID,
+			Name:          app.Name,
+			LatestScore:   score,
+		})
+	}
+	sort.Slice(federated.Applications, func(i, j int) bool {
+		return federated.Applications[i].Name < federated.Applications[j].Name
+	})
+	return federated, nil
+}
+
+// Portfolio pulls the summaries of all peers and aggregates them with this instance's. A peer
+// that cannot be reached contributes the summary last pulled from it by this process, if any.
+func (s *FederationService) Portfolio(ctx context.Context) (*models.FederatedPortfolio, error) {
+	local, err := s.Summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	now := time.Now()
+	instances := make([]models.FederatedInstance, len(s.config.Peers)+1)
+	instances[0] = models.FederatedInstance{Name: s.config.Instance, Status: models.FederationOK, FetchedAt: &now, Summary: local}
+	
+	var wg sync.WaitGroup
+	for i, peer := range s.config.Peers {
+		wg.Add(1)
+		go func(i int, peer models.FederationPeer) {
+			defer wg.Done()
+			instances[i+1] = s.pullPeer(ctx, peer)
+		}(i, peer)
+	}
+	wg.Wait()
+	
+	return &models.FederatedPortfolio{
+		GeneratedAt: now,
+		Totals:      federatedTotals(instances),
+		Instances:   instances,
+	}, nil
+}
+
+// pullPeer pulls a peer's summary, falling back to the last successful pull on failure
+func (s *FederationService) pullPeer(ctx context.Context, peer models.FederationPeer) models.FederatedInstance {
+	summary, err := s.fetchSummary(ctx, peer)
+	
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	
+	if err == nil {
+		fetchedAt := time.Now()
+		instance := models.FederatedInstance{
+			Name:      peer.Name,
+			URL:       peer.URL,
+			Status:    models.FederationOK,
+			FetchedAt: &fetchedAt,
+			Summary:   summary,
+		}
+		s.pulled[peer.Name] = instance
+		return instance
+	}
+	
+	if cached, ok := s.pulled[peer.Name]; ok {
+		cached.Status = models.FederationCached
+		cached.Error = err.Error()
+		return cached
+	}
+	return models.FederatedInstance{Name: peer.Name, URL: peer.URL, Status: models.FederationUnavailable, Error: err.Error()}
+}
+
+// fetchSummary requests a peer's federated summary
+func (s *FederationService) fetchSummary(ctx context.Context, peer models.FederationPeer) (*models.FederatedSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.config.Timeout)
+	defer cancel()
+	
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.URL+"/api/federation/summary", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	token := peer.Token
+	if peerToken, ok := s.config.PeerTokens[peer.Name]; ok {
+		token = peerToken()
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxFederatedSummarySize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read summary: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiError struct {
+			Error string `json:"error"`
+		}
+		if json.Unmarshal(data, &apiError) == nil && apiError.Error != "" {
+			return nil, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, apiError.Error)
+		}
+		return nil, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	
+	var summary models.FederatedSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to decode summary: %w", err)
+	}
+	if summary.Applications == nil {
+		summary.Applications = []models.FederatedApplication{}
+	}
+	return &summary, nil
+}
+
+// federatedTotals sums the summaries of the instances
+func federatedTotals(instances []models.FederatedInstance) models.FederatedTotals {
+	totals := models.FederatedTotals{
+		Instances:        len(instances),
+		Bands:            make(map[string]int),
+		Grades:           make(map[string]int),
+		CategoryAverages: make(map[string]float64),
+	}
+	
+	var scoreTotal float64
+	categoryTotals := make(map[string]float64)
+	categoryWeights := make(map[string]int)
+	for _, instance := range instances {
+		if instance.Summary == nil {
+			continue
+		}
+		summary := instance.Summary.Summary
+		totals.Reporting++
+		totals.Applications += summary.Applications
+		totals.Assessed += summary.Assessed
+		totals.Stale += summary.Stale
+		scoreTotal += summary.AverageScorePercent * float64(summary.Assessed)
+		for band, count := range summary.Bands {
+			totals.Bands[band] += count
+		}
+		for grade, count := range summary.Grades {
+			totals.Grades[grade] += count
+		}
+		for category, average := range summary.CategoryAverages {
+			categoryTotals[category] += average * float64(summary.Assessed)
+			categoryWeights[category] += summary.Assessed
+		}
+	}
+	
+	if totals.Assessed > 0 {
+		totals.AverageScorePercent = roundTenth(scoreTotal / float64(totals.Assessed))
+	}
+	for category, total := range categoryTotals {
+		if categoryWeights[category] > 0 {
+			totals.CategoryAverages[category] = roundTenth(total / float64(categoryWeights[category]))
+		}
+	}
+	return totals
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+)
+
+// findingsSchema is the JSON schema findings logs follow
+const findingsSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+// Finding kinds
+const (
+	findingRisk           = "risk"
+	findingRecommendation = "recommendation"
+)
+
+// Findings exports the risks and recommendations of an assessment's report as a SARIF-like log.
+// Each finding has a stable rule ID and fingerprint derived from its key, so tools can follow
+// it across reports. Accepted risks are listed as suppressed.
+func (s *AssessmentService) Findings(ctx context.Context, assessmentID string) (*models.FindingsLog, error) {
+	report, err := s.GetReport(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	if report == nil {
+		return nil, fmt.Errorf("report %w", ErrNotFound)
+	}
+	
+	return reportFindings(report), nil
+}
+
+// reportFindings converts a report whose tracking has been marked into a findings log
+func reportFindings(report *models.Report) *models.FindingsLog {
+	location := models.FindingLocation{LogicalLocations: []models.FindingLogicalLocation{{
+		Name:               report.ApplicationID,
+		FullyQualifiedName: report.ApplicationID,
+		Kind:               "module",
+	}}}
+	if report.Application != nil {
+		location.LogicalLocations[0].Name = report.Application.Name
+	}
+	
+	run := models.FindingsRun{
+		Tool: models.FindingsTool{Driver: models.FindingsDriver{
+			Name:  "questionnaire-app",
+			Rules: []models.FindingRule{},
+		}},
+		Results: []models.Finding{},
+		Properties: models.FindingsRunSummary{
+			AssessmentID:     report.AssessmentID,
+			ApplicationID:    report.ApplicationID,
+			GeneratedAt:      report.GeneratedAt,
+			TotalScore:       report.TotalScore,
+			MaxPossibleScore: report.MaxPossibleScore,
+			Grade:            report.Grade,
+			Band:             report.Band,
+		},
+	}
+	
+	// Risks point to the recommendations of their category as remediation
+	remediation := make(map[string][]string)
+	for _, recommendation := range report.Recommendations {
+		remediation[recommendation.Category] = append(remediation[recommendation.Category], recommendation.Description)
+	}
+	
+	for _, risk := range report.Risks {
+		finding := newFinding(&run, findingRisk, risk.Key, risk.Category, risk.Description, risk.Severity, location)
+		finding.Properties.Remediation = remediation[risk.Category]
+		if acceptance := risk.Acceptance; acceptance != nil {
+			finding.Properties.AcceptedBy = acceptance.AcceptedBy
+			finding.Properties.AcceptedUntil = acceptance.ExpiresOn
+			finding.Properties.AcceptanceExpired = acceptance.Expired
+			if !acceptance.Expired {
+				finding.Suppressions = []models.FindingSuppression{{
+					Kind:          "external",
+					Status:        "accepted",
+					Justification: acceptance.Reason,
+				}}
+			}
+		}
+		run.Results = append(run.Results, finding)
+	}
+	
+	for _, recommendation := range report.Recommendations {
+		finding := newFinding(&run, findingRecommendation, recommendation.Key, recommendation.Category, recommendation.Description, recommendation.Priority, location)
+		finding.Properties.RemediationStatus = models.RemediationOpen
+		if tracked := recommendation.Remediation; tracked != nil {
+			finding.Properties.RemediationStatus = tracked.Status
+			finding.Properties.Owner = tracked.Owner
+			finding.Properties.DueDate = tracked.DueDate
+		}
+		run.Results = append(run.Results, finding)
+	}
+	
+	return &models.FindingsLog{
+		Schema:  findingsSchema,
+		Version: "2.1.0",
+		Runs:    []models.FindingsRun{run},
+	}
+}
+
+// newFinding adds the rule of a finding to the run and returns the finding
+func newFinding(run *models.FindingsRun, kind, key, category, description, severity string, location models.FindingLocation) models.Finding {
+	ruleID := kind + "/" + key
+	run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, models.FindingRule{
+		ID:               ruleID,
+		ShortDescription: models.FindingsMessage{Text: description},
+		Properties:       models.FindingRuleTags{Category: category, Tags: []string{kind, category}},
+	})
+	
+	return models.Finding{
+		RuleID:              ruleID,
+		Level:               findingLevel(severity),
+		Message:             models.FindingsMessage{Text: category + ": " + description},
+		Locations:           []models.FindingLocation{location},
+		PartialFingerprints: map[string]string{"findingKey/v1": key},
+		Properties: models.FindingProperties{
+			Kind:     kind,
+			Category: category,
+			Severity: severity,
+		},
+	}
+}
+
+// findingLevel maps a severity or priority to a SARIF level
+func findingLevel(severity string) string {
+	switch severity {
+	case "High":
+		return "error"
+	case "Medium":
+		return "warning"
+	}
+	return "note"
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// gitCatalogTimeout bounds cloning a catalog repository
+const gitCatalogTimeout = 2 * time.Minute
+
+// checksumPrefix marks the algorithm of catalog checksums
+const checksumPrefix = "sha256:"
+
+// Errors returned when installing a catalog from Git
+var (
+	// ErrInvalidCatalogSource is returned for repository URLs, refs and paths that are not allowed
+	ErrInvalidCatalogSource = errors.New("invalid catalog source")
+	
+	// ErrCatalogFetch is returned when the repository cannot be cloned
+	ErrCatalogFetch = errors.New("failed to fetch catalog")
+	
+	// ErrCatalogChecksum is returned when the catalog files do not have the pinned checksum
+	ErrCatalogChecksum = errors.New("catalog checksum mismatch")
+	
+	// ErrInvalidGitCatalog is returned when the catalog of a repository cannot be read or fails
+	// validation
+	ErrInvalidGitCatalog = errors.New("invalid catalog")
+)
+
+// InstallGitCatalog installs the question catalog at a path of a Git repository, cloned at a
+// tag or branch. If the source pins a checksum, the catalog files must match it, so a moved
+// tag or a compromised repository cannot change what is installed. The catalog must pass the
+// same validation as `server validate`; questions that already exist are kept unless replace
+// is set.
+func (s *QuestionService) InstallGitCatalog(ctx context.Context, source models.GitCatalogSource, replace bool) (*models.CatalogInstallResult, error) {
+	dir, commit, err := cloneCatalog(ctx, source, s.gitEnv)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	
+	root := filepath.Join(dir, filepath.FromSlash(source.Path))
+	if _, err := os.Lstat(root); err != nil {
+		return nil, fmt.Errorf("%w: path %q not found in repository", ErrInvalidGitCatalog, source.Path)
+	}
+	if err := checkInsideClone(dir, root); err != nil {
+		return nil, err
+	}
+	checksum, err := CatalogChecksum(root)
+	if err != nil {
+		return nil, err
+	}
+	if source.Checksum != "" && !strings.EqualFold(source.Checksum, checksum) {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrCatalogChecksum, source.Checksum, checksum)
+	}
+	
+	questions, err := LoadCatalog(root)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGitCatalog, err)
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("%w: no questions found", ErrInvalidGitCatalog)
+	}
+	if validation := ValidateCatalog(questions); !validation.Valid {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidGitCatalog, validationErrors(validation))
+	}
+	
+	catalogID := source.URL
+	if source.Ref != "" {
+		catalogID += "@" + source.Ref
+	}
+	result := &models.CatalogInstallResult{CatalogID: catalogID, Commit: commit, Checksum: checksum}
+	return result, s.installQuestions(ctx, result, questions, replace)
+}
+
+// cloneCatalog makes a shallow clone of a catalog repository into a temporary directory and
+// returns it with the commit it checked out. env is added to the environment of git, to set
+// its proxy and CAs.
+func cloneCatalog(ctx context.Context, source models.GitCatalogSource, env []string) (string, string, error) {
+	if err := validateRepositoryURL(source.URL); err != nil {
+		return "", "", fmt.Errorf("%w: %v", ErrInvalidCatalogSource, err)
+	}
+	if strings.HasPrefix(source.Ref, "-") || strings.ContainsAny(source.Ref, " \t\n") {
+		return "", "", fmt.Errorf("%w: invalid ref %q", ErrInvalidCatalogSource, source.Ref)
+	}
+	if source.Path != "" && !filepath.IsLocal(filepath.FromSlash(source.Path)) {
+		return "", "", fmt.Errorf("%w: path must be relative to the repository root", ErrInvalidCatalogSource)
+	}
+	if source.Checksum != "" && !strings.HasPrefix(source.Checksum, checksumPrefix) {
+		return "", "", fmt.Errorf("%w: checksum must start with %s", ErrInvalidCatalogSource, checksumPrefix)
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, gitCatalogTimeout)
+	defer cancel()
+	
+	dir, err := os.MkdirTemp("", "questionnaire-catalog-")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create clone directory: %w", err)
+	}
+	
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if source.Ref != "" {
+		args = append(args, "--branch", source.Ref)
+	}
+	args = append(args, "--", source.URL, dir)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Env = append(append(os.Environ(), env...), "GIT_TERMINAL_PROMPT=0", "GIT_ALLOW_PROTOCOL=https:ssh:git")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("%w: %v: %s", ErrCatalogFetch, err, strings.TrimSpace(string(output)))
+	}
+	
+	output, err := exec.CommandContext(ctx, "git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", "", fmt.Errorf("%w: failed to read commit: %v", ErrCatalogFetch, err)
+	}
+	return dir, strings.TrimSpace(string(output)), nil
+}
+
+// checkInsideClone rejects a catalog path whose directories or files resolve, through
+// symbolic links in any component, to outside the clone directory
+func checkInsideClone(dir, root string) error {
+	base, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve clone directory: %w", err)
+	}
+	files, err := catalogFiles([]string{root})
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidGitCatalog, err)
+	}
+	
+	for _, path := range append([]string{root}, files...) {
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return fmt.Errorf("%w: %s: %v", ErrInvalidGitCatalog, filepath.ToSlash(name), err)
+		}
+		if rel, err := filepath.Rel(base, resolved); err != nil || !filepath.IsLocal(rel) {
+			return fmt.Errorf("%w: %s resolves to outside the repository", ErrInvalidGitCatalog, filepath.ToSlash(name))
+		}
+	}
+	return nil
+}
+
+// CatalogChecksum returns the checksum of the catalog files at a path: the SHA-256 of one
+// line per JSON or YAML file, in path order, holding the file's SHA-256 and its path relative
+// to the catalog with forward slashes, as printed by sha256sum. Symbolic links are rejected so
+// a catalog cannot pull in files from outside it.
+func CatalogChecksum(path string) (string, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidGitCatalog, err)
+	}
+	if info.Mode()&fs.ModeSymlink != 0 {
+		return "", fmt.Errorf("%w: %s is a symbolic link", ErrInvalidGitCatalog, filepath.Base(path))
+	}
+	
+	files, err := catalogFiles([]string{path})
+	if err != nil {
+		return "", err
+	}
+	
+	lines := make([]string, 0, len(files))
+	for _, file := range files {
+		rel := filepath.Base(file)
+		if info.IsDir() {
+			if rel, err = filepath.Rel(path, file); err != nil {
+				return "", err
+			}
+		}
+		rel = filepath.ToSlash(rel)
+		
+		fileInfo, err := os.Lstat(file)
+		if err != nil {
+			return "", err
+		}
+		if fileInfo.Mode()&fs.ModeSymlink != 0 {
+			return "", fmt.Errorf("%w: %s is a symbolic link", ErrInvalidGitCatalog, rel)
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return "", fmt.Errorf("failed to read %s: %w", rel, err)
+		}
+		sum := sha256.Sum256(data)
+		lines = append(lines, fmt.Sprintf("%s  %s\n", hex.EncodeToString(sum[:]), rel))
+	}
+	sort.Slice(lines, func(i, j int) bool { return lines[i][66:] < lines[j][66:] })
+	
+	digest := sha256.New()
+	for _, line := range lines {
+		digest.Write([]byte(line))
+	}
+	return checksumPrefix + hex.EncodeToString(digest.Sum(nil)), nil
+}
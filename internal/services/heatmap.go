@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"time"
+)
+
+// PortfolioHeatmap builds the matrix of category scores of every assessed application's latest
+// report, ordered by application name. Categories are the union of those scored by any report,
+// so an application's row has no cell for a category its report did not score.
+func (s *PortfolioService) PortfolioHeatmap(ctx context.Context) (*models.PortfolioHeatmap, error) {
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	heatmap := &models.PortfolioHeatmap{
+		GeneratedAt:  time.Now(),
+		Categories:   []string{},
+		Applications: []models.HeatmapRow{},
+	}
+	categories := make(map[string]bool)
+	for _, app := range apps {
+		report, err := s.assessments.GetLatestReport(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
+		if report == nil {
+			continue
+		}
+		
+		row := models.HeatmapRow{
+			ApplicationID:   app.ID,
+			ApplicationName: app.Name,
+			AssessmentID:    report.AssessmentID,
+			ReportedAt:      report.GeneratedAt,
+			ScorePercent:    ReportPercent(report),
+			Grade:           report.Grade,
+			Cells:           heatmapCells(report),
+		}
+		for category := range row.Cells {
+			categories[category] = true
+		}
+		heatmap.Applications = append(heatmap.Applications, row)
+	}
+	
+	for category := range categories {
+		heatmap.Categories = append(heatmap.Categories, category)
+	}
+	sort.Strings(heatmap.Categories)
+	sort.SliceStable(heatmap.Applications, func(i, j int) bool {
+		return heatmap.Applications[i].ApplicationName < heatmap.Applications[j].ApplicationName
+	})
+	return heatmap, nil
+}
+
+// heatmapCells returns a report's category scores with their maxima taken from the breakdown
+func heatmapCells(report *models.Report) map[string]models.HeatmapCell {
+	maxScores := make(map[string]int)
+	for _, question := range report.Breakdown {
+		if !question.Hidden {
+			maxScores[question.Category] += question.MaxScore
+		}
+	}
+	
+	cells := make(map[string]models.HeatmapCell, len(report.CategoryScores))
+	for category, score := range report.CategoryScores {
+		cell := models.HeatmapCell{Score: score, MaxScore: maxScores[category]}
+		if cell.MaxScore > 0 {
+			percent := ScorePercent(score, cell.MaxScore)
+			cell.Percent = &percent
+		}
+		cells[category] = cell
+	}
+	return cells
+}
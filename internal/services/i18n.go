@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLocale is returned for locale tags that are not of the form "de" or "pt-BR"
+var ErrInvalidLocale = errors.New("invalid locale")
+
+// localePattern matches BCP 47 style language tags: a language optionally followed by subtags
+var localePattern = regexp.MustCompile(`^[A-Za-z]{2,3}(-[A-Za-z0-9]{2,8})*$`)
+
+// ParseAcceptLanguage returns the language tags of an Accept-Language header from most to least
+// preferred. Wildcards and tags with a quality of zero are left out.
+func ParseAcceptLanguage(header string) []string {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+	
+	var preferences []preference
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(fields[0])
+		if tag == "" || tag == "*" {
+			continue
+		}
+		
+		quality := 1.0
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality > 0 {
+			preferences = append(preferences, preference{tag: tag, quality: quality})
+		}
+	}
+	
+	sort.SliceStable(preferences, func(i, j int) bool { return preferences[i].quality > preferences[j].quality })
+	tags := make([]string, len(preferences))
+	for i, p := range preferences {
+		tags[i] = p.tag
+	}
+	return tags
+}
+
+// Locales returns the locales the catalog is available in: the source locale followed by every
+// locale with question translations or a message catalog
+func (s *QuestionService) Locales(ctx context.Context) ([]string, error) {
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	catalogs, err := s.storage.ListMessageCatalogs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message catalogs: %w", err)
+	}
+	
+	seen := map[string]bool{strings.ToLower(models.SourceLocale): true}
+	var locales []string
+	add := func(locale string) {
+		if !seen[strings.ToLower(locale)] {
+			seen[strings.ToLower(locale)] = true
+			locales = append(locales, locale)
+		}
+	}
+	for _, question := range questions {
+		for locale := range question.Translations {
+			add(locale)
+		}
+	}
+	for _, catalog := range catalogs {
+		add(catalog.Locale)
+	}
+	
+	sort.Strings(locales)
+	return append([]string{models.SourceLocale}, locales...), nil
+}
+
+// NegotiateLocale picks the first preferred locale the catalog is available in, matching
+// "de-CH" to "de" when there is no exact match. It returns the source locale if none matches.
+func (s *QuestionService) NegotiateLocale(ctx context.Context, preferred []string) (string, error) {
+	if len(preferred) == 0 {
+		return models.SourceLocale, nil
+	}
+	
+	locales, err := s.Locales(ctx)
+	if err != nil {
+		return "", err
+	}
+	
+	for _, tag := range preferred {
+		for _, locale := range locales {
+			if strings.EqualFold(tag, locale) {
+				return locale, nil
+			}
+		}
+		for _, locale := range locales {
+			if strings.EqualFold(baseLanguage(tag), locale) {
+				return locale, nil
+			}
+		}
+	}
+	return models.SourceLocale, nil
+}
+
+// GetMessageCatalog returns the message catalog of a locale
+func (s *QuestionService) GetMessageCatalog(ctx context.Context, locale string) (*models.MessageCatalog, error) {
+	catalog, err := s.storage.GetMessageCatalog(ctx, locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message catalog: %w", err)
+	}
+	if catalog == nil {
+		return nil, fmt.Errorf("message catalog %w", ErrNotFound)
+	}
+	return catalog, nil
+}
+
+// SaveMessageCatalog creates or replaces the message catalog of a locale
+func (s *QuestionService) SaveMessageCatalog(ctx context.Context, catalog *models.MessageCatalog) (*models.MessageCatalog, error) {
+	if !localePattern.MatchString(catalog.Locale) || strings.EqualFold(catalog.Locale, models.SourceLocale) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidLocale, catalog.Locale)
+	}
+	if catalog.Messages == nil {
+		catalog.Messages = map[string]string{}
+	}
+	
+	now := time.Now().UTC()
+	catalog.UpdatedAt = &now
+	if err := s.storage.SaveMessageCatalog(ctx, catalog); err != nil {
+		return nil, fmt.Errorf("failed to save message catalog: %w", err)
+	}
+	return catalog, nil
+}
+
+// DeleteMessageCatalog removes the message catalog of a locale
+func (s *QuestionService) DeleteMessageCatalog(ctx context.Context, locale string) error {
+	if err := s.storage.DeleteMessageCatalog(ctx, locale); err != nil {
+		return fmt.Errorf("failed to delete message catalog: %w", err)
+	}
+	return nil
+}
+
+// LocalizePages translates the questions, titles and introductions of questionnaire pages
+func (s *QuestionService) LocalizePages(ctx context.Context, pages []*models.QuestionPage, locale string) ([]*models.QuestionPage, error) {
+	if isSourceLocale(locale) {
+		return pages, nil
+	}
+	
+	messages, err := messagesFor(ctx, s.storage, locale)
+	if err != nil {
+		return nil, err
+	}
+	
+	localized := make([]*models.QuestionPage, len(pages))
+	for i, page := range pages {
+		copied := *page
+		copied.Title = translateMessage(messages, page.Title)
+		if page.Intro != "" {
+			copied.Intro = translateMessage(messages, page.Intro)
+			copied.IntroHTML = renderMarkdown(copied.Intro)
+		}
+		copied.Questions = LocalizeQuestions(page.Questions, locale)
+		localized[i] = &copied
+	}
+	return localized, nil
+}
+
+// LocalizeReport returns a copy of a report with its recommendations, risks, plan steps and
+// question texts translated. Signatures cover the source text, so localized copies are for
+// reading only.
+func (s *AssessmentService) LocalizeReport(ctx context.Context, report *models.Report, locale string) (*models.Report, error) {
+	if report == nil || isSourceLocale(locale) {
+		return report, nil
+	}
+	
+	messages, err := messagesFor(ctx, s.storage, locale)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	
+	localized := *report
+	localized.BandLabel = translateMessage(messages, report.BandLabel)
+	
+	localized.Recommendations = make([]models.Recommendation, len(report.Recommendations))
+	for i, recommendation := range report.Recommendations {
+		recommendation.Description = translateMessage(messages, recommendation.Description)
+		localized.Recommendations[i] = recommendation
+	}
+	localized.Risks = make([]models.Risk, len(report.Risks))
+	for i, risk := range report.Risks {
+		risk.Description = translateMessage(messages, risk.Description)
+		localized.Risks[i] = risk
+	}
+	localized.ModernizationPlan = make([]models.ModernizationStep, len(report.ModernizationPlan))
+	for i, step := range report.ModernizationPlan {
+		step.Description = translateMessage(messages, step.Description)
+		localized.ModernizationPlan[i] = step
+	}
+	
+	// Question texts are snapshots; they are only translated while they match the catalog
+	localized.Breakdown = make([]models.QuestionScore, len(report.Breakdown))
+	for i, score := range report.Breakdown {
+		if question := byID[score.QuestionID]; question != nil && question.Text == score.Text {
+			translated := LocalizeQuestion(question, locale)
+			score.Text = translated.Text
+			for j, option := range question.Options {
+				if option.ID == score.OptionID && option.Text == score.OptionText {
+					score.OptionText = translated.Options[j].Text
+				}
+			}
+		}
+		localized.Breakdown[i] = score
+	}
+	localized.Unanswered = make([]models.UnansweredQuestion, len(report.Unanswered))
+	for i, unanswered := range report.Unanswered {
+		if question := byID[unanswered.QuestionID]; question != nil && question.Text == unanswered.Text {
+			unanswered.Text = LocalizeQuestion(question, locale).Text
+		}
+		localized.Unanswered[i] = unanswered
+	}
+	localized.Disagreements = make([]models.AnswerDisagreement, len(report.Disagreements))
+	for i, disagreement := range report.Disagreements {
+		if question := byID[disagreement.QuestionID]; question != nil && question.Text == disagreement.Text {
+			translated := LocalizeQuestion(question, locale)
+			disagreement.Text = translated.Text
+			options := make([]models.ResponseCount, len(disagreement.Options))
+			for j, count := range disagreement.Options {
+				for k, option := range question.Options {
+					if option.ID == count.OptionID && option.Text == count.OptionText {
+						count.OptionText = translated.Options[k].Text
+					}
+				}
+				options[j] = count
+			}
+			disagreement.Options = options
+		}
+		localized.Disagreements[i] = disagreement
+	}
+	
+	return &localized, nil
+}
+
+// LocalizeQuestions translates questions into a locale, see LocalizeQuestion
+func LocalizeQuestions(questions []*models.Question, locale string) []*models.Question {
+	if isSourceLocale(locale) {
+		return questions
+	}
+	
+	localized := make([]*models.Question, len(questions))
+	for i, question := range questions {
+		localized[i] = LocalizeQuestion(question, locale)
+	}
+	return localized
+}
+
+// LocalizeQuestion returns a copy of a question with its text, help and option texts in the
+// locale, falling back to the base language's translation and then to the source text. The
+// translations themselves are left out of the copy.
+func LocalizeQuestion(question *models.Question, locale string) *models.Question {
+	translation, ok := lookupLocale(question.Translations, locale)
+	if !ok {
+		return question
+	}
+	
+	localized := *question
+	localized.Translations = nil
+	if translation.Text != "" {
+		localized.Text = translation.Text
+	}
+	if translation.Help != "" {
+		localized.Help = translation.Help
+	}
+	localized.Options = make([]models.Option, len(question.Options))
+	for i, option := range question.Options {
+		if text := translation.Options[option.ID]; text != "" {
+			option.Text = text
+		}
+		localized.Options[i] = option
+	}
+	return &localized
+}
+
+// validateTranslations checks that translations use valid locales and known options
+func validateTranslations(question *models.Question) []models.QuestionProblem {
+	var problems []models.QuestionProblem
+	options := make(map[string]bool, len(question.Options))
+	for _, option := range question.Options {
+		options[option.ID] = true
+	}
+	
+	for locale, translation := range question.Translations {
+		if !localePattern.MatchString(locale) {
+			problems = append(problems, models.QuestionProblem{Field: "translations", Message: fmt.Sprintf("invalid locale %q", locale)})
+		}
+		for optionID := range translation.Options {
+			if !options[optionID] {
+				problems = append(problems, models.QuestionProblem{Field: "translations", Message: fmt.Sprintf("%s translation refers to unknown option %s", locale, optionID)})
+			}
+		}
+	}
+	
+	sort.Slice(problems, func(i, j int) bool { return problems[i].Message < problems[j].Message })
+	return problems
+}
+
+// messagesFor returns the messages of a locale, falling back to its base language's catalog
+func messagesFor(ctx context.Context, repo storage.TranslationRepository, locale string) (map[string]string, error) {
+	for _, candidate := range []string{locale, baseLanguage(locale)} {
+		catalog, err := repo.GetMessageCatalog(ctx, candidate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get message catalog: %w", err)
+		}
+		if catalog != nil {
+			return catalog.Messages, nil
+		}
+	}
+	return nil, nil
+}
+
+// translateMessage returns the translation of a source text, or the text itself
+func translateMessage(messages map[string]string, text string) string {
+	if translated := messages[text]; translated != "" {
+		return translated
+	}
+	return text
+}
+
+// lookupLocale finds the translation of a locale, falling back to its base language
+func lookupLocale(translations map[string]models.QuestionTranslation, locale string) (models.QuestionTranslation, bool) {
+	for _, candidate := range []string{locale, baseLanguage(locale)} {
+		for key, translation := range translations {
+			if strings.EqualFold(key, candidate) {
+				return translation, true
+			}
+		}
+	}
+	return models.QuestionTranslation{}, false
+}
+
+// baseLanguage returns the language of a tag, e.g. "pt" for "pt-BR"
+func baseLanguage(tag string) string {
+	language, _, _ := strings.Cut(tag, "-")
+	return language
+}
+
+// isSourceLocale reports whether text in a locale needs no translation
+func isSourceLocale(locale string) bool {
+	return locale == "" || strings.EqualFold(locale, models.SourceLocale)
+}
@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"sync"
+	"time"
+	
+	"github.com/google/uuid"
+)
+
+// JobFunc executes a background job with its JSON parameters and returns a JSON-serializable result
+type JobFunc func(ctx context.Context, params json.RawMessage) (interface{}, error)
+
+// JobService runs long-running operations in the background and records their status, so
+// they do not have to complete within the HTTP write timeout. A job's lock is held from
+// submission until it finishes, so replicas sharing the storage can tell jobs that are still
+// running elsewhere from jobs whose replica stopped.
+type JobService struct {
+	storage storage.JobRepository
+	locker  storage.Locker
+	owner   string
+	timeout time.Duration
+	slots   chan struct{}
+	
+	mu       sync.RWMutex
+	handlers map[string]JobFunc
+}
+
+// NewJobService creates a job runner executing at most workers jobs at a time, each bounded by
+// timeout; owner identifies this replica in the jobs it runs
+func NewJobService(storage storage.JobRepository, locker storage.Locker, owner string, workers int, timeout time.Duration) *JobService {
+	if workers <= 0 {
+		workers = 1
+	}
+	
+	return &JobService{
+		storage:  storage,
+		locker:   locker,
+		owner:    owner,
+		timeout:  timeout,
+		slots:    make(chan struct{}, workers),
+		handlers: make(map[string]JobFunc),
+	}
+}
+
+// Register makes a job type available for submission
+func (s *JobService) Register(jobType string, fn JobFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.handlers[jobType] = fn
+}
+
+// Submit records a new job and starts it in the background
+func (s *JobService) Submit(ctx context.Context, jobType string, params interface{}) (*models.Job, error) {
+	s.mu.RLock()
+	fn, ok := s.handlers[jobType]
+	s.mu.RUnlock()
+	
+	if !ok {
+		return nil, fmt.Errorf("unknown job type: %s", jobType)
+	}
+	
+	rawParams, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal job parameters: %w", err)
+	}
+	
+	job := &models.Job{
+		ID:        uuid.NewString(),
+		Type:      jobType,
+		Status:    models.JobQueued,
+		Owner:     s.owner,
+		Params:    rawParams,
+		CreatedAt: time.Now(),
+	}
+	
+	held, unlock, err := s.locker.Hold(ctx, jobLockKey(job.ID))
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.SaveJob(ctx, job); err != nil {
+		unlock()
+		return nil, fmt.Errorf("failed to save job: %w", err)
+	}
+	
+	go s.run(held, job, fn, unlock)
+	
+	return job, nil
+}
+
+// GetJob retrieves a job by ID
+func (s *JobService) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	return s.storage.GetJob(ctx, id)
+}
+
+// ListJobs returns all jobs, newest first
+func (s *JobService) ListJobs(ctx context.Context) ([]*models.Job, error) {
+	jobs, err := s.storage.ListJobs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].CreatedAt.After(jobs[j].CreatedAt) })
+	if jobs == nil {
+		jobs = []*models.Job{}
+	}
+	return jobs, nil
+}
+
+// Run periodically fails jobs interrupted by a stopped replica until the context is done. A
+// stopped replica's jobs are recovered once their locks expire.
+func (s *JobService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		if err := s.RecoverInterrupted(ctx); err != nil {
+			log.Printf("Job recovery failed: %v", err)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RecoverInterrupted marks jobs left queued or running by a stopped process as failed. Jobs
+// whose lock is still held are running on this or another replica and are left alone.
+func (s *JobService) RecoverInterrupted(ctx context.Context) error {
+	jobs, err := s.storage.ListJobs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+	
+	for _, job := range jobs {
+		if job.Status != models.JobQueued && job.Status != models.JobRunning {
+			continue
+		}
+		
+		if err := s.recoverJob(ctx, job.ID); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// recoverJob marks a queued or running job as failed if its lock is free
+func (s *JobService) recoverJob(ctx context.Context, id string) error {
+	lockCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(lockCtx, jobLockKey(id))
+	if err != nil {
+		// The job is still running
+		return nil
+	}
+	defer unlock()
+	
+	// Re-read under the lock in case the job finished in the meantime
+	job, err := s.storage.GetJob(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get job: %w", err)
+	}
+	if job == nil || job.Status != models.JobQueued && job.Status != models.JobRunning {
+		return nil
+	}
+	
+	job.Status = models.JobFailed
+	job.Error = "interrupted by server restart"
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	if err := s.storage.SaveJob(ctx, job); err != nil {
+		return fmt.Errorf("failed to save job: %w", err)
+	}
+	return nil
+}
+
+// run executes a job once a worker slot is free and records its outcome, releasing the job's
+// lock once the outcome is saved. held is cancelled if the lock is lost, which stops the job.
+func (s *JobService) run(held context.Context, job *models.Job, fn JobFunc, unlock func()) {
+	defer unlock()
+	
+	s.slots <- struct{}{}
+	defer func() { <-s.slots }()
+	
+	ctx, cancel := context.WithTimeout(held, s.timeout)
+	defer cancel()
+	
+	job.Status = models.JobRunning
+	startedAt := time.Now()
+	job.StartedAt = &startedAt
+	s.save(ctx, job)
+	
+	result, err := s.execute(ctx, job, fn)
+	
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	if err != nil {
+		job.Status = models.JobFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = models.JobSucceeded
+		job.Result = result
+	}
+	
+	// Another replica may have failed a job whose lock was lost, and status streams have
+	// closed on that
+	if held.Err() != nil {
+		log.Printf("Lost lock of job %s; its outcome is not recorded", job.ID)
+		return
+	}
+	
+	// Record the outcome even if the job consumed its whole deadline
+	s.save(context.Background(), job)
+}
+
+// execute invokes a job function, converting panics into job failures
+func (s *JobService) execute(ctx context.Context, job *models.Job, fn JobFunc) (result json.RawMessage, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	
+	value, err := fn(ctx, job.Params)
+	if err != nil {
+		return nil, err
+	}
+	
+	return json.Marshal(value)
+}
+
+// save persists job state, logging failures since there is no caller to report them to
+func (s *JobService) save(ctx context.Context, job *models.Job) {
+	if err := s.storage.SaveJob(ctx, job); err != nil {
+		log.Printf("Failed to save job %s: %v", job.ID, err)
+	}
+}
+
+// jobLockKey returns the lock held while a job is queued or running
+func jobLockKey(id string) string {
+	return "job-" + id
+}
@@ -0,0 +1,70 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+// waitForJob polls a job until it has the given status
+func waitForJob(t *testing.T, jobs *services.JobService, id, status string) *models.Job {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		job, err := jobs.GetJob(context.Background(), id)
+		if err != nil {
+			t.Fatalf("GetJob: %v", err)
+		}
+		if job != nil && job.Status == status {
+			return job
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("job %s has status %+v, want %s", id, job, status)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestRecoverInterruptedLeavesJobsOfRunningReplicas(t *testing.T) {
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	locker := questionnairetest.NewMemoryLocker()
+	running := services.NewJobService(store, locker, "replica-a", 1, time.Minute)
+	restarted := services.NewJobService(store, locker, "replica-b", 1, time.Minute)
+	
+	release := make(chan struct{})
+	running.Register("slow", func(ctx context.Context, params json.RawMessage) (interface{}, error) {
+		<-release
+		return "done", nil
+	})
+	job, err := running.Submit(ctx, "slow", nil)
+	if err != nil {
+		t.Fatalf("Submit: %v", err)
+	}
+	waitForJob(t, running, job.ID, models.JobRunning)
+	
+	// A job left running by a replica that stopped holds no lock
+	orphan := &models.Job{ID: "orphan", Type: "slow", Status: models.JobRunning, Owner: "replica-c", CreatedAt: time.Now()}
+	if err := store.SaveJob(ctx, orphan); err != nil {
+		t.Fatalf("SaveJob: %v", err)
+	}
+	
+	if err := restarted.RecoverInterrupted(ctx); err != nil {
+		t.Fatalf("RecoverInterrupted: %v", err)
+	}
+	
+	if job, _ := restarted.GetJob(ctx, job.ID); job.Status != models.JobRunning || job.Owner != "replica-a" {
+		t.Errorf("job of the running replica = %+v, want it still running on replica-a", job)
+	}
+	if orphan, _ := restarted.GetJob(ctx, "orphan"); orphan.Status != models.JobFailed {
+		t.Errorf("orphaned job = %+v, want it failed", orphan)
+	}
+	
+	close(release)
+	waitForJob(t, running, job.ID, models.JobSucceeded)
+}
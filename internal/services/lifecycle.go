@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLifecycle is returned for unknown lifecycle states
+var ErrInvalidLifecycle = errors.New("invalid lifecycle state")
+
+// lifecycleTransitions lists the states each lifecycle state can move to; applications without
+// a state can move to any of them, and retired applications stay retired
+var lifecycleTransitions = map[string][]string{
+	"":                          {models.LifecycleAssessed, models.LifecycleRemediation, models.LifecycleMigrated, models.LifecycleRetired},
+	models.LifecycleAssessed:    {models.LifecycleRemediation, models.LifecycleMigrated, models.LifecycleRetired},
+	models.LifecycleRemediation: {models.LifecycleAssessed, models.LifecycleMigrated, models.LifecycleRetired},
+	models.LifecycleMigrated:    {models.LifecycleRemediation, models.LifecycleRetired},
+	models.LifecycleRetired:     {},
+}
+
+// LifecycleStates returns the lifecycle states in their usual order
+func LifecycleStates() []string {
+	return []string{models.LifecycleAssessed, models.LifecycleRemediation, models.LifecycleMigrated, models.LifecycleRetired}
+}
+
+// lifecycleAllowed reports whether an application can move from one lifecycle state to another
+func lifecycleAllowed(from, to string) bool {
+	for _, next := range lifecycleTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionLifecycle moves an application to another lifecycle state and records the change
+// in its history. Transitions the current state does not allow return ErrConflict.
+func (s *ApplicationService) TransitionLifecycle(ctx context.Context, id string, transition models.LifecycleTransition, user string) (*models.Application, error) {
+	status := strings.TrimSpace(transition.Status)
+	if _, ok := lifecycleTransitions[status]; !ok || status == "" {
+		return nil, fmt.Errorf("%w: %q, expected one of %s", ErrInvalidLifecycle, transition.Status, strings.Join(LifecycleStates(), ", "))
+	}
+	
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	unlock, err := s.locker.Lock(lockCtx, "application-"+id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock application: %w", err)
+	}
+	defer unlock()
+	
+	app, err := s.storage.GetApplication(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	if !lifecycleAllowed(app.Lifecycle, status) {
+		from := app.Lifecycle
+		if from == "" {
+			from = models.LifecycleUntracked
+		}
+		return nil, fmt.Errorf("%w: application cannot move from %s to %s", ErrConflict, from, status)
+	}
+	
+	now := time.Now()
+	app.LifecycleHistory = append(app.LifecycleHistory, models.LifecycleChange{
+		From:      app.Lifecycle,
+		To:        status,
+		Note:      strings.TrimSpace(transition.Note),
+		ChangedBy: user,
+		ChangedAt: now,
+	})
+	app.Lifecycle = status
+	app.UpdatedAt = now
+	
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to save application: %w", err)
+	}
+	return app, nil
+}
+
+// lifecycleCounts counts applications by lifecycle state, including every state so that
+// empty ones show up as zero
+func lifecycleCounts(apps []*models.Application) map[string]int {
+	counts := map[string]int{models.LifecycleUntracked: 0}
+	for _, state := range LifecycleStates() {
+		counts[state] = 0
+	}
+	for _, app := range apps {
+		counts[lifecycleOf(app)]++
+	}
+	return counts
+}
+
+// lifecycleOf returns an application's lifecycle state, or LifecycleUntracked without one
+func lifecycleOf(app *models.Application) string {
+	if app.Lifecycle == "" {
+		return models.LifecycleUntracked
+	}
+	return app.Lifecycle
+}
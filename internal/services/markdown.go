@@ -0,0 +1,82 @@
+package services
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	markdownLink   = regexp.MustCompile(`\[([^\]]+)\]\((https?://[^)\s]+)\)`)
+	markdownBold   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownItalic = regexp.MustCompile(`\*([^*]+)\*`)
+	markdownCode   = regexp.MustCompile("`([^`]+)`")
+)
+
+// renderMarkdown converts the small Markdown subset used in question help texts (headings,
+// bullet lists, paragraphs, bold, italic, code and http(s) links) to HTML. Input is escaped
+// first, so raw HTML in help texts is never passed through.
+func renderMarkdown(src string) string {
+	if strings.TrimSpace(src) == "" {
+		return ""
+	}
+	
+	var out strings.Builder
+	var paragraph []string
+	inList := false
+	
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			out.WriteString("<p>" + renderInline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if inList {
+			out.WriteString("</ul>\n")
+			inList = false
+		}
+	}
+	
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			flushParagraph()
+			closeList()
+		case strings.HasPrefix(line, "#"):
+			flushParagraph()
+			closeList()
+			level := len(line) - len(strings.TrimLeft(line, "#"))
+			if level > 6 {
+				level = 6
+			}
+			tag := "h" + string(rune('0'+level))
+			out.WriteString("<" + tag + ">" + renderInline(strings.TrimSpace(line[level:])) + "</" + tag + ">\n")
+		case strings.HasPrefix(line, "- ") || strings.HasPrefix(line, "* "):
+			flushParagraph()
+			if !inList {
+				out.WriteString("<ul>\n")
+				inList = true
+			}
+			out.WriteString("<li>" + renderInline(line[2:]) + "</li>\n")
+		default:
+			closeList()
+			paragraph = append(paragraph, line)
+		}
+	}
+	flushParagraph()
+	closeList()
+	
+	return out.String()
+}
+
+// renderInline renders inline Markdown markup of an escaped line
+func renderInline(text string) string {
+	text = html.EscapeString(text)
+	text = markdownCode.ReplaceAllString(text, "<code>$1</code>")
+	text = markdownLink.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = markdownBold.ReplaceAllString(text, "<strong>$1</strong>")
+	text = markdownItalic.ReplaceAllString(text, "<em>$1</em>")
+	return text
+}
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// narrativeDisclaimer marks generated narratives in reports
+const narrativeDisclaimer = "This summary was generated by an AI model from the assessment results and may contain errors. Review it before relying on it."
+
+// NarrativeGenerator writes an executive-summary paragraph for a report
+type NarrativeGenerator interface {
+	GenerateNarrative(ctx context.Context, input *NarrativeInput) (string, error)
+	// Model identifies the model producing narratives, shown in reports
+	Model() string
+}
+
+// NarrativeInput is the assessment data a narrative is generated from
+type NarrativeInput struct {
+	ApplicationName string            `json:"applicationName"`
+	TotalScore      int               `json:"totalScore"`
+	MaxScore        int               `json:"maxPossibleScore"`
+	BandLabel       string            `json:"readiness"`
+	Grade           string            `json:"grade"`
+	CategoryScores  map[string]int    `json:"categoryScores"`
+	Risks           []models.Risk     `json:"risks"`
+	Answers         map[string]string `json:"answers"` // question text -> answer text
+	Scope           string            `json:"scope,omitempty"`
+	BusinessContext string            `json:"businessContext,omitempty"`
+}
+
+// WithNarrativeGenerator adds an AI-generated executive summary to new reports
+func WithNarrativeGenerator(g NarrativeGenerator) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.narrator = g
+	}
+}
+
+// narrativeTimeout bounds how long report generation waits for a narrative
+const narrativeTimeout = 30 * time.Second
+
+// addNarrative generates the report's narrative if a generator is configured. Failures are
+// logged and leave the report without a narrative rather than failing report generation.
+func (s *AssessmentService) addNarrative(ctx context.Context, report *models.Report, assessment *models.Assessment, questions []*models.Question) {
+	if s.narrator == nil {
+		return
+	}
+	
+	input := &NarrativeInput{
+		ApplicationName: assessment.ApplicationID,
+		TotalScore:      report.TotalScore,
+		MaxScore:        report.MaxPossibleScore,
+		BandLabel:       report.BandLabel,
+		Grade:           report.Grade,
+		CategoryScores:  report.CategoryScores,
+		Risks:           report.Risks,
+		Answers:         make(map[string]string),
+	}
+	
+	// Participants are left out so names are not sent to the model
+	if assessment.Context != nil {
+		input.Scope = assessment.Context.Scope
+		input.BusinessContext = assessment.Context.BusinessContext
+	}
+	
+	if assessment.Application != nil {
+		input.ApplicationName = assessment.Application.Name
+	} else if app, err := s.storage.GetApplication(ctx, assessment.ApplicationID); err == nil && app != nil {
+		input.ApplicationName = app.Name
+	}
+	
+	for _, question := range questions {
+		optionID, ok := assessment.Answers[question.ID]
+		if !ok {
+			continue
+		}
+		for _, option := range question.Options {
+			if option.ID == optionID {
+				input.Answers[question.Text] = option.Text
+				break
+			}
+		}
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, narrativeTimeout)
+	defer cancel()
+	
+	text, err := s.narrator.GenerateNarrative(ctx, input)
+	if err != nil {
+		log.Printf("Failed to generate narrative for assessment %s: %v", assessment.ID, err)
+		return
+	}
+	
+	report.Narrative = &models.Narrative{
+		Text:        strings.TrimSpace(text),
+		AIGenerated: true,
+		Model:       s.narrator.Model(),
+		Disclaimer:  narrativeDisclaimer,
+		GeneratedAt: time.Now(),
+	}
+}
+
+// OpenAINarrativeGenerator generates narratives with an OpenAI-compatible chat completions API,
+// which also covers Azure OpenAI, vLLM, Ollama and similar self-hosted servers
+type OpenAINarrativeGenerator struct {
+	BaseURL    string        // e.g. https://api.openai.com/v1
+	APIKey     func() string // optional bearer token
+	ModelName  string
+	HTTPClient *http.Client
+}
+
+// Model returns the configured model name
+func (g *OpenAINarrativeGenerator) Model() string {
+	return g.ModelName
+}
+
+// GenerateNarrative asks the model for a short executive summary of the assessment
+func (g *OpenAINarrativeGenerator) GenerateNarrative(ctx context.Context, input *NarrativeInput) (string, error) {
+	data, err := json.MarshalIndent(input, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal narrative input: %w", err)
+	}
+	
+	body, err := json.Marshal(map[string]interface{}{
+		"model": g.ModelName,
+		"messages": []map[string]string{
+			{
+				"role": "system",
+				"content": "You write executive summaries of Kubernetes migration-readiness assessments. " +
+					"Write one paragraph of at most 120 words for a non-technical audience. Only use facts from the data provided.",
+			},
+			{"role": "user", "content": string(data)},
+		},
+		"temperature": 0.2,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal completion request: %w", err)
+	}
+	
+	url := strings.TrimSuffix(g.BaseURL, "/") + "/chat/completions"
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.APIKey != nil {
+		if key := g.APIKey(); key != "" {
+			req.Header.Set("Authorization", "Bearer "+key)
+		}
+	}
+	
+	client := g.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	
+	var completion struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&completion); err != nil {
+		return "", fmt.Errorf("failed to decode completion: %w", err)
+	}
+	
+	if len(completion.Choices) == 0 || strings.TrimSpace(completion.Choices[0].Message.Content) == "" {
+		return "", errors.New("completion contained no text")
+	}
+	
+	return completion.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,435 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/smtp"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strings"
+	"time"
+	
+	"github.com/google/uuid"
+)
+
+// NotificationConfig configures outbound notification delivery
+type NotificationConfig struct {
+	Targets       []models.NotificationTarget
+	MaxAttempts   int
+	SMTPAddr      string // host:port of the SMTP relay
+	SMTPFrom      string
+	SMTPUsername  string
+	SMTPPassword  func() string
+	WebhookSecret func() []byte // signs webhook bodies when non-empty
+	HTTPClient    *http.Client
+}
+
+// NotificationService queues notifications in a persistent outbox and delivers them with
+// retries, so events are not lost when a downstream system is briefly unavailable
+type NotificationService struct {
+	storage storage.OutboxRepository
+	locker  storage.Locker
+	config  NotificationConfig
+}
+
+// emailTargetPrefix marks outbox messages addressed to a single email recipient rather than a
+// configured target, such as digests
+const emailTargetPrefix = "mailto:"
+
+// Retry backoff bounds for failed deliveries
+const (
+	initialRetryDelay = 30 * time.Second
+	maxRetryDelay     = time.Hour
+)
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(storage storage.OutboxRepository, locker storage.Locker, config NotificationConfig) *NotificationService {
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = 8
+	}
+	if config.HTTPClient == nil {
+		config.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	
+	return &NotificationService{
+		storage: storage,
+		locker:  locker,
+		config:  config,
+	}
+}
+
+// LoadNotificationTargets reads notification targets from a JSON file
+func LoadNotificationTargets(path string) ([]models.NotificationTarget, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification targets: %w", err)
+	}
+	
+	var targets []models.NotificationTarget
+	if err := json.Unmarshal(data, &targets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal notification targets: %w", err)
+	}
+	
+	for _, target := range targets {
+		switch target.Type {
+		case models.NotificationWebhook, models.NotificationSlack:
+			if target.URL == "" {
+				return nil, fmt.Errorf("notification target %q requires a url", target.Name)
+			}
+		case models.NotificationEmail:
+			if len(target.To) == 0 {
+				return nil, fmt.Errorf("notification target %q requires recipients", target.Name)
+			}
+		default:
+			return nil, fmt.Errorf("notification target %q has unknown type %q", target.Name, target.Type)
+		}
+		if err := validateNotificationFilter(target.Filter); err != nil {
+			return nil, fmt.Errorf("notification target %q: %w", target.Name, err)
+		}
+	}
+	
+	return targets, nil
+}
+
+// Notify queues an event for every target subscribed to it
+func (s *NotificationService) Notify(ctx context.Context, event string, payload map[string]interface{}) error {
+	now := time.Now()
+	
+	for _, target := range s.config.Targets {
+		if !subscribed(target, event) || !matchesFilter(target.Filter, payload) {
+			continue
+		}
+		
+		message := &models.OutboxMessage{
+			ID:            uuid.NewString(),
+			Target:        target.Name,
+			Event:         event,
+			Payload:       payload,
+			Status:        models.OutboxPending,
+			CreatedAt:     now,
+			NextAttemptAt: now,
+		}
+		
+		if err := s.storage.SaveOutboxMessage(ctx, message); err != nil {
+			return fmt.Errorf("failed to queue notification for %s: %w", target.Name, err)
+		}
+	}
+	
+	return nil
+}
+
+// QueueEmail queues a plain-text email to a single recipient, delivered with the same retries
+// as target notifications
+func (s *NotificationService) QueueEmail(ctx context.Context, event, to, subject, text string) error {
+	now := time.Now()
+	message := &models.OutboxMessage{
+		ID:            uuid.NewString(),
+		Target:        emailTargetPrefix + to,
+		Event:         event,
+		Payload:       map[string]interface{}{"subject": subject, "text": text},
+		Status:        models.OutboxPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+	
+	if err := s.storage.SaveOutboxMessage(ctx, message); err != nil {
+		return fmt.Errorf("failed to queue email to %s: %w", to, err)
+	}
+	return nil
+}
+
+// Run delivers due outbox messages periodically until the context is cancelled
+func (s *NotificationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		if err := s.DeliverPending(ctx); err != nil {
+			log.Printf("Notification delivery failed: %v", err)
+		}
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverPending attempts delivery of every pending message whose retry time has come
+func (s *NotificationService) DeliverPending(ctx context.Context) error {
+	messages, err := s.storage.ListOutboxMessages(ctx, models.OutboxPending)
+	if err != nil {
+		return fmt.Errorf("failed to list outbox: %w", err)
+	}
+	
+	// Deliver in creation order so subscribers observe events in sequence
+	sort.Slice(messages, func(i, j int) bool { return messages[i].CreatedAt.Before(messages[j].CreatedAt) })
+	
+	now := time.Now()
+	for _, message := range messages {
+		if message.NextAttemptAt.After(now) {
+			continue
+		}
+		
+		if err := s.deliverMessage(ctx, message.ID); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// ListDeadLetters returns messages that exhausted their delivery attempts
+func (s *NotificationService) ListDeadLetters(ctx context.Context) ([]*models.OutboxMessage, error) {
+	messages, err := s.storage.ListOutboxMessages(ctx, models.OutboxDead)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox: %w", err)
+	}
+	
+	if messages == nil {
+		messages = []*models.OutboxMessage{}
+	}
+	return messages, nil
+}
+
+// RetryDeadLetter puts a dead message back into the delivery queue
+func (s *NotificationService) RetryDeadLetter(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	message, err := s.storage.GetOutboxMessage(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get outbox message: %w", err)
+	}
+	
+	if message == nil || message.Status != models.OutboxDead {
+		return nil, errors.New("dead letter not found")
+	}
+	
+	message.Status = models.OutboxPending
+	message.Attempts = 0
+	message.NextAttemptAt = time.Now()
+	
+	if err := s.storage.SaveOutboxMessage(ctx, message); err != nil {
+		return nil, fmt.Errorf("failed to save outbox message: %w", err)
+	}
+	
+	return message, nil
+}
+
+// deliverMessage delivers one message under its lock so that replicas never send it twice
+func (s *NotificationService) deliverMessage(ctx context.Context, id string) error {
+	lockCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(lockCtx, "outbox-"+id)
+	if err != nil {
+		// Another replica is delivering this message
+		return nil
+	}
+	defer unlock()
+	
+	// Re-read under the lock in case another replica already delivered it
+	message, err := s.storage.GetOutboxMessage(ctx, id)
+	if err != nil || message == nil || message.Status != models.OutboxPending {
+		return err
+	}
+	
+	message.Attempts++
+	deliveryErr := s.send(ctx, message)
+	
+	if deliveryErr == nil {
+		return s.storage.DeleteOutboxMessage(ctx, message.ID)
+	}
+	
+	message.LastError = deliveryErr.Error()
+	if message.Attempts >= s.config.MaxAttempts {
+		message.Status = models.OutboxDead
+		log.Printf("Notification %s to %s moved to dead letters after %d attempts: %v", message.ID, message.Target, message.Attempts, deliveryErr)
+	} else {
+		delay := initialRetryDelay << (message.Attempts - 1)
+		if delay > maxRetryDelay || delay <= 0 {
+			delay = maxRetryDelay
+		}
+		message.NextAttemptAt = time.Now().Add(delay)
+	}
+	
+	return s.storage.SaveOutboxMessage(ctx, message)
+}
+
+// send delivers a message to its target
+func (s *NotificationService) send(ctx context.Context, message *models.OutboxMessage) error {
+	if to, ok := strings.CutPrefix(message.Target, emailTargetPrefix); ok {
+		subject, _ := message.Payload["subject"].(string)
+		text, _ := message.Payload["text"].(string)
+		return s.sendEmail([]string{to}, subject, text)
+	}
+	
+	var target *models.NotificationTarget
+	for i := range s.config.Targets {
+		if s.config.Targets[i].Name == message.Target {
+			target = &s.config.Targets[i]
+			break
+		}
+	}
+	
+	if target == nil {
+		return fmt.Errorf("notification target %s is no longer configured", message.Target)
+	}
+	
+	switch target.Type {
+	case models.NotificationWebhook:
+		body, err := json.Marshal(map[string]interface{}{
+			"id":        message.ID,
+			"event":     message.Event,
+			"createdAt": message.CreatedAt,
+			"data":      message.Payload,
+		})
+		if err != nil {
+			return err
+		}
+		return s.post(ctx, target.URL, body, true)
+	case models.NotificationSlack:
+		body, err := json.Marshal(map[string]string{"text": summarize(message)})
+		if err != nil {
+			return err
+		}
+		return s.post(ctx, target.URL, body, false)
+	case models.NotificationEmail:
+		return s.sendEmail(target.To, message.Event, summarize(message))
+	default:
+		return fmt.Errorf("unknown notification type: %s", target.Type)
+	}
+}
+
+// post sends a JSON body, optionally signed with the webhook secret
+func (s *NotificationService) post(ctx context.Context, url string, body []byte, sign bool) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	
+	if sign && s.config.WebhookSecret != nil {
+		if secret := s.config.WebhookSecret(); len(secret) > 0 {
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(body)
+			req.Header.Set("X-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+		}
+	}
+	
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d from %s", resp.StatusCode, url)
+	}
+	
+	return nil
+}
+
+// sendEmail sends a plain-text email through the configured SMTP relay
+func (s *NotificationService) sendEmail(to []string, subject, text string) error {
+	if s.config.SMTPAddr == "" {
+		return errors.New("SMTP is not configured")
+	}
+	
+	var auth smtp.Auth
+	if s.config.SMTPUsername != "" {
+		host := strings.Split(s.config.SMTPAddr, ":")[0]
+		password := ""
+		if s.config.SMTPPassword != nil {
+			password = s.config.SMTPPassword()
+		}
+		auth = smtp.PlainAuth("", s.config.SMTPUsername, password, host)
+	}
+	
+	// SMTP requires CRLF line endings in the message body
+	text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\n", "\r\n")
+	body := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: [questionnaire-app] %s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n%s\r\n",
+		s.config.SMTPFrom, strings.Join(to, ", "), subject, text)
+	
+	return smtp.SendMail(s.config.SMTPAddr, auth, s.config.SMTPFrom, to, []byte(body))
+}
+
+// summarize renders a one-line human readable description of a message
+func summarize(message *models.OutboxMessage) string {
+	keys := make([]string, 0, len(message.Payload))
+	for key := range message.Payload {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%v", key, message.Payload[key]))
+	}
+	
+	return fmt.Sprintf("%s: %s", message.Event, strings.Join(parts, ", "))
+}
+
+// subscribed reports whether a target receives an event type
+func subscribed(target models.NotificationTarget, event string) bool {
+	if len(target.Events) == 0 {
+		return true
+	}
+	
+	for _, subscribedEvent := range target.Events {
+		if subscribedEvent == event {
+			return true
+		}
+	}
+	return false
+}
+
+// validateNotificationFilter checks the conditions of a target's filter
+func validateNotificationFilter(filter *models.NotificationFilter) error {
+	if filter == nil {
+		return nil
+	}
+	if filter.ScoreBelow != nil && (*filter.ScoreBelow < 1 || *filter.ScoreBelow > 100) {
+		return errors.New("filter scoreBelow must be between 1 and 100")
+	}
+	for key := range filter.Tags {
+		if strings.TrimSpace(key) == "" {
+			return errors.New("filter tags must not have an empty key")
+		}
+	}
+	return nil
+}
+
+// matchesFilter reports whether an event's payload meets every condition of a filter; a nil
+// filter matches every event
+func matchesFilter(filter *models.NotificationFilter, payload map[string]interface{}) bool {
+	if filter == nil {
+		return true
+	}
+	
+	if filter.ScoreBelow != nil {
+		percent, ok := payload["scorePercent"].(int)
+		if !ok || percent >= *filter.ScoreBelow {
+			return false
+		}
+	}
+	
+	if len(filter.Tags) > 0 {
+		tags, _ := payload["tags"].(map[string]string)
+		for key, value := range filter.Tags {
+			if tags[key] != value {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// OutboundConfig configures the HTTP connections the server opens to integrations: webhook and
+// Slack notifications, AI summaries and federation peers
+type OutboundConfig struct {
+	Proxy          string        // proxy URL; HTTPS_PROXY, HTTP_PROXY and NO_PROXY apply if empty
+	NoProxy        []string      // hosts and domains reached directly despite Proxy
+	CABundle       string        // PEM file of CAs trusted in addition to the system ones
+	ConnectTimeout time.Duration // of establishing a connection, including the TLS handshake
+	Timeout        time.Duration // of a whole request; each integration's own default if zero
+}
+
+// OutboundHTTP creates the HTTP clients of integrations. They share one transport, so they
+// share its connection pool as well as the proxy and TLS settings.
+type OutboundHTTP struct {
+	transport *http.Transport
+	timeout   time.Duration
+	config    OutboundConfig
+}
+
+// NewOutboundHTTP builds the transport of integration clients from a configuration
+func NewOutboundHTTP(config OutboundConfig) (*OutboundHTTP, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	
+	if config.Proxy != "" {
+		proxy, err := url.Parse(config.Proxy)
+		if err != nil || proxy.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q", config.Proxy)
+		}
+		switch proxy.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("proxy scheme %s is not supported, expected http, https or socks5", proxy.Scheme)
+		}
+		noProxy := config.NoProxy
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			if bypassProxy(req.URL.Hostname(), noProxy) {
+				return nil, nil
+			}
+			return proxy, nil
+		}
+	}
+	
+	if config.CABundle != "" {
+		pem, err := os.ReadFile(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("CA bundle contains no PEM certificates")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+	
+	if config.ConnectTimeout > 0 {
+		transport.DialContext = (&net.Dialer{Timeout: config.ConnectTimeout, KeepAlive: 30 * time.Second}).DialContext
+		transport.TLSHandshakeTimeout = config.ConnectTimeout
+	}
+	
+	return &OutboundHTTP{transport: transport, timeout: config.Timeout, config: config}, nil
+}
+
+// Client returns a client for an integration, timing out requests after the configured
+// timeout or else defaultTimeout (none if zero)
+func (o *OutboundHTTP) Client(defaultTimeout time.Duration) *http.Client {
+	timeout := defaultTimeout
+	if o.timeout > 0 {
+		timeout = o.timeout
+	}
+	return &http.Client{Transport: o.transport, Timeout: timeout}
+}
+
+// GitEnv returns the environment variables that make Git clone over HTTPS through the same
+// proxy and with the same CA bundle as integrations. Git reads a single CA file, so with a
+// bundle configured it trusts only the bundle's CAs, not the system ones.
+func (o *OutboundHTTP) GitEnv() []string {
+	var env []string
+	if o.config.Proxy != "" {
+		noProxy := strings.Join(o.config.NoProxy, ",")
+		env = append(env,
+			"http_proxy="+o.config.Proxy, "https_proxy="+o.config.Proxy, "no_proxy="+noProxy,
+			"HTTP_PROXY="+o.config.Proxy, "HTTPS_PROXY="+o.config.Proxy, "NO_PROXY="+noProxy,
+		)
+	}
+	if o.config.CABundle != "" {
+		env = append(env, "GIT_SSL_CAINFO="+o.config.CABundle)
+	}
+	return env
+}
+
+// bypassProxy reports whether a host is reached directly: it is, or is in the domain of, an
+// entry of noProxy, or noProxy contains "*"
+func bypassProxy(host string, noProxy []string) bool {
+	host = strings.ToLower(host)
+	for _, entry := range noProxy {
+		entry = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(entry), "."))
+		if entry == "*" || host == entry || strings.HasSuffix(host, "."+entry) {
+			return true
+		}
+	}
+	return false
+}
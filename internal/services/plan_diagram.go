@@ -0,0 +1,117 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// Modernization plan diagram types
+const (
+	PlanDiagramGantt     = "gantt"
+	PlanDiagramFlowchart = "flowchart"
+)
+
+// PlanDiagramOptions controls how a modernization plan is drawn
+type PlanDiagramOptions struct {
+	Type        string    // gantt or flowchart
+	Start       time.Time // first day of the Gantt chart
+	HoursPerDay float64   // working hours per day used to turn estimates into durations
+}
+
+// PlanDiagram renders an assessment's modernization plan as a Mermaid definition
+func (s *AssessmentService) PlanDiagram(ctx context.Context, assessmentID string, opts PlanDiagramOptions) (string, error) {
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get report: %w", err)
+	}
+	if report == nil {
+		return "", fmt.Errorf("report %w", ErrNotFound)
+	}
+	
+	config, err := s.GetEstimationConfig(ctx)
+	if err != nil {
+		return "", err
+	}
+	report = withEstimate(report, config)
+	
+	title := "Modernization plan for " + report.ApplicationID
+	if report.Application != nil {
+		title = "Modernization plan for " + report.Application.Name
+	} else if app, err := s.storage.GetApplication(ctx, report.ApplicationID); err == nil && app != nil {
+		title = "Modernization plan for " + app.Name
+	}
+	
+	switch opts.Type {
+	case PlanDiagramGantt, "":
+		return planGantt(title, report.ModernizationPlan, opts), nil
+	case PlanDiagramFlowchart:
+		return planFlowchart(title, report.ModernizationPlan), nil
+	default:
+		return "", fmt.Errorf("unknown diagram type %q", opts.Type)
+	}
+}
+
+// planGantt draws the plan as consecutive Gantt tasks lasting the midpoint of their estimate.
+// Steps without an estimate get a single day.
+func planGantt(title string, plan []models.ModernizationStep, opts PlanDiagramOptions) string {
+	hoursPerDay := opts.HoursPerDay
+	if hoursPerDay <= 0 {
+		hoursPerDay = 8
+	}
+	
+	var b strings.Builder
+	b.WriteString("gantt\n")
+	fmt.Fprintf(&b, "    title %s\n", mermaidText(title))
+	b.WriteString("    dateFormat YYYY-MM-DD\n")
+	b.WriteString("    excludes weekends\n")
+	b.WriteString("    section Modernization\n")
+	
+	for i, step := range plan {
+		days := 1
+		if step.Estimate != nil {
+			days = int(math.Max(1, math.Ceil((step.Estimate.MinHours+step.Estimate.MaxHours)/2/hoursPerDay)))
+		}
+		
+		start := "after s" + fmt.Sprint(i)
+		if i == 0 {
+			start = opts.Start.Format("2006-01-02")
+		}
+		fmt.Fprintf(&b, "    %s (%s) :s%d, %s, %dd\n", mermaidText(step.Description), mermaidText(step.Effort), i+1, start, days)
+	}
+	return b.String()
+}
+
+// planFlowchart draws the plan as a top-down chain of steps labelled with their effort and estimate
+func planFlowchart(title string, plan []models.ModernizationStep) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	fmt.Fprintf(&b, "title: %s\n", mermaidText(title))
+	b.WriteString("---\n")
+	b.WriteString("flowchart TD\n")
+	
+	for i, step := range plan {
+		label := fmt.Sprintf("%d. %s<br/>%s effort", step.Order, mermaidLabel(step.Description), mermaidLabel(step.Effort))
+		if step.Estimate != nil {
+			label += fmt.Sprintf(", %g-%g h", step.Estimate.MinHours, step.Estimate.MaxHours)
+		}
+		fmt.Fprintf(&b, "    s%d[\"%s\"]\n", i+1, label)
+		if i > 0 {
+			fmt.Fprintf(&b, "    s%d --> s%d\n", i, i+1)
+		}
+	}
+	return b.String()
+}
+
+// mermaidText strips characters that end a Gantt task name or title
+func mermaidText(text string) string {
+	return strings.NewReplacer(":", " -", "#", "", ";", ",", "\n", " ").Replace(text)
+}
+
+// mermaidLabel escapes text for a quoted flowchart node label
+func mermaidLabel(text string) string {
+	return strings.NewReplacer(`"`, "#quot;", "<", "#lt;", ">", "#gt;", "\n", " ").Replace(text)
+}
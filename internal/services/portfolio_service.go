@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Application tags holding business value, checked in order
+const (
+	TagBusinessValue = "business-value" // 1 to 5
+	TagCriticality   = "criticality"    // low, medium, high or critical
+)
+
+// defaultBusinessValue is used for applications without a business value tag
+const defaultBusinessValue = 3
+
+// DefaultValueThreshold separates high from low business value in the prioritization matrix
+const DefaultValueThreshold = 3.0
+
+// criticalityValues maps criticality tags to business values
+var criticalityValues = map[string]float64{"low": 1, "medium": 3, "high": 4, "critical": 5}
+
+// quadrantWaves orders the matrix quadrants into suggested migration waves
+var quadrantWaves = map[string]int{
+	models.QuadrantQuickWin:   1,
+	models.QuadrantStrategic:  2,
+	models.QuadrantFillIn:     3,
+	models.QuadrantReconsider: 4,
+}
+
+// PortfolioService provides planning views across all applications
+type PortfolioService struct {
+	storage       storage.Storage
+	assessments   *AssessmentService
+	summaryMaxAge time.Duration
+}
+
+// NewPortfolioService creates a new portfolio service; the materialized portfolio summary is
+// rebuilt from scratch once it is older than summaryMaxAge (never if zero)
+func NewPortfolioService(storage storage.Storage, assessments *AssessmentService, summaryMaxAge time.Duration) *PortfolioService {
+	return &PortfolioService{storage: storage, assessments: assessments, summaryMaxAge: summaryMaxAge}
+}
+
+// PrioritizationMatrix places every assessed application by business value and estimated
+// effort. A zero effortThreshold uses the portfolio's median effort.
+func (s *PortfolioService) PrioritizationMatrix(ctx context.Context, valueThreshold, effortThreshold float64) (*models.PrioritizationMatrix, error) {
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].Name < apps[j].Name })
+	
+	estimation, err := s.assessments.GetEstimationConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	matrix := &models.PrioritizationMatrix{
+		GeneratedAt:    time.Now(),
+		ValueThreshold: valueThreshold,
+		Applications:   []models.MatrixPoint{},
+		Unassessed:     []string{},
+	}
+	
+	var efforts []float64
+	for _, app := range apps {
+		report, err := s.assessments.GetLatestReport(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
+		
+		if report == nil {
+			matrix.Unassessed = append(matrix.Unassessed, app.ID)
+			continue
+		}
+		
+		value, source := BusinessValue(app)
+		point := models.MatrixPoint{
+			ApplicationID: app.ID,
+			Name:          app.Name,
+			Value:         value,
+			ValueSource:   source,
+			EffortHours:   reportEffortHours(report, estimation),
+			Grade:         report.Grade,
+			AssessmentID:  report.AssessmentID,
+			Stale:         report.Stale,
+		}
+		efforts = append(efforts, point.EffortHours)
+		matrix.Applications = append(matrix.Applications, point)
+	}
+	
+	if effortThreshold <= 0 && len(efforts) > 0 {
+		sort.Float64s(efforts)
+		effortThreshold = percentile(efforts, 0.5)
+	}
+	matrix.EffortThreshold = effortThreshold
+	
+	for i := range matrix.Applications {
+		point := &matrix.Applications[i]
+		point.Quadrant = quadrant(point.Value >= valueThreshold, point.EffortHours > effortThreshold)
+		point.SuggestedWave = quadrantWaves[point.Quadrant]
+	}
+	
+	sort.SliceStable(matrix.Applications, func(i, j int) bool {
+		a, b := matrix.Applications[i], matrix.Applications[j]
+		if a.SuggestedWave != b.SuggestedWave {
+			return a.SuggestedWave < b.SuggestedWave
+		}
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		return a.EffortHours < b.EffortHours
+	})
+	
+	return matrix, nil
+}
+
+// BusinessValue reads an application's business value from its tags and reports which tag
+// it came from
+func BusinessValue(app *models.Application) (float64, string) {
+	if raw, ok := app.Tags[TagBusinessValue]; ok {
+		if value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64); err == nil && value >= 1 && value <= 5 {
+			return value, TagBusinessValue
+		}
+	}
+	
+	if raw, ok := app.Tags[TagCriticality]; ok {
+		if value, ok := criticalityValues[strings.ToLower(strings.TrimSpace(raw))]; ok {
+			return value, TagCriticality
+		}
+	}
+	
+	return defaultBusinessValue, "default"
+}
+
+// reportEffortHours returns the midpoint of a report's estimated hours
+func reportEffortHours(report *models.Report, estimation *models.EstimationConfig) float64 {
+	estimate := withEstimate(report, estimation).Estimate
+	return (estimate.MinHours + estimate.MaxHours) / 2
+}
+
+// quadrant names the matrix quadrant of an application
+func quadrant(highValue, highEffort bool) string {
+	switch {
+	case highValue && !highEffort:
+		return models.QuadrantQuickWin
+	case highValue:
+		return models.QuadrantStrategic
+	case !highEffort:
+		return models.QuadrantFillIn
+	default:
+		return models.QuadrantReconsider
+	}
+}
+
+// WavePlanOptions constrains the migration wave plan
+type WavePlanOptions struct {
+	CapacityHours   float64 // estimated hours per wave, 0 for no limit
+	MaxApplications int     // applications per wave, 0 for no limit
+	StartQuarter    string  // first wave's quarter (e.g. 2027-Q1), the next quarter if empty
+}
+
+// PlanWaves groups the assessed applications into quarterly migration waves. Applications are
+// taken in prioritization matrix order and only placed once their dependencies are placed in
+// the same or an earlier wave.
+func (s *PortfolioService) PlanWaves(ctx context.Context, opts WavePlanOptions) (*models.WavePlan, error) {
+	year, quarter, err := startQuarter(opts.StartQuarter, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	dependencies := make(map[string][]string)
+	for _, app := range apps {
+		dependencies[app.ID] = app.DependsOn
+	}
+	
+	matrix, err := s.PrioritizationMatrix(ctx, DefaultValueThreshold, 0)
+	if err != nil {
+		return nil, err
+	}
+	
+	planned := make(map[string]bool)
+	for _, point := range matrix.Applications {
+		planned[point.ApplicationID] = true
+	}
+	
+	plan := &models.WavePlan{
+		GeneratedAt:     time.Now(),
+		CapacityHours:   opts.CapacityHours,
+		MaxApplications: opts.MaxApplications,
+		Waves:           []models.MigrationWave{},
+		Unscheduled:     []models.UnscheduledApplication{},
+		Unassessed:      matrix.Unassessed,
+	}
+	
+	placed := make(map[string]bool)
+	remaining := matrix.Applications
+	for len(remaining) > 0 {
+		wave := models.MigrationWave{
+			Number:       len(plan.Waves) + 1,
+			Quarter:      fmt.Sprintf("%d-Q%d", year, quarter),
+			Applications: []models.WaveApplication{},
+		}
+		
+		// Keep sweeping the candidates so applications whose dependencies were just placed in
+		// this wave can join it
+		for added := true; added; {
+			added = false
+			var next []models.MatrixPoint
+			for _, point := range remaining {
+				if !dependenciesPlaced(dependencies[point.ApplicationID], planned, placed) || !fitsWave(&wave, point, opts) {
+					next = append(next, point)
+					continue
+				}
+				
+				wave.OverCapacity = opts.CapacityHours > 0 && point.EffortHours > opts.CapacityHours
+				wave.EffortHours += point.EffortHours
+				wave.Applications = append(wave.Applications, waveApplication(point, dependencies[point.ApplicationID], planned))
+				placed[point.ApplicationID] = true
+				added = true
+			}
+			remaining = next
+		}
+		
+		// Nothing could be placed, so the remaining applications wait on a dependency cycle
+		if len(wave.Applications) == 0 {
+			for _, point := range remaining {
+				plan.Unscheduled = append(plan.Unscheduled, models.UnscheduledApplication{
+					ApplicationID: point.ApplicationID,
+					Name:          point.Name,
+					Reason:        "dependency cycle",
+				})
+			}
+			break
+		}
+		
+		wave.EffortHours = roundHours(wave.EffortHours)
+		plan.Waves = append(plan.Waves, wave)
+		if quarter++; quarter > 4 {
+			year, quarter = year+1, 1
+		}
+	}
+	
+	return plan, nil
+}
+
+// startQuarter parses a YYYY-QN quarter, defaulting to the quarter after now
+func startQuarter(value string, now time.Time) (int, int, error) {
+	if value == "" {
+		year, quarter := now.Year(), (int(now.Month())-1)/3+2
+		if quarter > 4 {
+			year, quarter = year+1, 1
+		}
+		return year, quarter, nil
+	}
+	
+	var year, quarter int
+	if _, err := fmt.Sscanf(value, "%d-Q%d", &year, &quarter); err != nil || quarter < 1 || quarter > 4 {
+		return 0, 0, fmt.Errorf("invalid quarter %q, expected YYYY-QN", value)
+	}
+	return year, quarter, nil
+}
+
+// dependenciesPlaced reports whether every planned dependency already has a wave; dependencies
+// outside the plan do not block an application
+func dependenciesPlaced(dependsOn []string, planned, placed map[string]bool) bool {
+	for _, id := range dependsOn {
+		if planned[id] && !placed[id] {
+			return false
+		}
+	}
+	return true
+}
+
+// fitsWave reports whether an application fits the wave's remaining capacity. An empty wave
+// always accepts one application so oversized applications are still scheduled.
+func fitsWave(wave *models.MigrationWave, point models.MatrixPoint, opts WavePlanOptions) bool {
+	if len(wave.Applications) == 0 {
+		return true
+	}
+	if wave.OverCapacity {
+		return false
+	}
+	if opts.MaxApplications > 0 && len(wave.Applications) >= opts.MaxApplications {
+		return false
+	}
+	return opts.CapacityHours <= 0 || wave.EffortHours+point.EffortHours <= opts.CapacityHours
+}
+
+// waveApplication builds a wave entry, separating dependencies outside the plan
+func waveApplication(point models.MatrixPoint, dependsOn []string, planned map[string]bool) models.WaveApplication {
+	entry := models.WaveApplication{
+		ApplicationID: point.ApplicationID,
+		Name:          point.Name,
+		Value:         point.Value,
+		EffortHours:   point.EffortHours,
+		Quadrant:      point.Quadrant,
+	}
+	for _, id := range dependsOn {
+		if planned[id] {
+			entry.DependsOn = append(entry.DependsOn, id)
+		} else {
+			entry.ExternalDependencies = append(entry.ExternalDependencies, id)
+		}
+	}
+	return entry
+}
@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"time"
+)
+
+// portfolioSummaryLock serializes changes to the materialized portfolio summary across replicas
+const portfolioSummaryLock = "portfolio-summary"
+
+// PortfolioSummary returns the materialized portfolio summary. It is rebuilt from every
+// application's latest report when it is missing, was invalidated or is older than the
+// configured maximum age; otherwise it is read from a single stored file. Stale reports are
+// counted on every read since reports go stale without changing, and lifecycle states and
+// remediation progress since they change without a new report.
+func (s *PortfolioService) PortfolioSummary(ctx context.Context) (*models.PortfolioSummary, error) {
+	snapshot, err := s.portfolioSnapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	summary := snapshot.Summary
+	summary.Stale = s.staleEntries(snapshot)
+	summary.Lifecycles = lifecycleCounts(apps)
+	for _, app := range apps {
+		if entry, ok := snapshot.Entries[app.ID]; ok {
+			countRemediations(&summary.Remediation, entry.Recommendations, app)
+		}
+	}
+	return &summary, nil
+}
+
+// staleEntries counts the applications whose latest report is past its validity period
+func (s *PortfolioService) staleEntries(snapshot *models.PortfolioSummarySnapshot) int {
+	stale := 0
+	for _, entry := range snapshot.Entries {
+		if s.assessments.ReportStale(entry.GeneratedAt) {
+			stale++
+		}
+	}
+	return stale
+}
+
+// portfolioSnapshot returns the materialized portfolio summary with its per-application
+// entries, rebuilding it when needed
+func (s *PortfolioService) portfolioSnapshot(ctx context.Context) (*models.PortfolioSummarySnapshot, error) {
+	snapshot, err := s.storage.GetPortfolioSummary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio summary: %w", err)
+	}
+	if snapshot != nil && !s.summaryExpired(snapshot) {
+		return snapshot, nil
+	}
+	
+	unlock, err := s.assessments.lockPortfolioSummary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	// Another replica may have rebuilt the summary while we waited for the lock
+	snapshot, err = s.storage.GetPortfolioSummary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get portfolio summary: %w", err)
+	}
+	if snapshot != nil && !s.summaryExpired(snapshot) {
+		return snapshot, nil
+	}
+	
+	return s.rebuildPortfolioSummary(ctx)
+}
+
+// summaryExpired reports whether a summary was last rebuilt longer ago than the maximum age
+func (s *PortfolioService) summaryExpired(snapshot *models.PortfolioSummarySnapshot) bool {
+	if s.summaryMaxAge <= 0 {
+		return false
+	}
+	return time.Since(snapshot.Summary.BuiltAt) > s.summaryMaxAge
+}
+
+// rebuildPortfolioSummary recomputes the summary from every application's latest report
+func (s *PortfolioService) rebuildPortfolioSummary(ctx context.Context) (*models.PortfolioSummarySnapshot, error) {
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	scoring, err := s.assessments.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	
+	snapshot := &models.PortfolioSummarySnapshot{Entries: make(map[string]models.SummaryEntry)}
+	for _, app := range apps {
+		report, err := s.assessments.GetLatestReport(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
+		if report != nil {
+			snapshot.Entries[app.ID] = summaryEntry(report, scoring)
+		}
+	}
+	
+	now := time.Now()
+	snapshot.Summary = summarizeEntries(snapshot.Entries, len(apps))
+	snapshot.Summary.BuiltAt = now
+	snapshot.Summary.UpdatedAt = now
+	
+	if err := s.storage.SavePortfolioSummary(ctx, snapshot); err != nil {
+		return nil, fmt.Errorf("failed to save portfolio summary: %w", err)
+	}
+	return snapshot, nil
+}
+
+// lockPortfolioSummary takes the lock guarding the materialized portfolio summary
+func (s *AssessmentService) lockPortfolioSummary(ctx context.Context) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(ctx, portfolioSummaryLock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock portfolio summary: %w", err)
+	}
+	return unlock, nil
+}
+
+// refreshPortfolioSummary applies a newly saved report to the materialized summary. Failures
+// only invalidate the summary so it is rebuilt on the next read.
+func (s *AssessmentService) refreshPortfolioSummary(ctx context.Context, report *models.Report) {
+	if err := s.applyToPortfolioSummary(ctx, report); err != nil {
+		log.Printf("Failed to update portfolio summary, invalidating it: %v", err)
+		invalidatePortfolioSummary(ctx, s.storage)
+	}
+}
+
+// applyToPortfolioSummary replaces the entry of the report's application in the stored summary
+func (s *AssessmentService) applyToPortfolioSummary(ctx context.Context, report *models.Report) error {
+	unlock, err := s.lockPortfolioSummary(ctx)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
+	snapshot, err := s.storage.GetPortfolioSummary(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get portfolio summary: %w", err)
+	}
+	if snapshot == nil {
+		// Nothing materialized yet; the next read builds the summary including this report
+		return nil
+	}
+	
+	if current, ok := snapshot.Entries[report.ApplicationID]; ok && current.GeneratedAt.After(report.GeneratedAt) {
+		return nil
+	}
+	
+	scoring, err := s.GetScoringConfig(ctx)
+	if err != nil {
+		return err
+	}
+	if snapshot.Entries == nil {
+		snapshot.Entries = make(map[string]models.SummaryEntry)
+	}
+	snapshot.Entries[report.ApplicationID] = summaryEntry(report, scoring)
+	
+	builtAt := snapshot.Summary.BuiltAt
+	snapshot.Summary = summarizeEntries(snapshot.Entries, snapshot.Summary.Applications)
+	snapshot.Summary.BuiltAt = builtAt
+	snapshot.Summary.UpdatedAt = time.Now()
+	
+	if err := s.storage.SavePortfolioSummary(ctx, snapshot); err != nil {
+		return fmt.Errorf("failed to save portfolio summary: %w", err)
+	}
+	return nil
+}
+
+// invalidatePortfolioSummary discards the materialized summary after changes that cannot be
+// applied incrementally, such as deleted reports or applications, so the next read rebuilds it.
+// It does not wait for the summary lock; an update racing with it can only restore stale
+// entries until the summary exceeds its maximum age.
+func invalidatePortfolioSummary(ctx context.Context, store storage.Storage) {
+	// Invalidate even if the request was canceled so the summary cannot stay stale
+	if err := store.DeletePortfolioSummary(context.WithoutCancel(ctx)); err != nil {
+		log.Printf("Failed to invalidate portfolio summary: %v", err)
+	}
+}
+
+// summaryEntry captures a report's contribution to the portfolio summary
+func summaryEntry(report *models.Report, scoring *models.ScoringConfig) models.SummaryEntry {
+	return models.SummaryEntry{
+		LatestScore:     *latestScore(report, scoring),
+		CategoryScores:  report.CategoryScores,
+		Recommendations: recommendationKeys(report),
+	}
+}
+
+// summarizeEntries aggregates the entries of the assessed applications
+func summarizeEntries(entries map[string]models.SummaryEntry, applications int) models.PortfolioSummary {
+	summary := models.PortfolioSummary{
+		Applications:     applications,
+		Assessed:         len(entries),
+		Bands:            make(map[string]int),
+		Grades:           make(map[string]int),
+		CategoryAverages: make(map[string]float64),
+	}
+	
+	// An application may have been created since the count was taken
+	if summary.Applications < summary.Assessed {
+		summary.Applications = summary.Assessed
+	}
+	
+	var scoreTotal int
+	categoryTotals := make(map[string]int)
+	categoryCounts := make(map[string]int)
+	for _, entry := range entries {
+		scoreTotal += entry.ScorePercent
+		summary.Bands[entry.Band]++
+		summary.Grades[entry.Grade]++
+		for category, score := range entry.CategoryScores {
+			categoryTotals[category] += score
+			categoryCounts[category]++
+		}
+	}
+	
+	if len(entries) > 0 {
+		summary.AverageScorePercent = roundTenth(float64(scoreTotal) / float64(len(entries)))
+	}
+	for category, total := range categoryTotals {
+		summary.CategoryAverages[category] = roundTenth(float64(total) / float64(categoryCounts[category]))
+	}
+	
+	return summary
+}
+
+// roundTenth rounds to one decimal place
+func roundTenth(value float64) float64 {
+	return math.Round(value*10) / 10
+}
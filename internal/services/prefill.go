@@ -0,0 +1,377 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"questionnaire-app/internal/models"
+	"time"
+)
+
+// Suggestion sources
+const (
+	SourceStaticAnalysis = "static-analysis"
+	SourceClusterScan    = "cluster-scan"
+	SourceApplication    = "application-metadata"
+)
+
+// TagSignal names the signal raised by an application tag, e.g. "tag:stateless=true"
+func TagSignal(key, value string) string {
+	return "tag:" + key + "=" + value
+}
+
+// ApplicationSignals turns the tags of an application into signals, one per tag
+func ApplicationSignals(app *models.Application) []models.Signal {
+	signals := []models.Signal{}
+	for _, key := range sortedTagKeys(app.Tags) {
+		signals = append(signals, models.Signal{
+			Name:     TagSignal(key, app.Tags[key]),
+			Evidence: []string{"tags." + key},
+		})
+	}
+	return signals
+}
+
+// DefaultPrefillRules maps detected signals to answers of the default question catalog
+func DefaultPrefillRules() []models.PrefillRule {
+	return []models.PrefillRule{
+		{Signal: SignalInMemorySession, QuestionID: "q1", OptionID: "q1_a3", Confidence: 0.6},
+		{Signal: SignalEnvConfig, QuestionID: "q2", OptionID: "q2_a2", Confidence: 0.6},
+		{Signal: SignalFileConfig, QuestionID: "q2", OptionID: "q2_a3", Confidence: 0.5},
+		{Signal: SignalLogToStdout, QuestionID: "q3", OptionID: "q3_a1", Confidence: 0.7},
+		{Signal: SignalLogToFile, QuestionID: "q3", OptionID: "q3_a3", Confidence: 0.8},
+		{Signal: SignalExternalDatabase, QuestionID: "q4", OptionID: "q4_a1", Confidence: 0.6},
+		{Signal: SignalEmbeddedDatabase, QuestionID: "q4", OptionID: "q4_a4", Confidence: 0.8},
+		{Signal: SignalReadOnlyRootFS, QuestionID: "q1", OptionID: "q1_a2", Confidence: 0.5},
+		{Signal: SignalStatefulSet, QuestionID: "q1", OptionID: "q1_a3", Confidence: 0.7},
+		{Signal: SignalKubernetesWorkload, QuestionID: "q2", OptionID: "q2_a2", Confidence: 0.4},
+		{Signal: SignalStatefulSet, QuestionID: "q4", OptionID: "q4_a2", Confidence: 0.5},
+		{Signal: SignalHorizontalAutoscaling, QuestionID: "q5", OptionID: "q5_a1", Confidence: 0.8},
+		{Signal: SignalDisruptionBudget, QuestionID: "q5", OptionID: "q5_a2", Confidence: 0.5},
+		{Signal: SignalEnvironmentConfig, QuestionID: "q2", OptionID: "q2_a2", Confidence: 0.6},
+		{Signal: SignalMountedConfig, QuestionID: "q2", OptionID: "q2_a3", Confidence: 0.4},
+		{Signal: SignalPersistentVolumes, QuestionID: "q4", OptionID: "q4_a2", Confidence: 0.6},
+		{Signal: SignalHostPathVolumes, QuestionID: "q4", OptionID: "q4_a3", Confidence: 0.8},
+		{Signal: TagSignal("stateless", "true"), QuestionID: "q1", OptionID: "q1_a1", Confidence: 0.9},
+		{Signal: TagSignal("stateless", "false"), QuestionID: "q1", OptionID: "q1_a4", Confidence: 0.9},
+		{Signal: TagSignal("logging", "stdout"), QuestionID: "q3", OptionID: "q3_a1", Confidence: 0.9},
+	}
+}
+
+// LoadPrefillRules reads prefill rules from a JSON file
+func LoadPrefillRules(path string) ([]models.PrefillRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prefill rules: %w", err)
+	}
+	
+	var rules []models.PrefillRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse prefill rules: %w", err)
+	}
+	
+	for i, rule := range rules {
+		if rule.Signal == "" || rule.QuestionID == "" || rule.OptionID == "" {
+			return nil, fmt.Errorf("prefill rule %d needs a signal, question and option", i)
+		}
+	}
+	
+	return rules, nil
+}
+
+// PrefillService proposes answers from automated sources. Proposals are stored on the
+// assessment as suggestions until an assessor confirms or dismisses them.
+type PrefillService struct {
+	assessments *AssessmentService
+	rules       []models.PrefillRule
+	scanner     *RepositoryScanner
+}
+
+// NewPrefillService creates a new prefill service
+func NewPrefillService(assessments *AssessmentService, rules []models.PrefillRule, scanner *RepositoryScanner) *PrefillService {
+	return &PrefillService{
+		assessments: assessments,
+		rules:       rules,
+		scanner:     scanner,
+	}
+}
+
+// PrefillFromRepository scans the application's Git repository, or repoURL if given, and
+// suggests answers from what was found
+func (s *PrefillService) PrefillFromRepository(ctx context.Context, assessmentID, repoURL string) (*models.PrefillResult, error) {
+	assessment, err := s.assessments.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	if repoURL == "" {
+		app, err := s.assessments.storage.GetApplication(ctx, assessment.ApplicationID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get application: %w", err)
+		}
+		if app != nil {
+			repoURL = app.Repository
+		}
+	}
+	
+	if repoURL == "" {
+		return nil, errors.New("application has no linked repository")
+	}
+	
+	signals, err := s.scanner.Scan(ctx, repoURL)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.suggest(ctx, assessmentID, SourceStaticAnalysis, signals)
+}
+
+// PrefillFromClusterScan suggests answers from kube-score, Polaris or Popeye output for an
+// application already running in a cluster. format may be empty to detect it.
+func (s *PrefillService) PrefillFromClusterScan(ctx context.Context, assessmentID, format string, data []byte) (*models.PrefillResult, error) {
+	signals, format, err := ParseClusterScan(format, data)
+	if err != nil {
+		return nil, err
+	}
+	
+	return s.suggest(ctx, assessmentID, SourceClusterScan+":"+format, signals)
+}
+
+// PrefillFromApplication suggests answers from the tags of the assessment's application
+func (s *PrefillService) PrefillFromApplication(ctx context.Context, assessmentID string) (*models.PrefillResult, error) {
+	assessment, err := s.assessments.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	app, err := s.assessments.storage.GetApplication(ctx, assessment.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	return s.suggest(ctx, assessmentID, SourceApplication, ApplicationSignals(app))
+}
+
+// JobPrefillRepository is the background job type scanning a repository for an assessment
+const JobPrefillRepository = "prefill-repository"
+
+// PrefillRepositoryParams are the parameters of a repository prefill job
+type PrefillRepositoryParams struct {
+	AssessmentID string `json:"assessmentId"`
+	Repository   string `json:"repository,omitempty"`
+}
+
+// PrefillFromRepositoryJob adapts PrefillFromRepository to the job runner
+func (s *PrefillService) PrefillFromRepositoryJob(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var p PrefillRepositoryParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid job parameters: %w", err)
+	}
+	return s.PrefillFromRepository(ctx, p.AssessmentID, p.Repository)
+}
+
+// suggest applies the prefill rules to the signals and records the resulting suggestions
+func (s *PrefillService) suggest(ctx context.Context, assessmentID, source string, signals []models.Signal) (*models.PrefillResult, error) {
+	questions, err := s.assessments.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	// Only suggest options that exist in the current catalog
+	validOptions := make(map[string]bool)
+	for _, question := range questions {
+		for _, option := range question.Options {
+			validOptions[question.ID+"/"+option.ID] = true
+		}
+	}
+	
+	bySignal := make(map[string]models.Signal, len(signals))
+	for _, signal := range signals {
+		bySignal[signal.Name] = signal
+	}
+	
+	now := time.Now()
+	best := make(map[string]models.AnswerSuggestion)
+	var order []string
+	for _, rule := range s.rules {
+		signal, ok := bySignal[rule.Signal]
+		if !ok || !validOptions[rule.QuestionID+"/"+rule.OptionID] {
+			continue
+		}
+		
+		current, exists := best[rule.QuestionID]
+		if exists && current.Confidence >= rule.Confidence {
+			continue
+		}
+		if !exists {
+			order = append(order, rule.QuestionID)
+		}
+		best[rule.QuestionID] = models.AnswerSuggestion{
+			QuestionID:  rule.QuestionID,
+			OptionID:    rule.OptionID,
+			Source:      source,
+			Signal:      rule.Signal,
+			Confidence:  rule.Confidence,
+			Evidence:    signal.Evidence,
+			SuggestedAt: now,
+		}
+	}
+	
+	suggestions := make([]models.AnswerSuggestion, 0, len(order))
+	for _, questionID := range order {
+		suggestions = append(suggestions, best[questionID])
+	}
+	
+	recorded, err := s.assessments.SuggestAnswers(ctx, assessmentID, suggestions)
+	if err != nil {
+		return nil, err
+	}
+	
+	if signals == nil {
+		signals = []models.Signal{}
+	}
+	return &models.PrefillResult{
+		AssessmentID: assessmentID,
+		Source:       source,
+		Signals:      signals,
+		Suggestions:  recorded,
+	}, nil
+}
+
+// SuggestAnswers records suggested answers for questions of the assessment that have not been
+// answered yet and returns the suggestions that were recorded
+func (s *AssessmentService) SuggestAnswers(ctx context.Context, assessmentID string, suggestions []models.AnswerSuggestion) ([]models.AnswerSuggestion, error) {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	if assessment.Status == "approved" {
+		return nil, ErrAssessmentApproved
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	
+	recorded := []models.AnswerSuggestion{}
+	for _, suggestion := range suggestions {
+		question := byID[suggestion.QuestionID]
+		if _, answered := assessment.Answers[suggestion.QuestionID]; !answered && inSpotCheck(assessment, suggestion.QuestionID) && (question == nil || inScope(assessment, question)) {
+			recorded = append(recorded, suggestion)
+		}
+	}
+	
+	if len(recorded) == 0 {
+		return recorded, nil
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:        models.EventAnswersSuggested,
+		Suggestions: recorded,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	return recorded, nil
+}
+
+// ConfirmSuggestion accepts a suggested answer as the answer of the confirming user, recording
+// the suggestion as the answer's provenance
+func (s *AssessmentService) ConfirmSuggestion(ctx context.Context, assessmentID, questionID, confirmedBy string) error {
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	suggestion, ok := assessment.Suggestions[questionID]
+	if !ok {
+		return fmt.Errorf("suggestion %w", ErrNotFound)
+	}
+	
+	provenance := models.AnswerProvenance{
+		Method:     models.ProvenancePrefilled,
+		Source:     suggestion.Source,
+		Signal:     suggestion.Signal,
+		Confidence: suggestion.Confidence,
+	}
+	if suggestion.Source == SourceClusterScan {
+		provenance.Method = models.ProvenanceAgentScan
+	}
+	return s.saveAnswer(ctx, assessmentID, questionID, suggestion.OptionID, "", nil, confirmedBy, provenance)
+}
+
+// DismissSuggestion discards a suggested answer
+func (s *AssessmentService) DismissSuggestion(ctx context.Context, assessmentID, questionID, dismissedBy string) error {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	if _, ok := assessment.Suggestions[questionID]; !ok {
+		return fmt.Errorf("suggestion %w", ErrNotFound)
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:       models.EventSuggestionDismissed,
+		User:       dismissedBy,
+		QuestionID: questionID,
+	})
+	if err != nil {
+		return err
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,580 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"time"
+)
+
+// PrivacyService handles data subject requests such as export and erasure of personal data
+type PrivacyService struct {
+	storage storage.Storage
+	locker  storage.Locker
+}
+
+// NewPrivacyService creates a new privacy service. Erasure takes the same locks as the other
+// writers of assessments and applications, so concurrent changes are neither lost nor bring
+// an erased identity back.
+func NewPrivacyService(storage storage.Storage, locker storage.Locker) *PrivacyService {
+	return &PrivacyService{
+		storage: storage,
+		locker:  locker,
+	}
+}
+
+// lock acquires the lock of an entity, such as "assessment-<id>" or "application-<id>"
+func (s *PrivacyService) lock(ctx context.Context, key string) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock %s: %w", key, err)
+	}
+	return unlock, nil
+}
+
+// ExportUserData collects every assessment, answer, annotation and application or campaign
+// change attributed to a user
+func (s *PrivacyService) ExportUserData(ctx context.Context, userID string) (*models.UserDataExport, error) {
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	export := &models.UserDataExport{
+		UserID:           userID,
+		ExportedAt:       time.Now(),
+		Assessments:      []*models.Assessment{},
+		Answers:          []models.UserAnswer{},
+		Responses:        []models.UserAnswer{},
+		Annotations:      []models.AssessmentAnnotation{},
+		Votes:            []models.UserWorkshopVote{},
+		LifecycleChanges: []models.UserLifecycleChange{},
+		Remediations:     []models.UserRemediation{},
+		RiskAcceptances:  []models.UserRiskAcceptance{},
+		Campaigns:        []*models.Campaign{},
+	}
+	
+	for _, assessment := range assessments {
+		if assessment.StartedBy == userID || assessment.ApprovedBy == userID || isParticipant(assessment.Context, userID) {
+			export.Assessments = append(export.Assessments, assessment)
+		}
+		
+		for questionID, answeredBy := range assessment.AnsweredBy {
+			if answeredBy == userID {
+				export.Answers = append(export.Answers, models.UserAnswer{
+					AssessmentID: assessment.ID,
+					QuestionID:   questionID,
+					OptionID:     assessment.Answers[questionID],
+					Explanation:  assessment.Explanations[questionID],
+				})
+			}
+		}
+		for questionID, responses := range assessment.Responses {
+			if response, ok := responses[userID]; ok {
+				export.Responses = append(export.Responses, models.UserAnswer{
+					AssessmentID: assessment.ID,
+					QuestionID:   questionID,
+					OptionID:     response.OptionID,
+					Explanation:  response.Explanation,
+				})
+			}
+		}
+		
+		report, err := s.storage.GetReport(ctx, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		
+		// Report versions superseded by re-scoring keep their own annotations
+		reports, err := s.storage.ListReportVersions(ctx, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list report versions: %w", err)
+		}
+		if report != nil {
+			reports = append(reports, report)
+		}
+		
+		for _, report := range reports {
+			for _, annotation := range report.Annotations {
+				if annotation.Author == userID {
+					export.Annotations = append(export.Annotations, models.AssessmentAnnotation{
+						AssessmentID: assessment.ID,
+						Annotation:   annotation,
+					})
+				}
+			}
+		}
+	}
+	
+	workshops, err := s.storage.ListWorkshops(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workshops: %w", err)
+	}
+	for _, workshop := range workshops {
+		for _, questionID := range workshop.QuestionIDs {
+			if optionID, ok := workshop.Votes[questionID][userID]; ok {
+				export.Votes = append(export.Votes, models.UserWorkshopVote{
+					AssessmentID: workshop.AssessmentID,
+					QuestionID:   questionID,
+					OptionID:     optionID,
+				})
+			}
+		}
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		for _, change := range app.LifecycleHistory {
+			if change.ChangedBy == userID {
+				export.LifecycleChanges = append(export.LifecycleChanges, models.UserLifecycleChange{ApplicationID: app.ID, LifecycleChange: change})
+			}
+		}
+		for _, remediation := range app.Remediations {
+			if remediation.UpdatedBy == userID || remediation.Owner == userID {
+				export.Remediations = append(export.Remediations, models.UserRemediation{ApplicationID: app.ID, Remediation: remediation})
+			}
+		}
+		for _, acceptance := range app.RiskAcceptances {
+			if acceptance.AcceptedBy == userID {
+				export.RiskAcceptances = append(export.RiskAcceptances, models.UserRiskAcceptance{ApplicationID: app.ID, RiskAcceptance: acceptance})
+			}
+		}
+	}
+	
+	campaigns, err := s.storage.ListCampaigns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	for _, campaign := range campaigns {
+		if campaign.CreatedBy == userID || campaign.Owner == userID {
+			export.Campaigns = append(export.Campaigns, campaign)
+		}
+	}
+	
+	export.Digest, err = s.storage.GetDigestSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscription: %w", err)
+	}
+	
+	return export, nil
+}
+
+// EraseUserData anonymizes or purges all data attributed to a user. Names recorded for changes
+// to applications and campaigns, such as who accepted a risk, are anonymized in both modes, so
+// the history of those changes stays complete.
+func (s *PrivacyService) EraseUserData(ctx context.Context, userID, mode string) (*models.UserDataErasure, error) {
+	if mode != models.ErasureModeAnonymize && mode != models.ErasureModePurge {
+		return nil, fmt.Errorf("unknown erasure mode: %s", mode)
+	}
+	
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	result := &models.UserDataErasure{UserID: userID, Mode: mode}
+	defer func() {
+		if result.AssessmentsDeleted > 0 {
+			invalidatePortfolioSummary(ctx, s.storage)
+		}
+	}()
+	
+	for _, assessment := range assessments {
+		if err := s.eraseAssessment(ctx, assessment.ID, userID, mode, result); err != nil {
+			return nil, err
+		}
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		// The listed copy only tells whether the application mentions the user at all
+		if !eraseApplicationUser(app, userID) {
+			continue
+		}
+		if err := s.eraseApplication(ctx, app.ID, userID, result); err != nil {
+			return nil, err
+		}
+	}
+	
+	campaigns, err := s.storage.ListCampaigns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	for _, campaign := range campaigns {
+		if eraseCampaignUser(campaign, userID) {
+			if err := s.storage.SaveCampaign(ctx, campaign); err != nil {
+				return nil, fmt.Errorf("failed to save campaign: %w", err)
+			}
+			result.CampaignsUpdated++
+		}
+	}
+	
+	workshops, err := s.storage.ListWorkshops(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workshops: %w", err)
+	}
+	for _, workshop := range workshops {
+		if eraseWorkshopUser(workshop, userID, mode) {
+			if err := s.storage.SaveWorkshop(ctx, workshop); err != nil {
+				return nil, fmt.Errorf("failed to save workshop: %w", err)
+			}
+			result.WorkshopsUpdated++
+		}
+	}
+	
+	// A subscription holds the user's email address, so both modes remove it
+	subscription, err := s.storage.GetDigestSubscription(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get digest subscription: %w", err)
+	}
+	if subscription != nil {
+		if err := s.storage.DeleteDigestSubscription(ctx, userID); err != nil {
+			return nil, fmt.Errorf("failed to delete digest subscription: %w", err)
+		}
+		result.DigestUnsubscribed = true
+	}
+	
+	return result, nil
+}
+
+// eraseAssessment erases a user from an assessment, its events and its reports while holding
+// the assessment's lock. The assessment is read again under the lock, since it may have
+// changed since it was listed.
+func (s *PrivacyService) eraseAssessment(ctx context.Context, assessmentID, userID, mode string, result *models.UserDataErasure) error {
+	unlock, err := s.lock(ctx, "assessment-"+assessmentID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil
+	}
+	
+	// Purging removes assessments the user started along with their reports
+	if mode == models.ErasureModePurge && assessment.StartedBy == userID {
+		if err := s.storage.DeleteReport(ctx, assessment.ID); err != nil {
+			return fmt.Errorf("failed to delete report: %w", err)
+		}
+		if err := s.storage.DeleteAssessment(ctx, assessment.ID); err != nil {
+			return fmt.Errorf("failed to delete assessment: %w", err)
+		}
+		if err := s.storage.DeleteEvents(ctx, assessment.ID); err != nil {
+			return fmt.Errorf("failed to delete events: %w", err)
+		}
+		if err := s.storage.DeleteWorkshop(ctx, assessment.ID); err != nil {
+			return fmt.Errorf("failed to delete workshop: %w", err)
+		}
+		result.AssessmentsDeleted++
+		return nil
+	}
+	
+	if eraseAssessmentUser(assessment, userID, mode) {
+		if err := s.storage.UpdateAssessment(ctx, assessment); err != nil {
+			return fmt.Errorf("failed to update assessment: %w", err)
+		}
+		result.AssessmentsUpdated++
+	}
+	
+	// The event log is append-only except for compliance rewrites like this one
+	events, err := s.storage.ListEvents(ctx, assessment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list events: %w", err)
+	}
+	
+	if eraseEventUser(events, userID, mode) {
+		if err := s.storage.ReplaceEvents(ctx, assessment.ID, events); err != nil {
+			return fmt.Errorf("failed to rewrite events: %w", err)
+		}
+	}
+	
+	versions, err := s.storage.ListReportVersions(ctx, assessment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list report versions: %w", err)
+	}
+	
+	for _, version := range versions {
+		updated, deleted := eraseAnnotationAuthor(version, userID, mode)
+		if updated+deleted > 0 || eraseParticipant(version.Context, userID, mode) {
+			if err := s.storage.SaveReportVersion(ctx, version); err != nil {
+				return fmt.Errorf("failed to save report version: %w", err)
+			}
+			result.AnnotationsUpdated += updated
+			result.AnnotationsDeleted += deleted
+		}
+	}
+	
+	report, err := s.storage.GetReport(ctx, assessment.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get report: %w", err)
+	}
+	
+	if report == nil {
+		return nil
+	}
+	
+	updated, deleted := eraseAnnotationAuthor(report, userID, mode)
+	if updated+deleted > 0 || eraseParticipant(report.Context, userID, mode) {
+		now := time.Now()
+		report.UpdatedAt = &now
+		if err := s.storage.SaveReport(ctx, report); err != nil {
+			return fmt.Errorf("failed to save report: %w", err)
+		}
+		result.AnnotationsUpdated += updated
+		result.AnnotationsDeleted += deleted
+	}
+	
+	return nil
+}
+
+// eraseApplication erases a user from an application's lifecycle history, remediations and
+// risk acceptances while holding the application's lock
+func (s *PrivacyService) eraseApplication(ctx context.Context, applicationID, userID string, result *models.UserDataErasure) error {
+	unlock, err := s.lock(ctx, "application-"+applicationID)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil || !eraseApplicationUser(app, userID) {
+		return nil
+	}
+	
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+	result.ApplicationsUpdated++
+	return nil
+}
+
+// eraseAssessmentUser removes a user's identity from an assessment and reports whether it changed
+func eraseAssessmentUser(assessment *models.Assessment, userID, mode string) bool {
+	changed := false
+	
+	if assessment.StartedBy == userID {
+		assessment.StartedBy = models.AnonymizedUser
+		changed = true
+	}
+	
+	if eraseName(&assessment.ApprovedBy, userID) {
+		changed = true
+	}
+	
+	if eraseParticipant(assessment.Context, userID, mode) {
+		changed = true
+	}
+	
+	for questionID, answeredBy := range assessment.AnsweredBy {
+		if answeredBy != userID {
+			continue
+		}
+		
+		if mode == models.ErasureModePurge {
+			delete(assessment.AnsweredBy, questionID)
+		} else {
+			assessment.AnsweredBy[questionID] = models.AnonymizedUser
+		}
+		changed = true
+	}
+	
+	// Anonymized responses keep distinct names so the agreement among assessors is unchanged
+	for _, responses := range assessment.Responses {
+		response, ok := responses[userID]
+		if !ok {
+			continue
+		}
+		
+		delete(responses, userID)
+		if mode == models.ErasureModeAnonymize {
+			responses[anonymousName(responses)] = response
+		}
+		changed = true
+	}
+	
+	return changed
+}
+
+// anonymousName returns a name for an anonymized user not yet used as a key of m
+func anonymousName[V any](m map[string]V) string {
+	name := models.AnonymizedUser
+	for i := 2; ; i++ {
+		if _, taken := m[name]; !taken {
+			return name
+		}
+		name = fmt.Sprintf("%s-%d", models.AnonymizedUser, i)
+	}
+}
+
+// isParticipant reports whether a user is listed among the participants of an assessment
+func isParticipant(details *models.AssessmentContext, userID string) bool {
+	if details == nil {
+		return false
+	}
+	for _, participant := range details.Participants {
+		if participant == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// eraseParticipant anonymizes or drops a user among the participants of an assessment and
+// reports whether it changed
+func eraseParticipant(details *models.AssessmentContext, userID, mode string) bool {
+	if !isParticipant(details, userID) {
+		return false
+	}
+	
+	kept := details.Participants[:0]
+	for _, participant := range details.Participants {
+		if participant == userID {
+			if mode == models.ErasureModePurge {
+				continue
+			}
+			participant = models.AnonymizedUser
+		}
+		kept = append(kept, participant)
+	}
+	details.Participants = kept
+	return true
+}
+
+// eraseEventUser removes a user's identity from an event log and reports whether it changed
+func eraseEventUser(events []*models.AssessmentEvent, userID, mode string) bool {
+	changed := false
+	
+	for _, event := range events {
+		if event.User == userID {
+			event.User = ""
+			if mode == models.ErasureModeAnonymize {
+				event.User = models.AnonymizedUser
+			}
+			changed = true
+		}
+		
+		if event.Assessment != nil && eraseAssessmentUser(event.Assessment, userID, mode) {
+			changed = true
+		}
+	}
+	
+	return changed
+}
+
+// eraseWorkshopUser removes a user's identity from a workshop and reports whether it changed.
+// Purging drops the user's votes; anonymizing keeps them under a distinct anonymous name so
+// votes of several erased users stay apart.
+func eraseWorkshopUser(workshop *models.Workshop, userID, mode string) bool {
+	changed := false
+	
+	if workshop.Facilitator == userID {
+		workshop.Facilitator = models.AnonymizedUser
+		changed = true
+	}
+	
+	for questionID, decision := range workshop.Decisions {
+		if decision.DecidedBy == userID {
+			decision.DecidedBy = models.AnonymizedUser
+			workshop.Decisions[questionID] = decision
+			changed = true
+		}
+	}
+	
+	for _, votes := range workshop.Votes {
+		optionID, ok := votes[userID]
+		if !ok {
+			continue
+		}
+		
+		delete(votes, userID)
+		if mode == models.ErasureModeAnonymize {
+			votes[anonymousName(votes)] = optionID
+		}
+		changed = true
+	}
+	
+	return changed
+}
+
+// eraseApplicationUser anonymizes a user's name in the lifecycle history, remediations and
+// risk acceptances of an application and reports whether it changed
+func eraseApplicationUser(app *models.Application, userID string) bool {
+	changed := false
+	
+	for i := range app.LifecycleHistory {
+		if eraseName(&app.LifecycleHistory[i].ChangedBy, userID) {
+			changed = true
+		}
+	}
+	
+	for i := range app.Remediations {
+		remediation := &app.Remediations[i]
+		if eraseName(&remediation.UpdatedBy, userID) {
+			changed = true
+		}
+		if eraseName(&remediation.Owner, userID) {
+			changed = true
+		}
+	}
+	
+	for i := range app.RiskAcceptances {
+		if eraseName(&app.RiskAcceptances[i].AcceptedBy, userID) {
+			changed = true
+		}
+	}
+	
+	return changed
+}
+
+// eraseCampaignUser anonymizes a user as the creator or owner of a campaign and reports
+// whether it changed
+func eraseCampaignUser(campaign *models.Campaign, userID string) bool {
+	created := eraseName(&campaign.CreatedBy, userID)
+	owned := eraseName(&campaign.Owner, userID)
+	return created || owned
+}
+
+// eraseName replaces a user's name with the anonymized user and reports whether it did
+func eraseName(name *string, userID string) bool {
+	if *name != userID {
+		return false
+	}
+	*name = models.AnonymizedUser
+	return true
+}
+
+// eraseAnnotationAuthor anonymizes or drops a user's annotations on a report
+func eraseAnnotationAuthor(report *models.Report, userID, mode string) (updated, deleted int) {
+	kept := report.Annotations[:0]
+	for _, annotation := range report.Annotations {
+		if annotation.Author == userID {
+			if mode == models.ErasureModePurge {
+				deleted++
+				continue
+			}
+			annotation.Author = models.AnonymizedUser
+			updated++
+		}
+		kept = append(kept, annotation)
+	}
+	report.Annotations = kept
+	
+	return updated, deleted
+}
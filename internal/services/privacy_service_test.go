@@ -0,0 +1,185 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+)
+
+// seedAttributions stores an application, a campaign and two assessments that name alice in
+// every attribution field the privacy service has to cover, with one answer copied by alice
+func seedAttributions(t *testing.T) *questionnairetest.MemoryStorage {
+	t.Helper()
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	
+	app := questionnairetest.NewApplication("app1").Name("Billing").Build()
+	app.LifecycleHistory = []models.LifecycleChange{{To: "active", ChangedBy: "alice", ChangedAt: time.Now()}}
+	app.Remediations = []models.Remediation{{Key: "r1", Category: "Security", Description: "Rotate keys", Status: "open", Owner: "alice", UpdatedBy: "alice", UpdatedAt: time.Now()}}
+	app.RiskAcceptances = []models.RiskAcceptance{{Key: "k1", Category: "Security", Description: "Legacy TLS", AcceptedBy: "alice", ExpiresOn: "2099-01-01", AcceptedAt: time.Now()}}
+	
+	approved := questionnairetest.NewAssessment("a1", "app1").Answer("q1", "yes").StartedBy("bob").Status("completed").Build()
+	approvedAt := time.Now()
+	approved.ApprovedBy = "alice"
+	approved.ApprovedAt = &approvedAt
+	
+	store.Seed(t,
+		app,
+		questionnairetest.NewQuestion("q1").Category("Security").Option("yes", "Yes", 10).Option("no", "No", 0).Build(),
+		approved,
+		questionnairetest.NewAssessment("a2", "app1").StartedBy("bob").Build(),
+		&models.Campaign{ID: "c1", Name: "Q3 review", Owner: "alice", CreatedBy: "alice", ApplicationIDs: []string{"app1"}},
+	)
+	
+	assessments := questionnairetest.NewAssessmentService(store)
+	if _, err := assessments.CopyAnswers(ctx, "a2", models.AnswerCopyRequest{SourceAssessmentID: "a1", QuestionIDs: []string{"q1"}}, "alice"); err != nil {
+		t.Fatalf("CopyAnswers: %v", err)
+	}
+	return store
+}
+
+// storedDocuments encodes everything the storage holds about app1 and its assessments
+func storedDocuments(t *testing.T, store *questionnairetest.MemoryStorage) string {
+	t.Helper()
+	ctx := context.Background()
+	var documents []interface{}
+	
+	apps, err := store.ListApplications(ctx)
+	if err != nil {
+		t.Fatalf("ListApplications: %v", err)
+	}
+	campaigns, err := store.ListCampaigns(ctx)
+	if err != nil {
+		t.Fatalf("ListCampaigns: %v", err)
+	}
+	assessments, err := store.ListAssessments(ctx, "")
+	if err != nil {
+		t.Fatalf("ListAssessments: %v", err)
+	}
+	documents = append(documents, apps, campaigns, assessments)
+	for _, assessment := range assessments {
+		events, err := store.ListEvents(ctx, assessment.ID)
+		if err != nil {
+			t.Fatalf("ListEvents: %v", err)
+		}
+		versions, err := store.ListReportVersions(ctx, assessment.ID)
+		if err != nil {
+			t.Fatalf("ListReportVersions: %v", err)
+		}
+		documents = append(documents, events, versions)
+	}
+	
+	data, err := json.Marshal(documents)
+	if err != nil {
+		t.Fatalf("failed to encode documents: %v", err)
+	}
+	return string(data)
+}
+
+func TestEraseUserDataRemovesAttributions(t *testing.T) {
+	for _, mode := range []string{models.ErasureModeAnonymize, models.ErasureModePurge} {
+		t.Run(mode, func(t *testing.T) {
+			store := seedAttributions(t)
+			if !strings.Contains(storedDocuments(t, store), `"alice"`) {
+				t.Fatal("seeded documents do not mention alice")
+			}
+			
+			privacy := services.NewPrivacyService(store, questionnairetest.NewMemoryLocker())
+			result, err := privacy.EraseUserData(context.Background(), "alice", mode)
+			if err != nil {
+				t.Fatalf("EraseUserData: %v", err)
+			}
+			
+			if documents := storedDocuments(t, store); strings.Contains(documents, `"alice"`) {
+				t.Errorf("alice survived erasure: %s", documents)
+			}
+			if result.ApplicationsUpdated != 1 {
+				t.Errorf("ApplicationsUpdated = %d, want 1", result.ApplicationsUpdated)
+			}
+			if result.CampaignsUpdated != 1 {
+				t.Errorf("CampaignsUpdated = %d, want 1", result.CampaignsUpdated)
+			}
+		})
+	}
+}
+
+func TestExportUserDataIncludesAttributions(t *testing.T) {
+	store := seedAttributions(t)
+	privacy := services.NewPrivacyService(store, questionnairetest.NewMemoryLocker())
+	
+	export, err := privacy.ExportUserData(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("ExportUserData: %v", err)
+	}
+	
+	if len(export.Assessments) != 1 || export.Assessments[0].ID != "a1" {
+		t.Errorf("Assessments = %+v, want the approved a1", export.Assessments)
+	}
+	if len(export.Answers) != 1 || export.Answers[0].AssessmentID != "a2" {
+		t.Errorf("Answers = %+v, want the answer copied into a2", export.Answers)
+	}
+	if len(export.LifecycleChanges) != 1 || export.LifecycleChanges[0].ApplicationID != "app1" {
+		t.Errorf("LifecycleChanges = %+v, want the transition on app1", export.LifecycleChanges)
+	}
+	if len(export.Remediations) != 1 {
+		t.Errorf("Remediations = %+v, want one", export.Remediations)
+	}
+	if len(export.RiskAcceptances) != 1 {
+		t.Errorf("RiskAcceptances = %+v, want one", export.RiskAcceptances)
+	}
+	if len(export.Campaigns) != 1 || export.Campaigns[0].ID != "c1" {
+		t.Errorf("Campaigns = %+v, want c1", export.Campaigns)
+	}
+}
+
+func TestEraseUserDataWaitsForAssessmentLock(t *testing.T) {
+	store := seedAttributions(t)
+	locker := questionnairetest.NewMemoryLocker()
+	privacy := services.NewPrivacyService(store, locker)
+	
+	unlock, err := locker.Lock(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	defer unlock()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := privacy.EraseUserData(ctx, "alice", models.ErasureModeAnonymize); !errors.Is(err, storage.ErrLockTimeout) {
+		t.Fatalf("EraseUserData with the assessment locked: err = %v, want ErrLockTimeout", err)
+	}
+	
+	assessment, err := store.GetAssessment(context.Background(), "a1")
+	if err != nil {
+		t.Fatalf("GetAssessment: %v", err)
+	}
+	if assessment.ApprovedBy != "alice" {
+		t.Errorf("ApprovedBy = %q, the locked assessment was rewritten", assessment.ApprovedBy)
+	}
+}
+
+func TestEraseUserDataWaitsForApplicationLock(t *testing.T) {
+	store := seedAttributions(t)
+	locker := questionnairetest.NewMemoryLocker()
+	privacy := services.NewPrivacyService(store, locker)
+	
+	unlock, err := locker.Lock(context.Background(), "application-app1")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	defer unlock()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := privacy.EraseUserData(ctx, "alice", models.ErasureModeAnonymize); !errors.Is(err, storage.ErrLockTimeout) {
+		t.Fatalf("EraseUserData with the application locked: err = %v, want ErrLockTimeout", err)
+	}
+}
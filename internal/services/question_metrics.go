@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"questionnaire-app/internal/models"
+	"sort"
+	"time"
+)
+
+// answerIdleCutoff is the longest gap between an assessor's activities counted as time spent
+// on a question; longer gaps are breaks, and the answer after them is not timed
+const answerIdleCutoff = 30 * time.Minute
+
+// Orders of question metrics
+const (
+	QuestionMetricsByTime     = "time"     // slowest median time to answer first
+	QuestionMetricsByRevisits = "revisits" // highest revisit rate first
+)
+
+// QuestionMetricsOptions filters and orders question metrics
+type QuestionMetricsOptions struct {
+	Category   string
+	Sort       string // QuestionMetricsByTime if empty
+	MinAnswers int    // questions answered in fewer assessments are left out
+}
+
+// questionActivity accumulates the answers to a question across assessments
+type questionActivity struct {
+	seconds     []float64
+	assessments int
+	revisited   int // assessments with a revisit
+	revisits    int
+	changes     int
+}
+
+// GetQuestionMetrics derives time to answer and revisits of every catalog question from the
+// assessments' event logs. Only answers an assessor chose by hand are counted; confirmed
+// suggestions, imports and copies take no time to think about. The time to answer is the time
+// since the assessor's previous activity in the assessment, which assumes questions are
+// answered one after another.
+func (s *AssessmentService) GetQuestionMetrics(ctx context.Context, opts QuestionMetricsOptions) ([]models.QuestionMetrics, error) {
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	activity := make(map[string]*questionActivity, len(questions))
+	for _, question := range questions {
+		activity[question.ID] = &questionActivity{}
+	}
+	
+	for _, assessment := range assessments {
+		events, err := s.storage.ListEvents(ctx, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+		collectQuestionActivity(events, activity)
+	}
+	
+	metrics := make([]models.QuestionMetrics, 0, len(questions))
+	for _, question := range questions {
+		if opts.Category != "" && question.Category != opts.Category {
+			continue
+		}
+		collected := activity[question.ID]
+		if collected.assessments == 0 || collected.assessments < opts.MinAnswers {
+			continue
+		}
+		
+		entry := models.QuestionMetrics{
+			QuestionID:   question.ID,
+			Text:         question.Text,
+			Category:     question.Category,
+			Assessments:  collected.assessments,
+			TimedAnswers: len(collected.seconds),
+			Revisits:     collected.revisits,
+			Changes:      collected.changes,
+			RevisitRate:  math.Round(float64(collected.revisited)*1000/float64(collected.assessments)) / 10,
+		}
+		if len(collected.seconds) > 0 {
+			sort.Float64s(collected.seconds)
+			total := 0.0
+			for _, seconds := range collected.seconds {
+				total += seconds
+			}
+			entry.AverageSeconds = math.Round(total / float64(len(collected.seconds)))
+			entry.MedianSeconds = math.Round(percentile(collected.seconds, 0.5))
+			entry.P90Seconds = math.Round(percentile(collected.seconds, 0.9))
+		}
+		metrics = append(metrics, entry)
+	}
+	
+	sort.SliceStable(metrics, func(i, j int) bool {
+		if opts.Sort == QuestionMetricsByRevisits {
+			if metrics[i].RevisitRate != metrics[j].RevisitRate {
+				return metrics[i].RevisitRate > metrics[j].RevisitRate
+			}
+			return metrics[i].Revisits > metrics[j].Revisits
+		}
+		return metrics[i].MedianSeconds > metrics[j].MedianSeconds
+	})
+	return metrics, nil
+}
+
+// collectQuestionActivity adds the hand-chosen answers of an assessment's event log to the
+// activity of their questions
+func collectQuestionActivity(events []*models.AssessmentEvent, activity map[string]*questionActivity) {
+	lastActive := make(map[string]time.Time)       // assessor -> time of their previous event
+	answered := make(map[string]map[string]string) // question -> assessor -> option
+	revisited := make(map[string]bool)             // questions revisited in this assessment
+	
+	for _, event := range events {
+		occurredAt := event.OccurredAt
+		if occurredAt.IsZero() {
+			continue
+		}
+		previous, active := lastActive[event.User]
+		lastActive[event.User] = occurredAt
+		
+		collected := activity[event.QuestionID]
+		if event.Type != models.EventAnswerSaved || collected == nil {
+			continue
+		}
+		if event.Provenance != nil && event.Provenance.Method != models.ProvenanceManual {
+			continue
+		}
+		
+		if answered[event.QuestionID] == nil {
+			answered[event.QuestionID] = make(map[string]string)
+			collected.assessments++
+		}
+		if optionID, ok := answered[event.QuestionID][event.User]; ok {
+			collected.revisits++
+			if optionID != event.OptionID {
+				collected.changes++
+			}
+			if !revisited[event.QuestionID] {
+				revisited[event.QuestionID] = true
+				collected.revisited++
+			}
+		} else if spent := occurredAt.Sub(previous); active && spent >= 0 && spent <= answerIdleCutoff {
+			// Revisits are not timed; assessors usually come back to a question from another one
+			collected.seconds = append(collected.seconds, spent.Seconds())
+		}
+		answered[event.QuestionID][event.User] = event.OptionID
+	}
+}
@@ -0,0 +1,413 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Columns of the CSV question import format, one row per option. The question columns may be
+// left empty on the rows following the first option of a question. The optional
+// requires_explanation column marks options such as "Other" that must be explained in free text.
+var csvImportColumns = []string{"question_id", "question_text", "category", "weight", "option_id", "option_text", "points"}
+
+// questionIDPattern restricts question IDs to slugs, since they name the files questions are
+// stored in. Upper case and dots are allowed for catalogs written before IDs were checked.
+var questionIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// questionIDProblem describes question IDs that do not match questionIDPattern
+const questionIDProblem = "must consist of letters, digits, dots, dashes and underscores"
+
+// CatalogRepository stores the question catalog with its categories, sections and translations
+type CatalogRepository interface {
+	storage.QuestionRepository
+	storage.CategoryRepository
+	storage.SectionRepository
+	storage.TranslationRepository
+}
+
+// QuestionService handles authoring of the question catalog, its categories and sections
+type QuestionService struct {
+	storage CatalogRepository
+	gitEnv  []string
+}
+
+// QuestionOption configures optional behaviour of the question service
+type QuestionOption func(*QuestionService)
+
+// WithCatalogOutbound clones Git catalogs through the proxy and with the CA bundle of
+// integrations
+func WithCatalogOutbound(outbound *OutboundHTTP) QuestionOption {
+	return func(s *QuestionService) {
+		s.gitEnv = outbound.GitEnv()
+	}
+}
+
+// NewQuestionService creates a new question service
+func NewQuestionService(storage CatalogRepository, opts ...QuestionOption) *QuestionService {
+	s := &QuestionService{storage: storage}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// ImportCSV parses a question catalog from CSV and saves it unless dryRun is set or the file
+// has validation errors. Nothing is saved when any row is invalid.
+func (s *QuestionService) ImportCSV(ctx context.Context, r io.Reader, dryRun bool) (*models.ImportReport, error) {
+	report := &models.ImportReport{DryRun: dryRun, Errors: []models.ImportError{}}
+	
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	
+	header, err := reader.Read()
+	if err == io.EOF {
+		report.Errors = append(report.Errors, models.ImportError{Row: 1, Error: "file is empty"})
+		return report, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvImportColumns {
+		if _, ok := columns[name]; !ok {
+			report.Errors = append(report.Errors, models.ImportError{Row: 1, Column: name, Error: "missing column"})
+		}
+	}
+	if len(report.Errors) > 0 {
+		return report, nil
+	}
+	
+	var questions []*models.Question
+	byID := make(map[string]*models.Question)
+	firstRow := make(map[string]int)
+	optionIDs := make(map[string]int)
+	var current *models.Question
+	
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			report.Errors = append(report.Errors, models.ImportError{Row: parseErr.Line, Error: parseErr.Err.Error()})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		report.Rows++
+		
+		field := func(name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+		addError := func(column, message string) {
+			report.Errors = append(report.Errors, models.ImportError{Row: row, Column: column, Error: message})
+		}
+		
+		// A row with a question ID starts or continues that question; a blank ID continues the previous one
+		if id := field("question_id"); id != "" {
+			if !questionIDPattern.MatchString(id) {
+				addError("question_id", questionIDProblem)
+			}
+			if existing, ok := byID[id]; ok {
+				if current != existing {
+					addError("question_id", fmt.Sprintf("options of question %s must be on consecutive rows (first seen on row %d)", id, firstRow[id]))
+				}
+				current = existing
+			} else {
+				current = &models.Question{ID: id}
+				byID[id] = current
+				firstRow[id] = row
+				questions = append(questions, current)
+			}
+		} else if current == nil {
+			addError("question_id", "required on the first row")
+			continue
+		}
+		
+		if text := field("question_text"); text != "" {
+			if current.Text != "" && current.Text != text {
+				addError("question_text", "conflicts with the text on row "+strconv.Itoa(firstRow[current.ID]))
+			}
+			current.Text = text
+		}
+		if category := field("category"); category != "" {
+			if current.Category != "" && current.Category != category {
+				addError("category", "conflicts with the category on row "+strconv.Itoa(firstRow[current.ID]))
+			}
+			current.Category = category
+		}
+		if value := field("weight"); value != "" {
+			weight, err := strconv.Atoi(value)
+			if err != nil || weight < 1 {
+				addError("weight", "must be a positive integer")
+			} else {
+				current.Weight = weight
+			}
+		}
+		
+		option := models.Option{ID: field("option_id"), Text: field("option_text")}
+		if option.ID == "" {
+			addError("option_id", "required")
+		} else if previous, ok := optionIDs[option.ID]; ok {
+			addError("option_id", fmt.Sprintf("duplicate option ID (first used on row %d)", previous))
+		} else {
+			optionIDs[option.ID] = row
+		}
+		if option.Text == "" {
+			addError("option_text", "required")
+		}
+		points, err := strconv.Atoi(field("points"))
+		if err != nil {
+			addError("points", "must be an integer")
+		}
+		option.Points = points
+		if value := field("requires_explanation"); value != "" {
+			requires, err := strconv.ParseBool(value)
+			if err != nil {
+				addError("requires_explanation", "must be true or false")
+			}
+			option.RequiresExplanation = requires
+		}
+		
+		current.Options = append(current.Options, option)
+	}
+	
+	// Question-level checks, skipping columns already reported on the question's first row
+	reported := make(map[models.ImportError]bool)
+	for _, e := range report.Errors {
+		reported[models.ImportError{Row: e.Row, Column: e.Column}] = true
+	}
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return nil, err
+	}
+	for _, question := range questions {
+		problems := ValidateQuestion(question)
+		if problem := categoryProblem(question, categories); problem != nil {
+			problems = append(problems, *problem)
+		}
+		for _, problem := range problems {
+			if reported[models.ImportError{Row: firstRow[question.ID], Column: problem.Field}] {
+				continue
+			}
+			report.Errors = append(report.Errors, models.ImportError{Row: firstRow[question.ID], Column: problem.Field, Error: problem.Message})
+		}
+	}
+	
+	report.Questions = len(questions)
+	report.Valid = len(report.Errors) == 0
+	if !report.Valid || dryRun {
+		return report, nil
+	}
+	
+	for _, question := range questions {
+		if err := s.storage.SaveQuestion(ctx, question); err != nil {
+			return report, fmt.Errorf("failed to save question %s: %w", question.ID, err)
+		}
+		report.Imported++
+	}
+	
+	return report, nil
+}
+
+// ValidateQuestion checks that a question is complete and can be scored
+func ValidateQuestion(question *models.Question) []models.QuestionProblem {
+	var problems []models.QuestionProblem
+	add := func(field, message string) {
+		problems = append(problems, models.QuestionProblem{Field: field, Message: message})
+	}
+	
+	if question.ID == "" {
+		add("question_id", "required")
+	} else if !questionIDPattern.MatchString(question.ID) {
+		add("question_id", questionIDProblem)
+	}
+	if question.Text == "" {
+		add("question_text", "required")
+	}
+	if question.Category == "" {
+		add("category", "required")
+	}
+	if question.Weight < 1 {
+		add("weight", "must be a positive integer")
+	}
+	if len(question.Options) < 2 {
+		add("option_id", "a question needs at least two options")
+	}
+	
+	// Options may carry negative points to penalize anti-patterns, but the best answer must
+	// still award points so the question contributes to the maximum score
+	maxPoints := 0
+	for _, option := range question.Options {
+		if option.Points > maxPoints {
+			maxPoints = option.Points
+		}
+	}
+	if len(question.Options) > 0 && maxPoints == 0 {
+		add("points", "at least one option must award points")
+	}
+	
+	for _, condition := range question.VisibleWhen {
+		if condition.QuestionID == question.ID {
+			add("visibleWhen", "a question cannot depend on itself")
+		}
+		if len(condition.OptionIDs) == 0 {
+			add("visibleWhen", fmt.Sprintf("condition on %s lists no options", condition.QuestionID))
+		}
+	}
+	
+	for _, rule := range question.AppliesWhen {
+		if rule.Tag == "" {
+			add("appliesWhen", "a rule needs a tag")
+		}
+		if rule.Operator != models.TagRuleIn && rule.Operator != models.TagRuleNotIn {
+			add("appliesWhen", fmt.Sprintf("rule on tag %s has unknown operator %q", rule.Tag, rule.Operator))
+		}
+		if len(rule.Values) == 0 {
+			add("appliesWhen", fmt.Sprintf("rule on tag %s lists no values", rule.Tag))
+		}
+	}
+	
+	problems = append(problems, validateAnswerRules(question)...)
+	problems = append(problems, validateEvidenceRule(question)...)
+	problems = append(problems, validateTranslations(question)...)
+	
+	return problems
+}
+
+// PreviewQuestion renders a draft question and validates it against the live catalog without
+// saving it. answers are sample answers used to evaluate its visibility conditions.
+func (s *QuestionService) PreviewQuestion(ctx context.Context, question *models.Question, answers map[string]string) (*models.QuestionPreview, error) {
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	byID := make(map[string]*models.Question, len(questions))
+	for _, q := range questions {
+		byID[q.ID] = q
+	}
+	
+	preview := &models.QuestionPreview{
+		Question:        question,
+		HelpHTML:        renderMarkdown(question.Help),
+		Visible:         question.VisibleFor(answers),
+		VisibilityRules: []string{},
+		MaxContribution: question.Weight * maxOptionPoints(question.Options),
+		Problems:        ValidateQuestion(question),
+	}
+	_, preview.ReplacesExisting = byID[question.ID]
+	
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return nil, err
+	}
+	if problem := categoryProblem(question, categories); problem != nil {
+		preview.Problems = append(preview.Problems, *problem)
+	}
+	
+	for _, condition := range question.VisibleWhen {
+		dependency, ok := byID[condition.QuestionID]
+		if !ok {
+			preview.Problems = append(preview.Problems, models.QuestionProblem{
+				Field:   "visibleWhen",
+				Message: fmt.Sprintf("condition refers to unknown question %s", condition.QuestionID),
+			})
+			continue
+		}
+		
+		var texts []string
+		for _, optionID := range condition.OptionIDs {
+			text := ""
+			for _, option := range dependency.Options {
+				if option.ID == optionID {
+					text = option.Text
+					break
+				}
+			}
+			if text == "" {
+				preview.Problems = append(preview.Problems, models.QuestionProblem{
+					Field:   "visibleWhen",
+					Message: fmt.Sprintf("question %s has no option %s", condition.QuestionID, optionID),
+				})
+				continue
+			}
+			texts = append(texts, fmt.Sprintf("%q", text))
+		}
+		preview.VisibilityRules = append(preview.VisibilityRules,
+			fmt.Sprintf("Shown when %q is answered with %s", dependency.Text, strings.Join(texts, " or ")))
+	}
+	
+	if preview.Problems == nil {
+		preview.Problems = []models.QuestionProblem{}
+	}
+	preview.Valid = len(preview.Problems) == 0
+	
+	return preview, nil
+}
+
+// PublishQuestion validates a question and saves it into the live catalog. The preview is
+// returned with ErrInvalidQuestion when the question has problems.
+func (s *QuestionService) PublishQuestion(ctx context.Context, question *models.Question) (*models.QuestionPreview, error) {
+	preview, err := s.PreviewQuestion(ctx, question, nil)
+	if err != nil {
+		return nil, err
+	}
+	
+	if !preview.Valid {
+		return preview, ErrInvalidQuestion
+	}
+	
+	if err := s.storage.SaveQuestion(ctx, question); err != nil {
+		return preview, fmt.Errorf("failed to save question: %w", err)
+	}
+	
+	return preview, nil
+}
+
+// ReloadCatalog re-reads an in-memory question catalog, such as one served from a catalog
+// directory, and swaps it in atomically. The current catalog stays active if the new one is
+// invalid. Catalogs read from storage on every request need no reload.
+func (s *QuestionService) ReloadCatalog(ctx context.Context) (*models.CatalogReload, error) {
+	result := &models.CatalogReload{Source: "storage"}
+	
+	if reloader, ok := s.storage.(storage.CatalogReloader); ok {
+		if err := reloader.Reload(); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCatalog, err)
+		}
+		result.Reloaded = true
+		result.Source = "catalog-dir"
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	result.Questions = len(questions)
+	
+	return result, nil
+}
+
+// ErrInvalidCatalog is returned when a reloaded question catalog cannot be read or is invalid
+var ErrInvalidCatalog = errors.New("question catalog is invalid; keeping the current catalog")
+
+// ErrInvalidQuestion is returned when publishing a question that fails validation
+var ErrInvalidQuestion = errors.New("question is invalid")
@@ -0,0 +1,66 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+)
+
+func TestImportCSVRejectsQuestionIDsOutsideTheCatalog(t *testing.T) {
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	questions := services.NewQuestionService(store)
+	
+	csv := strings.Join([]string{
+		"question_id,question_text,category,weight,option_id,option_text,points",
+		"q1,Is TLS enforced?,Security,1,q1_yes,Yes,10",
+		",,,,q1_no,No,0",
+		"../applications/pwn,Overwrite?,Security,1,pwn_yes,Yes,10",
+		",,,,pwn_no,No,0",
+	}, "\n")
+	report, err := questions.ImportCSV(ctx, strings.NewReader(csv), false)
+	if err != nil {
+		t.Fatalf("ImportCSV: %v", err)
+	}
+	
+	want := models.ImportError{Row: 4, Column: "question_id", Error: "must consist of letters, digits, dots, dashes and underscores"}
+	if report.Valid || len(report.Errors) != 1 || report.Errors[0] != want {
+		t.Errorf("Errors = %+v, want only %+v", report.Errors, want)
+	}
+	saved, err := store.GetQuestions(ctx)
+	if err != nil {
+		t.Fatalf("GetQuestions: %v", err)
+	}
+	if len(saved) != 0 {
+		t.Errorf("questions = %+v, want none saved from an invalid file", saved)
+	}
+}
+
+func TestPublishQuestionRejectsQuestionIDsOutsideTheCatalog(t *testing.T) {
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	questions := services.NewQuestionService(store)
+	
+	question := questionnairetest.NewQuestion("../applications/pwn").Option("yes", "Yes", 10).Option("no", "No", 0).Build()
+	preview, err := questions.PublishQuestion(ctx, question)
+	if !errors.Is(err, services.ErrInvalidQuestion) {
+		t.Fatalf("PublishQuestion: err = %v, want ErrInvalidQuestion", err)
+	}
+	
+	want := models.QuestionProblem{Field: "question_id", Message: "must consist of letters, digits, dots, dashes and underscores"}
+	if len(preview.Problems) != 1 || preview.Problems[0] != want {
+		t.Errorf("Problems = %+v, want only %+v", preview.Problems, want)
+	}
+	saved, err := store.GetQuestions(ctx)
+	if err != nil {
+		t.Fatalf("GetQuestions: %v", err)
+	}
+	if len(saved) != 0 {
+		t.Errorf("questions = %+v, want the invalid question not published", saved)
+	}
+}
@@ -0,0 +1,172 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRemediation is returned for remediation updates that fail validation
+var ErrInvalidRemediation = errors.New("invalid remediation")
+
+// recommendationKey identifies a recommendation across reports by its category and description,
+// which stay the same while the answers causing it do
+func recommendationKey(recommendation models.Recommendation) string {
+	sum := sha256.Sum256([]byte(recommendation.Category + "\x00" + recommendation.Description))
+	return hex.EncodeToString(sum[:6])
+}
+
+// SetRemediation records the remediation status of the recommendation at index in an
+// assessment's report. The status is stored with the application, so it also applies to the
+// same recommendation in later reports. Approved reports can be tracked as well, since
+// remediation happens after the report is issued.
+func (s *AssessmentService) SetRemediation(ctx context.Context, assessmentID string, index int, update models.RemediationUpdate, user string) (*models.Remediation, error) {
+	switch update.Status {
+	case models.RemediationAccepted, models.RemediationRejected, models.RemediationDone:
+	default:
+		return nil, fmt.Errorf("%w: status must be accepted, rejected or done", ErrInvalidRemediation)
+	}
+	if update.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", update.DueDate); err != nil {
+			return nil, fmt.Errorf("%w: dueDate must be a date (YYYY-MM-DD)", ErrInvalidRemediation)
+		}
+	}
+	
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	if report == nil {
+		return nil, fmt.Errorf("report %w", ErrNotFound)
+	}
+	if index < 0 || index >= len(report.Recommendations) {
+		return nil, fmt.Errorf("recommendation %d %w", index, ErrNotFound)
+	}
+	recommendation := report.Recommendations[index]
+	
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	unlock, err := s.locker.Lock(lockCtx, "application-"+report.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock application: %w", err)
+	}
+	defer unlock()
+	
+	app, err := s.storage.GetApplication(ctx, report.ApplicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	now := time.Now()
+	remediation := models.Remediation{
+		Key:          recommendationKey(recommendation),
+		Category:     recommendation.Category,
+		Description:  recommendation.Description,
+		Status:       update.Status,
+		Owner:        strings.TrimSpace(update.Owner),
+		DueDate:      update.DueDate,
+		Note:         strings.TrimSpace(update.Note),
+		AssessmentID: assessmentID,
+		UpdatedBy:    user,
+		UpdatedAt:    now,
+	}
+	
+	// Recommendations marked done again keep their original completion time
+	existing := findRemediation(app.Remediations, remediation.Key)
+	if update.Status == models.RemediationDone {
+		remediation.CompletedAt = &now
+		if existing != nil && existing.CompletedAt != nil {
+			remediation.CompletedAt = existing.CompletedAt
+		}
+	}
+	if existing != nil {
+		*existing = remediation
+	} else {
+		app.Remediations = append(app.Remediations, remediation)
+	}
+	
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to save application: %w", err)
+	}
+	return &remediation, nil
+}
+
+// findRemediation returns the remediation with the given key, or nil
+func findRemediation(remediations []models.Remediation, key string) *models.Remediation {
+	for i := range remediations {
+		if remediations[i].Key == key {
+			return &remediations[i]
+		}
+	}
+	return nil
+}
+
+// markTracking sets the remediation of each recommendation and the acceptance of each risk of
+// a report from its application, and the report's approval from its assessment
+func (s *AssessmentService) markTracking(ctx context.Context, report *models.Report) error {
+	app, err := s.storage.GetApplication(ctx, report.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		app = &models.Application{}
+	}
+	
+	markRemediation(report, app.Remediations)
+	markRiskAcceptances(report, app.RiskAcceptances, time.Now())
+	return s.MarkApproval(ctx, report)
+}
+
+// markRemediation sets the key and remediation of each recommendation of a report, along with
+// the report's remediation progress
+func markRemediation(report *models.Report, remediations []models.Remediation) {
+	report.Remediation = &models.RemediationProgress{}
+	for i := range report.Recommendations {
+		recommendation := &report.Recommendations[i]
+		recommendation.Key = recommendationKey(*recommendation)
+		recommendation.Remediation = findRemediation(remediations, recommendation.Key)
+		
+		status := models.RemediationOpen
+		if recommendation.Remediation != nil {
+			status = recommendation.Remediation.Status
+		}
+		report.Remediation.Add(status)
+	}
+}
+
+// remediationProgress counts the recommendations with the given keys by their status among an
+// application's remediations
+func remediationProgress(keys []string, app *models.Application) *models.RemediationProgress {
+	progress := &models.RemediationProgress{}
+	countRemediations(progress, keys, app)
+	return progress
+}
+
+// countRemediations adds the recommendations with the given keys to progress by their status
+// among an application's remediations
+func countRemediations(progress *models.RemediationProgress, keys []string, app *models.Application) {
+	for _, key := range keys {
+		status := models.RemediationOpen
+		if remediation := findRemediation(app.Remediations, key); remediation != nil {
+			status = remediation.Status
+		}
+		progress.Add(status)
+	}
+}
+
+// recommendationKeys returns the keys of a report's recommendations
+func recommendationKeys(report *models.Report) []string {
+	keys := make([]string, len(report.Recommendations))
+	for i, recommendation := range report.Recommendations {
+		keys[i] = recommendationKey(recommendation)
+	}
+	return keys
+}
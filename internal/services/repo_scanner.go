@@ -0,0 +1,229 @@
+package services
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Signals detected by repository analysis
+const (
+	SignalDockerfile          = "repo:dockerfile"
+	SignalKubernetesManifests = "repo:kubernetes-manifests"
+	SignalEnvConfig           = "repo:env-config"
+	SignalFileConfig          = "repo:file-config"
+	SignalLogToStdout         = "repo:log-to-stdout"
+	SignalLogToFile           = "repo:log-to-file"
+	SignalExternalDatabase    = "repo:external-database"
+	SignalEmbeddedDatabase    = "repo:embedded-database"
+	SignalInMemorySession     = "repo:in-memory-session"
+)
+
+// Limits keeping scans of large repositories bounded
+const (
+	maxScannedFiles    = 5000
+	maxScannedFileSize = 512 << 10
+	maxEvidencePerHit  = 5
+)
+
+// contentPattern detects a signal from file contents
+type contentPattern struct {
+	signal     string
+	extensions []string // empty matches every scanned file
+	pattern    *regexp.Regexp
+}
+
+var contentPatterns = []contentPattern{
+	{SignalEnvConfig, nil, regexp.MustCompile(`os\.Getenv\(|System\.getenv\(|process\.env\.|os\.environ|ENV\[|Environment\.GetEnvironmentVariable|\$\{[A-Z][A-Z0-9_]+(:[^}]*)?\}`)},
+	{SignalLogToStdout, nil, regexp.MustCompile(`ConsoleAppender|StreamHandler\(sys\.stdout|logging\.StreamHandler|zap\.NewProduction|log\.SetOutput\(os\.Stdout\)|winston\.transports\.Console|slog\.NewJSONHandler\(os\.Stdout`)},
+	{SignalLogToFile, nil, regexp.MustCompile(`RollingFileAppender|<FileAppender|FileAppender"|logging\.FileHandler|RotatingFileHandler|winston\.transports\.File|lumberjack\.Logger`)},
+	{SignalExternalDatabase, nil, regexp.MustCompile(`jdbc:(postgresql|mysql|sqlserver|oracle|mariadb)|DATABASE_URL|spring\.datasource\.url|mongodb(\+srv)?://|lib/pq|go-sql-driver/mysql|psycopg2|pgx`)},
+	{SignalEmbeddedDatabase, nil, regexp.MustCompile(`jdbc:h2:(file|~)|jdbc:sqlite|jdbc:derby|mattn/go-sqlite3|sqlite3\.connect|better-sqlite3|bbolt|leveldb`)},
+	{SignalInMemorySession, nil, regexp.MustCompile(`HttpSession|MemoryStore\(|express-session|session\.Store|sticky[-_ ]?sessions?`)},
+	{SignalKubernetesManifests, []string{".yaml", ".yml"}, regexp.MustCompile(`(?m)^kind:\s*(Deployment|StatefulSet|DaemonSet|Service)\s*$`)},
+}
+
+// scannedExtensions are the source and configuration files inspected for content patterns
+var scannedExtensions = map[string]bool{
+	".go": true, ".java": true, ".kt": true, ".scala": true, ".py": true, ".js": true, ".ts": true,
+	".rb": true, ".cs": true, ".php": true, ".xml": true, ".properties": true, ".yaml": true,
+	".yml": true, ".json": true, ".toml": true, ".conf": true, ".ini": true, ".gradle": true,
+}
+
+// skippedDirs are not descended into during scans
+var skippedDirs = map[string]bool{
+	".git": true, "node_modules": true, "vendor": true, "target": true, "build": true, "dist": true,
+}
+
+// RepositoryScanner detects signals about an application by statically analysing a shallow
+// clone of its Git repository
+type RepositoryScanner struct {
+	GitPath string        // git executable, "git" if empty
+	Timeout time.Duration // clone and scan timeout
+}
+
+// Scan clones the repository and returns the detected signals
+func (s *RepositoryScanner) Scan(ctx context.Context, repoURL string) ([]models.Signal, error) {
+	if err := validateRepositoryURL(repoURL); err != nil {
+		return nil, err
+	}
+	
+	timeout := s.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	
+	dir, err := os.MkdirTemp("", "questionnaire-scan-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create scan directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	
+	gitPath := s.GitPath
+	if gitPath == "" {
+		gitPath = "git"
+	}
+	
+	cmd := exec.CommandContext(ctx, gitPath, "clone", "--depth", "1", "--quiet", "--", repoURL, dir)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GIT_ALLOW_PROTOCOL=https:ssh:git")
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("failed to clone repository: %v: %s", err, strings.TrimSpace(string(output)))
+	}
+	
+	return scanDirectory(ctx, dir)
+}
+
+// validateRepositoryURL only allows remote Git URLs, so scans cannot read the server's filesystem
+func validateRepositoryURL(repoURL string) error {
+	if strings.HasPrefix(repoURL, "-") {
+		return errors.New("invalid repository URL")
+	}
+	
+	// scp-like SSH syntax, e.g. git@github.com:org/repo.git
+	if !strings.Contains(repoURL, "://") && strings.Contains(repoURL, "@") && strings.Contains(repoURL, ":") {
+		return nil
+	}
+	
+	parsed, err := url.Parse(repoURL)
+	if err != nil {
+		return fmt.Errorf("invalid repository URL: %w", err)
+	}
+	
+	switch parsed.Scheme {
+	case "https", "ssh", "git":
+		return nil
+	default:
+		return fmt.Errorf("unsupported repository URL scheme %q", parsed.Scheme)
+	}
+}
+
+// scanDirectory walks a checked-out repository and collects signals with the files they came from
+func scanDirectory(ctx context.Context, root string) ([]models.Signal, error) {
+	evidence := make(map[string][]string)
+	add := func(signal, path string) {
+		if len(evidence[signal]) < maxEvidencePerHit {
+			evidence[signal] = append(evidence[signal], path)
+		}
+	}
+	
+	scanned := 0
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		
+		if entry.IsDir() {
+			if skippedDirs[entry.Name()] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		
+		rel, _ := filepath.Rel(root, path)
+		name := strings.ToLower(entry.Name())
+		
+		switch {
+		case name == "dockerfile" || strings.HasPrefix(name, "dockerfile.") || name == "containerfile":
+			add(SignalDockerfile, rel)
+		case name == "chart.yaml" || name == "kustomization.yaml":
+			add(SignalKubernetesManifests, rel)
+		case name == ".env.example" || name == ".env.sample":
+			add(SignalEnvConfig, rel)
+		case name == "web.config" || name == "app.config" || name == "config.ini":
+			add(SignalFileConfig, rel)
+		}
+		
+		ext := strings.ToLower(filepath.Ext(name))
+		if !scannedExtensions[ext] || scanned >= maxScannedFiles {
+			return nil
+		}
+		if info, err := entry.Info(); err != nil || info.Size() > maxScannedFileSize || !info.Mode().IsRegular() {
+			return nil
+		}
+		scanned++
+		
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil
+		}
+		
+		for _, p := range contentPatterns {
+			if len(p.extensions) > 0 && !containsString(p.extensions, ext) {
+				continue
+			}
+			if p.pattern.Match(data) {
+				add(p.signal, rel+lineHint(data, p.pattern))
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan repository: %w", err)
+	}
+	
+	signals := make([]models.Signal, 0, len(evidence))
+	for name, files := range evidence {
+		signals = append(signals, models.Signal{Name: name, Evidence: files})
+	}
+	sort.Slice(signals, func(i, j int) bool { return signals[i].Name < signals[j].Name })
+	
+	return signals, nil
+}
+
+// lineHint returns ":<line>" for the first line matching the pattern
+func lineHint(data []byte, pattern *regexp.Regexp) string {
+	scanner := bufio.NewScanner(strings.NewReader(string(data)))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxScannedFileSize)
+	for line := 1; scanner.Scan(); line++ {
+		if pattern.MatchString(scanner.Text()) {
+			return fmt.Sprintf(":%d", line)
+		}
+	}
+	return ""
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
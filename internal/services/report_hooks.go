@@ -0,0 +1,195 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// reportPluginTimeout bounds one run of a report plugin executable
+const reportPluginTimeout = 30 * time.Second
+
+// reportPluginEnvPrefix selects the environment variables passed on to report plugin
+// executables, so plugins do not see the server's credentials
+const reportPluginEnvPrefix = "REPORT_PLUGIN_"
+
+// Stages of report generation at which hooks run
+const (
+	ReportStageBefore = "before" // the report is created but nothing is scored yet
+	ReportStageAfter  = "after"  // the report is scored but not yet signed or saved
+)
+
+// ReportHook extends report generation without changing generateReport. Hooks may add plugin
+// sections, recommendations and risks to the report; what they add is signed with the rest of
+// the report. A failing hook is logged and skipped rather than failing report generation.
+type ReportHook interface {
+	// Name identifies the hook in logs and in the sections it adds
+	Name() string
+	BeforeReport(ctx context.Context, assessment *models.Assessment, report *models.Report) error
+	AfterReport(ctx context.Context, assessment *models.Assessment, report *models.Report) error
+}
+
+// registeredReportHooks are the hooks compiled into the server
+var registeredReportHooks []ReportHook
+
+// RegisterReportHook adds a hook to every AssessmentService created afterwards. It is meant to
+// be called from the init function of a file added to the build, so an organization can ship
+// its own hooks without forking report generation.
+func RegisterReportHook(hook ReportHook) {
+	registeredReportHooks = append(registeredReportHooks, hook)
+}
+
+// WithReportHooks runs hooks, after the registered ones, whenever a report is generated
+func WithReportHooks(hooks ...ReportHook) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.reportHooks = append(s.reportHooks, hooks...)
+	}
+}
+
+// runReportHooks runs the hooks of a stage in order. Failures are logged and leave the report
+// as the previous hooks left it.
+func (s *AssessmentService) runReportHooks(ctx context.Context, stage string, assessment *models.Assessment, report *models.Report) {
+	for _, hook := range s.reportHooks {
+		var err error
+		if stage == ReportStageBefore {
+			err = hook.BeforeReport(ctx, assessment, report)
+		} else {
+			err = hook.AfterReport(ctx, assessment, report)
+		}
+		if err != nil {
+			log.Printf("Report hook %s failed %s generating the report of assessment %s: %v", hook.Name(), stage, assessment.ID, err)
+		}
+	}
+}
+
+// ReportPatch is what a report plugin executable prints: the sections, recommendations and
+// risks it adds to the report
+type ReportPatch struct {
+	Sections        []models.PluginSection  `json:"sections,omitempty"`
+	Recommendations []models.Recommendation `json:"recommendations,omitempty"`
+	Risks           []models.Risk           `json:"risks,omitempty"`
+}
+
+// reportPluginRequest is what a report plugin executable reads from standard input
+type reportPluginRequest struct {
+	Stage      string             `json:"stage"`
+	Assessment *models.Assessment `json:"assessment"`
+	Report     *models.Report     `json:"report"`
+}
+
+// ExecReportHook runs an executable of the report plugins directory at each stage. It is
+// called with the stage as its only argument and the assessment and report as JSON on
+// standard input, and prints a ReportPatch as JSON on standard output; printing nothing
+// leaves the report unchanged.
+type ExecReportHook struct {
+	Path string
+}
+
+// Name returns the executable's file name
+func (h *ExecReportHook) Name() string {
+	return filepath.Base(h.Path)
+}
+
+// BeforeReport runs the executable with the "before" stage
+func (h *ExecReportHook) BeforeReport(ctx context.Context, assessment *models.Assessment, report *models.Report) error {
+	return h.run(ctx, ReportStageBefore, assessment, report)
+}
+
+// AfterReport runs the executable with the "after" stage
+func (h *ExecReportHook) AfterReport(ctx context.Context, assessment *models.Assessment, report *models.Report) error {
+	return h.run(ctx, ReportStageAfter, assessment, report)
+}
+
+// run executes the plugin and applies its patch to the report
+func (h *ExecReportHook) run(ctx context.Context, stage string, assessment *models.Assessment, report *models.Report) error {
+	input, err := json.Marshal(reportPluginRequest{Stage: stage, Assessment: assessment, Report: report})
+	if err != nil {
+		return err
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, reportPluginTimeout)
+	defer cancel()
+	
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Path, stage)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = reportPluginEnv()
+	if err := cmd.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return fmt.Errorf("%w: %s", err, message)
+		}
+		return err
+	}
+	if len(bytes.TrimSpace(stdout.Bytes())) == 0 {
+		return nil
+	}
+	
+	var patch ReportPatch
+	if err := json.Unmarshal(stdout.Bytes(), &patch); err != nil {
+		return fmt.Errorf("invalid output: %w", err)
+	}
+	for i, section := range patch.Sections {
+		if section.Title == "" {
+			return fmt.Errorf("section %d has no title", i+1)
+		}
+	}
+	applyReportPatch(report, h.Name(), &patch)
+	return nil
+}
+
+// applyReportPatch adds a plugin's sections, recommendations and risks to a report
+func applyReportPatch(report *models.Report, plugin string, patch *ReportPatch) {
+	for _, section := range patch.Sections {
+		section.Plugin = plugin
+		report.PluginSections = append(report.PluginSections, section)
+	}
+	report.Recommendations = append(report.Recommendations, patch.Recommendations...)
+	report.Risks = append(report.Risks, patch.Risks...)
+}
+
+// reportPluginEnv returns the environment of a plugin executable: PATH and the variables
+// starting with REPORT_PLUGIN_
+func reportPluginEnv() []string {
+	var env []string
+	for _, variable := range os.Environ() {
+		if strings.HasPrefix(variable, "PATH=") || strings.HasPrefix(variable, reportPluginEnvPrefix) {
+			env = append(env, variable)
+		}
+	}
+	return env
+}
+
+// LoadReportPlugins returns a hook for each executable file of a directory, in file name
+// order. Hidden files, directories and files that are not executable are skipped.
+func LoadReportPlugins(dir string) ([]ReportHook, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report plugins directory: %w", err)
+	}
+	
+	var hooks []ReportHook
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, err
+		}
+		if info.Mode()&0111 == 0 {
+			continue
+		}
+		hooks = append(hooks, &ExecReportHook{Path: filepath.Join(dir, entry.Name())})
+	}
+	return hooks, nil
+}
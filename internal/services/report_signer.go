@@ -0,0 +1,182 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"strings"
+)
+
+// ReportSigner signs issued reports with an Ed25519 key as detached JWS (RFC 7515 Appendix F)
+// so consumers can prove a report was not modified after it was generated
+type ReportSigner struct {
+	key   ed25519.PrivateKey
+	keyID string
+}
+
+// jwsHeader is the protected header of report signatures
+type jwsHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// NewReportSigner creates a signer for an Ed25519 private key
+func NewReportSigner(key ed25519.PrivateKey) *ReportSigner {
+	sum := sha256.Sum256(key.Public().(ed25519.PublicKey))
+	return &ReportSigner{key: key, keyID: hex.EncodeToString(sum[:8])}
+}
+
+// NewRandomReportSigner creates a signer with a freshly generated key
+func NewRandomReportSigner() (*ReportSigner, error) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	return NewReportSigner(key), nil
+}
+
+// ParseReportSigningKey decodes a PEM encoded PKCS #8 Ed25519 private key
+func ParseReportSigningKey(data []byte) (*ReportSigner, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found in signing key")
+	}
+	
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key: %w", err)
+	}
+	
+	key, ok := parsed.(ed25519.PrivateKey)
+	if !ok {
+		return nil, errors.New("signing key is not an Ed25519 key")
+	}
+	return NewReportSigner(key), nil
+}
+
+// KeyID identifies the signing key in signatures
+func (s *ReportSigner) KeyID() string {
+	return s.keyID
+}
+
+// PublicJWK returns the verification key as a JSON Web Key
+func (s *ReportSigner) PublicJWK() map[string]string {
+	return map[string]string{
+		"kty": "OKP",
+		"crv": "Ed25519",
+		"alg": "EdDSA",
+		"use": "sig",
+		"kid": s.keyID,
+		"x":   base64.RawURLEncoding.EncodeToString(s.key.Public().(ed25519.PublicKey)),
+	}
+}
+
+// Sign sets the report's signature
+func (s *ReportSigner) Sign(report *models.Report) error {
+	header, err := json.Marshal(jwsHeader{Alg: "EdDSA", Kid: s.keyID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal signature header: %w", err)
+	}
+	
+	payload, err := signedReportPayload(report)
+	if err != nil {
+		return err
+	}
+	
+	encodedHeader := base64.RawURLEncoding.EncodeToString(header)
+	signingInput := encodedHeader + "." + base64.RawURLEncoding.EncodeToString(payload)
+	signature := ed25519.Sign(s.key, []byte(signingInput))
+	
+	report.Signature = encodedHeader + ".." + base64.RawURLEncoding.EncodeToString(signature)
+	return nil
+}
+
+// Verify checks that the report's signature was made by this signer over its current content
+func (s *ReportSigner) Verify(report *models.Report) error {
+	if report.Signature == "" {
+		return errors.New("report is not signed")
+	}
+	
+	parts := strings.Split(report.Signature, ".")
+	if len(parts) != 3 || parts[1] != "" {
+		return errors.New("signature is not a detached JWS")
+	}
+	
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return errors.New("invalid signature header encoding")
+	}
+	
+	var header jwsHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return errors.New("invalid signature header")
+	}
+	
+	if header.Alg != "EdDSA" {
+		return fmt.Errorf("unsupported signature algorithm: %s", header.Alg)
+	}
+	if header.Kid != s.keyID {
+		return fmt.Errorf("report was signed with unknown key %s", header.Kid)
+	}
+	
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errors.New("invalid signature encoding")
+	}
+	
+	payload, err := signedReportPayload(report)
+	if err != nil {
+		return err
+	}
+	
+	signingInput := parts[0] + "." + base64.RawURLEncoding.EncodeToString(payload)
+	if !ed25519.Verify(s.key.Public().(ed25519.PublicKey), []byte(signingInput), signature) {
+		return errors.New("signature does not match report content")
+	}
+	return nil
+}
+
+// signedReportPayload returns the signed representation of a report: its JSON encoding without
+// the signature, without reviewer annotations and their update time, which change after
+// issuance, and without the validity, remediation, risk acceptance and approval fields derived
+// on read
+func signedReportPayload(report *models.Report) ([]byte, error) {
+	unsigned := *report
+	unsigned.Signature = ""
+	unsigned.Annotations = nil
+	unsigned.UpdatedAt = nil
+	unsigned.ValidUntil = nil
+	unsigned.Stale = false
+	unsigned.Remediation = nil
+	unsigned.Approval = nil
+	if unsigned.Recommendations != nil {
+		unsigned.Recommendations = make([]models.Recommendation, len(report.Recommendations))
+		for i, recommendation := range report.Recommendations {
+			recommendation.Key = ""
+			recommendation.Remediation = nil
+			unsigned.Recommendations[i] = recommendation
+		}
+	}
+	if unsigned.Risks != nil {
+		unsigned.Risks = make([]models.Risk, len(report.Risks))
+		for i, risk := range report.Risks {
+			risk.Key = ""
+			risk.Acceptance = nil
+			unsigned.Risks[i] = risk
+		}
+	}
+	
+	payload, err := json.Marshal(&unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	return payload, nil
+}
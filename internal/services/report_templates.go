@@ -0,0 +1,212 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// Report templates for the audiences of an exported report
+const (
+	ReportTemplateExecutive = "executive"
+	ReportTemplateTechnical = "technical"
+	ReportTemplateAuditor   = "auditor"
+)
+
+// ErrInvalidReportTemplate is returned when a report is requested with an unknown template
+var ErrInvalidReportTemplate = errors.New("unknown report template")
+
+// reportTemplates lists the built-in templates. Only the auditor template keeps every signed
+// section, so it is the only one that carries the signature.
+var reportTemplates = []models.ReportTemplate{
+	{
+		ID:          ReportTemplateExecutive,
+		Name:        "Executive summary",
+		Description: "Score, grade, category scores, the summary and the most urgent recommendations and risks with the estimated effort",
+		Sections: []string{
+			models.ReportSectionContext,
+			models.ReportSectionScores,
+			models.ReportSectionCategoryScores,
+			models.ReportSectionNarrative,
+			models.ReportSectionRecommendations,
+			models.ReportSectionRisks,
+			models.ReportSectionEstimate,
+			models.ReportSectionPlugins,
+		},
+		Highlights: 3,
+	},
+	{
+		ID:          ReportTemplateTechnical,
+		Name:        "Detailed technical",
+		Description: "Every finding with the per-question breakdown, unanswered and disputed questions and the modernization plan",
+		Sections: []string{
+			models.ReportSectionContext,
+			models.ReportSectionScores,
+			models.ReportSectionCategoryScores,
+			models.ReportSectionRecommendations,
+			models.ReportSectionRisks,
+			models.ReportSectionPlan,
+			models.ReportSectionEstimate,
+			models.ReportSectionBreakdown,
+			models.ReportSectionUnanswered,
+			models.ReportSectionDisagreements,
+			models.ReportSectionAppliedWeights,
+			models.ReportSectionPlugins,
+			models.ReportSectionApproval,
+		},
+	},
+	{
+		ID:          ReportTemplateAuditor,
+		Name:        "Auditor",
+		Description: "The complete report with weight overrides, reviewer annotations, the approval checklist and the signature for verification",
+		Sections: []string{
+			models.ReportSectionContext,
+			models.ReportSectionScores,
+			models.ReportSectionCategoryScores,
+			models.ReportSectionNarrative,
+			models.ReportSectionRecommendations,
+			models.ReportSectionRisks,
+			models.ReportSectionPlan,
+			models.ReportSectionEstimate,
+			models.ReportSectionBreakdown,
+			models.ReportSectionUnanswered,
+			models.ReportSectionDisagreements,
+			models.ReportSectionAppliedWeights,
+			models.ReportSectionPlugins,
+			models.ReportSectionAnnotations,
+			models.ReportSectionApproval,
+			models.ReportSectionSignature,
+		},
+	},
+}
+
+// urgency orders recommendation priorities and risk severities, most urgent first
+var urgency = map[string]int{"Critical": 0, "High": 1, "Medium": 2, "Low": 3}
+
+// ReportTemplates returns the built-in report templates
+func ReportTemplates() []models.ReportTemplate {
+	return reportTemplates
+}
+
+// ValidateReportTemplate checks that a template ID is known; the empty ID selects the full report
+func ValidateReportTemplate(templateID string) error {
+	_, err := lookupReportTemplate(templateID)
+	return err
+}
+
+// ApplyReportTemplate returns a copy of a report with only the sections of a template. The
+// empty template ID returns the report unchanged.
+func ApplyReportTemplate(report *models.Report, templateID string) (*models.Report, error) {
+	tmpl, err := lookupReportTemplate(templateID)
+	if err != nil || tmpl == nil {
+		return report, err
+	}
+	
+	included := make(map[string]bool, len(tmpl.Sections))
+	for _, section := range tmpl.Sections {
+		included[section] = true
+	}
+	
+	filtered := *report
+	if !included[models.ReportSectionContext] {
+		filtered.Context = nil
+	}
+	if !included[models.ReportSectionScores] {
+		filtered.TotalScore = 0
+		filtered.MaxPossibleScore = 0
+		filtered.PenaltyScore = 0
+		filtered.Band = ""
+		filtered.BandLabel = ""
+		filtered.Grade = ""
+	}
+	if !included[models.ReportSectionCategoryScores] {
+		filtered.CategoryScores = nil
+	}
+	if !included[models.ReportSectionNarrative] {
+		filtered.Narrative = nil
+	}
+	if !included[models.ReportSectionPlugins] {
+		filtered.PluginSections = nil
+	}
+	if !included[models.ReportSectionRecommendations] {
+		filtered.Recommendations = nil
+	}
+	if !included[models.ReportSectionRisks] {
+		filtered.Risks = nil
+	}
+	if !included[models.ReportSectionPlan] {
+		filtered.ModernizationPlan = nil
+	}
+	if !included[models.ReportSectionEstimate] {
+		filtered.Estimate = nil
+	}
+	if !included[models.ReportSectionBreakdown] {
+		filtered.Breakdown = nil
+		filtered.AnswerProvenance = nil
+	}
+	if !included[models.ReportSectionUnanswered] {
+		filtered.Unanswered = nil
+		filtered.UnansweredScore = 0
+	}
+	if !included[models.ReportSectionDisagreements] {
+		filtered.Disagreements = nil
+	}
+	if !included[models.ReportSectionAppliedWeights] {
+		filtered.AppliedWeights = nil
+		filtered.ScoringPlugins = nil
+	}
+	if !included[models.ReportSectionAnnotations] {
+		filtered.Annotations = nil
+	}
+	if !included[models.ReportSectionApproval] {
+		filtered.Approval = nil
+	}
+	if !included[models.ReportSectionSignature] {
+		filtered.Signature = ""
+	}
+	
+	if tmpl.Highlights > 0 {
+		filtered.Recommendations = mostUrgent(filtered.Recommendations, tmpl.Highlights, func(r models.Recommendation) string { return r.Priority })
+		filtered.Risks = mostUrgent(filtered.Risks, tmpl.Highlights, func(r models.Risk) string { return r.Severity })
+	}
+	return &filtered, nil
+}
+
+// lookupReportTemplate returns the template with an ID, or nil for the empty ID
+func lookupReportTemplate(templateID string) (*models.ReportTemplate, error) {
+	if templateID == "" {
+		return nil, nil
+	}
+	for i := range reportTemplates {
+		if reportTemplates[i].ID == templateID {
+			return &reportTemplates[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrInvalidReportTemplate, templateID)
+}
+
+// mostUrgent returns up to limit items ordered by urgency, keeping the report order among
+// equally urgent items
+func mostUrgent[T any](items []T, limit int, level func(T) string) []T {
+	if items == nil {
+		return nil
+	}
+	
+	sorted := append([]T(nil), items...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return urgencyRank(level(sorted[i])) < urgencyRank(level(sorted[j]))
+	})
+	if len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
+// urgencyRank ranks a priority or severity, placing unknown levels last
+func urgencyRank(level string) int {
+	if rank, ok := urgency[level]; ok {
+		return rank
+	}
+	return len(urgency)
+}
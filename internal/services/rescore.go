@@ -0,0 +1,215 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"questionnaire-app/internal/models"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRescore is returned for rescore requests that fail validation
+var ErrInvalidRescore = errors.New("invalid rescore request")
+
+// JobRescoreReports is the background job type re-scoring reports
+const JobRescoreReports = "rescore-reports"
+
+// RescoreReports recomputes the reports of completed assessments against a catalog and scoring
+// configuration. Each new report becomes the assessment's current report and links to the
+// version it superseded and to the report issued at completion, which are both kept.
+// Approved assessments keep their issued report and are skipped, like assessments without one.
+func (s *AssessmentService) RescoreReports(ctx context.Context, request models.RescoreRequest) (*models.RescoreResult, error) {
+	if len(request.AssessmentIDs) == 0 {
+		return nil, fmt.Errorf("%w: assessmentIds is required", ErrInvalidRescore)
+	}
+	for _, assessmentID := range request.AssessmentIDs {
+		if strings.TrimSpace(assessmentID) == "" {
+			return nil, fmt.Errorf("%w: assessmentIds must not be empty", ErrInvalidRescore)
+		}
+	}
+	
+	scoringSource := "current"
+	if request.Scoring != nil {
+		if err := validateScoringConfig(request.Scoring); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRescore, err)
+		}
+		if err := s.validateScoringPlugins(request.Scoring); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRescore, err)
+		}
+		scoringSource = "custom"
+	}
+	
+	questions, err := s.catalogQuestions(ctx, request.Catalog)
+	if err != nil {
+		return nil, err
+	}
+	catalogSource := "live"
+	switch {
+	case request.Catalog.Catalog != "":
+		catalogSource = request.Catalog.Catalog
+	case len(request.Catalog.Questions) > 0:
+		catalogSource = "custom"
+	}
+	
+	result := &models.RescoreResult{
+		DryRun:   request.DryRun,
+		Rescored: []models.RescoredReport{},
+		Skipped:  []models.RescoreSkip{},
+	}
+	for _, assessmentID := range request.AssessmentIDs {
+		rescore := &models.ReportRescore{
+			Catalog: catalogSource,
+			Scoring: scoringSource,
+			Reason:  strings.TrimSpace(request.Reason),
+		}
+		rescored, skipped, err := s.rescoreReport(ctx, assessmentID, questions, request.Scoring, rescore, request.DryRun)
+		if err != nil {
+			return nil, err
+		}
+		if skipped != "" {
+			result.Skipped = append(result.Skipped, models.RescoreSkip{AssessmentID: assessmentID, Reason: skipped})
+			continue
+		}
+		result.Rescored = append(result.Rescored, *rescored)
+	}
+	return result, nil
+}
+
+// RescoreReportsJob runs RescoreReports as a background job
+func (s *AssessmentService) RescoreReportsJob(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var request models.RescoreRequest
+	if err := json.Unmarshal(params, &request); err != nil {
+		return nil, fmt.Errorf("invalid rescore job parameters: %w", err)
+	}
+	return s.RescoreReports(ctx, request)
+}
+
+// rescoreReport recomputes the report of one assessment under its lock. It returns the reason
+// if the assessment is skipped.
+func (s *AssessmentService) rescoreReport(ctx context.Context, assessmentID string, questions []*models.Question, scoring *models.ScoringConfig, rescore *models.ReportRescore, dryRun bool) (*models.RescoredReport, string, error) {
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, "", err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get assessment: %w", err)
+	}
+	switch {
+	case assessment == nil:
+		return nil, "assessment not found", nil
+	case assessment.Status == "approved":
+		return nil, "approved assessments keep their issued report", nil
+	case assessment.Status != "completed":
+		return nil, "assessment is not completed", nil
+	}
+	
+	current, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get report: %w", err)
+	}
+	if current == nil {
+		return nil, "assessment has no current report", nil
+	}
+	if current.Version == 0 {
+		current.Version = 1
+	}
+	
+	report, err := s.generateReport(ctx, assessment, questions, scoring)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate report: %w", err)
+	}
+	
+	// The new version still describes the assessment as completed, so it keeps the time the
+	// report was generated and with it the report's validity period
+	now := time.Now()
+	rescore.RescoredAt = now
+	rescore.Previous = reportVersionRef(current)
+	rescore.Original = rescore.Previous
+	if current.Rescore != nil {
+		rescore.Original = current.Rescore.Original
+	}
+	report.GeneratedAt = current.GeneratedAt
+	report.UpdatedAt = &now
+	report.Version = current.Version + 1
+	report.Rescore = rescore
+	
+	rescored := &models.RescoredReport{
+		AssessmentID:     report.AssessmentID,
+		ApplicationID:    report.ApplicationID,
+		Version:          report.Version,
+		TotalScore:       report.TotalScore,
+		MaxPossibleScore: report.MaxPossibleScore,
+		Grade:            report.Grade,
+		Previous:         rescore.Previous,
+	}
+	if dryRun {
+		return rescored, "", nil
+	}
+	
+	if s.signer != nil {
+		if err := s.signer.Sign(report); err != nil {
+			return nil, "", fmt.Errorf("failed to sign report: %w", err)
+		}
+	}
+	
+	if err := s.storage.SaveReportVersion(ctx, current); err != nil {
+		return nil, "", fmt.Errorf("failed to save report version: %w", err)
+	}
+	if err := s.storage.SaveReport(ctx, report); err != nil {
+		return nil, "", fmt.Errorf("failed to save report: %w", err)
+	}
+	s.refreshPortfolioSummary(ctx, report)
+	log.Printf("Re-scored report of assessment %s as version %d", assessmentID, report.Version)
+	
+	return rescored, "", nil
+}
+
+// reportVersionRef summarizes a report version
+func reportVersionRef(report *models.Report) models.ReportVersionRef {
+	return models.ReportVersionRef{
+		Version:          report.Version,
+		GeneratedAt:      report.GeneratedAt,
+		TotalScore:       report.TotalScore,
+		MaxPossibleScore: report.MaxPossibleScore,
+		Grade:            report.Grade,
+	}
+}
+
+// ListReportVersions returns the versions of an assessment's report that re-scoring
+// superseded, oldest first. The current report is returned by GetReport.
+func (s *AssessmentService) ListReportVersions(ctx context.Context, assessmentID string) ([]*models.Report, error) {
+	versions, err := s.storage.ListReportVersions(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report versions: %w", err)
+	}
+	return versions, nil
+}
+
+// GetReportVersion returns a version of an assessment's report, current or superseded
+func (s *AssessmentService) GetReportVersion(ctx context.Context, assessmentID string, version int) (*models.Report, error) {
+	current, err := s.GetReport(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report: %w", err)
+	}
+	if current != nil && max(current.Version, 1) == version {
+		return current, nil
+	}
+	
+	versions, err := s.ListReportVersions(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	for _, report := range versions {
+		if report.Version == version {
+			s.MarkValidity(report)
+			return report, nil
+		}
+	}
+	return nil, fmt.Errorf("report version %d %w", version, ErrNotFound)
+}
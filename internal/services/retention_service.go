@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"time"
+)
+
+// RetentionService enforces retention rules on stored assessments and reports
+type RetentionService struct {
+	storage storage.Storage
+	locker  storage.Locker
+	rules   []models.RetentionRule
+}
+
+// NewRetentionService creates a new retention service enforcing the given rules. Assessments
+// are purged under their lock, so they cannot be answered while they are deleted.
+func NewRetentionService(storage storage.Storage, locker storage.Locker, rules []models.RetentionRule) *RetentionService {
+	return &RetentionService{
+		storage: storage,
+		locker:  locker,
+		rules:   rules,
+	}
+}
+
+// LoadRetentionRules reads retention rules from a JSON file
+func LoadRetentionRules(path string) ([]models.RetentionRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read retention rules: %w", err)
+	}
+	
+	var rules []models.RetentionRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal retention rules: %w", err)
+	}
+	
+	for _, rule := range rules {
+		if err := validateRetentionRule(rule); err != nil {
+			return nil, fmt.Errorf("invalid retention rule %q: %w", rule.Name, err)
+		}
+	}
+	
+	return rules, nil
+}
+
+// validateRetentionRule checks a rule uses a supported entity and action combination
+func validateRetentionRule(rule models.RetentionRule) error {
+	if rule.OlderThanDays <= 0 {
+		return fmt.Errorf("olderThanDays must be positive")
+	}
+	
+	switch rule.Entity {
+	case models.RetentionEntityAssessment:
+		if rule.Action != models.RetentionActionPurge {
+			return fmt.Errorf("assessments can only be purged")
+		}
+	case models.RetentionEntityReport:
+		if rule.Action != models.RetentionActionPurge && rule.Action != models.RetentionActionArchive {
+			return fmt.Errorf("unknown action: %s", rule.Action)
+		}
+	default:
+		return fmt.Errorf("unknown entity: %s", rule.Entity)
+	}
+	
+	return nil
+}
+
+// Rules returns the configured retention rules
+func (s *RetentionService) Rules() []models.RetentionRule {
+	return s.rules
+}
+
+// Enforce applies all retention rules; in dry-run mode it only lists what would be done
+func (s *RetentionService) Enforce(ctx context.Context, dryRun bool) ([]models.RetentionAction, error) {
+	now := time.Now()
+	actions := []models.RetentionAction{}
+	defer func() {
+		if !dryRun && len(actions) > 0 {
+			invalidatePortfolioSummary(ctx, s.storage)
+		}
+	}()
+	
+	for _, rule := range s.rules {
+		var ruleActions []models.RetentionAction
+		var err error
+		
+		switch rule.Entity {
+		case models.RetentionEntityAssessment:
+			ruleActions, err = s.enforceAssessmentRule(ctx, rule, now, dryRun)
+		case models.RetentionEntityReport:
+			ruleActions, err = s.enforceReportRule(ctx, rule, now, dryRun)
+		}
+		
+		if err != nil {
+			return actions, fmt.Errorf("failed to enforce retention rule %q: %w", rule.Name, err)
+		}
+		
+		actions = append(actions, ruleActions...)
+	}
+	
+	return actions, nil
+}
+
+// JobRetention is the background job type enforcing retention rules
+const JobRetention = "retention"
+
+// EnforceJob adapts Enforce to the job runner; params is the dry-run flag
+func (s *RetentionService) EnforceJob(ctx context.Context, params json.RawMessage) (interface{}, error) {
+	var dryRun bool
+	if err := json.Unmarshal(params, &dryRun); err != nil {
+		return nil, fmt.Errorf("invalid retention job parameters: %w", err)
+	}
+	return s.Enforce(ctx, dryRun)
+}
+
+// Run enforces retention rules periodically until the context is cancelled
+func (s *RetentionService) Run(ctx context.Context, interval time.Duration, dryRun bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		actions, err := s.Enforce(ctx, dryRun)
+		if err != nil {
+			log.Printf("Retention run failed: %v", err)
+		}
+		
+		for _, action := range actions {
+			if action.Executed {
+				log.Printf("Retention rule %q: %s %s %s (%d days old)", action.Rule, action.Action, action.Entity, action.ID, action.AgeDays)
+			} else {
+				log.Printf("Retention rule %q (dry run): would %s %s %s (%d days old)", action.Rule, action.Action, action.Entity, action.ID, action.AgeDays)
+			}
+		}
+		
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// enforceAssessmentRule purges assessments (and their reports) left untouched for longer than
+// the rule allows
+func (s *RetentionService) enforceAssessmentRule(ctx context.Context, rule models.RetentionRule, now time.Time, dryRun bool) ([]models.RetentionAction, error) {
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	var actions []models.RetentionAction
+	for _, assessment := range assessments {
+		age, ok, err := s.inactiveDays(ctx, rule, assessment, now)
+		if err != nil {
+			return actions, err
+		}
+		if !ok {
+			continue
+		}
+		
+		action := models.RetentionAction{
+			Rule:    rule.Name,
+			Entity:  rule.Entity,
+			ID:      assessment.ID,
+			Action:  rule.Action,
+			AgeDays: age,
+		}
+		
+		if !dryRun {
+			purged, err := s.purgeAssessment(ctx, rule, assessment.ID, now)
+			if err != nil {
+				return actions, err
+			}
+			if !purged {
+				continue
+			}
+			action.Executed = true
+		}
+		
+		actions = append(actions, action)
+	}
+	
+	return actions, nil
+}
+
+// inactiveDays returns the days since an assessment was last worked on and whether the rule
+// applies to it. Activity is the latest event, so an old assessment that is still being
+// answered is kept. Approved assessments are records of a review and are always kept.
+func (s *RetentionService) inactiveDays(ctx context.Context, rule models.RetentionRule, assessment *models.Assessment, now time.Time) (int, bool, error) {
+	if (rule.Status != "" && assessment.Status != rule.Status) || assessment.Status == "approved" {
+		return 0, false, nil
+	}
+	
+	lastActivity := assessment.CreatedAt
+	if assessment.UpdatedAt.After(lastActivity) {
+		lastActivity = assessment.UpdatedAt
+	}
+	age, ok := ageInDays(lastActivity, now)
+	if !ok || age < rule.OlderThanDays {
+		return 0, false, nil
+	}
+	
+	// Assessments written before updatedAt tracked every event may have later events
+	events, err := s.storage.ListEvents(ctx, assessment.ID)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to list events: %w", err)
+	}
+	for _, event := range events {
+		if event.OccurredAt.After(lastActivity) {
+			lastActivity = event.OccurredAt
+		}
+	}
+	age, ok = ageInDays(lastActivity, now)
+	return age, ok && age >= rule.OlderThanDays, nil
+}
+
+// purgeAssessment deletes an assessment with its report, events and workshop while holding
+// its lock. The assessment is read again under the lock and kept if it was worked on or
+// approved since it was listed.
+func (s *RetentionService) purgeAssessment(ctx context.Context, rule models.RetentionRule, assessmentID string, now time.Time) (bool, error) {
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(lockCtx, "assessment-"+assessmentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to lock assessment: %w", err)
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return false, nil
+	}
+	if _, ok, err := s.inactiveDays(ctx, rule, assessment, now); err != nil || !ok {
+		return false, err
+	}
+	
+	if err := s.storage.DeleteReport(ctx, assessmentID); err != nil {
+		return false, err
+	}
+	if err := s.storage.DeleteAssessment(ctx, assessmentID); err != nil {
+		return false, err
+	}
+	if err := s.storage.DeleteEvents(ctx, assessmentID); err != nil {
+		return false, err
+	}
+	if err := s.storage.DeleteWorkshop(ctx, assessmentID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// enforceReportRule purges or archives reports generated longer ago than the rule allows
+func (s *RetentionService) enforceReportRule(ctx context.Context, rule models.RetentionRule, now time.Time, dryRun bool) ([]models.RetentionAction, error) {
+	reports, err := s.storage.ListReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	
+	var actions []models.RetentionAction
+	for _, report := range reports {
+		age, ok := ageInDays(report.GeneratedAt, now)
+		if !ok || age < rule.OlderThanDays {
+			continue
+		}
+		
+		action := models.RetentionAction{
+			Rule:    rule.Name,
+			Entity:  rule.Entity,
+			ID:      report.AssessmentID,
+			Action:  rule.Action,
+			AgeDays: age,
+		}
+		
+		if !dryRun {
+			if rule.Action == models.RetentionActionArchive {
+				err = s.storage.ArchiveReport(ctx, report.AssessmentID)
+			} else {
+				err = s.storage.DeleteReport(ctx, report.AssessmentID)
+			}
+			if err != nil {
+				return actions, err
+			}
+			action.Executed = true
+		}
+		
+		actions = append(actions, action)
+	}
+	
+	return actions, nil
+}
+
+// ageInDays returns the whole number of days between a timestamp and now; entities without a
+// recorded timestamp have no age and are never matched by retention rules
+func ageInDays(timestamp, now time.Time) (int, bool) {
+	if timestamp.IsZero() {
+		return 0, false
+	}
+	return int(now.Sub(timestamp).Hours() / 24), true
+}
@@ -0,0 +1,71 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/questionnairetest"
+	"questionnaire-app/internal/services"
+	"questionnaire-app/internal/storage"
+)
+
+var purgeAbandoned = []models.RetentionRule{{Name: "abandoned", Entity: models.RetentionEntityAssessment, OlderThanDays: 90, Action: models.RetentionActionPurge}}
+
+func TestEnforcePurgesOnlyInactiveAssessments(t *testing.T) {
+	ctx := context.Background()
+	store := questionnairetest.NewMemoryStorage()
+	started := time.Now().AddDate(0, 0, -200)
+	
+	edited := questionnairetest.NewAssessment("edited", "app1").CreatedAt(started).Build()
+	edited.UpdatedAt = time.Now().AddDate(0, 0, -1)
+	store.Seed(t,
+		questionnairetest.NewAssessment("abandoned", "app1").CreatedAt(started).Build(),
+		edited,
+		questionnairetest.NewAssessment("answered", "app1").CreatedAt(started).Build(),
+		questionnairetest.NewAssessment("approved", "app1").CreatedAt(started).Status("approved").Build(),
+	)
+	// An event newer than the assessment's updatedAt, as written before updatedAt was kept
+	if err := store.AppendEvent(ctx, &models.AssessmentEvent{AssessmentID: "answered", Type: models.EventAnswerSaved, OccurredAt: time.Now()}); err != nil {
+		t.Fatalf("AppendEvent: %v", err)
+	}
+	
+	retention := services.NewRetentionService(store, questionnairetest.NewMemoryLocker(), purgeAbandoned)
+	actions, err := retention.Enforce(ctx, false)
+	if err != nil {
+		t.Fatalf("Enforce: %v", err)
+	}
+	
+	if len(actions) != 1 || actions[0].ID != "abandoned" || !actions[0].Executed {
+		t.Errorf("actions = %+v, want only the abandoned assessment purged", actions)
+	}
+	for _, id := range []string{"edited", "answered", "approved"} {
+		if assessment, err := store.GetAssessment(ctx, id); err != nil || assessment == nil {
+			t.Errorf("assessment %s was purged: %v", id, err)
+		}
+	}
+}
+
+func TestEnforceWaitsForAssessmentLock(t *testing.T) {
+	store := questionnairetest.NewMemoryStorage()
+	store.Seed(t, questionnairetest.NewAssessment("a1", "app1").CreatedAt(time.Now().AddDate(0, 0, -200)).Build())
+	locker := questionnairetest.NewMemoryLocker()
+	retention := services.NewRetentionService(store, locker, purgeAbandoned)
+	
+	unlock, err := locker.Lock(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	defer unlock()
+	
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if _, err := retention.Enforce(ctx, false); !errors.Is(err, storage.ErrLockTimeout) {
+		t.Fatalf("Enforce with the assessment locked: err = %v, want ErrLockTimeout", err)
+	}
+	if assessment, err := store.GetAssessment(context.Background(), "a1"); err != nil || assessment == nil {
+		t.Errorf("the locked assessment was purged: %v", err)
+	}
+}
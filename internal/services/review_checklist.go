@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// checklistIDPattern matches the IDs of review checklist items, which reviewers send back
+var checklistIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// maxChecklistItemText limits an item's text and a reviewer's note to a sentence or two
+const maxChecklistItemText = 500
+
+// Errors returned when approving an assessment against the review checklist
+var (
+	// ErrChecklistIncomplete is returned when items of the review checklist are not ticked
+	ErrChecklistIncomplete = errors.New("review checklist incomplete")
+	
+	// ErrInvalidChecklist is returned when an approval ticks items the checklist does not have
+	ErrInvalidChecklist = errors.New("invalid review checklist")
+)
+
+// GetReviewChecklist returns the items reviewers must tick to approve an assessment; empty if
+// none was configured
+func (s *AssessmentService) GetReviewChecklist(ctx context.Context) (*models.ReviewChecklist, error) {
+	checklist, err := s.storage.GetReviewChecklist(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review checklist: %w", err)
+	}
+	
+	if checklist == nil {
+		return &models.ReviewChecklist{Items: []models.ChecklistItem{}}, nil
+	}
+	return checklist, nil
+}
+
+// UpdateReviewChecklist validates and stores the review checklist. Assessments approved
+// earlier keep the items ticked at the time.
+func (s *AssessmentService) UpdateReviewChecklist(ctx context.Context, checklist *models.ReviewChecklist) (*models.ReviewChecklist, error) {
+	if checklist.Items == nil {
+		checklist.Items = []models.ChecklistItem{}
+	}
+	for i := range checklist.Items {
+		checklist.Items[i].ID = strings.TrimSpace(checklist.Items[i].ID)
+		checklist.Items[i].Text = strings.TrimSpace(checklist.Items[i].Text)
+	}
+	if err := validateReviewChecklist(checklist); err != nil {
+		return nil, err
+	}
+	
+	now := time.Now()
+	checklist.UpdatedAt = &now
+	if err := s.storage.SaveReviewChecklist(ctx, checklist); err != nil {
+		return nil, fmt.Errorf("failed to save review checklist: %w", err)
+	}
+	
+	return checklist, nil
+}
+
+// validateReviewChecklist checks that every item has a unique ID and a text
+func validateReviewChecklist(checklist *models.ReviewChecklist) error {
+	seen := make(map[string]bool)
+	for i, item := range checklist.Items {
+		if !checklistIDPattern.MatchString(item.ID) {
+			return fmt.Errorf("item %d: id must be lowercase letters, digits, dashes and underscores", i+1)
+		}
+		if seen[item.ID] {
+			return fmt.Errorf("item %d: duplicate id %s", i+1, item.ID)
+		}
+		seen[item.ID] = true
+		
+		if item.Text == "" {
+			return fmt.Errorf("item %s: text is required", item.ID)
+		}
+		if len(item.Text) > maxChecklistItemText {
+			return fmt.Errorf("item %s: text must not exceed %d characters", item.ID, maxChecklistItemText)
+		}
+	}
+	return nil
+}
+
+// checkReviewChecklist matches the items ticked for an approval against the checklist and
+// returns them in checklist order with the text reviewers saw
+func checkReviewChecklist(checklist *models.ReviewChecklist, ticked []models.CheckedItem) ([]models.CheckedItem, error) {
+	byID := make(map[string]models.CheckedItem, len(ticked))
+	for _, item := range ticked {
+		item.Note = strings.TrimSpace(item.Note)
+		if len(item.Note) > maxChecklistItemText {
+			return nil, fmt.Errorf("%w: the note of item %s must not exceed %d characters", ErrInvalidChecklist, item.ID, maxChecklistItemText)
+		}
+		byID[item.ID] = item
+	}
+	
+	var checked []models.CheckedItem
+	var missing []string
+	for _, item := range checklist.Items {
+		tick, ok := byID[item.ID]
+		if !ok {
+			missing = append(missing, item.ID)
+			continue
+		}
+		delete(byID, item.ID)
+		checked = append(checked, models.CheckedItem{ID: item.ID, Text: item.Text, Note: tick.Note})
+	}
+	
+	if len(byID) > 0 {
+		unknown := make([]string, 0, len(byID))
+		for id := range byID {
+			unknown = append(unknown, id)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: the checklist has no item %s", ErrInvalidChecklist, strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: %s must be ticked before approval", ErrChecklistIncomplete, strings.Join(missing, ", "))
+	}
+	return checked, nil
+}
+
+// MarkApproval sets the approval of a report from its assessment; nil unless it is approved.
+// Like the validity it is derived on every read, since approval follows the report's issue.
+func (s *AssessmentService) MarkApproval(ctx context.Context, report *models.Report) error {
+	report.Approval = nil
+	assessment, err := s.storage.GetAssessment(ctx, report.AssessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil || assessment.Status != "approved" || assessment.ApprovedAt == nil {
+		return nil
+	}
+	
+	report.Approval = &models.ReportApproval{
+		ApprovedBy: assessment.ApprovedBy,
+		ApprovedAt: *assessment.ApprovedAt,
+		Checklist:  assessment.ApprovalChecklist,
+	}
+	return nil
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidRiskAcceptance is returned for risk acceptances that fail validation
+var ErrInvalidRiskAcceptance = errors.New("invalid risk acceptance")
+
+// riskKey identifies a risk across reports by its category and description
+func riskKey(risk models.Risk) string {
+	sum := sha256.Sum256([]byte(risk.Category + "\x00" + risk.Description))
+	return hex.EncodeToString(sum[:6])
+}
+
+// AcceptRisk accepts the risk at index in an assessment's report until an expiry date. Like
+// remediations, the acceptance is stored with the application and applies to the same risk in
+// later reports; accepting a risk again replaces the previous acceptance.
+func (s *AssessmentService) AcceptRisk(ctx context.Context, assessmentID string, index int, request models.RiskAcceptanceRequest, user string) (*models.RiskAcceptance, error) {
+	acceptedBy := strings.TrimSpace(request.AcceptedBy)
+	if user != "" {
+		acceptedBy = user
+	}
+	if acceptedBy == "" {
+		return nil, fmt.Errorf("%w: acceptedBy is required", ErrInvalidRiskAcceptance)
+	}
+	
+	now := time.Now()
+	expires, err := time.Parse("2006-01-02", request.ExpiresOn)
+	if err != nil {
+		return nil, fmt.Errorf("%w: expiresOn must be a date (YYYY-MM-DD)", ErrInvalidRiskAcceptance)
+	}
+	if expires.Format("2006-01-02") < now.Format("2006-01-02") {
+		return nil, fmt.Errorf("%w: expiresOn is in the past", ErrInvalidRiskAcceptance)
+	}
+	
+	var acceptance *models.RiskAcceptance
+	err = s.updateReportRisk(ctx, assessmentID, index, func(app *models.Application, risk models.Risk) {
+		acceptance = &models.RiskAcceptance{
+			Key:          riskKey(risk),
+			Category:     risk.Category,
+			Description:  risk.Description,
+			AcceptedBy:   acceptedBy,
+			Reason:       strings.TrimSpace(request.Reason),
+			ExpiresOn:    request.ExpiresOn,
+			AssessmentID: assessmentID,
+			AcceptedAt:   now,
+		}
+		if existing := findRiskAcceptance(app.RiskAcceptances, acceptance.Key); existing != nil {
+			*existing = *acceptance
+		} else {
+			app.RiskAcceptances = append(app.RiskAcceptances, *acceptance)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return acceptance, nil
+}
+
+// RevokeRiskAcceptance reopens the risk at index in an assessment's report
+func (s *AssessmentService) RevokeRiskAcceptance(ctx context.Context, assessmentID string, index int) error {
+	return s.updateReportRisk(ctx, assessmentID, index, func(app *models.Application, risk models.Risk) {
+		key := riskKey(risk)
+		kept := app.RiskAcceptances[:0]
+		for _, acceptance := range app.RiskAcceptances {
+			if acceptance.Key != key {
+				kept = append(kept, acceptance)
+			}
+		}
+		app.RiskAcceptances = kept
+	})
+}
+
+// updateReportRisk applies update to the application of an assessment's report under the
+// application's lock, passing the risk at index
+func (s *AssessmentService) updateReportRisk(ctx context.Context, assessmentID string, index int, update func(app *models.Application, risk models.Risk)) error {
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return fmt.Errorf("failed to get report: %w", err)
+	}
+	if report == nil {
+		return fmt.Errorf("report %w", ErrNotFound)
+	}
+	if index < 0 || index >= len(report.Risks) {
+		return fmt.Errorf("risk %d %w", index, ErrNotFound)
+	}
+	
+	lockCtx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	unlock, err := s.locker.Lock(lockCtx, "application-"+report.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("failed to lock application: %w", err)
+	}
+	defer unlock()
+	
+	app, err := s.storage.GetApplication(ctx, report.ApplicationID)
+	if err != nil {
+		return fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	update(app, report.Risks[index])
+	if err := s.storage.SaveApplication(ctx, app); err != nil {
+		return fmt.Errorf("failed to save application: %w", err)
+	}
+	return nil
+}
+
+// findRiskAcceptance returns the acceptance of the risk with the given key, or nil
+func findRiskAcceptance(acceptances []models.RiskAcceptance, key string) *models.RiskAcceptance {
+	for i := range acceptances {
+		if acceptances[i].Key == key {
+			return &acceptances[i]
+		}
+	}
+	return nil
+}
+
+// riskStatus returns the register status of a risk and a copy of its acceptance marked as
+// expired once its expiry date has passed
+func riskStatus(acceptances []models.RiskAcceptance, key string, now time.Time) (string, *models.RiskAcceptance) {
+	stored := findRiskAcceptance(acceptances, key)
+	if stored == nil {
+		return models.RiskStatusOpen, nil
+	}
+	
+	acceptance := *stored
+	acceptance.Expired = acceptance.ExpiresOn < now.Format("2006-01-02")
+	if acceptance.Expired {
+		return models.RiskStatusExpired, &acceptance
+	}
+	return models.RiskStatusAccepted, &acceptance
+}
+
+// markRiskAcceptances sets the key and acceptance of each risk of a report
+func markRiskAcceptances(report *models.Report, acceptances []models.RiskAcceptance, now time.Time) {
+	for i := range report.Risks {
+		risk := &report.Risks[i]
+		risk.Key = riskKey(*risk)
+		_, risk.Acceptance = riskStatus(acceptances, risk.Key, now)
+	}
+}
+
+// RiskRegisterOptions filters the risk register; empty lists match everything
+type RiskRegisterOptions struct {
+	Severities []string
+	Categories []string
+	Statuses   []string // open, accepted or expired
+}
+
+// RiskRegister lists the risks of every application's latest report with their acceptance
+// status, most severe first
+func (s *PortfolioService) RiskRegister(ctx context.Context, opts RiskRegisterOptions) (*models.RiskRegister, error) {
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	
+	now := time.Now()
+	register := &models.RiskRegister{
+		GeneratedAt: now,
+		Risks:       []models.RiskRegisterEntry{},
+		Counts:      map[string]int{models.RiskStatusOpen: 0, models.RiskStatusAccepted: 0, models.RiskStatusExpired: 0},
+	}
+	for _, app := range apps {
+		report, err := s.assessments.GetLatestReport(ctx, app.ID)
+		if err != nil {
+			return nil, err
+		}
+		if report == nil {
+			continue
+		}
+		
+		for i, risk := range report.Risks {
+			key := riskKey(risk)
+			status, acceptance := riskStatus(app.RiskAcceptances, key, now)
+			entry := models.RiskRegisterEntry{
+				ApplicationID:   app.ID,
+				ApplicationName: app.Name,
+				AssessmentID:    report.AssessmentID,
+				Index:           i,
+				Key:             key,
+				Category:        risk.Category,
+				Description:     risk.Description,
+				Severity:        risk.Severity,
+				Status:          status,
+				Acceptance:      acceptance,
+			}
+			if !matchesAny(opts.Severities, entry.Severity) || !matchesAny(opts.Categories, entry.Category) || !matchesAny(opts.Statuses, entry.Status) {
+				continue
+			}
+			register.Risks = append(register.Risks, entry)
+			register.Counts[status]++
+		}
+	}
+	
+	sort.SliceStable(register.Risks, func(i, j int) bool {
+		a, b := register.Risks[i], register.Risks[j]
+		if urgencyRank(a.Severity) != urgencyRank(b.Severity) {
+			return urgencyRank(a.Severity) < urgencyRank(b.Severity)
+		}
+		return a.ApplicationName < b.ApplicationName
+	})
+	return register, nil
+}
+
+// matchesAny reports whether value is among wanted, ignoring case; an empty list matches anything
+func matchesAny(wanted []string, value string) bool {
+	if len(wanted) == 0 {
+		return true
+	}
+	for _, candidate := range wanted {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
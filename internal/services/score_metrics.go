@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// labelEscaper escapes Prometheus label values
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// WriteScoreMetrics writes the latest score of every assessed application and its category
+// scores as Prometheus gauges in the text exposition format. The values come from the
+// materialized portfolio summary, so scrapes do not read every report.
+func (s *PortfolioService) WriteScoreMetrics(ctx context.Context, w io.Writer) error {
+	snapshot, err := s.portfolioSnapshot(ctx)
+	if err != nil {
+		return err
+	}
+	
+	applicationIDs := make([]string, 0, len(snapshot.Entries))
+	for applicationID := range snapshot.Entries {
+		applicationIDs = append(applicationIDs, applicationID)
+	}
+	sort.Strings(applicationIDs)
+	
+	var b strings.Builder
+	
+	b.WriteString("# HELP questionnaire_portfolio_applications Number of applications in the portfolio.\n")
+	b.WriteString("# TYPE questionnaire_portfolio_applications gauge\n")
+	fmt.Fprintf(&b, "questionnaire_portfolio_applications %d\n", snapshot.Summary.Applications)
+	
+	b.WriteString("# HELP questionnaire_portfolio_assessed_applications Number of applications with a report.\n")
+	b.WriteString("# TYPE questionnaire_portfolio_assessed_applications gauge\n")
+	fmt.Fprintf(&b, "questionnaire_portfolio_assessed_applications %d\n", snapshot.Summary.Assessed)
+	
+	b.WriteString("# HELP questionnaire_portfolio_stale_applications Number of applications whose latest report is past its validity period.\n")
+	b.WriteString("# TYPE questionnaire_portfolio_stale_applications gauge\n")
+	fmt.Fprintf(&b, "questionnaire_portfolio_stale_applications %d\n", s.staleEntries(snapshot))
+	
+	b.WriteString("# HELP questionnaire_application_score_percent Latest readiness score of an application as a percentage of the maximum.\n")
+	b.WriteString("# TYPE questionnaire_application_score_percent gauge\n")
+	for _, applicationID := range applicationIDs {
+		entry := snapshot.Entries[applicationID]
+		fmt.Fprintf(&b, "questionnaire_application_score_percent{application=\"%s\",band=\"%s\",grade=\"%s\"} %d\n",
+			labelEscaper.Replace(applicationID), labelEscaper.Replace(entry.Band), labelEscaper.Replace(entry.Grade), entry.ScorePercent)
+	}
+	
+	b.WriteString("# HELP questionnaire_application_category_score Latest score of an application in a category.\n")
+	b.WriteString("# TYPE questionnaire_application_category_score gauge\n")
+	for _, applicationID := range applicationIDs {
+		entry := snapshot.Entries[applicationID]
+		categories := make([]string, 0, len(entry.CategoryScores))
+		for category := range entry.CategoryScores {
+			categories = append(categories, category)
+		}
+		sort.Strings(categories)
+		
+		for _, category := range categories {
+			fmt.Fprintf(&b, "questionnaire_application_category_score{application=\"%s\",category=\"%s\"} %d\n",
+				labelEscaper.Replace(applicationID), labelEscaper.Replace(category), entry.CategoryScores[category])
+		}
+	}
+	
+	_, err = io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"time"
+)
+
+// GetScorecard summarizes an application on one page: its latest score with the trend over all
+// of its reports, the risks and recommendations of the latest report still to be dealt with,
+// most urgent first, and when the application is due for reassessment. Texts are translated
+// to locale.
+func (s *ApplicationService) GetScorecard(ctx context.Context, applicationID, locale string) (*models.Scorecard, error) {
+	app, err := s.storage.GetApplication(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get application: %w", err)
+	}
+	if app == nil {
+		return nil, fmt.Errorf("application %w", ErrNotFound)
+	}
+	
+	scoring, err := s.assessments.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trend, err := s.scoreTrend(ctx, applicationID, scoring)
+	if err != nil {
+		return nil, err
+	}
+	
+	scorecard := &models.Scorecard{
+		Application:                app,
+		Trend:                      trend,
+		OpenRisks:                  []models.Risk{},
+		OutstandingRecommendations: []models.Recommendation{},
+	}
+	if len(trend) > 1 {
+		change := trend[len(trend)-1].ScorePercent - trend[len(trend)-2].ScorePercent
+		scorecard.ScoreChange = &change
+	}
+	
+	report, err := s.assessments.GetLatestReport(ctx, applicationID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil {
+		return scorecard, nil
+	}
+	
+	scorecard.LatestScore = latestScore(report, scoring)
+	scorecard.LatestScore.Stale = report.Stale
+	scorecard.NextAssessmentDue = report.ValidUntil
+	scorecard.Overdue = report.Stale
+	
+	markRemediation(report, app.Remediations)
+	markRiskAcceptances(report, app.RiskAcceptances, time.Now())
+	scorecard.Remediation = report.Remediation
+	report, err = s.assessments.LocalizeReport(ctx, report, locale)
+	if err != nil {
+		return nil, err
+	}
+	
+	for _, risk := range report.Risks {
+		if risk.Acceptance == nil || risk.Acceptance.Expired {
+			scorecard.OpenRisks = append(scorecard.OpenRisks, risk)
+		}
+	}
+	sort.SliceStable(scorecard.OpenRisks, func(i, j int) bool {
+		return urgencyRank(scorecard.OpenRisks[i].Severity) < urgencyRank(scorecard.OpenRisks[j].Severity)
+	})
+	for _, recommendation := range report.Recommendations {
+		if recommendation.Remediation == nil || recommendation.Remediation.Status == models.RemediationOpen || recommendation.Remediation.Status == models.RemediationAccepted {
+			scorecard.OutstandingRecommendations = append(scorecard.OutstandingRecommendations, recommendation)
+		}
+	}
+	sort.SliceStable(scorecard.OutstandingRecommendations, func(i, j int) bool {
+		return urgencyRank(scorecard.OutstandingRecommendations[i].Priority) < urgencyRank(scorecard.OutstandingRecommendations[j].Priority)
+	})
+	return scorecard, nil
+}
+
+// scoreTrend returns the score of each report of an application's completed assessments,
+// oldest first
+func (s *ApplicationService) scoreTrend(ctx context.Context, applicationID string, scoring *models.ScoringConfig) ([]models.ScorePoint, error) {
+	assessments, err := s.storage.ListAssessments(ctx, applicationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	
+	trend := []models.ScorePoint{}
+	for _, assessment := range assessments {
+		if assessment.Status != "completed" && assessment.Status != "approved" {
+			continue
+		}
+		report, err := s.storage.GetReport(ctx, assessment.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report: %w", err)
+		}
+		if report == nil {
+			continue
+		}
+		
+		score := latestScore(report, scoring)
+		trend = append(trend, models.ScorePoint{
+			AssessmentID: score.AssessmentID,
+			GeneratedAt:  score.GeneratedAt,
+			ScorePercent: score.ScorePercent,
+			Band:         score.Band,
+			Grade:        score.Grade,
+		})
+	}
+	sort.SliceStable(trend, func(i, j int) bool { return trend[i].GeneratedAt.Before(trend[j].GeneratedAt) })
+	return trend, nil
+}
@@ -0,0 +1,317 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"questionnaire-app/internal/models"
+	"sort"
+	"time"
+)
+
+// DefaultScoringConfig returns the built-in score bands and grades
+func DefaultScoringConfig() *models.ScoringConfig {
+	return &models.ScoringConfig{
+		Bands: []models.ScoreBand{
+			{Level: models.BandLow, Label: "Significant changes required", MinRatio: 0},
+			{Level: models.BandMedium, Label: "Moderate changes required", MinRatio: 0.5},
+			{Level: models.BandHigh, Label: "Good candidate", MinRatio: 0.7},
+		},
+		Grades: []models.GradeThreshold{
+			{Grade: "A", MinRatio: 0.85},
+			{Grade: "B", MinRatio: 0.7},
+			{Grade: "C", MinRatio: 0.5},
+			{Grade: "D", MinRatio: 0.3},
+			{Grade: "F", MinRatio: 0},
+		},
+	}
+}
+
+// GetScoringConfig returns the active scoring configuration
+func (s *AssessmentService) GetScoringConfig(ctx context.Context) (*models.ScoringConfig, error) {
+	config, err := s.storage.GetScoringConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scoring config: %w", err)
+	}
+	
+	if config == nil {
+		return DefaultScoringConfig(), nil
+	}
+	return config, nil
+}
+
+// UpdateScoringConfig validates and stores a new scoring configuration. It applies to
+// reports generated from now on; existing reports keep the band they were issued with.
+func (s *AssessmentService) UpdateScoringConfig(ctx context.Context, config *models.ScoringConfig) (*models.ScoringConfig, error) {
+	if err := validateScoringConfig(config); err != nil {
+		return nil, err
+	}
+	if err := s.validateScoringPlugins(config); err != nil {
+		return nil, err
+	}
+	
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return nil, err
+	}
+	for category := range config.CategoryShares {
+		if categories != nil && categories[category] == nil {
+			return nil, fmt.Errorf("unknown category: %s", category)
+		}
+	}
+	for _, gate := range config.CategoryGates {
+		if categories != nil && categories[gate.Category] == nil {
+			return nil, fmt.Errorf("unknown category: %s", gate.Category)
+		}
+	}
+	
+	now := time.Now()
+	config.UpdatedAt = &now
+	if err := s.storage.SaveScoringConfig(ctx, config); err != nil {
+		return nil, fmt.Errorf("failed to save scoring config: %w", err)
+	}
+	
+	return config, nil
+}
+
+// ReadinessGrade converts a score into a letter grade using the default grade thresholds,
+// for reports issued before grades were recorded on them
+func ReadinessGrade(totalScore, maxScore int) string {
+	if maxScore <= 0 {
+		return "N/A"
+	}
+	return DefaultScoringConfig().GradeFor(scoreRatio(totalScore, maxScore))
+}
+
+// ScorePercent returns the score as a whole percentage of the maximum, rounded down, with
+// penalized scores below zero reported as 0%. It uses integer arithmetic, since 29/100 as a
+// float times 100 is just below 29.
+func ScorePercent(totalScore, maxScore int) int {
+	if maxScore <= 0 || totalScore <= 0 {
+		return 0
+	}
+	return totalScore * 100 / maxScore
+}
+
+// scoreRatio returns the fraction of the maximum score achieved, or 0 without a maximum.
+// Penalty points can push the total below zero; such scores count as 0 so they fall into
+// the lowest band and grade.
+func scoreRatio(totalScore, maxScore int) float64 {
+	if maxScore <= 0 || totalScore <= 0 {
+		return 0
+	}
+	return float64(totalScore) / float64(maxScore)
+}
+
+// ReportRatio returns the fraction of the maximum score a report achieved: the ratio after
+// category shares if they applied, or else the total over the maximum
+func ReportRatio(report *models.Report) float64 {
+	if report.WeightedRatio != nil {
+		return *report.WeightedRatio
+	}
+	return scoreRatio(report.TotalScore, report.MaxPossibleScore)
+}
+
+// ReportPercent returns ReportRatio as a whole percentage, rounded down like ScorePercent.
+// Weighted ratios are floats, so they are rounded to a millionth of a percent first to keep a
+// ratio of 0.29 from reporting 28%.
+func ReportPercent(report *models.Report) int {
+	if report.WeightedRatio == nil {
+		return ScorePercent(report.TotalScore, report.MaxPossibleScore)
+	}
+	return int(math.Floor(math.Round(math.Max(*report.WeightedRatio, 0)*1e8) / 1e6))
+}
+
+// weightCategories combines category ratios using the configured category shares. Categories
+// without a share divide the rest of 100% in proportion to their maximum scores, and shares of
+// categories the report does not score are spread over the others. It returns the weighted
+// ratio and the effective shares in percent, or nil if no shares are configured or apply.
+func weightCategories(shares map[string]float64, categoryScores, categoryMaxScores map[string]int) (*float64, map[string]float64) {
+	if len(shares) == 0 {
+		return nil, nil
+	}
+	
+	remainder := 100.0
+	for _, share := range shares {
+		remainder -= share
+	}
+	if remainder < 0 {
+		remainder = 0
+	}
+	
+	unsharedMax := 0
+	for category, max := range categoryMaxScores {
+		if _, ok := shares[category]; !ok && max > 0 {
+			unsharedMax += max
+		}
+	}
+	
+	// Sum in a fixed order so the ratio does not vary with map iteration
+	categories := make([]string, 0, len(categoryMaxScores))
+	for category := range categoryMaxScores {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	
+	effective := make(map[string]float64)
+	total := 0.0
+	for _, category := range categories {
+		max := categoryMaxScores[category]
+		if max <= 0 {
+			continue
+		}
+		share, ok := shares[category]
+		if !ok {
+			share = remainder * float64(max) / float64(unsharedMax)
+		}
+		if share > 0 {
+			effective[category] = share
+			total += share
+		}
+	}
+	if total == 0 {
+		return nil, nil
+	}
+	
+	ratio := 0.0
+	for _, category := range categories {
+		share, ok := effective[category]
+		if !ok {
+			continue
+		}
+		ratio += share / total * scoreRatio(categoryScores[category], categoryMaxScores[category])
+		effective[category] = math.Round(share/total*1000) / 10
+	}
+	return &ratio, effective
+}
+
+// applyCategoryGates caps the report's grade for every scored category below its configured
+// minimum and puts a risk explaining the cap at the top of the report's risks
+func applyCategoryGates(report *models.Report, config *models.ScoringConfig, categoryScores, categoryMaxScores map[string]int) {
+	var risks []models.Risk
+	for _, gate := range config.CategoryGates {
+		max := categoryMaxScores[gate.Category]
+		if max <= 0 {
+			continue
+		}
+		
+		ratio := scoreRatio(categoryScores[gate.Category], max)
+		if ratio >= gate.MinRatio {
+			continue
+		}
+		
+		limit := gate.MaxGrade
+		if limit == "" {
+			limit = config.LowestGrade()
+		}
+		report.Grade = config.CapGrade(report.Grade, limit)
+		report.FailedGates = append(report.FailedGates, models.FailedGate{
+			Category: gate.Category,
+			Ratio:    math.Round(ratio*1000) / 1000,
+			MinRatio: gate.MinRatio,
+			MaxGrade: limit,
+		})
+		risks = append(risks, models.Risk{
+			Category:    gate.Category,
+			Description: fmt.Sprintf("%s reaches %d%% of its maximum score but must reach at least %d%%; the overall grade is capped at %s until it does", gate.Category, int(math.Round(ratio*100)), int(math.Round(gate.MinRatio*100)), limit),
+			Severity:    "High",
+		})
+	}
+	
+	if len(risks) > 0 {
+		report.Risks = append(risks, report.Risks...)
+	}
+}
+
+// validateScoringConfig checks that every readiness level has exactly one band with
+// thresholds increasing from low to high, and that grades cover the full ratio range
+func validateScoringConfig(config *models.ScoringConfig) error {
+	levels := make(map[string]models.ScoreBand)
+	for _, band := range config.Bands {
+		switch band.Level {
+		case models.BandLow, models.BandMedium, models.BandHigh:
+		default:
+			return fmt.Errorf("unknown band level: %s", band.Level)
+		}
+		
+		if _, exists := levels[band.Level]; exists {
+			return fmt.Errorf("duplicate band level: %s", band.Level)
+		}
+		
+		if band.MinRatio < 0 || band.MinRatio > 1 {
+			return fmt.Errorf("band %s minRatio must be between 0 and 1", band.Level)
+		}
+		levels[band.Level] = band
+	}
+	
+	if len(levels) != 3 {
+		return errors.New("bands must define the low, medium and high levels")
+	}
+	
+	if levels[models.BandLow].MinRatio != 0 {
+		return errors.New("the low band must start at ratio 0")
+	}
+	
+	if !(levels[models.BandLow].MinRatio < levels[models.BandMedium].MinRatio && levels[models.BandMedium].MinRatio < levels[models.BandHigh].MinRatio) {
+		return errors.New("band thresholds must increase from low to high")
+	}
+	
+	coversZero := false
+	for _, grade := range config.Grades {
+		if grade.Grade == "" {
+			return errors.New("grade name is required")
+		}
+		if grade.MinRatio < 0 || grade.MinRatio > 1 {
+			return fmt.Errorf("grade %s minRatio must be between 0 and 1", grade.Grade)
+		}
+		if grade.MinRatio == 0 {
+			coversZero = true
+		}
+	}
+	
+	if !coversZero {
+		return errors.New("one grade must start at ratio 0")
+	}
+	
+	sum := 0.0
+	for category, share := range config.CategoryShares {
+		if share <= 0 || share > 100 {
+			return fmt.Errorf("share of category %s must be above 0 and at most 100", category)
+		}
+		sum += share
+	}
+	if sum > 100+1e-9 {
+		return fmt.Errorf("category shares add up to %g%%, more than 100%%", sum)
+	}
+	
+	for _, plugin := range config.Plugins {
+		if plugin.Module == "" {
+			return errors.New("scoring plugin needs a module")
+		}
+	}
+	
+	grades := make(map[string]bool, len(config.Grades))
+	for _, grade := range config.Grades {
+		grades[grade.Grade] = true
+	}
+	gated := make(map[string]bool, len(config.CategoryGates))
+	for _, gate := range config.CategoryGates {
+		if gate.Category == "" {
+			return errors.New("category gate needs a category")
+		}
+		if gated[gate.Category] {
+			return fmt.Errorf("duplicate gate for category %s", gate.Category)
+		}
+		gated[gate.Category] = true
+		
+		if gate.MinRatio <= 0 || gate.MinRatio > 1 {
+			return fmt.Errorf("gate of category %s: minRatio must be above 0 and at most 1", gate.Category)
+		}
+		if gate.MaxGrade != "" && !grades[gate.MaxGrade] {
+			return fmt.Errorf("gate of category %s: unknown grade %s", gate.Category, gate.MaxGrade)
+		}
+	}
+	
+	return nil
+}
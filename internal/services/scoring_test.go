@@ -0,0 +1,51 @@
+package services_test
+
+import (
+	"testing"
+	
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/services"
+)
+
+func TestScorePercent(t *testing.T) {
+	tests := []struct {
+		total, max int
+		want       int
+	}{
+		{29, 100, 29},
+		{57, 100, 57},
+		{58, 100, 58},
+		{2, 3, 66},
+		{100, 100, 100},
+		{-10, 100, 0},
+		{10, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := services.ScorePercent(tt.total, tt.max); got != tt.want {
+			t.Errorf("ScorePercent(%d, %d) = %d, want %d", tt.total, tt.max, got, tt.want)
+		}
+	}
+}
+
+func TestReportPercent(t *testing.T) {
+	ratio := func(value float64) *float64 { return &value }
+	tests := []struct {
+		name   string
+		report models.Report
+		want   int
+	}{
+		{"total", models.Report{TotalScore: 29, MaxPossibleScore: 100}, 29},
+		{"penalized total", models.Report{TotalScore: -5, MaxPossibleScore: 100}, 0},
+		{"weighted", models.Report{TotalScore: 10, MaxPossibleScore: 100, WeightedRatio: ratio(0.29)}, 29},
+		{"weighted sum", models.Report{WeightedRatio: ratio(0.1 + 0.47)}, 57},
+		{"weighted fraction", models.Report{WeightedRatio: ratio(2.0 / 3)}, 66},
+		{"weighted penalty", models.Report{WeightedRatio: ratio(-0.2)}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := services.ReportPercent(&tt.report); got != tt.want {
+				t.Errorf("ReportPercent = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
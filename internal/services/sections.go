@@ -0,0 +1,238 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSection is returned when saving a section that fails validation
+var ErrInvalidSection = errors.New("section is invalid")
+
+// sectionIDPattern restricts section IDs to slugs, since they name files and appear in URLs
+var sectionIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// unsectionedTitle is the title of the last page, holding the questions of no section
+const unsectionedTitle = "Other questions"
+
+// ListSections returns the sections in page order
+func (s *QuestionService) ListSections(ctx context.Context) ([]*models.Section, error) {
+	sections, err := s.storage.ListSections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sections: %w", err)
+	}
+	
+	sortSections(sections)
+	if sections == nil {
+		sections = []*models.Section{}
+	}
+	return sections, nil
+}
+
+// SaveSection creates or replaces a section. Its questions must exist and may not belong to
+// another section, so every question appears on exactly one page.
+func (s *QuestionService) SaveSection(ctx context.Context, section *models.Section) (*models.Section, error) {
+	if !sectionIDPattern.MatchString(section.ID) {
+		return nil, fmt.Errorf("%w: id must consist of lowercase letters, digits, dashes and underscores", ErrInvalidSection)
+	}
+	section.Title = strings.TrimSpace(section.Title)
+	if section.Title == "" {
+		return nil, fmt.Errorf("%w: title is required", ErrInvalidSection)
+	}
+	if section.QuestionIDs == nil {
+		section.QuestionIDs = []string{}
+	}
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	known := make(map[string]bool, len(questions))
+	for _, question := range questions {
+		known[question.ID] = true
+	}
+	
+	others, err := s.storage.ListSections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sections: %w", err)
+	}
+	owner := make(map[string]string)
+	for _, other := range others {
+		if other.ID == section.ID {
+			continue
+		}
+		for _, id := range other.QuestionIDs {
+			owner[id] = other.ID
+		}
+	}
+	
+	listed := make(map[string]bool, len(section.QuestionIDs))
+	for _, id := range section.QuestionIDs {
+		switch {
+		case !known[id]:
+			return nil, fmt.Errorf("%w: unknown question %s", ErrInvalidSection, id)
+		case listed[id]:
+			return nil, fmt.Errorf("%w: question %s is listed twice", ErrInvalidSection, id)
+		case owner[id] != "":
+			return nil, fmt.Errorf("%w: question %s already belongs to section %s", ErrConflict, id, owner[id])
+		}
+		listed[id] = true
+	}
+	
+	now := time.Now().UTC()
+	section.UpdatedAt = &now
+	if err := s.storage.SaveSection(ctx, section); err != nil {
+		return nil, fmt.Errorf("failed to save section: %w", err)
+	}
+	
+	return section, nil
+}
+
+// DeleteSection removes a section; its questions move to the page of unsectioned questions
+func (s *QuestionService) DeleteSection(ctx context.Context, id string) error {
+	section, err := s.storage.GetSection(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get section: %w", err)
+	}
+	if section == nil {
+		return fmt.Errorf("section %w", ErrNotFound)
+	}
+	
+	if err := s.storage.DeleteSection(ctx, id); err != nil {
+		return fmt.Errorf("failed to delete section: %w", err)
+	}
+	return nil
+}
+
+// GetPages returns the whole question catalog as questionnaire pages
+func (s *QuestionService) GetPages(ctx context.Context) ([]*models.QuestionPage, error) {
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	return buildPages(ctx, s.storage, questions, nil)
+}
+
+// GetAssessmentPages returns the questions that apply to an assessment's application as
+// questionnaire pages, counting the answered questions of each page
+func (s *AssessmentService) GetAssessmentPages(ctx context.Context, assessmentID string) ([]*models.QuestionPage, error) {
+	questions, err := s.GetAssessmentQuestions(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	
+	return buildPages(ctx, s.storage, questions, assessment.Answers)
+}
+
+// pageStorage is the storage needed to lay out questionnaire pages
+type pageStorage interface {
+	storage.SectionRepository
+	storage.CategoryRepository
+}
+
+// buildPages groups questions into pages. With sections defined there is one page per section
+// in section order, followed by a page of the questions in no section; otherwise there is one
+// page per category in category order, introduced by the category's description. Pages
+// without questions are left out.
+func buildPages(ctx context.Context, repo pageStorage, questions []*models.Question, answers map[string]string) ([]*models.QuestionPage, error) {
+	sections, err := repo.ListSections(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sections: %w", err)
+	}
+	
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+	
+	var pages []*models.QuestionPage
+	if len(sections) > 0 {
+		sortSections(sections)
+		placed := make(map[string]bool)
+		for _, section := range sections {
+			page := &models.QuestionPage{SectionID: section.ID, Title: section.Title, Intro: section.Intro}
+			for _, id := range section.QuestionIDs {
+				if question := byID[id]; question != nil && !placed[id] {
+					page.Questions = append(page.Questions, question)
+					placed[id] = true
+				}
+			}
+			pages = append(pages, page)
+		}
+		
+		rest := &models.QuestionPage{Title: unsectionedTitle}
+		for _, question := range questions {
+			if !placed[question.ID] {
+				rest.Questions = append(rest.Questions, question)
+			}
+		}
+		pages = append(pages, rest)
+	} else {
+		categories, err := repo.ListCategories(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list categories: %w", err)
+		}
+		sortCategories(categories)
+		
+		// Defined categories come first; categories in use but not defined follow in the order
+		// the catalog uses them
+		byCategory := make(map[string]*models.QuestionPage)
+		for _, category := range categories {
+			page := &models.QuestionPage{Category: category.Name, Title: category.Name, Intro: category.Description}
+			byCategory[category.Name] = page
+			pages = append(pages, page)
+		}
+		for _, question := range questions {
+			page := byCategory[question.Category]
+			if page == nil {
+				page = &models.QuestionPage{Category: question.Category, Title: question.Category}
+				byCategory[question.Category] = page
+				pages = append(pages, page)
+			}
+			page.Questions = append(page.Questions, question)
+		}
+	}
+	
+	result := []*models.QuestionPage{}
+	for _, page := range pages {
+		if len(page.Questions) == 0 {
+			continue
+		}
+		
+		page.Number = len(result) + 1
+		page.IntroHTML = renderMarkdown(page.Intro)
+		for _, question := range page.Questions {
+			if _, ok := answers[question.ID]; ok {
+				page.Answered++
+			}
+		}
+		result = append(result, page)
+	}
+	
+	return result, nil
+}
+
+// sortSections sorts sections by page order, then by ID
+func sortSections(sections []*models.Section) {
+	sort.Slice(sections, func(i, j int) bool {
+		if sections[i].Order != sections[j].Order {
+			return sections[i].Order < sections[j].Order
+		}
+		return sections[i].ID < sections[j].ID
+	})
+}
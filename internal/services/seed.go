@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"strings"
+	"time"
+	
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed seed/default.json
+var defaultSeed embed.FS
+
+// DefaultSeedData returns the built-in sample questions and application
+func DefaultSeedData() (*models.SeedData, error) {
+	data, err := defaultSeed.ReadFile("seed/default.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read default seed data: %w", err)
+	}
+	return parseSeedFile("default.json", data)
+}
+
+// LoadSeedDir reads every JSON and YAML file in dir. Each file holds an object with
+// "categories", "questions" and/or "applications" lists; files are read in name order and later files
+// may not repeat an ID.
+func LoadSeedDir(dir string) (*models.SeedData, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read seed directory: %w", err)
+	}
+	
+	var names []string
+	for _, file := range files {
+		switch strings.ToLower(filepath.Ext(file.Name())) {
+		case ".json", ".yaml", ".yml":
+			if !file.IsDir() && !strings.HasPrefix(file.Name(), ".") {
+				names = append(names, file.Name())
+			}
+		}
+	}
+	sort.Strings(names)
+	
+	seed := &models.SeedData{}
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read seed file %s: %w", name, err)
+		}
+		
+		parsed, err := parseSeedFile(name, data)
+		if err != nil {
+			return nil, err
+		}
+		seed.Categories = append(seed.Categories, parsed.Categories...)
+		seed.Questions = append(seed.Questions, parsed.Questions...)
+		seed.Applications = append(seed.Applications, parsed.Applications...)
+	}
+	
+	if err := validateSeedData(seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+// yamlToJSON converts the content of YAML files to JSON, so both formats are decoded with the
+// JSON field names of the models. Other files are returned unchanged.
+func yamlToJSON(name string, data []byte) ([]byte, error) {
+	if ext := strings.ToLower(filepath.Ext(name)); ext != ".yaml" && ext != ".yml" {
+		return data, nil
+	}
+	
+	var document interface{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return json.Marshal(document)
+}
+
+// parseSeedFile decodes a JSON or YAML seed file
+func parseSeedFile(name string, data []byte) (*models.SeedData, error) {
+	data, err := yamlToJSON(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse seed file %s: %w", name, err)
+	}
+	
+	var seed models.SeedData
+	if err := json.Unmarshal(data, &seed); err != nil {
+		return nil, fmt.Errorf("failed to parse seed file %s: %w", name, err)
+	}
+	return &seed, nil
+}
+
+// validateSeedData checks every seed question like a published question and rejects
+// categories without a name, applications without an ID and duplicate names and IDs
+func validateSeedData(seed *models.SeedData) error {
+	categoryNames := make(map[string]bool)
+	for _, category := range seed.Categories {
+		if strings.TrimSpace(category.Name) == "" {
+			return fmt.Errorf("seed category has no name")
+		}
+		if categoryNames[category.Name] {
+			return fmt.Errorf("duplicate seed category: %s", category.Name)
+		}
+		categoryNames[category.Name] = true
+	}
+	
+	questionIDs := make(map[string]bool)
+	for _, question := range seed.Questions {
+		if problems := ValidateQuestion(question); len(problems) > 0 {
+			return fmt.Errorf("invalid seed question %q: %s %s", question.ID, problems[0].Field, problems[0].Message)
+		}
+		if questionIDs[question.ID] {
+			return fmt.Errorf("duplicate seed question ID: %s", question.ID)
+		}
+		questionIDs[question.ID] = true
+	}
+	
+	applicationIDs := make(map[string]bool)
+	for _, app := range seed.Applications {
+		if app.ID == "" {
+			return fmt.Errorf("seed application %q has no ID", app.Name)
+		}
+		if applicationIDs[app.ID] {
+			return fmt.Errorf("duplicate seed application ID: %s", app.ID)
+		}
+		applicationIDs[app.ID] = true
+	}
+	
+	return nil
+}
+
+// SeedStorage stores the seed categories, questions and applications whose names and IDs are
+// not in storage yet. Existing entities are never overwritten, so changes made through the API
+// survive restarts. Questions are skipped if they are served from a read-only catalog directory.
+func SeedStorage(ctx context.Context, store storage.Storage, seed *models.SeedData) (*models.SeedResult, error) {
+	result := &models.SeedResult{Categories: []string{}, Questions: []string{}, Applications: []string{}}
+	
+	for _, category := range seed.Categories {
+		existing, err := store.GetCategory(ctx, category.Name)
+		if err != nil {
+			return result, fmt.Errorf("failed to get category: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+		
+		if category.UpdatedAt == nil {
+			now := time.Now().UTC()
+			category.UpdatedAt = &now
+		}
+		if err := store.SaveCategory(ctx, category); err != nil {
+			return result, fmt.Errorf("failed to save category: %w", err)
+		}
+		result.Categories = append(result.Categories, category.Name)
+	}
+	
+	for i, question := range seed.Questions {
+		existing, err := store.GetQuestion(ctx, question.ID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get question: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+		
+		if err := store.SaveQuestion(ctx, question); err != nil {
+			if errors.Is(err, storage.ErrCatalogReadOnly) {
+				result.SkippedQuestions = len(seed.Questions) - i
+				break
+			}
+			return result, fmt.Errorf("failed to save question: %w", err)
+		}
+		result.Questions = append(result.Questions, question.ID)
+	}
+	
+	for _, app := range seed.Applications {
+		existing, err := store.GetApplication(ctx, app.ID)
+		if err != nil {
+			return result, fmt.Errorf("failed to get application: %w", err)
+		}
+		if existing != nil {
+			continue
+		}
+		
+		if app.Tags == nil {
+			app.Tags = map[string]string{}
+		}
+		if app.ExternalID, err = normalizeExternalID(app.ExternalID); err != nil {
+			return result, fmt.Errorf("application %s: %w", app.ID, err)
+		}
+		if app.ExternalID != "" {
+			owner, err := findApplicationByExternalID(ctx, store, app.ExternalID)
+			if err != nil {
+				return result, err
+			}
+			if owner != nil {
+				return result, fmt.Errorf("application %s: external ID %q belongs to application %s", app.ID, app.ExternalID, owner.ID)
+			}
+		}
+		stampApplication(app, nil, time.Now())
+		if err := store.SaveApplication(ctx, app); err != nil {
+			return result, fmt.Errorf("failed to save application: %w", err)
+		}
+		result.Applications = append(result.Applications, app.ID)
+	}
+	
+	return result, nil
+}
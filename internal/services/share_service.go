@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidShareTTL is returned for share links that would expire immediately or later than
+// the configured maximum
+var ErrInvalidShareTTL = errors.New("invalid share link lifetime")
+
+// ShareService issues and verifies signed read-only report links
+type ShareService struct {
+	storage    storage.ReportRepository
+	signingKey func() []byte
+	maxTTL     time.Duration
+}
+
+// NewShareService creates a new share service signing tokens with the key returned by
+// signingKey, which is consulted on every use so that rotated secrets take effect. Links
+// expire after at most maxTTL.
+func NewShareService(storage storage.ReportRepository, signingKey func() []byte, maxTTL time.Duration) *ShareService {
+	return &ShareService{
+		storage:    storage,
+		signingKey: signingKey,
+		maxTTL:     maxTTL,
+	}
+}
+
+// MaxTTL returns the longest lifetime of a share link
+func (s *ShareService) MaxTTL() time.Duration {
+	return s.maxTTL
+}
+
+// CreateShareToken generates a signed token granting read access to a report until it expires.
+// The report template is part of the signed payload, so link holders cannot widen their view.
+func (s *ShareService) CreateShareToken(ctx context.Context, assessmentID string, ttl time.Duration, templateID string) (string, time.Time, error) {
+	if ttl <= 0 || ttl > s.maxTTL {
+		return "", time.Time{}, fmt.Errorf("%w: links expire after at most %d hours", ErrInvalidShareTTL, s.maxTTL/time.Hour)
+	}
+	if err := ValidateReportTemplate(templateID); err != nil {
+		return "", time.Time{}, err
+	}
+	
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to get report: %w", err)
+	}
+	
+	if report == nil {
+		return "", time.Time{}, fmt.Errorf("report %w", ErrNotFound)
+	}
+	
+	expiresAt := time.Now().Add(ttl).UTC().Truncate(time.Second)
+	payload := assessmentID + "|" + strconv.FormatInt(expiresAt.Unix(), 10)
+	if templateID != "" {
+		payload += "|" + templateID
+	}
+	
+	token := base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + s.sign(payload)
+	return token, expiresAt, nil
+}
+
+// GetSharedReport verifies a share token and returns the report it grants access to, reduced to
+// the template the link was created with
+func (s *ShareService) GetSharedReport(ctx context.Context, token string) (*models.Report, error) {
+	encoded, signature, found := strings.Cut(token, ".")
+	if !found {
+		return nil, errors.New("malformed share token")
+	}
+	
+	payloadBytes, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, errors.New("malformed share token")
+	}
+	payload := string(payloadBytes)
+	
+	if !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return nil, errors.New("invalid share token signature")
+	}
+	
+	assessmentID, expiry, found := strings.Cut(payload, "|")
+	if !found {
+		return nil, errors.New("malformed share token")
+	}
+	
+	expiry, templateID, _ := strings.Cut(expiry, "|")
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return nil, errors.New("malformed share token")
+	}
+	
+	if time.Now().Unix() > expiresAt {
+		return nil, errors.New("share token has expired")
+	}
+	
+	report, err := s.storage.GetReport(ctx, assessmentID)
+	if err != nil || report == nil {
+		return report, err
+	}
+	return ApplyReportTemplate(report, templateID)
+}
+
+// sign computes the base64url HMAC-SHA256 signature of a payload
+func (s *ShareService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.signingKey())
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
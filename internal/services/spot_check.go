@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"questionnaire-app/internal/models"
+	"sort"
+)
+
+// ErrInvalidSpotCheck is returned when an assessment is started with an invalid spot check
+var ErrInvalidSpotCheck = errors.New("spot check is invalid")
+
+// sampleQuestions draws the questions of a spot check: up to PerCategory of the questions that
+// apply to the application from every category. The draw only depends on the seed and the
+// catalog, so the same seed yields the same sample. A zero seed is replaced by one derived
+// from the application ID, so repeated triage of an application sees the same questions.
+func sampleQuestions(spotCheck *models.SpotCheck, applicationID string, questions []*models.Question, tags map[string]string) (*models.SpotCheck, error) {
+	if spotCheck.PerCategory < 1 {
+		return nil, fmt.Errorf("%w: perCategory must be at least 1", ErrInvalidSpotCheck)
+	}
+	
+	sampled := &models.SpotCheck{PerCategory: spotCheck.PerCategory, Seed: spotCheck.Seed}
+	if sampled.Seed == 0 {
+		hash := fnv.New64a()
+		hash.Write([]byte(applicationID))
+		sampled.Seed = int64(hash.Sum64() >> 1)
+	}
+	
+	byCategory := make(map[string][]string)
+	for _, question := range questions {
+		if question.AppliesTo(tags) {
+			byCategory[question.Category] = append(byCategory[question.Category], question.ID)
+		}
+	}
+	
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	
+	rng := rand.New(rand.NewSource(sampled.Seed))
+	sampled.QuestionIDs = []string{}
+	for _, category := range categories {
+		ids := byCategory[category]
+		sort.Strings(ids)
+		rng.Shuffle(len(ids), func(i, j int) { ids[i], ids[j] = ids[j], ids[i] })
+		if len(ids) > sampled.PerCategory {
+			ids = ids[:sampled.PerCategory]
+		}
+		sampled.QuestionIDs = append(sampled.QuestionIDs, ids...)
+	}
+	sort.Strings(sampled.QuestionIDs)
+	
+	return sampled, nil
+}
+
+// inSpotCheck reports whether a question is part of an assessment: every question is, unless
+// the assessment is a spot check that did not sample it
+func inSpotCheck(assessment *models.Assessment, questionID string) bool {
+	if assessment.SpotCheck == nil {
+		return true
+	}
+	return containsString(assessment.SpotCheck.QuestionIDs, questionID)
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"time"
+)
+
+// StatsService reports the size and health of the instance to operators
+type StatsService struct {
+	storage storage.Storage
+}
+
+// NewStatsService creates a new stats service
+func NewStatsService(storage storage.Storage) *StatsService {
+	return &StatsService{storage: storage}
+}
+
+// InstanceStats counts the stored entities, reports generated recently and failing
+// notifications, and finds the assessment that has been in progress the longest
+func (s *StatsService) InstanceStats(ctx context.Context) (*models.InstanceStats, error) {
+	now := time.Now()
+	stats := &models.InstanceStats{
+		GeneratedAt: now,
+		Counts:      make(map[string]int),
+		Assessments: make(map[string]int),
+		Notifications: models.NotificationStats{
+			Failures: make(map[string]int),
+		},
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	stats.Counts["applications"] = len(apps)
+	
+	archetypes, err := s.storage.ListArchetypes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archetypes: %w", err)
+	}
+	stats.Counts["archetypes"] = len(archetypes)
+	
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	stats.Counts["questions"] = len(questions)
+	
+	campaigns, err := s.storage.ListCampaigns(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list campaigns: %w", err)
+	}
+	stats.Counts["campaigns"] = len(campaigns)
+	
+	jobs, err := s.storage.ListJobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list jobs: %w", err)
+	}
+	stats.Counts["jobs"] = len(jobs)
+	
+	subscriptions, err := s.storage.ListDigestSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list digest subscriptions: %w", err)
+	}
+	stats.Counts["digestSubscriptions"] = len(subscriptions)
+	
+	assessments, err := s.storage.ListAssessments(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list assessments: %w", err)
+	}
+	stats.Counts["assessments"] = len(assessments)
+	var oldestStart time.Time
+	for _, assessment := range assessments {
+		stats.Assessments[assessment.Status]++
+		if assessment.Status != "in_progress" {
+			continue
+		}
+		
+		started := assessment.StartedAt
+		if started.IsZero() {
+			started = assessment.CreatedAt
+		}
+		if stats.OldestOpen == nil || started.Before(oldestStart) {
+			oldestStart = started
+			stats.OldestOpen = &models.OldestOpenAssessment{
+				AssessmentID:  assessment.ID,
+				ApplicationID: assessment.ApplicationID,
+				StartedAt:     started,
+			}
+		}
+	}
+	if stats.OldestOpen != nil {
+		stats.OldestOpen.AgeDays = roundTenth(now.Sub(oldestStart).Hours() / 24)
+	}
+	
+	reports, err := s.storage.ListReports(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reports: %w", err)
+	}
+	stats.Counts["reports"] = len(reports)
+	stats.Reports.Total = len(reports)
+	for _, report := range reports {
+		age := now.Sub(report.GeneratedAt)
+		if age <= 24*time.Hour {
+			stats.Reports.Last24h++
+		}
+		if age <= 7*24*time.Hour {
+			stats.Reports.Last7Days++
+		}
+		if age <= 30*24*time.Hour {
+			stats.Reports.Last30Days++
+		}
+	}
+	
+	messages, err := s.storage.ListOutboxMessages(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	stats.Counts["outboxMessages"] = len(messages)
+	for _, message := range messages {
+		switch {
+		case message.Status == models.OutboxDelivered:
+			stats.Notifications.Delivered++
+		case message.Status == models.OutboxDead:
+			stats.Notifications.Dead++
+			stats.Notifications.Failures[message.Target]++
+		case message.Attempts > 0:
+			stats.Notifications.Pending++
+			stats.Notifications.Failing++
+			stats.Notifications.Failures[message.Target]++
+		default:
+			stats.Notifications.Pending++
+		}
+	}
+	
+	if sizer, ok := s.storage.(storage.SizeReporter); ok {
+		size, err := sizer.Size(ctx)
+		if err != nil {
+			return nil, err
+		}
+		stats.StorageBytes = &size
+	}
+	
+	return stats, nil
+}
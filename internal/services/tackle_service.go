@@ -0,0 +1,476 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Points given to imported Tackle answers by risk, and the score ratios exported as each risk
+var (
+	tackleRiskPoints  = map[string]int{"green": 10, "yellow": 5, "unknown": 2, "red": 0}
+	tackleGreenRatio  = 0.7
+	tackleYellowRatio = 0.4
+)
+
+// tackleUser is recorded as the author of imported assessments and answers
+const tackleUser = "tackle-import"
+
+var nonSlugChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// TackleService converts questionnaires, applications and assessments from and to the
+// Konveyor Tackle hub format
+type TackleService struct {
+	storage     storage.Storage
+	assessments *AssessmentService
+}
+
+// NewTackleService creates a new Tackle import/export service
+func NewTackleService(storage storage.Storage, assessments *AssessmentService) *TackleService {
+	return &TackleService{storage: storage, assessments: assessments}
+}
+
+// Import stores the questionnaires and applications of a Tackle bundle and replays its
+// assessments. With dryRun nothing is saved and the result lists what would be imported.
+// New applications that likely duplicate existing ones fail the import with
+// ErrDuplicateApplications unless force is set; the result lists them either way.
+func (s *TackleService) Import(ctx context.Context, bundle *models.TackleBundle, dryRun, force bool) (*models.TackleImportResult, error) {
+	result := &models.TackleImportResult{DryRun: dryRun, Warnings: []string{}}
+	
+	apps := make([]*models.Application, len(bundle.Applications))
+	for i := range bundle.Applications {
+		apps[i] = tackleApplication(&bundle.Applications[i])
+	}
+	duplicates, err := findDuplicateApplications(ctx, s.storage, apps)
+	if err != nil {
+		return nil, err
+	}
+	if len(duplicates) > 0 {
+		result.Duplicates = duplicates
+		if !dryRun && !force {
+			return result, ErrDuplicateApplications
+		}
+	}
+	
+	existing, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	catalog := make(map[string]*models.Question, len(existing))
+	for _, question := range existing {
+		catalog[question.ID] = question
+	}
+	
+	categories, err := categoryIndex(ctx, s.storage)
+	if err != nil {
+		return nil, err
+	}
+	
+	// Questionnaires become questions with one category per section
+	for _, questionnaire := range bundle.Questionnaires {
+		questions := tackleQuestions(&questionnaire)
+		for _, question := range questions {
+			if !dryRun {
+				if err := s.storage.SaveQuestion(ctx, question); err != nil {
+					return result, fmt.Errorf("failed to save question %s: %w", question.ID, err)
+				}
+			}
+			catalog[question.ID] = question
+			result.Questions++
+		}
+		
+		// Sections missing from the managed categories are defined alongside their questions
+		if !dryRun && categories != nil {
+			if _, err := addMissingCategories(ctx, s.storage, questions, categories); err != nil {
+				return result, err
+			}
+		}
+	}
+	
+	// Applications keep their Tackle ID in ours so assessments and re-imports can find them
+	imported := make(map[uint]string)
+	for i, tackleApp := range bundle.Applications {
+		app := apps[i]
+		if !dryRun {
+			existing, err := s.storage.GetApplication(ctx, app.ID)
+			if err != nil {
+				return result, fmt.Errorf("failed to get application %s: %w", app.Name, err)
+			}
+			stampApplication(app, existing, time.Now())
+			
+			if err := s.storage.SaveApplication(ctx, app); err != nil {
+				return result, fmt.Errorf("failed to save application %s: %w", app.Name, err)
+			}
+		}
+		imported[tackleApp.ID] = app.ID
+		result.Applications++
+	}
+	if !dryRun && len(bundle.Applications) > 0 {
+		invalidatePortfolioSummary(ctx, s.storage)
+	}
+	
+	for i, tackleAssessment := range bundle.Assessments {
+		if tackleAssessment.Status == "empty" {
+			continue
+		}
+		
+		applicationID, err := s.resolveApplication(ctx, tackleAssessment.Application, imported)
+		if err != nil {
+			return result, err
+		}
+		if applicationID == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("assessment %d: application not found, skipped", i+1))
+			continue
+		}
+		
+		answers := s.resolveAnswers(&tackleAssessment, catalog, i+1, result)
+		if !dryRun {
+			if err := s.replayAssessment(ctx, applicationID, answers, tackleAssessment.Status == "complete"); err != nil {
+				return result, fmt.Errorf("failed to import assessment %d: %w", i+1, err)
+			}
+		}
+		result.Assessments++
+	}
+	
+	return result, nil
+}
+
+// Export converts the question catalog, applications and assessments to a Tackle bundle
+func (s *TackleService) Export(ctx context.Context) (*models.TackleBundle, error) {
+	questions, err := s.storage.GetQuestions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get questions: %w", err)
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list applications: %w", err)
+	}
+	sort.Slice(apps, func(i, j int) bool { return apps[i].ID < apps[j].ID })
+	
+	questionnaire := exportQuestionnaire(questions)
+	bundle := &models.TackleBundle{
+		Questionnaires: []models.TackleQuestionnaire{questionnaire},
+		Applications:   []models.TackleApplication{},
+		Assessments:    []models.TackleAssessment{},
+	}
+	
+	for i, app := range apps {
+		tackleApp := models.TackleApplication{
+			ID:          uint(i + 1),
+			Name:        app.Name,
+			Description: app.Description,
+		}
+		if app.Repository != "" {
+			tackleApp.Repository = &models.TackleRepository{Kind: "git", URL: app.Repository}
+		}
+		for _, key := range sortedTagKeys(app.Tags) {
+			tackleApp.Tags = append(tackleApp.Tags, models.TackleTag{Name: key + "=" + app.Tags[key]})
+		}
+		bundle.Applications = append(bundle.Applications, tackleApp)
+		
+		assessments, err := s.storage.ListAssessments(ctx, app.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list assessments: %w", err)
+		}
+		
+		for _, assessment := range assessments {
+			status := "started"
+			if assessment.Status == "completed" || assessment.Status == "approved" {
+				status = "complete"
+			}
+			if len(assessment.Answers) == 0 && status == "started" {
+				status = "empty"
+			}
+			
+			exported := models.TackleAssessment{
+				Application:   &models.TackleRef{ID: tackleApp.ID, Name: app.Name},
+				Questionnaire: models.TackleRef{ID: questionnaire.ID, Name: questionnaire.Name},
+				Sections:      selectAnswers(questionnaire.Sections, questions, assessment.Answers),
+				Status:        status,
+			}
+			bundle.Assessments = append(bundle.Assessments, exported)
+		}
+	}
+	
+	return bundle, nil
+}
+
+// resolveApplication finds the application an imported assessment belongs to
+func (s *TackleService) resolveApplication(ctx context.Context, ref *models.TackleRef, imported map[uint]string) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	
+	if id, ok := imported[ref.ID]; ok {
+		return id, nil
+	}
+	
+	app, err := s.storage.GetApplication(ctx, tackleApplicationID(ref.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to get application: %w", err)
+	}
+	if app != nil {
+		return app.ID, nil
+	}
+	
+	if ref.Name == "" {
+		return "", nil
+	}
+	
+	apps, err := s.storage.ListApplications(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list applications: %w", err)
+	}
+	for _, app := range apps {
+		if app.Name == ref.Name {
+			return app.ID, nil
+		}
+	}
+	return "", nil
+}
+
+// resolveAnswers maps the selected Tackle answers to question and option IDs of the catalog,
+// matching by the IDs given to imported questionnaires and falling back to the question text
+func (s *TackleService) resolveAnswers(assessment *models.TackleAssessment, catalog map[string]*models.Question, index int, result *models.TackleImportResult) map[string]string {
+	byText := make(map[string]*models.Question, len(catalog))
+	for _, question := range catalog {
+		byText[question.Text] = question
+	}
+	
+	answers := make(map[string]string)
+	for _, section := range assessment.Sections {
+		for _, tackleQuestion := range section.Questions {
+			var selected *models.TackleAnswer
+			for i := range tackleQuestion.Answers {
+				if tackleQuestion.Answers[i].Selected {
+					selected = &tackleQuestion.Answers[i]
+					break
+				}
+			}
+			if selected == nil {
+				continue
+			}
+			
+			question := catalog[tackleQuestionID(assessment.Questionnaire.Name, section.Order, tackleQuestion.Order)]
+			if question == nil {
+				question = byText[tackleQuestion.Text]
+			}
+			if question == nil {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("assessment %d: no question matches %q", index, tackleQuestion.Text))
+				continue
+			}
+			
+			optionID := ""
+			for _, option := range question.Options {
+				if option.Text == selected.Text {
+					optionID = option.ID
+					break
+				}
+			}
+			if optionID == "" {
+				result.Warnings = append(result.Warnings, fmt.Sprintf("assessment %d: question %s has no option %q", index, question.ID, selected.Text))
+				continue
+			}
+			
+			answers[question.ID] = optionID
+		}
+	}
+	
+	return answers
+}
+
+// replayAssessment creates an assessment through the regular workflow so it gets an event
+// log and, if complete, a report
+func (s *TackleService) replayAssessment(ctx context.Context, applicationID string, answers map[string]string, complete bool) error {
+	assessment, err := s.assessments.StartAssessment(ctx, applicationID, StartOptions{StartedBy: tackleUser})
+	if err != nil {
+		return err
+	}
+	
+	questionIDs := make([]string, 0, len(answers))
+	for questionID := range answers {
+		questionIDs = append(questionIDs, questionID)
+	}
+	sort.Strings(questionIDs)
+	
+	for _, questionID := range questionIDs {
+		provenance := models.AnswerProvenance{Method: models.ProvenanceImported, Source: "tackle"}
+		if err := s.assessments.saveAnswer(ctx, assessment.ID, questionID, answers[questionID], "", nil, tackleUser, provenance); err != nil {
+			return err
+		}
+	}
+	
+	if complete {
+		if _, err := s.assessments.CompleteAssessment(ctx, assessment.ID); err != nil {
+			return err
+		}
+	}
+	
+	return nil
+}
+
+// tackleQuestions converts a Tackle questionnaire to questions
+func tackleQuestions(questionnaire *models.TackleQuestionnaire) []*models.Question {
+	var questions []*models.Question
+	for _, section := range questionnaire.Sections {
+		for _, tackleQuestion := range section.Questions {
+			question := &models.Question{
+				ID:       tackleQuestionID(questionnaire.Name, section.Order, tackleQuestion.Order),
+				Text:     tackleQuestion.Text,
+				Category: section.Name,
+				Weight:   1,
+				Help:     tackleQuestion.Explanation,
+			}
+			for _, answer := range tackleQuestion.Answers {
+				question.Options = append(question.Options, models.Option{
+					ID:     question.ID + "_a" + strconv.Itoa(int(answer.Order)),
+					Text:   answer.Text,
+					Points: tackleRiskPoints[answer.Risk],
+				})
+			}
+			questions = append(questions, question)
+		}
+	}
+	return questions
+}
+
+// tackleApplication converts a Tackle application; tags of the form key=value become tags
+// with that value, other tags are set to "true"
+func tackleApplication(tackleApp *models.TackleApplication) *models.Application {
+	app := &models.Application{
+		ID:          tackleApplicationID(tackleApp.ID),
+		Name:        tackleApp.Name,
+		Description: tackleApp.Description,
+		Tags:        make(map[string]string),
+	}
+	if tackleApp.Repository != nil {
+		app.Repository = tackleApp.Repository.URL
+	}
+	for _, tag := range tackleApp.Tags {
+		if key, value, ok := strings.Cut(tag.Name, "="); ok {
+			app.Tags[key] = value
+		} else {
+			app.Tags[tag.Name] = "true"
+		}
+	}
+	return app
+}
+
+// exportQuestionnaire converts the question catalog to a Tackle questionnaire with one
+// section per category
+func exportQuestionnaire(questions []*models.Question) models.TackleQuestionnaire {
+	byCategory := make(map[string][]*models.Question)
+	for _, question := range questions {
+		byCategory[question.Category] = append(byCategory[question.Category], question)
+	}
+	
+	categories := make([]string, 0, len(byCategory))
+	for category := range byCategory {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	
+	questionnaire := models.TackleQuestionnaire{
+		ID:          1,
+		Name:        "Kubernetes Readiness",
+		Description: "Exported from questionnaire-app",
+		Required:    true,
+		Sections:    []models.TackleSection{},
+	}
+	
+	for i, category := range categories {
+		section := models.TackleSection{Order: uint(i + 1), Name: category}
+		questions := byCategory[category]
+		sort.Slice(questions, func(a, b int) bool { return questions[a].ID < questions[b].ID })
+		
+		for j, question := range questions {
+			maxPoints := maxOptionPoints(question.Options)
+			tackleQuestion := models.TackleQuestion{Order: uint(j + 1), Text: question.Text, Explanation: question.Help}
+			for k, option := range question.Options {
+				tackleQuestion.Answers = append(tackleQuestion.Answers, models.TackleAnswer{
+					Order: uint(k + 1),
+					Text:  option.Text,
+					Risk:  tackleRisk(option.Points, maxPoints),
+				})
+			}
+			section.Questions = append(section.Questions, tackleQuestion)
+		}
+		questionnaire.Sections = append(questionnaire.Sections, section)
+	}
+	
+	return questionnaire
+}
+
+// selectAnswers copies questionnaire sections and marks the answers chosen in an assessment
+func selectAnswers(sections []models.TackleSection, questions []*models.Question, answers map[string]string) []models.TackleSection {
+	byText := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		byText[question.Category+"\x00"+question.Text] = question
+	}
+	
+	selected := make([]models.TackleSection, len(sections))
+	for i, section := range sections {
+		selected[i] = section
+		selected[i].Questions = make([]models.TackleQuestion, len(section.Questions))
+		for j, tackleQuestion := range section.Questions {
+			copied := tackleQuestion
+			copied.Answers = append([]models.TackleAnswer(nil), tackleQuestion.Answers...)
+			
+			if question := byText[section.Name+"\x00"+tackleQuestion.Text]; question != nil {
+				for k, option := range question.Options {
+					if k < len(copied.Answers) && answers[question.ID] == option.ID {
+						copied.Answers[k].Selected = true
+					}
+				}
+			}
+			selected[i].Questions[j] = copied
+		}
+	}
+	return selected
+}
+
+// tackleRisk classifies an option's points relative to the best option of its question
+func tackleRisk(points, maxPoints int) string {
+	if maxPoints <= 0 {
+		return "unknown"
+	}
+	
+	ratio := float64(points) / float64(maxPoints)
+	switch {
+	case ratio >= tackleGreenRatio:
+		return "green"
+	case ratio >= tackleYellowRatio:
+		return "yellow"
+	default:
+		return "red"
+	}
+}
+
+// tackleQuestionID derives a stable question ID from a Tackle questionnaire position
+func tackleQuestionID(questionnaire string, section, question uint) string {
+	slug := strings.Trim(nonSlugChars.ReplaceAllString(strings.ToLower(questionnaire), "-"), "-")
+	return fmt.Sprintf("tackle-%s-%d-%d", slug, section, question)
+}
+
+// tackleApplicationID derives the ID of an application imported from Tackle
+func tackleApplicationID(id uint) string {
+	return "tackle-" + strconv.FormatUint(uint64(id), 10)
+}
+
+// sortedTagKeys returns the keys of a tag map in order
+func sortedTagKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+	"time"
+	
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// wasmMemoryLimitPages caps the memory of a scoring module instance at 64 MiB
+const wasmMemoryLimitPages = 1024
+
+// wasmPluginTimeout bounds one evaluation of a scoring module; the instance is stopped when
+// it runs out
+const wasmPluginTimeout = 5 * time.Second
+
+// wasmMaxOutput caps the result a scoring module can return
+const wasmMaxOutput = 1 << 20
+
+// ErrUnknownScoringPlugin is returned when a scoring configuration names a module the plugins
+// directory does not contain, or WASM plugins are not enabled
+var ErrUnknownScoringPlugin = errors.New("unknown scoring plugin")
+
+// WasmPlugins runs the scoring modules of a plugins directory. Modules are compiled once and
+// instantiated afresh for every evaluation, so no state carries over between reports. They
+// get WASI without a file system, network, environment, real clock or real randomness, so the
+// same input always produces the same score.
+type WasmPlugins struct {
+	runtime wazero.Runtime
+	modules map[string]*wasmModule
+}
+
+// wasmModule is a compiled scoring module
+type wasmModule struct {
+	compiled wazero.CompiledModule
+	digest   string
+}
+
+// LoadWasmPlugins compiles every .wasm file of a directory. A module must export its memory,
+// alloc(size i32) i32 returning a buffer for the input, and evaluate(ptr i32, len i32) i64
+// returning the location of its output as ptr<<32 | len.
+func LoadWasmPlugins(ctx context.Context, dir string) (*WasmPlugins, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read WASM plugins directory: %w", err)
+	}
+	
+	config := wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(wasmMemoryLimitPages).
+		WithCloseOnContextDone(true)
+	plugins := &WasmPlugins{
+		runtime: wazero.NewRuntimeWithConfig(ctx, config),
+		modules: make(map[string]*wasmModule),
+	}
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, plugins.runtime); err != nil {
+		plugins.Close(ctx)
+		return nil, fmt.Errorf("failed to set up WASI: %w", err)
+	}
+	
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".wasm" {
+			continue
+		}
+		name := strings.TrimSuffix(entry.Name(), ".wasm")
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			plugins.Close(ctx)
+			return nil, fmt.Errorf("failed to read WASM plugin %s: %w", name, err)
+		}
+		
+		compiled, err := plugins.runtime.CompileModule(ctx, data)
+		if err != nil {
+			plugins.Close(ctx)
+			return nil, fmt.Errorf("failed to compile WASM plugin %s: %w", name, err)
+		}
+		exports := compiled.ExportedFunctions()
+		for _, function := range []string{"alloc", "evaluate"} {
+			if _, ok := exports[function]; !ok {
+				plugins.Close(ctx)
+				return nil, fmt.Errorf("WASM plugin %s does not export %s", name, function)
+			}
+		}
+		
+		digest := sha256.Sum256(data)
+		plugins.modules[name] = &wasmModule{compiled: compiled, digest: hex.EncodeToString(digest[:])}
+	}
+	return plugins, nil
+}
+
+// Modules returns the names of the loaded modules
+func (p *WasmPlugins) Modules() []string {
+	names := make([]string, 0, len(p.modules))
+	for name := range p.modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Close releases the compiled modules
+func (p *WasmPlugins) Close(ctx context.Context) error {
+	return p.runtime.Close(ctx)
+}
+
+// Evaluate runs a module on a JSON input and returns its JSON output
+func (p *WasmPlugins) Evaluate(ctx context.Context, name string, input []byte) ([]byte, error) {
+	module, ok := p.modules[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownScoringPlugin, name)
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, wasmPluginTimeout)
+	defer cancel()
+	
+	// An anonymous instance, so evaluations of the same module can run concurrently
+	instance, err := p.runtime.InstantiateModule(ctx, module.compiled, wazero.NewModuleConfig().
+		WithName("").
+		WithStartFunctions("_initialize"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate: %w", err)
+	}
+	defer instance.Close(ctx)
+	
+	memory := instance.Memory()
+	if memory == nil {
+		return nil, errors.New("module does not export its memory")
+	}
+	results, err := instance.ExportedFunction("alloc").Call(ctx, uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("alloc failed: %w", err)
+	}
+	ptr := uint32(results[0])
+	if !memory.Write(ptr, input) {
+		return nil, errors.New("alloc returned a buffer outside the module's memory")
+	}
+	
+	results, err = instance.ExportedFunction("evaluate").Call(ctx, uint64(ptr), uint64(len(input)))
+	if err != nil {
+		return nil, fmt.Errorf("evaluate failed: %w", err)
+	}
+	return readWasmOutput(memory, results[0])
+}
+
+// readWasmOutput copies the output a module returned as ptr<<32 | len out of its memory
+func readWasmOutput(memory api.Memory, location uint64) ([]byte, error) {
+	ptr, size := uint32(location>>32), uint32(location)
+	if size > wasmMaxOutput {
+		return nil, fmt.Errorf("output of %d bytes exceeds the limit of %d", size, wasmMaxOutput)
+	}
+	output, ok := memory.Read(ptr, size)
+	if !ok {
+		return nil, errors.New("output lies outside the module's memory")
+	}
+	return append([]byte(nil), output...), nil
+}
+
+// digest returns the SHA-256 of a module
+func (p *WasmPlugins) digest(name string) string {
+	if module, ok := p.modules[name]; ok {
+		return module.digest
+	}
+	return ""
+}
+
+// WithScoringPlugins runs the WASM modules named by the scoring configuration from plugins
+func WithScoringPlugins(plugins *WasmPlugins) AssessmentOption {
+	return func(s *AssessmentService) {
+		s.scoringPlugins = plugins
+	}
+}
+
+// scoringPluginInput is the JSON a scoring module is evaluated on
+type scoringPluginInput struct {
+	Config            map[string]any         `json:"config,omitempty"`
+	ApplicationID     string                 `json:"applicationId"`
+	Tags              map[string]string      `json:"tags,omitempty"`
+	Answers           map[string]string      `json:"answers"`
+	Breakdown         []models.QuestionScore `json:"breakdown"`
+	TotalScore        int                    `json:"totalScore"`
+	MaxPossibleScore  int                    `json:"maxPossibleScore"`
+	CategoryScores    map[string]int         `json:"categoryScores"`
+	CategoryMaxScores map[string]int         `json:"categoryMaxScores"`
+}
+
+// scoringPluginOutput is what a scoring module returns. Scores it leaves out are kept.
+type scoringPluginOutput struct {
+	TotalScore      *int                    `json:"totalScore,omitempty"`
+	CategoryScores  map[string]int          `json:"categoryScores,omitempty"`
+	Recommendations []models.Recommendation `json:"recommendations,omitempty"`
+	Risks           []models.Risk           `json:"risks,omitempty"`
+}
+
+// validateScoringPlugins checks that every module a scoring configuration names is loaded
+func (s *AssessmentService) validateScoringPlugins(config *models.ScoringConfig) error {
+	for _, plugin := range config.Plugins {
+		if s.scoringPlugins == nil {
+			return fmt.Errorf("%w: %s (WASM plugins are not enabled)", ErrUnknownScoringPlugin, plugin.Module)
+		}
+		if _, ok := s.scoringPlugins.modules[plugin.Module]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownScoringPlugin, plugin.Module)
+		}
+	}
+	return nil
+}
+
+// runScoringPlugins runs the configured scoring modules in order, each on the scores the
+// previous one left, and records them on the report. Scores are changed in place; the
+// recommendations and risks the modules add are returned, to be added after the built-in
+// ones. A failing module fails report generation, since the report would otherwise carry a
+// score its configuration does not produce.
+func (s *AssessmentService) runScoringPlugins(ctx context.Context, config *models.ScoringConfig, assessment *models.Assessment, tags map[string]string, report *models.Report, categoryMaxScores map[string]int) (*scoringPluginOutput, error) {
+	added := &scoringPluginOutput{}
+	if len(config.Plugins) == 0 {
+		return added, nil
+	}
+	if err := s.validateScoringPlugins(config); err != nil {
+		return nil, err
+	}
+	
+	for _, plugin := range config.Plugins {
+		input, err := json.Marshal(scoringPluginInput{
+			Config:            plugin.Config,
+			ApplicationID:     assessment.ApplicationID,
+			Tags:              tags,
+			Answers:           assessment.Answers,
+			Breakdown:         report.Breakdown,
+			TotalScore:        report.TotalScore,
+			MaxPossibleScore:  report.MaxPossibleScore,
+			CategoryScores:    report.CategoryScores,
+			CategoryMaxScores: categoryMaxScores,
+		})
+		if err != nil {
+			return nil, err
+		}
+		
+		data, err := s.scoringPlugins.Evaluate(ctx, plugin.Module, input)
+		if err != nil {
+			return nil, fmt.Errorf("scoring plugin %s: %w", plugin.Module, err)
+		}
+		var output scoringPluginOutput
+		if err := json.Unmarshal(data, &output); err != nil {
+			return nil, fmt.Errorf("scoring plugin %s returned invalid output: %w", plugin.Module, err)
+		}
+		if err := checkPluginScores(&output, report.MaxPossibleScore, categoryMaxScores); err != nil {
+			return nil, fmt.Errorf("scoring plugin %s: %w", plugin.Module, err)
+		}
+		
+		applied := models.AppliedPlugin{
+			Module:      plugin.Module,
+			Digest:      s.scoringPlugins.digest(plugin.Module),
+			ScoreBefore: report.TotalScore,
+		}
+		if output.TotalScore != nil {
+			report.TotalScore = *output.TotalScore
+		}
+		for category, score := range output.CategoryScores {
+			report.CategoryScores[category] = score
+		}
+		applied.ScoreAfter = report.TotalScore
+		report.ScoringPlugins = append(report.ScoringPlugins, applied)
+		
+		added.Recommendations = append(added.Recommendations, output.Recommendations...)
+		added.Risks = append(added.Risks, output.Risks...)
+	}
+	return added, nil
+}
+
+// checkPluginScores rejects scores above the maximum or for categories the report does not
+// score
+func checkPluginScores(output *scoringPluginOutput, maxScore int, categoryMaxScores map[string]int) error {
+	if output.TotalScore != nil && *output.TotalScore > maxScore {
+		return fmt.Errorf("total score %d exceeds the maximum of %d", *output.TotalScore, maxScore)
+	}
+	for category, score := range output.CategoryScores {
+		max, ok := categoryMaxScores[category]
+		if !ok {
+			return fmt.Errorf("category %s is not scored in this report", category)
+		}
+		if score > max {
+			return fmt.Errorf("score %d of category %s exceeds its maximum of %d", score, category, max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"questionnaire-app/internal/models"
+)
+
+// Sources of weight overrides recorded on assessments
+const (
+	WeightSourceAssessment = "assessment"
+	weightSourceClass      = "class:"
+)
+
+// LoadWeightProfiles reads weight override profiles keyed by application class from a JSON file
+func LoadWeightProfiles(path string) (map[string][]models.WeightOverride, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read weight profiles: %w", err)
+	}
+	
+	var profiles map[string][]models.WeightOverride
+	if err := json.Unmarshal(data, &profiles); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal weight profiles: %w", err)
+	}
+	
+	for class, overrides := range profiles {
+		if err := validateWeightOverrides(overrides); err != nil {
+			return nil, fmt.Errorf("invalid weight profile %q: %w", class, err)
+		}
+	}
+	
+	return profiles, nil
+}
+
+// SetWeightOverrides replaces the assessment-specific weight overrides of an assessment.
+// Overrides inherited from the application class are kept.
+func (s *AssessmentService) SetWeightOverrides(ctx context.Context, assessmentID string, overrides []models.WeightOverride) (*models.Assessment, error) {
+	if err := validateWeightOverrides(overrides); err != nil {
+		return nil, err
+	}
+	
+	unlock, err := s.lockAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	
+	if assessment == nil {
+		return nil, errors.New("assessment not found")
+	}
+	
+	if assessment.Status == "approved" {
+		return nil, ErrAssessmentApproved
+	}
+	
+	merged := []models.WeightOverride{}
+	for _, override := range assessment.WeightOverrides {
+		if override.Source != WeightSourceAssessment {
+			merged = append(merged, override)
+		}
+	}
+	for _, override := range overrides {
+		override.Source = WeightSourceAssessment
+		merged = append(merged, override)
+	}
+	
+	state, err := s.appendEvent(ctx, assessment, &models.AssessmentEvent{
+		Type:            models.EventWeightsOverridden,
+		WeightOverrides: merged,
+	})
+	if err != nil {
+		return nil, err
+	}
+	
+	if err := s.storage.UpdateAssessment(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to update assessment: %w", err)
+	}
+	
+	return state, nil
+}
+
+// initialWeightOverrides combines the application class profile with overrides requested
+// when the assessment is started
+func (s *AssessmentService) initialWeightOverrides(app *models.Application, requested []models.WeightOverride) []models.WeightOverride {
+	var overrides []models.WeightOverride
+	
+	if class := app.Tags["class"]; class != "" {
+		for _, override := range s.weightProfiles[class] {
+			override.Source = weightSourceClass + class
+			overrides = append(overrides, override)
+		}
+	}
+	
+	for _, override := range requested {
+		override.Source = WeightSourceAssessment
+		overrides = append(overrides, override)
+	}
+	
+	return overrides
+}
+
+// validateWeightOverrides checks each override targets exactly one question or category
+func validateWeightOverrides(overrides []models.WeightOverride) error {
+	for _, override := range overrides {
+		if (override.QuestionID == "") == (override.Category == "") {
+			return errors.New("weight override must target either a questionId or a category")
+		}
+		
+		if override.Weight < 0 {
+			return fmt.Errorf("weight override for %s%s must not be negative", override.QuestionID, override.Category)
+		}
+	}
+	
+	return nil
+}
+
+// effectiveWeightOverride returns the override applying to a question, if any. Question
+// overrides win over category overrides, and later overrides win over earlier ones, so
+// assessment-specific overrides take precedence over class defaults.
+func effectiveWeightOverride(question *models.Question, overrides []models.WeightOverride) *models.WeightOverride {
+	var match *models.WeightOverride
+	
+	for i := range overrides {
+		override := &overrides[i]
+		if override.QuestionID == question.ID {
+			match = override
+		} else if override.Category == question.Category && (match == nil || match.QuestionID == "") {
+			match = override
+		}
+	}
+	
+	if match != nil && match.Weight == question.Weight {
+		return nil
+	}
+	return match
+}
@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Workshop errors
+var (
+	// ErrInvalidWorkshop is returned for workshop requests that fail validation
+	ErrInvalidWorkshop = errors.New("invalid workshop request")
+	
+	// ErrNotFacilitator is returned when someone other than the facilitator controls a workshop
+	ErrNotFacilitator = errors.New("only the workshop's facilitator can do this")
+)
+
+// StartWorkshop runs an assessment in workshop mode with the given user as its facilitator.
+// The workshop goes through the assessment's questions in catalog order, starting at the first
+// one without an answer. A closed workshop is replaced; an open one is a conflict.
+func (s *AssessmentService) StartWorkshop(ctx context.Context, assessmentID, facilitator string) (*models.WorkshopView, error) {
+	if facilitator == "" {
+		return nil, fmt.Errorf("%w: the facilitator must be identified by X-Forwarded-User", ErrInvalidWorkshop)
+	}
+	
+	unlock, err := s.lockWorkshop(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	existing, err := s.storage.GetWorkshop(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workshop: %w", err)
+	}
+	if existing != nil && existing.Status == models.WorkshopOpen {
+		return nil, fmt.Errorf("%w: a workshop facilitated by %s is already open", ErrConflict, existing.Facilitator)
+	}
+	
+	assessment, err := s.storage.GetAssessment(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assessment: %w", err)
+	}
+	if assessment == nil {
+		return nil, fmt.Errorf("assessment %w", ErrNotFound)
+	}
+	if assessment.Status == "approved" {
+		return nil, ErrAssessmentApproved
+	}
+	
+	questions, err := s.GetAssessmentQuestions(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) == 0 {
+		return nil, fmt.Errorf("%w: the assessment has no questions", ErrInvalidWorkshop)
+	}
+	
+	workshop := &models.Workshop{
+		AssessmentID: assessmentID,
+		Facilitator:  facilitator,
+		Status:       models.WorkshopOpen,
+		Votes:        make(map[string]map[string]string),
+		Decisions:    make(map[string]models.WorkshopDecision),
+		StartedAt:    time.Now(),
+	}
+	current := -1
+	for i, question := range questions {
+		workshop.QuestionIDs = append(workshop.QuestionIDs, question.ID)
+		if _, answered := assessment.Answers[question.ID]; !answered && current < 0 {
+			current = i
+		}
+	}
+	if current > 0 {
+		workshop.Current = current
+	}
+	
+	if err := s.storage.SaveWorkshop(ctx, workshop); err != nil {
+		return nil, fmt.Errorf("failed to save workshop: %w", err)
+	}
+	return s.workshopView(ctx, workshop, facilitator)
+}
+
+// GetWorkshop returns the state of an assessment's workshop as seen by a user
+func (s *AssessmentService) GetWorkshop(ctx context.Context, assessmentID, user string) (*models.WorkshopView, error) {
+	workshop, err := s.storage.GetWorkshop(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workshop: %w", err)
+	}
+	if workshop == nil {
+		return nil, fmt.Errorf("workshop %w", ErrNotFound)
+	}
+	return s.workshopView(ctx, workshop, user)
+}
+
+// MoveWorkshop lets the facilitator move to another question; votes already cast on a
+// question are kept when coming back to it
+func (s *AssessmentService) MoveWorkshop(ctx context.Context, assessmentID, user string, move models.WorkshopMove) (*models.WorkshopView, error) {
+	return s.updateWorkshop(ctx, assessmentID, user, func(workshop *models.Workshop) error {
+		if workshop.Facilitator != user {
+			return ErrNotFacilitator
+		}
+		
+		if move.Direction == "" {
+			move.Direction = "next"
+		}
+		target := workshop.Current
+		switch {
+		case move.QuestionID != "":
+			target = indexOf(workshop.QuestionIDs, move.QuestionID)
+			if target < 0 {
+				return fmt.Errorf("%w: question %s is not part of the workshop", ErrInvalidWorkshop, move.QuestionID)
+			}
+		case move.Direction == "next":
+			target++
+		case move.Direction == "previous":
+			target--
+		default:
+			return fmt.Errorf("%w: direction must be next or previous", ErrInvalidWorkshop)
+		}
+		if target < 0 || target >= len(workshop.QuestionIDs) {
+			return fmt.Errorf("%w: there is no %s question", ErrInvalidWorkshop, move.Direction)
+		}
+		
+		workshop.Current = target
+		return nil
+	})
+}
+
+// VoteInWorkshop records a participant's vote on the current question, replacing an earlier
+// vote on it
+func (s *AssessmentService) VoteInWorkshop(ctx context.Context, assessmentID, participant string, vote models.WorkshopVote) (*models.WorkshopView, error) {
+	if participant == "" {
+		return nil, fmt.Errorf("%w: participants must be identified by X-Forwarded-User", ErrInvalidWorkshop)
+	}
+	
+	return s.updateWorkshop(ctx, assessmentID, participant, func(workshop *models.Workshop) error {
+		questionID := workshop.QuestionIDs[workshop.Current]
+		if vote.QuestionID != "" && vote.QuestionID != questionID {
+			return fmt.Errorf("%w: voting is open on question %s, not %s", ErrConflict, questionID, vote.QuestionID)
+		}
+		
+		question, err := s.storage.GetQuestion(ctx, questionID)
+		if err != nil {
+			return fmt.Errorf("failed to get question: %w", err)
+		}
+		if question == nil || findOption(question.Options, vote.OptionID) == nil {
+			return fmt.Errorf("%w: option %q is not an option of question %s", ErrInvalidWorkshop, vote.OptionID, questionID)
+		}
+		
+		if workshop.Votes == nil {
+			workshop.Votes = make(map[string]map[string]string)
+		}
+		if workshop.Votes[questionID] == nil {
+			workshop.Votes[questionID] = make(map[string]string)
+		}
+		workshop.Votes[questionID][participant] = vote.OptionID
+		return nil
+	})
+}
+
+// DecideWorkshopQuestion lets the facilitator record the consensus on the current question as
+// the assessment's answer, with the vote distribution as its provenance, and moves on to the
+// next question. Without an option, the option with the most votes is recorded.
+func (s *AssessmentService) DecideWorkshopQuestion(ctx context.Context, assessmentID, user string, request models.WorkshopDecisionRequest) (*models.WorkshopView, error) {
+	return s.updateWorkshop(ctx, assessmentID, user, func(workshop *models.Workshop) error {
+		if workshop.Facilitator != user {
+			return ErrNotFacilitator
+		}
+		
+		questionID := workshop.QuestionIDs[workshop.Current]
+		distribution := voteDistribution(workshop.Votes[questionID])
+		optionID := request.OptionID
+		if optionID == "" {
+			leaders := mostVoted(distribution)
+			switch len(leaders) {
+			case 0:
+				return fmt.Errorf("%w: there are no votes on question %s; choose an option", ErrInvalidWorkshop, questionID)
+			case 1:
+				optionID = leaders[0]
+			default:
+				return fmt.Errorf("%w: the votes on question %s are tied between %s; choose an option", ErrInvalidWorkshop, questionID, strings.Join(leaders, ", "))
+			}
+		}
+		
+		participants := len(workshop.Votes[questionID])
+		decision := models.WorkshopDecision{
+			OptionID:     optionID,
+			Votes:        distribution,
+			Participants: participants,
+			DecidedBy:    user,
+			DecidedAt:    time.Now(),
+		}
+		if participants > 0 {
+			decision.Agreement = distribution[optionID] * 100 / participants
+		}
+		
+		provenance := models.AnswerProvenance{
+			Method:     models.ProvenanceWorkshop,
+			Source:     "workshop",
+			Confidence: float64(decision.Agreement) / 100,
+			Votes:      distribution,
+		}
+		if err := s.saveAnswer(ctx, assessmentID, questionID, optionID, request.Explanation, nil, user, provenance); err != nil {
+			return err
+		}
+		
+		if workshop.Decisions == nil {
+			workshop.Decisions = make(map[string]models.WorkshopDecision)
+		}
+		workshop.Decisions[questionID] = decision
+		if workshop.Current < len(workshop.QuestionIDs)-1 {
+			workshop.Current++
+		}
+		return nil
+	})
+}
+
+// CloseWorkshop ends a workshop; its decisions stay with the assessment's answers
+func (s *AssessmentService) CloseWorkshop(ctx context.Context, assessmentID, user string) (*models.WorkshopView, error) {
+	return s.updateWorkshop(ctx, assessmentID, user, func(workshop *models.Workshop) error {
+		if workshop.Facilitator != user {
+			return ErrNotFacilitator
+		}
+		workshop.Status = models.WorkshopClosed
+		closedAt := time.Now()
+		workshop.ClosedAt = &closedAt
+		return nil
+	})
+}
+
+// lockWorkshop takes the lock guarding an assessment's workshop. It is taken before the
+// assessment's lock when both are needed.
+func (s *AssessmentService) lockWorkshop(ctx context.Context, assessmentID string) (func(), error) {
+	ctx, cancel := context.WithTimeout(ctx, lockTimeout)
+	defer cancel()
+	
+	unlock, err := s.locker.Lock(ctx, "workshop-"+assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock workshop: %w", err)
+	}
+	return unlock, nil
+}
+
+// updateWorkshop applies update to an open workshop under its lock and returns the saved
+// state as seen by the user
+func (s *AssessmentService) updateWorkshop(ctx context.Context, assessmentID, user string, update func(workshop *models.Workshop) error) (*models.WorkshopView, error) {
+	unlock, err := s.lockWorkshop(ctx, assessmentID)
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	
+	workshop, err := s.storage.GetWorkshop(ctx, assessmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workshop: %w", err)
+	}
+	if workshop == nil {
+		return nil, fmt.Errorf("workshop %w", ErrNotFound)
+	}
+	if workshop.Status != models.WorkshopOpen {
+		return nil, fmt.Errorf("%w: the workshop is closed", ErrConflict)
+	}
+	
+	if err := update(workshop); err != nil {
+		return nil, err
+	}
+	if err := s.storage.SaveWorkshop(ctx, workshop); err != nil {
+		return nil, fmt.Errorf("failed to save workshop: %w", err)
+	}
+	return s.workshopView(ctx, workshop, user)
+}
+
+// workshopView shows a workshop to a user: vote counts of the current question, but only the
+// user's own vote
+func (s *AssessmentService) workshopView(ctx context.Context, workshop *models.Workshop, user string) (*models.WorkshopView, error) {
+	view := &models.WorkshopView{
+		AssessmentID: workshop.AssessmentID,
+		Facilitator:  workshop.Facilitator,
+		Status:       workshop.Status,
+		Questions:    len(workshop.QuestionIDs),
+		Decided:      len(workshop.Decisions),
+		Votes:        map[string]int{},
+		Decisions:    workshop.Decisions,
+		StartedAt:    workshop.StartedAt,
+		ClosedAt:     workshop.ClosedAt,
+	}
+	if view.Decisions == nil {
+		view.Decisions = map[string]models.WorkshopDecision{}
+	}
+	if workshop.Current < 0 || workshop.Current >= len(workshop.QuestionIDs) {
+		return view, nil
+	}
+	
+	questionID := workshop.QuestionIDs[workshop.Current]
+	question, err := s.storage.GetQuestion(ctx, questionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %w", err)
+	}
+	
+	votes := workshop.Votes[questionID]
+	view.Position = workshop.Current + 1
+	view.Question = question
+	view.Votes = voteDistribution(votes)
+	view.Participants = len(votes)
+	view.MyVote = votes[user]
+	if decision, ok := workshop.Decisions[questionID]; ok {
+		view.Decision = &decision
+	}
+	return view, nil
+}
+
+// voteDistribution counts the votes per option
+func voteDistribution(votes map[string]string) map[string]int {
+	distribution := make(map[string]int)
+	for _, optionID := range votes {
+		distribution[optionID]++
+	}
+	return distribution
+}
+
+// mostVoted returns the options with the most votes, sorted
+func mostVoted(distribution map[string]int) []string {
+	most := 0
+	var leaders []string
+	for optionID, votes := range distribution {
+		switch {
+		case votes > most:
+			most = votes
+			leaders = []string{optionID}
+		case votes == most:
+			leaders = append(leaders, optionID)
+		}
+	}
+	sort.Strings(leaders)
+	return leaders
+}
+
+// indexOf returns the position of value in values, or -1
+func indexOf(values []string, value string) int {
+	for i, candidate := range values {
+		if candidate == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxWorkbookPartSize limits the size of a single uncompressed part of a workbook, guarding
+// against archives that expand to far more than was uploaded
+const maxWorkbookPartSize = 64 << 20
+
+// workbookSheet is a worksheet of an XLSX workbook with its cells as text
+type workbookSheet struct {
+	Name string
+	Rows []workbookRow
+}
+
+// workbookRow is a row of a worksheet; Number is the row number shown in spreadsheet programs
+type workbookRow struct {
+	Number int
+	Cells  []string // by column, starting at column A
+}
+
+// Cell returns the text of a cell of the row, or "" if it is empty
+func (r workbookRow) Cell(column int) string {
+	if column < 0 || column >= len(r.Cells) {
+		return ""
+	}
+	return r.Cells[column]
+}
+
+// XML of the workbook parts read by readWorkbook
+type (
+	xlsxWorkbook struct {
+		Sheets []struct {
+			Name string `xml:"name,attr"`
+			RID  string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+		} `xml:"sheets>sheet"`
+	}
+	xlsxRelationships struct {
+		Relationships []struct {
+			ID     string `xml:"Id,attr"`
+			Target string `xml:"Target,attr"`
+		} `xml:"Relationship"`
+	}
+	xlsxText struct {
+		Text string `xml:"t"`
+		Runs []struct {
+			Text string `xml:"t"`
+		} `xml:"r"`
+	}
+	xlsxSharedStrings struct {
+		Items []xlsxText `xml:"si"`
+	}
+	xlsxWorksheet struct {
+		Rows []struct {
+			Number int `xml:"r,attr"`
+			Cells  []struct {
+				Ref    string   `xml:"r,attr"`
+				Type   string   `xml:"t,attr"`
+				Value  string   `xml:"v"`
+				Inline xlsxText `xml:"is"`
+			} `xml:"c"`
+		} `xml:"sheetData>row"`
+	}
+)
+
+// String returns the text of a plain or rich text string
+func (t xlsxText) String() string {
+	if len(t.Runs) == 0 {
+		return t.Text
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+// readWorkbook reads the worksheets of an XLSX workbook in workbook order. Only cell values are
+// read: formulas contribute their cached result, and formatting is ignored.
+func readWorkbook(data []byte) ([]workbookSheet, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.New("not an XLSX workbook")
+	}
+	parts := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		parts[strings.TrimPrefix(file.Name, "/")] = file
+	}
+	
+	var workbook xlsxWorkbook
+	if err := readWorkbookPart(parts, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	var relationships xlsxRelationships
+	if err := readWorkbookPart(parts, "xl/_rels/workbook.xml.rels", &relationships); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(relationships.Relationships))
+	for _, relationship := range relationships.Relationships {
+		target := relationship.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[relationship.ID] = target
+	}
+	
+	var sharedStrings xlsxSharedStrings
+	if _, ok := parts["xl/sharedStrings.xml"]; ok {
+		if err := readWorkbookPart(parts, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, err
+		}
+	}
+	
+	sheets := make([]workbookSheet, 0, len(workbook.Sheets))
+	for _, entry := range workbook.Sheets {
+		target, ok := targets[entry.RID]
+		if !ok {
+			return nil, fmt.Errorf("sheet %q has no worksheet", entry.Name)
+		}
+		var worksheet xlsxWorksheet
+		if err := readWorkbookPart(parts, target, &worksheet); err != nil {
+			return nil, err
+		}
+		
+		sheet := workbookSheet{Name: entry.Name}
+		for i, xmlRow := range worksheet.Rows {
+			row := workbookRow{Number: xmlRow.Number}
+			if row.Number == 0 {
+				row.Number = i + 1
+			}
+			for j, cell := range xmlRow.Cells {
+				column := j
+				if cell.Ref != "" {
+					if column, err = cellColumn(cell.Ref); err != nil {
+						return nil, fmt.Errorf("sheet %q: %w", entry.Name, err)
+					}
+				}
+				
+				value := cell.Value
+				switch cell.Type {
+				case "s":
+					index, err := strconv.Atoi(cell.Value)
+					if err != nil || index < 0 || index >= len(sharedStrings.Items) {
+						return nil, fmt.Errorf("sheet %q: cell %s refers to an unknown string", entry.Name, cell.Ref)
+					}
+					value = sharedStrings.Items[index].String()
+				case "inlineStr":
+					value = cell.Inline.String()
+				case "b":
+					value = map[string]string{"0": "FALSE", "1": "TRUE"}[cell.Value]
+				}
+				
+				for len(row.Cells) <= column {
+					row.Cells = append(row.Cells, "")
+				}
+				row.Cells[column] = value
+			}
+			sheet.Rows = append(sheet.Rows, row)
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
+
+// readWorkbookPart decodes an XML part of a workbook
+func readWorkbookPart(parts map[string]*zip.File, name string, v interface{}) error {
+	file, ok := parts[name]
+	if !ok {
+		return fmt.Errorf("workbook has no %s", name)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", name, err)
+	}
+	defer reader.Close()
+	
+	data, err := io.ReadAll(io.LimitReader(reader, maxWorkbookPartSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if len(data) > maxWorkbookPartSize {
+		return fmt.Errorf("%s is too large", name)
+	}
+	if err := xml.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+	return nil
+}
+
+// cellColumn returns the zero-based column of a cell reference such as C12
+func cellColumn(ref string) (int, error) {
+	column := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		column = column*26 + int(r-'A'+1)
+		letters++
+	}
+	if letters == 0 || letters > 3 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return column - 1, nil
+}
+
+// cellName returns the reference of a cell, e.g. C12 for column 2 of row 12
+func cellName(column, row int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name + strconv.Itoa(row)
+}
@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CatalogStorage serves questions from an in-memory catalog loaded from a directory, such
+// as a mounted Kubernetes ConfigMap, and delegates everything else to the wrapped Storage
+type CatalogStorage struct {
+	Storage
+	dir string
+	
+	mu          sync.RWMutex
+	questions   []*models.Question
+	byID        map[string]*models.Question
+	fingerprint string
+}
+
+// NewCatalogStorage wraps a storage backend with a question catalog read from dir
+func NewCatalogStorage(base Storage, dir string) (*CatalogStorage, error) {
+	s := &CatalogStorage{Storage: base, dir: dir}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetQuestions returns all questions in the catalog
+func (s *CatalogStorage) GetQuestions(ctx context.Context) ([]*models.Question, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	questions := make([]*models.Question, len(s.questions))
+	copy(questions, s.questions)
+	return questions, nil
+}
+
+// GetQuestion retrieves a catalog question by ID
+func (s *CatalogStorage) GetQuestion(ctx context.Context, id string) (*models.Question, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	
+	return s.byID[id], nil
+}
+
+// CatalogReloader is implemented by question repositories that keep the catalog in memory and
+// can re-read it from its source
+type CatalogReloader interface {
+	Reload() error
+}
+
+var _ CatalogReloader = (*CatalogStorage)(nil)
+
+// ErrCatalogReadOnly is returned when saving questions while they are served from a catalog directory
+var ErrCatalogReadOnly = errors.New("question catalog is read-only; update the catalog directory instead")
+
+// SaveQuestion rejects changes since the catalog is managed outside the application
+func (s *CatalogStorage) SaveQuestion(ctx context.Context, question *models.Question) error {
+	return ErrCatalogReadOnly
+}
+
+// Reload re-reads the catalog directory and atomically swaps in the new catalog. The current
+// catalog is kept if the directory contains invalid files.
+func (s *CatalogStorage) Reload() error {
+	questions, fingerprint, err := loadCatalogDir(s.dir)
+	if err != nil {
+		return err
+	}
+	
+	byID := make(map[string]*models.Question, len(questions))
+	for _, question := range questions {
+		if _, exists := byID[question.ID]; exists {
+			return fmt.Errorf("duplicate question ID in catalog: %s", question.ID)
+		}
+		byID[question.ID] = question
+	}
+	
+	s.mu.Lock()
+	s.questions = questions
+	s.byID = byID
+	s.fingerprint = fingerprint
+	s.mu.Unlock()
+	
+	return nil
+}
+
+// Watch polls the catalog directory and reloads it whenever its contents change, until the
+// context is cancelled. Polling is used because ConfigMap updates are published by swapping
+// a symlink, which file watchers on the individual files do not observe reliably. An interval
+// of zero disables polling, leaving reloads to the reload endpoint.
+func (s *CatalogStorage) Watch(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		
+		_, fingerprint, err := loadCatalogDir(s.dir)
+		if err != nil {
+			log.Printf("Question catalog in %s is invalid, keeping current catalog: %v", s.dir, err)
+			continue
+		}
+		
+		s.mu.RLock()
+		changed := fingerprint != s.fingerprint
+		s.mu.RUnlock()
+		
+		if !changed {
+			continue
+		}
+		
+		if err := s.Reload(); err != nil {
+			log.Printf("Failed to reload question catalog, keeping current catalog: %v", err)
+			continue
+		}
+		log.Printf("Reloaded question catalog from %s", s.dir)
+	}
+}
+
+// loadCatalogDir reads every JSON file in dir, each holding a question or an array of
+// questions, and returns the questions with a fingerprint of the directory contents
+func loadCatalogDir(dir string) ([]*models.Question, string, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read catalog directory: %w", err)
+	}
+	
+	// ConfigMap volumes contain ..data and timestamped ..* entries next to the key symlinks
+	var names []string
+	for _, file := range files {
+		if strings.HasPrefix(file.Name(), ".") || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		names = append(names, file.Name())
+	}
+	sort.Strings(names)
+	
+	hash := sha256.New()
+	var questions []*models.Question
+	for _, name := range names {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read catalog file %s: %w", name, err)
+		}
+		hash.Write([]byte(name))
+		hash.Write(data)
+		
+		parsed, err := parseCatalogFile(data)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to parse catalog file %s: %w", name, err)
+		}
+		questions = append(questions, parsed...)
+	}
+	
+	return questions, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// parseCatalogFile decodes either a single question or an array of questions
+func parseCatalogFile(data []byte) ([]*models.Question, error) {
+	trimmed := strings.TrimSpace(string(data))
+	if strings.HasPrefix(trimmed, "[") {
+		var questions []*models.Question
+		if err := json.Unmarshal(data, &questions); err != nil {
+			return nil, err
+		}
+		return questions, nil
+	}
+	
+	var question models.Question
+	if err := json.Unmarshal(data, &question); err != nil {
+		return nil, err
+	}
+	return []*models.Question{&question}, nil
+}
@@ -0,0 +1,372 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	
+	"github.com/google/uuid"
+)
+
+// Locker provides mutual exclusion on named entities across all replicas sharing a backend
+type Locker interface {
+	// Lock blocks until the lock for key is held or the context is done. The returned
+	// function releases the lock.
+	Lock(ctx context.Context, key string) (func(), error)
+	
+	// Hold acquires the lock for key like Lock. It also returns a context that keeps the
+	// values of ctx but not its deadline and is cancelled once the lock is released or lost,
+	// so holders doing long work can stop when another replica may have taken over.
+	Hold(ctx context.Context, key string) (context.Context, func(), error)
+}
+
+// ErrLockTimeout is returned when a lock could not be acquired before the context expired
+var ErrLockTimeout = errors.New("timed out waiting for lock")
+
+// lease is the content of a lock file
+type lease struct {
+	Owner     string `json:"owner"`
+	Token     string `json:"token"`     // identifies one acquisition, even among acquisitions by the same owner
+	ExpiresAt int64  `json:"expiresAt"` // unix milliseconds
+}
+
+// FileLocker implements Locker with lease files in a shared directory, suitable for replicas
+// sharing a data directory over NFS. Leases expire after ttl unless renewed by the holder, so
+// a crashed replica cannot block an entity forever.
+//
+// A lease file is never rewritten in place: leases are created by hard-linking a complete
+// file, so an existing lease is never overwritten, and expired or released leases are first
+// renamed aside, so only one replica can take over the same lease.
+type FileLocker struct {
+	dir   string
+	owner string
+	ttl   time.Duration
+	poll  time.Duration
+}
+
+// NewFileLocker creates a lease-file locker in dir; owner identifies this replica
+func NewFileLocker(dir, owner string, ttl time.Duration) (*FileLocker, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create lock directory %s: %w", dir, err)
+	}
+	
+	return &FileLocker{
+		dir:   dir,
+		owner: owner,
+		ttl:   ttl,
+		poll:  50 * time.Millisecond,
+	}, nil
+}
+
+// Lock acquires the lease for key, renewing it in the background until released
+func (l *FileLocker) Lock(ctx context.Context, key string) (func(), error) {
+	_, unlock, err := l.Hold(ctx, key)
+	return unlock, err
+}
+
+// Hold acquires the lease for key, renewing it in the background until released. The
+// returned context is cancelled once the lease is released or lost.
+func (l *FileLocker) Hold(ctx context.Context, key string) (context.Context, func(), error) {
+	path := filepath.Join(l.dir, key+".lock")
+	token := uuid.NewString()
+	
+	var expiresAt time.Time
+	for {
+		var err error
+		expiresAt, err = l.tryAcquire(path, token)
+		if err != nil {
+			return nil, nil, err
+		}
+		
+		if !expiresAt.IsZero() {
+			break
+		}
+		
+		select {
+		case <-ctx.Done():
+			return nil, nil, fmt.Errorf("%w %s: %v", ErrLockTimeout, key, ctx.Err())
+		case <-time.After(l.poll):
+		}
+	}
+	
+	held, lost := context.WithCancel(context.WithoutCancel(ctx))
+	done := make(chan struct{})
+	go l.keep(key, path, token, expiresAt, done, lost)
+	
+	var once sync.Once
+	return held, func() {
+		once.Do(func() {
+			close(done)
+			lost()
+			l.release(path, token)
+		})
+	}, nil
+}
+
+// keep renews the lease for token until done is closed and calls lost if it cannot. A lease
+// that cannot be found may just be renamed aside by a replica checking whether it expired,
+// which puts it back, so renewal is retried until the lease would have expired.
+func (l *FileLocker) keep(key, path, token string, expiresAt time.Time, done <-chan struct{}, lost context.CancelFunc) {
+	timer := time.NewTimer(l.ttl / 3)
+	defer timer.Stop()
+	
+	for {
+		select {
+		case <-done:
+			return
+		case <-timer.C:
+		}
+		
+		renewed, err := l.renew(path, token)
+		switch {
+		case err == nil:
+			expiresAt = renewed
+			timer.Reset(l.ttl / 3)
+		case errors.Is(err, fs.ErrNotExist) && time.Now().Add(l.poll).Before(expiresAt):
+			timer.Reset(l.poll)
+		default:
+			log.Printf("Lost lock %s: %v", key, err)
+			lost()
+			return
+		}
+	}
+}
+
+// tryAcquire creates the lease file, taking over a lease that has expired. It returns the
+// expiry of the new lease, or the zero time if the lease is held by another acquisition.
+func (l *FileLocker) tryAcquire(path, token string) (time.Time, error) {
+	expiresAt, err := l.create(path, token)
+	if err == nil {
+		return l.confirm(path, token, expiresAt), nil
+	}
+	
+	if !os.IsExist(err) {
+		return time.Time{}, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	
+	current, err := readLease(path)
+	if err != nil {
+		// The lease may have been released since
+		return time.Time{}, nil
+	}
+	
+	if time.Now().UnixMilli() <= current.ExpiresAt {
+		return time.Time{}, nil
+	}
+	
+	if err := l.takeOver(path, token, current); err != nil {
+		return time.Time{}, err
+	}
+	
+	// Another replica may still create its lease first
+	expiresAt, err = l.create(path, token)
+	if err == nil {
+		return l.confirm(path, token, expiresAt), nil
+	}
+	if !os.IsExist(err) {
+		return time.Time{}, fmt.Errorf("failed to create lock file: %w", err)
+	}
+	return time.Time{}, nil
+}
+
+// confirm checks a lease just created for token and returns its expiry, or the zero time if
+// it is not held after all. The path may only have been free because another replica moved a
+// valid lease aside to check it and is about to put it back, in which case the new lease is
+// withdrawn; the holder of the moved lease may also have renewed it over the new one.
+func (l *FileLocker) confirm(path, token string, expiresAt time.Time) time.Time {
+	if l.restorePending(path, token) {
+		l.release(path, token)
+		return time.Time{}
+	}
+	
+	for {
+		current, err := readLease(path)
+		if err == nil {
+			if current.Token != token {
+				return time.Time{}
+			}
+			return expiresAt
+		}
+		
+		// A replica checking the new lease puts it back
+		if !errors.Is(err, fs.ErrNotExist) || !time.Now().Add(l.poll).Before(expiresAt) {
+			return time.Time{}
+		}
+		time.Sleep(l.poll)
+	}
+}
+
+// restorePending reports whether another acquisition's valid lease for path is moved aside,
+// which the replica that moved it puts back. A lease moved aside by its own release is not.
+func (l *FileLocker) restorePending(path, token string) bool {
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return true
+	}
+	
+	prefix := filepath.Base(path) + "."
+	for _, entry := range entries {
+		mover, kind, ok := strings.Cut(strings.TrimPrefix(entry.Name(), prefix), ".")
+		if !strings.HasPrefix(entry.Name(), prefix) || !ok || kind != "expired" && kind != "released" {
+			continue
+		}
+		
+		moved, err := readLease(filepath.Join(l.dir, entry.Name()))
+		if err != nil || moved.Token == token || time.Now().UnixMilli() > moved.ExpiresAt {
+			continue
+		}
+		if kind == "released" && moved.Token == mover {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// takeOver removes an expired lease. The lease is renamed aside first: when several replicas
+// saw the same expired lease, only the first rename moves it, and a later one moves the lease
+// another replica has created or renewed since, which is put back.
+func (l *FileLocker) takeOver(path, token string, expired *lease) error {
+	aside := path + "." + token + ".expired"
+	if err := os.Rename(path, aside); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to take over lock file: %w", err)
+	}
+	defer os.Remove(aside)
+	
+	moved, err := readLease(aside)
+	if err == nil && *moved == *expired {
+		return nil
+	}
+	return l.restore(aside, path, moved)
+}
+
+// restore puts a valid lease that was moved aside back. Another replica may have created a
+// lease in its place meanwhile; that replica withdraws it on seeing the moved lease (see
+// confirm), so restoring is retried until the lease is back, renewed by its holder or expired.
+func (l *FileLocker) restore(aside, path string, moved *lease) error {
+	for {
+		err := os.Link(aside, path)
+		if err == nil {
+			return nil
+		}
+		if !os.IsExist(err) {
+			return fmt.Errorf("failed to restore lock file: %w", err)
+		}
+		
+		if moved == nil || time.Now().UnixMilli() > moved.ExpiresAt {
+			return nil
+		}
+		if current, err := readLease(path); err == nil && current.Token == moved.Token {
+			return nil
+		}
+		time.Sleep(l.poll)
+	}
+}
+
+// renew extends the lease for token and returns its new expiry. It fails once the lease is
+// held by another acquisition or has expired, since another replica may take over an expired
+// lease at any time.
+func (l *FileLocker) renew(path, token string) (time.Time, error) {
+	current, err := readLease(path)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to read lease: %w", err)
+	}
+	
+	if current.Token != token {
+		return time.Time{}, fmt.Errorf("lease is held by %s", current.Owner)
+	}
+	
+	if time.Now().Add(l.poll).UnixMilli() > current.ExpiresAt {
+		return time.Time{}, errors.New("lease expired before it was renewed")
+	}
+	
+	temp := path + "." + token + ".tmp"
+	expiresAt, err := l.writeLease(temp, token)
+	if err != nil {
+		os.Remove(temp)
+		return time.Time{}, fmt.Errorf("failed to write lease: %w", err)
+	}
+	
+	if err := os.Rename(temp, path); err != nil {
+		os.Remove(temp)
+		return time.Time{}, fmt.Errorf("failed to write lease: %w", err)
+	}
+	return expiresAt, nil
+}
+
+// release removes the lease for token. Like a takeover, the lease is renamed aside first and
+// put back if it turns out to belong to another acquisition.
+func (l *FileLocker) release(path, token string) {
+	aside := path + "." + token + ".released"
+	if err := os.Rename(path, aside); err != nil {
+		return
+	}
+	defer os.Remove(aside)
+	
+	current, err := readLease(aside)
+	if err == nil && current.Token == token {
+		return
+	}
+	if err := l.restore(aside, path, current); err != nil {
+		log.Printf("Failed to release lock %s: %v", filepath.Base(path), err)
+	}
+}
+
+// create writes a new lease for token to path and returns its expiry, failing if a lease
+// exists. The lease is written to a temporary file and linked into place, so other replicas
+// never read a partial lease.
+func (l *FileLocker) create(path, token string) (time.Time, error) {
+	temp := path + "." + token + ".tmp"
+	defer os.Remove(temp)
+	
+	expiresAt, err := l.writeLease(temp, token)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return expiresAt, os.Link(temp, path)
+}
+
+// writeLease writes this replica's lease for token to path and returns its expiry
+func (l *FileLocker) writeLease(path, token string) (time.Time, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return time.Time{}, err
+	}
+	
+	expiresAt := time.Now().Add(l.ttl)
+	err = json.NewEncoder(file).Encode(lease{
+		Owner:     l.owner,
+		Token:     token,
+		ExpiresAt: expiresAt.UnixMilli(),
+	})
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return expiresAt, err
+}
+
+// readLease reads the lease currently stored at path
+func readLease(path string) (*lease, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	
+	var current lease
+	if err := json.Unmarshal(data, &current); err != nil {
+		return nil, err
+	}
+	
+	return &current, nil
+}
@@ -0,0 +1,235 @@
+package storage_test
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	
+	"questionnaire-app/internal/storage"
+)
+
+// writeLease stores a lease of another replica for key
+func writeLease(t *testing.T, dir, key, owner string, expiresAt time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, key+".lock")
+	data := fmt.Sprintf(`{"owner":%q,"token":%q,"expiresAt":%d}`, owner, owner+"-token", expiresAt.UnixMilli())
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("failed to write lease: %v", err)
+	}
+	return path
+}
+
+func newLocker(t *testing.T, dir, owner string, ttl time.Duration) *storage.FileLocker {
+	t.Helper()
+	locker, err := storage.NewFileLocker(dir, owner, ttl)
+	if err != nil {
+		t.Fatalf("NewFileLocker: %v", err)
+	}
+	return locker
+}
+
+func TestFileLockerTakesOverExpiredLeaseOnce(t *testing.T) {
+	dir := t.TempDir()
+	lockers := []*storage.FileLocker{
+		newLocker(t, dir, "replica-a", time.Second),
+		newLocker(t, dir, "replica-b", time.Second),
+	}
+	
+	for round := 0; round < 10; round++ {
+		writeLease(t, dir, "assessment-a1", "crashed", time.Now().Add(-time.Minute))
+		
+		var active, overlaps int32
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(locker *storage.FileLocker) {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				
+				unlock, err := locker.Lock(ctx, "assessment-a1")
+				if err != nil {
+					t.Errorf("Lock: %v", err)
+					return
+				}
+				if atomic.AddInt32(&active, 1) > 1 {
+					atomic.AddInt32(&overlaps, 1)
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&active, -1)
+				unlock()
+			}(lockers[i%2])
+		}
+		wg.Wait()
+		
+		if overlaps > 0 {
+			t.Fatalf("round %d: the lock was held by %d acquisitions at once", round, overlaps+1)
+		}
+	}
+}
+
+func TestFileLockerTakesOverExpiredLeaseOnceAmongThreeReplicas(t *testing.T) {
+	dir := t.TempDir()
+	lockers := []*storage.FileLocker{
+		newLocker(t, dir, "replica-a", time.Second),
+		newLocker(t, dir, "replica-b", time.Second),
+		newLocker(t, dir, "replica-c", time.Second),
+	}
+	
+	for round := 0; round < 20; round++ {
+		writeLease(t, dir, "assessment-a1", "crashed", time.Now().Add(-time.Minute))
+		
+		var active, overlaps int32
+		var wg sync.WaitGroup
+		for i := 0; i < 6; i++ {
+			wg.Add(1)
+			go func(locker *storage.FileLocker) {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				
+				unlock, err := locker.Lock(ctx, "assessment-a1")
+				if err != nil {
+					t.Errorf("Lock: %v", err)
+					return
+				}
+				if atomic.AddInt32(&active, 1) > 1 {
+					atomic.AddInt32(&overlaps, 1)
+				}
+				time.Sleep(time.Millisecond)
+				atomic.AddInt32(&active, -1)
+				unlock()
+			}(lockers[i%3])
+		}
+		wg.Wait()
+		
+		if overlaps > 0 {
+			t.Fatalf("round %d: the lock was held by %d acquisitions at once", round, overlaps+1)
+		}
+	}
+}
+
+func TestFileLockerDoesNotAcquireWhileValidLeaseIsMovedAside(t *testing.T) {
+	dir := t.TempDir()
+	holder := newLocker(t, dir, "replica-a", time.Minute)
+	unlock, err := holder.Lock(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	defer unlock()
+	
+	// A second replica checking for an expired lease has moved the valid lease aside and is
+	// about to put it back
+	path := filepath.Join(dir, "assessment-a1.lock")
+	aside := path + ".checking.expired"
+	if err := os.Rename(path, aside); err != nil {
+		t.Fatalf("failed to move lease aside: %v", err)
+	}
+	held, err := os.ReadFile(aside)
+	if err != nil {
+		t.Fatalf("failed to read lease: %v", err)
+	}
+	
+	// A third replica finds the path free but must not keep a lease there
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if _, err := newLocker(t, dir, "replica-c", time.Minute).Lock(ctx, "assessment-a1"); err == nil {
+		t.Fatal("a third replica acquired the lock while the holder's lease was moved aside")
+	}
+	
+	if err := os.Link(aside, path); err != nil {
+		t.Fatalf("failed to restore the holder's lease: %v", err)
+	}
+	os.Remove(aside)
+	current, err := os.ReadFile(path)
+	if err != nil || string(current) != string(held) {
+		t.Errorf("lease = %s (%v), want the holder's %s", current, err, held)
+	}
+}
+
+func TestFileLockerDoesNotRenewOrReleaseLostLease(t *testing.T) {
+	dir := t.TempDir()
+	locker := newLocker(t, dir, "replica-a", 300*time.Millisecond)
+	
+	unlock, err := locker.Lock(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	
+	// Another replica took the lease over while this one stalled
+	path := writeLease(t, dir, "assessment-a1", "replica-b", time.Now().Add(time.Hour))
+	taken, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read lease: %v", err)
+	}
+	
+	// Wait for a renewal, which must not overwrite the other replica's lease
+	time.Sleep(250 * time.Millisecond)
+	unlock()
+	
+	current, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("the other replica's lease was removed: %v", err)
+	}
+	if string(current) != string(taken) {
+		t.Errorf("lease = %s, want the other replica's %s", current, taken)
+	}
+}
+
+func TestFileLockerRenewsLeaseMovedAsideBriefly(t *testing.T) {
+	dir := t.TempDir()
+	holder := newLocker(t, dir, "replica-a", 300*time.Millisecond)
+	
+	held, unlock, err := holder.Hold(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Hold: %v", err)
+	}
+	defer unlock()
+	
+	// Another replica checking for an expired lease moves the lease aside across a renewal
+	path := filepath.Join(dir, "assessment-a1.lock")
+	aside := path + ".checking.expired"
+	if err := os.Rename(path, aside); err != nil {
+		t.Fatalf("failed to move lease aside: %v", err)
+	}
+	time.Sleep(150 * time.Millisecond)
+	if err := os.Link(aside, path); err != nil {
+		t.Fatalf("failed to restore lease: %v", err)
+	}
+	os.Remove(aside)
+	
+	// The lease must outlive its first expiry
+	time.Sleep(400 * time.Millisecond)
+	if held.Err() != nil {
+		t.Fatal("the lock was reported lost")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := newLocker(t, dir, "replica-b", time.Second).Lock(ctx, "assessment-a1"); err == nil {
+		t.Fatal("another replica acquired the lease while it was held")
+	}
+}
+
+func TestFileLockerReportsLostLease(t *testing.T) {
+	dir := t.TempDir()
+	locker := newLocker(t, dir, "replica-a", 300*time.Millisecond)
+	
+	held, unlock, err := locker.Hold(context.Background(), "assessment-a1")
+	if err != nil {
+		t.Fatalf("Hold: %v", err)
+	}
+	defer unlock()
+	
+	writeLease(t, dir, "assessment-a1", "replica-b", time.Now().Add(time.Hour))
+	
+	select {
+	case <-held.Done():
+	case <-time.After(time.Second):
+		t.Fatal("the holder was not told that the lock was lost")
+	}
+}
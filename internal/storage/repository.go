@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"questionnaire-app/internal/models"
+)
+
+// Storage defines the interface for persistence operations. It combines one repository per
+// entity; a backend implements all of them, and storagetest.Run verifies that it behaves
+// like FileStorage.
+//
+// Lookups of missing entities return nil without an error, and deleting a missing entity is
+// not an error. Read operations return the context's error once it is done.
+type Storage interface {
+	ApplicationRepository
+	ArchetypeRepository
+	QuestionRepository
+	CategoryRepository
+	CatalogReleaseRepository
+	SectionRepository
+	TranslationRepository
+	AssessmentRepository
+	EventRepository
+	ReportRepository
+	OutboxRepository
+	JobRepository
+	CampaignRepository
+	ConfigRepository
+	ViewRepository
+	DigestRepository
+	WorkshopRepository
+}
+
+// ApplicationRepository stores the applications being assessed
+type ApplicationRepository interface {
+	GetApplication(ctx context.Context, id string) (*models.Application, error)
+	ListApplications(ctx context.Context) ([]*models.Application, error)
+	SaveApplication(ctx context.Context, app *models.Application) error
+	DeleteApplication(ctx context.Context, id string) error
+}
+
+// ArchetypeRepository stores the application archetypes and their default answers
+type ArchetypeRepository interface {
+	GetArchetype(ctx context.Context, id string) (*models.Archetype, error)
+	ListArchetypes(ctx context.Context) ([]*models.Archetype, error)
+	SaveArchetype(ctx context.Context, archetype *models.Archetype) error
+	DeleteArchetype(ctx context.Context, id string) error
+}
+
+// QuestionRepository stores the question catalog
+type QuestionRepository interface {
+	GetQuestions(ctx context.Context) ([]*models.Question, error)
+	GetQuestion(ctx context.Context, id string) (*models.Question, error)
+	SaveQuestion(ctx context.Context, question *models.Question) error
+}
+
+// CategoryRepository stores the question categories, keyed by name
+type CategoryRepository interface {
+	GetCategory(ctx context.Context, name string) (*models.Category, error)
+	ListCategories(ctx context.Context) ([]*models.Category, error)
+	SaveCategory(ctx context.Context, category *models.Category) error
+	DeleteCategory(ctx context.Context, name string) error
+}
+
+// CatalogReleaseRepository stores the published versions of the question catalog, keyed by
+// version
+type CatalogReleaseRepository interface {
+	GetCatalogRelease(ctx context.Context, version string) (*models.CatalogRelease, error)
+	ListCatalogReleases(ctx context.Context) ([]*models.CatalogRelease, error)
+	SaveCatalogRelease(ctx context.Context, release *models.CatalogRelease) error
+}
+
+// SectionRepository stores the sections that group questions into pages
+type SectionRepository interface {
+	GetSection(ctx context.Context, id string) (*models.Section, error)
+	ListSections(ctx context.Context) ([]*models.Section, error)
+	SaveSection(ctx context.Context, section *models.Section) error
+	DeleteSection(ctx context.Context, id string) error
+}
+
+// TranslationRepository stores message catalogs, one per locale
+type TranslationRepository interface {
+	GetMessageCatalog(ctx context.Context, locale string) (*models.MessageCatalog, error)
+	ListMessageCatalogs(ctx context.Context) ([]*models.MessageCatalog, error)
+	SaveMessageCatalog(ctx context.Context, catalog *models.MessageCatalog) error
+	DeleteMessageCatalog(ctx context.Context, locale string) error
+}
+
+// AssessmentRepository stores the current state of assessments
+type AssessmentRepository interface {
+	CreateAssessment(ctx context.Context, assessment *models.Assessment) error
+	GetAssessment(ctx context.Context, id string) (*models.Assessment, error)
+	UpdateAssessment(ctx context.Context, assessment *models.Assessment) error
+	ListAssessments(ctx context.Context, applicationID string) ([]*models.Assessment, error) // all if applicationID is empty
+	DeleteAssessment(ctx context.Context, id string) error
+}
+
+// EventRepository stores the append-only event log of each assessment
+type EventRepository interface {
+	AppendEvent(ctx context.Context, event *models.AssessmentEvent) error
+	ListEvents(ctx context.Context, assessmentID string) ([]*models.AssessmentEvent, error) // in append order
+	ReplaceEvents(ctx context.Context, assessmentID string, events []*models.AssessmentEvent) error
+	DeleteEvents(ctx context.Context, assessmentID string) error
+}
+
+// ReportRepository stores generated reports, one current report per assessment along with
+// the versions it superseded
+type ReportRepository interface {
+	SaveReport(ctx context.Context, report *models.Report) error
+	GetReport(ctx context.Context, assessmentID string) (*models.Report, error)
+	DeleteReport(ctx context.Context, assessmentID string) error // also deletes superseded versions
+	ListReports(ctx context.Context) ([]*models.Report, error)
+	ArchiveReport(ctx context.Context, assessmentID string) error                          // removes the report from GetReport and ListReports
+	SaveReportVersion(ctx context.Context, report *models.Report) error                    // keeps a superseded version, keyed by its Version
+	ListReportVersions(ctx context.Context, assessmentID string) ([]*models.Report, error) // superseded versions, oldest first
+}
+
+// OutboxRepository stores notifications waiting for delivery
+type OutboxRepository interface {
+	SaveOutboxMessage(ctx context.Context, message *models.OutboxMessage) error
+	GetOutboxMessage(ctx context.Context, id string) (*models.OutboxMessage, error)
+	ListOutboxMessages(ctx context.Context, status string) ([]*models.OutboxMessage, error) // all if status is empty
+	DeleteOutboxMessage(ctx context.Context, id string) error
+}
+
+// JobRepository stores background jobs
+type JobRepository interface {
+	SaveJob(ctx context.Context, job *models.Job) error
+	GetJob(ctx context.Context, id string) (*models.Job, error)
+	ListJobs(ctx context.Context) ([]*models.Job, error)
+}
+
+// CampaignRepository stores assessment campaigns
+type CampaignRepository interface {
+	SaveCampaign(ctx context.Context, campaign *models.Campaign) error
+	GetCampaign(ctx context.Context, id string) (*models.Campaign, error)
+	ListCampaigns(ctx context.Context) ([]*models.Campaign, error)
+}
+
+// ConfigRepository stores settings changed at runtime; getters return nil until one is saved
+type ConfigRepository interface {
+	GetScoringConfig(ctx context.Context) (*models.ScoringConfig, error)
+	SaveScoringConfig(ctx context.Context, config *models.ScoringConfig) error
+	GetEstimationConfig(ctx context.Context) (*models.EstimationConfig, error)
+	SaveEstimationConfig(ctx context.Context, config *models.EstimationConfig) error
+	GetBranding(ctx context.Context) (*models.Branding, error)
+	SaveBranding(ctx context.Context, branding *models.Branding) error
+	GetReviewChecklist(ctx context.Context) (*models.ReviewChecklist, error)
+	SaveReviewChecklist(ctx context.Context, checklist *models.ReviewChecklist) error
+}
+
+// ViewRepository stores materialized views derived from the other entities
+type ViewRepository interface {
+	GetPortfolioSummary(ctx context.Context) (*models.PortfolioSummarySnapshot, error)
+	SavePortfolioSummary(ctx context.Context, snapshot *models.PortfolioSummarySnapshot) error
+	DeletePortfolioSummary(ctx context.Context) error
+}
+
+// DigestRepository stores digest subscriptions, one per user, and the digest schedule state
+type DigestRepository interface {
+	GetDigestSubscription(ctx context.Context, user string) (*models.DigestSubscription, error)
+	ListDigestSubscriptions(ctx context.Context) ([]*models.DigestSubscription, error)
+	SaveDigestSubscription(ctx context.Context, subscription *models.DigestSubscription) error
+	DeleteDigestSubscription(ctx context.Context, user string) error
+	GetDigestState(ctx context.Context) (*models.DigestState, error) // nil until one is saved
+	SaveDigestState(ctx context.Context, state *models.DigestState) error
+}
+
+// WorkshopRepository stores assessment workshops, at most one per assessment
+type WorkshopRepository interface {
+	GetWorkshop(ctx context.Context, assessmentID string) (*models.Workshop, error)
+	ListWorkshops(ctx context.Context) ([]*models.Workshop, error)
+	SaveWorkshop(ctx context.Context, workshop *models.Workshop) error
+	DeleteWorkshop(ctx context.Context, assessmentID string) error
+}
+
+// SizeReporter is implemented by storage backends that can tell how much space they use
+type SizeReporter interface {
+	Size(ctx context.Context) (int64, error) // in bytes
+}
+
+var _ Storage = (*FileStorage)(nil)
+var _ SizeReporter = (*FileStorage)(nil)
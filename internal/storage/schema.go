@@ -0,0 +1,403 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"questionnaire-app/internal/models"
+	"slices"
+	"sort"
+	"time"
+)
+
+// Kinds of documents the file storage keeps, each with its own schema version
+const (
+	KindApplication        = "application"
+	KindArchetype          = "archetype"
+	KindAssessment         = "assessment"
+	KindBranding           = "branding"
+	KindCampaign           = "campaign"
+	KindCatalogRelease     = "catalog-release"
+	KindCategory           = "category"
+	KindDigestState        = "digest-state"
+	KindDigestSubscription = "digest-subscription"
+	KindEstimationConfig   = "estimation-config"
+	KindEvent              = "event"
+	KindJob                = "job"
+	KindMessageCatalog     = "message-catalog"
+	KindOutboxMessage      = "outbox-message"
+	KindPortfolioSummary   = "portfolio-summary"
+	KindQuestion           = "question"
+	KindReport             = "report"
+	KindReviewChecklist    = "review-checklist"
+	KindScoringConfig      = "scoring-config"
+	KindSection            = "section"
+	KindWorkshop           = "workshop"
+)
+
+// Migration upgrades a stored document of a kind from one schema version to the next. It
+// works on the decoded JSON object, so it does not depend on the current Go models.
+type Migration struct {
+	Kind    string
+	From    int // the version upgraded from; the document has version From+1 afterwards
+	Upgrade func(document map[string]any) error
+}
+
+// migrations lists the upgrades of stored documents. When the stored JSON of an entity
+// changes incompatibly, for example a map becoming a list of records, add a migration from
+// the kind's current version; the kind's version then goes up by one. Documents written
+// before versioning are version 1.
+var migrations = []Migration{
+	// Version 2 stores timestamps that were strings as times
+	timestampMigration(KindArchetype, "updatedAt"),
+	{Kind: KindAssessment, From: 1, Upgrade: upgradeAssessmentTimestamps},
+	timestampMigration(KindBranding, "updatedAt"),
+	timestampMigration(KindCampaign, "createdAt"),
+	timestampMigration(KindCatalogRelease, "publishedAt", "receivedAt", "updatedAt"),
+	timestampMigration(KindCategory, "updatedAt"),
+	timestampMigration(KindDigestState, "lastSentAt"),
+	timestampMigration(KindDigestSubscription, "updatedAt"),
+	timestampMigration(KindEstimationConfig, "updatedAt"),
+	timestampMigration(KindEvent, "occurredAt", "startedAt", "answeredAt", "approvedAt", "suggestedAt"),
+	timestampMigration(KindJob, "createdAt", "startedAt", "finishedAt"),
+	timestampMigration(KindMessageCatalog, "updatedAt"),
+	timestampMigration(KindOutboxMessage, "createdAt", "nextAttemptAt", "deliveredAt"),
+	timestampMigration(KindPortfolioSummary, "builtAt", "updatedAt"),
+	timestampMigration(KindReport, "generatedAt", "createdAt", "approvedAt"),
+	timestampMigration(KindReviewChecklist, "updatedAt"),
+	timestampMigration(KindScoringConfig, "updatedAt"),
+	timestampMigration(KindSection, "updatedAt"),
+	timestampMigration(KindWorkshop, "startedAt", "closedAt", "decidedAt"),
+}
+
+// timestampMigration upgrades a kind whose timestamp fields were RFC3339 strings to version 2,
+// where they are times. Set timestamps are read as they are; the empty strings that meant
+// "not set" are removed, since they are not valid times.
+func timestampMigration(kind string, fields ...string) Migration {
+	return Migration{Kind: kind, From: 1, Upgrade: func(document map[string]any) error {
+		return clearEmptyTimestamps(document, fields)
+	}}
+}
+
+// upgradeAssessmentTimestamps upgrades the timestamps of an assessment like
+// timestampMigration. Assessments started before startedAt was recorded take it from
+// createdAt.
+func upgradeAssessmentTimestamps(document map[string]any) error {
+	if err := clearEmptyTimestamps(document, []string{"startedAt", "answeredAt", "approvedAt", "suggestedAt"}); err != nil {
+		return err
+	}
+	if _, ok := document["startedAt"]; !ok && document["createdAt"] != nil {
+		document["startedAt"] = document["createdAt"]
+	}
+	return nil
+}
+
+// clearEmptyTimestamps removes the named timestamp fields holding empty strings from a
+// document and the objects nested in it, including empty entries of maps of timestamps such
+// as an assessment's answeredAt. It fails on a value that is not an RFC3339 time.
+func clearEmptyTimestamps(value any, fields []string) error {
+	switch value := value.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if !slices.Contains(fields, key) {
+				if err := clearEmptyTimestamps(child, fields); err != nil {
+					return err
+				}
+				continue
+			}
+			
+			switch child := child.(type) {
+			case string:
+				if child == "" {
+					delete(value, key)
+				} else if _, err := time.Parse(time.RFC3339, child); err != nil {
+					return fmt.Errorf("invalid %s: %w", key, err)
+				}
+			case map[string]any:
+				for entry, at := range child {
+					if at == "" {
+						delete(child, entry)
+					} else if text, ok := at.(string); ok {
+						if _, err := time.Parse(time.RFC3339, text); err != nil {
+							return fmt.Errorf("invalid %s of %s: %w", key, entry, err)
+						}
+					}
+				}
+			}
+		}
+	case []any:
+		for _, child := range value {
+			if err := clearEmptyTimestamps(child, fields); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// SchemaVersion returns the version of a kind's documents written by this build
+func SchemaVersion(kind string) int {
+	version := 1
+	for _, migration := range migrations {
+		if migration.Kind == kind && migration.From >= version {
+			version = migration.From + 1
+		}
+	}
+	return version
+}
+
+// documentKind returns the kind of a stored entity
+func documentKind(v any) string {
+	switch v.(type) {
+	case *models.Application:
+		return KindApplication
+	case *models.Archetype:
+		return KindArchetype
+	case *models.Assessment:
+		return KindAssessment
+	case *models.Branding:
+		return KindBranding
+	case *models.Campaign:
+		return KindCampaign
+	case *models.CatalogRelease:
+		return KindCatalogRelease
+	case *models.Category:
+		return KindCategory
+	case *models.DigestState:
+		return KindDigestState
+	case *models.DigestSubscription:
+		return KindDigestSubscription
+	case *models.EstimationConfig:
+		return KindEstimationConfig
+	case *models.AssessmentEvent:
+		return KindEvent
+	case *models.Job:
+		return KindJob
+	case *models.MessageCatalog:
+		return KindMessageCatalog
+	case *models.OutboxMessage:
+		return KindOutboxMessage
+	case *models.PortfolioSummarySnapshot:
+		return KindPortfolioSummary
+	case *models.Question:
+		return KindQuestion
+	case *models.Report:
+		return KindReport
+	case *models.ReviewChecklist:
+		return KindReviewChecklist
+	case *models.ScoringConfig:
+		return KindScoringConfig
+	case *models.Section:
+		return KindSection
+	case *models.Workshop:
+		return KindWorkshop
+	default:
+		panic(fmt.Sprintf("no document kind for %T", v))
+	}
+}
+
+// encodeDocument marshals an entity with the schema version of its kind
+func encodeDocument(v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return stampVersion(data, SchemaVersion(documentKind(v))), nil
+}
+
+// stampVersion adds a schemaVersion field to a marshaled JSON object
+func stampVersion(data []byte, version int) []byte {
+	if len(data) < 2 || data[0] != '{' {
+		return data
+	}
+	field := fmt.Sprintf(`{"schemaVersion":%d`, version)
+	if bytes.Equal(bytes.TrimSpace(data[1:]), []byte("}")) {
+		return append([]byte(field), '}')
+	}
+	return append([]byte(field+","), data[1:]...)
+}
+
+// decodeDocument unmarshals a stored document into an entity, first upgrading documents
+// written with an older schema version. Documents from a newer version are rejected rather
+// than read with fields missing.
+func decodeDocument(data []byte, v any) error {
+	upgraded, _, err := upgradeDocument(documentKind(v), data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(upgraded, v)
+}
+
+// documentVersion returns the schema version a document was written with
+func documentVersion(data []byte) (int, error) {
+	var header struct {
+		SchemaVersion int `json:"schemaVersion"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return 0, err
+	}
+	if header.SchemaVersion == 0 {
+		return 1, nil
+	}
+	return header.SchemaVersion, nil
+}
+
+// upgradeDocument runs the migrations of a kind on a document written with an older schema
+// version. It returns the document unchanged, and false, if it is current.
+func upgradeDocument(kind string, data []byte) ([]byte, bool, error) {
+	version, err := documentVersion(data)
+	if err != nil {
+		return nil, false, err
+	}
+	current := SchemaVersion(kind)
+	if version > current {
+		return nil, false, fmt.Errorf("%s document has schema version %d, this build supports up to %d", kind, version, current)
+	}
+	if version == current {
+		return data, false, nil
+	}
+	
+	var document map[string]any
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, false, err
+	}
+	for ; version < current; version++ {
+		migration := findMigration(kind, version)
+		if migration == nil {
+			return nil, false, fmt.Errorf("no migration of %s documents from schema version %d", kind, version)
+		}
+		if err := migration.Upgrade(document); err != nil {
+			return nil, false, fmt.Errorf("failed to upgrade %s document from schema version %d: %w", kind, version, err)
+		}
+	}
+	delete(document, "schemaVersion")
+	
+	upgraded, err := json.Marshal(document)
+	if err != nil {
+		return nil, false, err
+	}
+	return stampVersion(upgraded, current), true, nil
+}
+
+// findMigration returns the migration of a kind from a version, or nil
+func findMigration(kind string, from int) *Migration {
+	for i := range migrations {
+		if migrations[i].Kind == kind && migrations[i].From == from {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// documentLocations lists where the documents of each kind are stored in the data directory
+var documentLocations = []struct {
+	Kind    string
+	Pattern string
+}{
+	{KindApplication, "applications/*.json"},
+	{KindArchetype, "archetypes/*.json"},
+	{KindAssessment, "assessments/*.json"},
+	{KindBranding, "config/branding.json"},
+	{KindCampaign, "campaigns/*.json"},
+	{KindCatalogRelease, "catalog-releases/*.json"},
+	{KindCategory, "categories/*.json"},
+	{KindDigestState, "config/digest-state.json"},
+	{KindDigestSubscription, "subscriptions/*.json"},
+	{KindEstimationConfig, "config/estimation.json"},
+	{KindEvent, "events/*.jsonl"},
+	{KindJob, "jobs/*.json"},
+	{KindMessageCatalog, "translations/*.json"},
+	{KindOutboxMessage, "outbox/*.json"},
+	{KindPortfolioSummary, "views/portfolio-summary.json"},
+	{KindQuestion, "questions/*.json"},
+	{KindReport, "reports/*.json"},
+	{KindReport, "reports/archive/*.json"},
+	{KindReport, "reports/versions/*/*.json"},
+	{KindReviewChecklist, "config/review-checklist.json"},
+	{KindScoringConfig, "config/scoring.json"},
+	{KindSection, "sections/*.json"},
+	{KindWorkshop, "workshops/*.json"},
+}
+
+// MigrationResult counts the documents of each kind a migration run checked and upgraded
+type MigrationResult struct {
+	Checked  map[string]int
+	Upgraded map[string]int
+}
+
+// Migrate rewrites every document of the data directory written with an older schema
+// version, so later reads need not upgrade them. With dryRun nothing is written. Documents
+// are otherwise upgraded when they are read, so running it is optional; it stops at the
+// first document it cannot upgrade.
+func (s *FileStorage) Migrate(ctx context.Context, dryRun bool) (*MigrationResult, error) {
+	result := &MigrationResult{Checked: make(map[string]int), Upgraded: make(map[string]int)}
+	for _, location := range documentLocations {
+		paths, err := filepath.Glob(filepath.Join(s.basePath, filepath.FromSlash(location.Pattern)))
+		if err != nil {
+			return result, err
+		}
+		sort.Strings(paths)
+		
+		for _, path := range paths {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+			upgraded, checked, err := s.migrateFile(location.Kind, path, dryRun)
+			if err != nil {
+				return result, err
+			}
+			result.Checked[location.Kind] += checked
+			result.Upgraded[location.Kind] += upgraded
+		}
+	}
+	return result, nil
+}
+
+// migrateFile upgrades the documents of a file, one per line for event logs, and returns how
+// many it upgraded and checked
+func (s *FileStorage) migrateFile(kind, path string, dryRun bool) (int, int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	
+	lines := [][]byte{data}
+	if filepath.Ext(path) == ".jsonl" {
+		lines = bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n"))
+	}
+	
+	upgraded, checked := 0, 0
+	var buf bytes.Buffer
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		document, changed, err := upgradeDocument(kind, line)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%s, document %d: %w", path, i+1, err)
+		}
+		checked++
+		if changed {
+			upgraded++
+		}
+		buf.Write(document)
+		if len(lines) > 1 || filepath.Ext(path) == ".jsonl" {
+			buf.WriteByte('\n')
+		}
+	}
+	if upgraded == 0 || dryRun {
+		return upgraded, checked, nil
+	}
+	
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return 0, 0, fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return 0, 0, fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return upgraded, checked, nil
+}
@@ -1,39 +1,21 @@
 package storage
 
 import (
+	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
 	"questionnaire-app/internal/models"
+	"sort"
 )
 
-// Storage defines the interface for persistence operations
-type Storage interface {
-	// Application operations
-	GetApplication(ctx context.Context, id string) (*models.Application, error)
-	ListApplications(ctx context.Context) ([]*models.Application, error)
-	SaveApplication(ctx context.Context, app *models.Application) error
-	
-	// Question operations
-	GetQuestions(ctx context.Context) ([]*models.Question, error)
-	GetQuestion(ctx context.Context, id string) (*models.Question, error)
-	
-	// Assessment operations
-	CreateAssessment(ctx context.Context, assessment *models.Assessment) error
-	GetAssessment(ctx context.Context, id string) (*models.Assessment, error)
-	UpdateAssessment(ctx context.Context, assessment *models.Assessment) error
-	ListAssessments(ctx context.Context, applicationID string) ([]*models.Assessment, error)
-	
-	// Report operations
-	SaveReport(ctx context.Context, report *models.Report) error
-	GetReport(ctx context.Context, assessmentID string) (*models.Report, error)
-}
-
-// FileStorage implements Storage interface using local file system
+// FileStorage implements Storage interface using local file system. Reads and directory scans
+// stop with the context's error once it is canceled or past its deadline; writes always run to
+// completion so a disconnecting client cannot leave an event log and its assessment out of step.
 type FileStorage struct {
-	BasePath string // Exported field for access by sample data creation
+	basePath string // data directory
 }
 
 // NewFileStorage creates a new file-based storage
@@ -41,9 +23,24 @@ func NewFileStorage(basePath string) (*FileStorage, error) {
 	// Create necessary directories
 	dirs := []string{
 		filepath.Join(basePath, "applications"),
+		filepath.Join(basePath, "archetypes"),
 		filepath.Join(basePath, "questions"),
+		filepath.Join(basePath, "categories"),
+		filepath.Join(basePath, "sections"),
+		filepath.Join(basePath, "translations"),
 		filepath.Join(basePath, "assessments"),
+		filepath.Join(basePath, "events"),
 		filepath.Join(basePath, "reports"),
+		filepath.Join(basePath, "reports", "archive"),
+		filepath.Join(basePath, "reports", "versions"),
+		filepath.Join(basePath, "outbox"),
+		filepath.Join(basePath, "jobs"),
+		filepath.Join(basePath, "config"),
+		filepath.Join(basePath, "campaigns"),
+		filepath.Join(basePath, "catalog-releases"),
+		filepath.Join(basePath, "views"),
+		filepath.Join(basePath, "subscriptions"),
+		filepath.Join(basePath, "workshops"),
 	}
 	
 	for _, dir := range dirs {
@@ -52,12 +49,16 @@ func NewFileStorage(basePath string) (*FileStorage, error) {
 		}
 	}
 	
-	return &FileStorage{BasePath: basePath}, nil
+	return &FileStorage{basePath: basePath}, nil
 }
 
 // GetApplication retrieves an application by ID
 func (s *FileStorage) GetApplication(ctx context.Context, id string) (*models.Application, error) {
-	path := filepath.Join(s.BasePath, "applications", id+".json")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "applications", id+".json")
 	
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -68,7 +69,7 @@ func (s *FileStorage) GetApplication(ctx context.Context, id string) (*models.Ap
 	}
 	
 	var app models.Application
-	if err := json.Unmarshal(data, &app); err != nil {
+	if err := decodeDocument(data, &app); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal application: %w", err)
 	}
 	
@@ -77,7 +78,11 @@ func (s *FileStorage) GetApplication(ctx context.Context, id string) (*models.Ap
 
 // ListApplications returns all applications
 func (s *FileStorage) ListApplications(ctx context.Context) ([]*models.Application, error) {
-	dir := filepath.Join(s.BasePath, "applications")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "applications")
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read applications directory: %w", err)
@@ -85,6 +90,10 @@ func (s *FileStorage) ListApplications(ctx context.Context) ([]*models.Applicati
 	
 	var apps []*models.Application
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
 		if filepath.Ext(file.Name()) != ".json" {
 			continue
 		}
@@ -96,7 +105,7 @@ func (s *FileStorage) ListApplications(ctx context.Context) ([]*models.Applicati
 		}
 		
 		var app models.Application
-		if err := json.Unmarshal(data, &app); err != nil {
+		if err := decodeDocument(data, &app); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal application %s: %w", file.Name(), err)
 		}
 		
@@ -108,12 +117,12 @@ func (s *FileStorage) ListApplications(ctx context.Context) ([]*models.Applicati
 
 // SaveApplication stores an application
 func (s *FileStorage) SaveApplication(ctx context.Context, app *models.Application) error {
-	data, err := json.Marshal(app)
+	data, err := encodeDocument(app)
 	if err != nil {
 		return fmt.Errorf("failed to marshal application: %w", err)
 	}
 	
-	path := filepath.Join(s.BasePath, "applications", app.ID+".json")
+	path := filepath.Join(s.basePath, "applications", app.ID+".json")
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write application file: %w", err)
 	}
@@ -121,9 +130,23 @@ func (s *FileStorage) SaveApplication(ctx context.Context, app *models.Applicati
 	return nil
 }
 
+// DeleteApplication removes an application; deleting a missing application is not an error
+func (s *FileStorage) DeleteApplication(ctx context.Context, id string) error {
+	path := filepath.Join(s.basePath, "applications", id+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete application file: %w", err)
+	}
+	
+	return nil
+}
+
 // GetQuestions returns all questions
 func (s *FileStorage) GetQuestions(ctx context.Context) ([]*models.Question, error) {
-	dir := filepath.Join(s.BasePath, "questions")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "questions")
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read questions directory: %w", err)
@@ -131,6 +154,10 @@ func (s *FileStorage) GetQuestions(ctx context.Context) ([]*models.Question, err
 	
 	var questions []*models.Question
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
 		if filepath.Ext(file.Name()) != ".json" {
 			continue
 		}
@@ -142,7 +169,7 @@ func (s *FileStorage) GetQuestions(ctx context.Context) ([]*models.Question, err
 		}
 		
 		var question models.Question
-		if err := json.Unmarshal(data, &question); err != nil {
+		if err := decodeDocument(data, &question); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal question %s: %w", file.Name(), err)
 		}
 		
@@ -154,7 +181,11 @@ func (s *FileStorage) GetQuestions(ctx context.Context) ([]*models.Question, err
 
 // GetQuestion retrieves a question by ID
 func (s *FileStorage) GetQuestion(ctx context.Context, id string) (*models.Question, error) {
-	path := filepath.Join(s.BasePath, "questions", id+".json")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "questions", id+".json")
 	
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -165,21 +196,36 @@ func (s *FileStorage) GetQuestion(ctx context.Context, id string) (*models.Quest
 	}
 	
 	var question models.Question
-	if err := json.Unmarshal(data, &question); err != nil {
+	if err := decodeDocument(data, &question); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal question: %w", err)
 	}
 	
 	return &question, nil
 }
 
+// SaveQuestion saves a question, replacing any existing question with the same ID
+func (s *FileStorage) SaveQuestion(ctx context.Context, question *models.Question) error {
+	data, err := encodeDocument(question)
+	if err != nil {
+		return fmt.Errorf("failed to marshal question: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "questions", question.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write question file: %w", err)
+	}
+	
+	return nil
+}
+
 // CreateAssessment creates a new assessment
 func (s *FileStorage) CreateAssessment(ctx context.Context, assessment *models.Assessment) error {
-	data, err := json.Marshal(assessment)
+	data, err := encodeDocument(assessment)
 	if err != nil {
 		return fmt.Errorf("failed to marshal assessment: %w", err)
 	}
 	
-	path := filepath.Join(s.BasePath, "assessments", assessment.ID+".json")
+	path := filepath.Join(s.basePath, "assessments", assessment.ID+".json")
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write assessment file: %w", err)
 	}
@@ -189,7 +235,11 @@ func (s *FileStorage) CreateAssessment(ctx context.Context, assessment *models.A
 
 // GetAssessment retrieves an assessment by ID
 func (s *FileStorage) GetAssessment(ctx context.Context, id string) (*models.Assessment, error) {
-	path := filepath.Join(s.BasePath, "assessments", id+".json")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "assessments", id+".json")
 	
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -200,7 +250,7 @@ func (s *FileStorage) GetAssessment(ctx context.Context, id string) (*models.Ass
 	}
 	
 	var assessment models.Assessment
-	if err := json.Unmarshal(data, &assessment); err != nil {
+	if err := decodeDocument(data, &assessment); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal assessment: %w", err)
 	}
 	
@@ -210,13 +260,13 @@ func (s *FileStorage) GetAssessment(ctx context.Context, id string) (*models.Ass
 // UpdateAssessment updates an existing assessment
 func (s *FileStorage) UpdateAssessment(ctx context.Context, assessment *models.Assessment) error {
 	// Check if assessment exists
-	path := filepath.Join(s.BasePath, "assessments", assessment.ID+".json")
+	path := filepath.Join(s.basePath, "assessments", assessment.ID+".json")
 	if _, err := os.Stat(path); os.IsNotExist(err) {
 		return fmt.Errorf("assessment not found: %s", assessment.ID)
 	}
 	
 	// Update assessment
-	data, err := json.Marshal(assessment)
+	data, err := encodeDocument(assessment)
 	if err != nil {
 		return fmt.Errorf("failed to marshal assessment: %w", err)
 	}
@@ -230,7 +280,11 @@ func (s *FileStorage) UpdateAssessment(ctx context.Context, assessment *models.A
 
 // ListAssessments returns all assessments for an application
 func (s *FileStorage) ListAssessments(ctx context.Context, applicationID string) ([]*models.Assessment, error) {
-	dir := filepath.Join(s.BasePath, "assessments")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "assessments")
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read assessments directory: %w", err)
@@ -238,6 +292,10 @@ func (s *FileStorage) ListAssessments(ctx context.Context, applicationID string)
 	
 	var assessments []*models.Assessment
 	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
 		if filepath.Ext(file.Name()) != ".json" {
 			continue
 		}
@@ -249,7 +307,7 @@ func (s *FileStorage) ListAssessments(ctx context.Context, applicationID string)
 		}
 		
 		var assessment models.Assessment
-		if err := json.Unmarshal(data, &assessment); err != nil {
+		if err := decodeDocument(data, &assessment); err != nil {
 			return nil, fmt.Errorf("failed to unmarshal assessment %s: %w", file.Name(), err)
 		}
 		
@@ -262,14 +320,114 @@ func (s *FileStorage) ListAssessments(ctx context.Context, applicationID string)
 	return assessments, nil
 }
 
+// DeleteAssessment removes an assessment; deleting a missing assessment is not an error
+func (s *FileStorage) DeleteAssessment(ctx context.Context, id string) error {
+	path := filepath.Join(s.basePath, "assessments", id+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete assessment file: %w", err)
+	}
+	
+	return nil
+}
+
+// AppendEvent appends an event to the assessment's event log
+func (s *FileStorage) AppendEvent(ctx context.Context, event *models.AssessmentEvent) error {
+	data, err := encodeDocument(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "events", event.AssessmentID+".jsonl")
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open event log: %w", err)
+	}
+	defer file.Close()
+	
+	// A single write of one line keeps appends from concurrent writers from interleaving
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to append event: %w", err)
+	}
+	
+	return file.Sync()
+}
+
+// ListEvents returns the events of an assessment in the order they were appended
+func (s *FileStorage) ListEvents(ctx context.Context, assessmentID string) ([]*models.AssessmentEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "events", assessmentID+".jsonl")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event log: %w", err)
+	}
+	
+	var events []*models.AssessmentEvent
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		
+		var event models.AssessmentEvent
+		if err := decodeDocument(line, &event); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal event on line %d: %w", i+1, err)
+		}
+		events = append(events, &event)
+	}
+	
+	return events, nil
+}
+
+// ReplaceEvents rewrites an assessment's event log. It is reserved for compliance operations
+// such as anonymization; regular changes must use AppendEvent.
+func (s *FileStorage) ReplaceEvents(ctx context.Context, assessmentID string, events []*models.AssessmentEvent) error {
+	var buf bytes.Buffer
+	for _, event := range events {
+		data, err := encodeDocument(event)
+		if err != nil {
+			return fmt.Errorf("failed to marshal event: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	
+	path := filepath.Join(s.basePath, "events", assessmentID+".jsonl")
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write event log: %w", err)
+	}
+	
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace event log: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteEvents removes an assessment's event log; deleting a missing log is not an error
+func (s *FileStorage) DeleteEvents(ctx context.Context, assessmentID string) error {
+	path := filepath.Join(s.basePath, "events", assessmentID+".jsonl")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete event log: %w", err)
+	}
+	
+	return nil
+}
+
 // SaveReport stores a report
 func (s *FileStorage) SaveReport(ctx context.Context, report *models.Report) error {
-	data, err := json.Marshal(report)
+	data, err := encodeDocument(report)
 	if err != nil {
 		return fmt.Errorf("failed to marshal report: %w", err)
 	}
 	
-	path := filepath.Join(s.BasePath, "reports", report.AssessmentID+".json")
+	path := filepath.Join(s.basePath, "reports", report.AssessmentID+".json")
 	if err := os.WriteFile(path, data, 0644); err != nil {
 		return fmt.Errorf("failed to write report file: %w", err)
 	}
@@ -279,7 +437,11 @@ func (s *FileStorage) SaveReport(ctx context.Context, report *models.Report) err
 
 // GetReport retrieves a report by assessment ID
 func (s *FileStorage) GetReport(ctx context.Context, assessmentID string) (*models.Report, error) {
-	path := filepath.Join(s.BasePath, "reports", assessmentID+".json")
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "reports", assessmentID+".json")
 	
 	data, err := os.ReadFile(path)
 	if os.IsNotExist(err) {
@@ -290,9 +452,1283 @@ func (s *FileStorage) GetReport(ctx context.Context, assessmentID string) (*mode
 	}
 	
 	var report models.Report
-	if err := json.Unmarshal(data, &report); err != nil {
+	if err := decodeDocument(data, &report); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal report: %w", err)
 	}
 	
 	return &report, nil
 }
+
+// DeleteReport removes a report and its superseded versions; deleting a missing report is not
+// an error
+func (s *FileStorage) DeleteReport(ctx context.Context, assessmentID string) error {
+	path := filepath.Join(s.basePath, "reports", assessmentID+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete report file: %w", err)
+	}
+	
+	if err := os.RemoveAll(filepath.Join(s.basePath, "reports", "versions", assessmentID)); err != nil {
+		return fmt.Errorf("failed to delete report versions: %w", err)
+	}
+	
+	return nil
+}
+
+// ListReports returns all active (non-archived) reports
+func (s *FileStorage) ListReports(ctx context.Context) ([]*models.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "reports")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read reports directory: %w", err)
+	}
+	
+	var reports []*models.Report
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report file %s: %w", file.Name(), err)
+		}
+		
+		var report models.Report
+		if err := decodeDocument(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal report %s: %w", file.Name(), err)
+		}
+		
+		reports = append(reports, &report)
+	}
+	
+	return reports, nil
+}
+
+// ArchiveReport moves a report out of the active set into the archive directory
+func (s *FileStorage) ArchiveReport(ctx context.Context, assessmentID string) error {
+	path := filepath.Join(s.basePath, "reports", assessmentID+".json")
+	archivePath := filepath.Join(s.basePath, "reports", "archive", assessmentID+".json")
+	
+	if err := os.Rename(path, archivePath); err != nil {
+		return fmt.Errorf("failed to archive report file: %w", err)
+	}
+	
+	return nil
+}
+
+// SaveReportVersion keeps a superseded version of a report in a directory per assessment
+func (s *FileStorage) SaveReportVersion(ctx context.Context, report *models.Report) error {
+	data, err := encodeDocument(report)
+	if err != nil {
+		return fmt.Errorf("failed to marshal report version: %w", err)
+	}
+	
+	dir := filepath.Join(s.basePath, "reports", "versions", report.AssessmentID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create report versions directory: %w", err)
+	}
+	
+	path := filepath.Join(dir, fmt.Sprintf("%d.json", report.Version))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report version file: %w", err)
+	}
+	
+	return nil
+}
+
+// ListReportVersions returns the superseded versions of an assessment's report, oldest first
+func (s *FileStorage) ListReportVersions(ctx context.Context, assessmentID string) ([]*models.Report, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "reports", "versions", assessmentID)
+	files, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []*models.Report{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report versions directory: %w", err)
+	}
+	
+	versions := []*models.Report{}
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		data, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read report version file %s: %w", file.Name(), err)
+		}
+		
+		var report models.Report
+		if err := decodeDocument(data, &report); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal report version %s: %w", file.Name(), err)
+		}
+		versions = append(versions, &report)
+	}
+	
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions, nil
+}
+
+// SaveOutboxMessage creates or updates an outbox message
+func (s *FileStorage) SaveOutboxMessage(ctx context.Context, message *models.OutboxMessage) error {
+	data, err := encodeDocument(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox message: %w", err)
+	}
+	
+	// Write through a temporary file so workers never read a partially written message
+	path := filepath.Join(s.basePath, "outbox", message.ID+".json")
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write outbox message file: %w", err)
+	}
+	
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write outbox message file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetOutboxMessage retrieves an outbox message by ID
+func (s *FileStorage) GetOutboxMessage(ctx context.Context, id string) (*models.OutboxMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "outbox", id+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox message file: %w", err)
+	}
+	
+	var message models.OutboxMessage
+	if err := decodeDocument(data, &message); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal outbox message: %w", err)
+	}
+	
+	return &message, nil
+}
+
+// ListOutboxMessages returns outbox messages, optionally filtered by status
+func (s *FileStorage) ListOutboxMessages(ctx context.Context, status string) ([]*models.OutboxMessage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "outbox")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read outbox directory: %w", err)
+	}
+	
+	var messages []*models.OutboxMessage
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			// Delivered messages may be cleaned up concurrently
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read outbox message file %s: %w", file.Name(), err)
+		}
+		
+		var message models.OutboxMessage
+		if err := decodeDocument(data, &message); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal outbox message %s: %w", file.Name(), err)
+		}
+		
+		if status == "" || message.Status == status {
+			messages = append(messages, &message)
+		}
+	}
+	
+	return messages, nil
+}
+
+// DeleteOutboxMessage removes an outbox message; deleting a missing message is not an error
+func (s *FileStorage) DeleteOutboxMessage(ctx context.Context, id string) error {
+	path := filepath.Join(s.basePath, "outbox", id+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete outbox message file: %w", err)
+	}
+	
+	return nil
+}
+
+// SaveJob creates or updates a job
+func (s *FileStorage) SaveJob(ctx context.Context, job *models.Job) error {
+	data, err := encodeDocument(job)
+	if err != nil {
+		return fmt.Errorf("failed to marshal job: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "jobs", job.ID+".json")
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write job file: %w", err)
+	}
+	
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write job file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetJob retrieves a job by ID
+func (s *FileStorage) GetJob(ctx context.Context, id string) (*models.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "jobs", id+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read job file: %w", err)
+	}
+	
+	var job models.Job
+	if err := decodeDocument(data, &job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job: %w", err)
+	}
+	
+	return &job, nil
+}
+
+// ListJobs returns all jobs
+func (s *FileStorage) ListJobs(ctx context.Context) ([]*models.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "jobs")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jobs directory: %w", err)
+	}
+	
+	var jobs []*models.Job
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read job file %s: %w", file.Name(), err)
+		}
+		
+		var job models.Job
+		if err := decodeDocument(data, &job); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job %s: %w", file.Name(), err)
+		}
+		
+		jobs = append(jobs, &job)
+	}
+	
+	return jobs, nil
+}
+
+// SaveCampaign saves a campaign
+func (s *FileStorage) SaveCampaign(ctx context.Context, campaign *models.Campaign) error {
+	data, err := encodeDocument(campaign)
+	if err != nil {
+		return fmt.Errorf("failed to marshal campaign: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "campaigns", campaign.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write campaign file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetCampaign retrieves a campaign by ID
+func (s *FileStorage) GetCampaign(ctx context.Context, id string) (*models.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "campaigns", id+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campaign file: %w", err)
+	}
+	
+	var campaign models.Campaign
+	if err := decodeDocument(data, &campaign); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal campaign: %w", err)
+	}
+	
+	return &campaign, nil
+}
+
+// ListCampaigns returns all campaigns
+func (s *FileStorage) ListCampaigns(ctx context.Context) ([]*models.Campaign, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "campaigns")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read campaigns directory: %w", err)
+	}
+	
+	var campaigns []*models.Campaign
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read campaign file %s: %w", file.Name(), err)
+		}
+		
+		var campaign models.Campaign
+		if err := decodeDocument(data, &campaign); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal campaign %s: %w", file.Name(), err)
+		}
+		
+		campaigns = append(campaigns, &campaign)
+	}
+	
+	return campaigns, nil
+}
+
+// SaveCatalogRelease saves a catalog release
+func (s *FileStorage) SaveCatalogRelease(ctx context.Context, release *models.CatalogRelease) error {
+	data, err := encodeDocument(release)
+	if err != nil {
+		return fmt.Errorf("failed to marshal catalog release: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "catalog-releases", release.Version+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write catalog release file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetCatalogRelease retrieves a catalog release by version
+func (s *FileStorage) GetCatalogRelease(ctx context.Context, version string) (*models.CatalogRelease, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "catalog-releases", version+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog release file: %w", err)
+	}
+	
+	var release models.CatalogRelease
+	if err := decodeDocument(data, &release); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal catalog release: %w", err)
+	}
+	
+	return &release, nil
+}
+
+// ListCatalogReleases returns all catalog releases
+func (s *FileStorage) ListCatalogReleases(ctx context.Context) ([]*models.CatalogRelease, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "catalog-releases")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog releases directory: %w", err)
+	}
+	
+	var releases []*models.CatalogRelease
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read catalog release file %s: %w", file.Name(), err)
+		}
+		
+		var release models.CatalogRelease
+		if err := decodeDocument(data, &release); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal catalog release %s: %w", file.Name(), err)
+		}
+		
+		releases = append(releases, &release)
+	}
+	
+	return releases, nil
+}
+
+// GetScoringConfig retrieves the stored scoring configuration, or nil if none was saved
+func (s *FileStorage) GetScoringConfig(ctx context.Context) (*models.ScoringConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "config", "scoring.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scoring config file: %w", err)
+	}
+	
+	var config models.ScoringConfig
+	if err := decodeDocument(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal scoring config: %w", err)
+	}
+	
+	return &config, nil
+}
+
+// SaveScoringConfig stores the scoring configuration
+func (s *FileStorage) SaveScoringConfig(ctx context.Context, config *models.ScoringConfig) error {
+	data, err := encodeDocument(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal scoring config: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "config", "scoring.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write scoring config file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetEstimationConfig retrieves the stored estimation model, or nil if none was saved
+func (s *FileStorage) GetEstimationConfig(ctx context.Context) (*models.EstimationConfig, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "config", "estimation.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read estimation config file: %w", err)
+	}
+	
+	var config models.EstimationConfig
+	if err := decodeDocument(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal estimation config: %w", err)
+	}
+	
+	return &config, nil
+}
+
+// SaveEstimationConfig stores the estimation model
+func (s *FileStorage) SaveEstimationConfig(ctx context.Context, config *models.EstimationConfig) error {
+	data, err := encodeDocument(config)
+	if err != nil {
+		return fmt.Errorf("failed to marshal estimation config: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "config", "estimation.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write estimation config file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetBranding retrieves the stored report branding, or nil if none was saved
+func (s *FileStorage) GetBranding(ctx context.Context) (*models.Branding, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "config", "branding.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read branding file: %w", err)
+	}
+	
+	var branding models.Branding
+	if err := decodeDocument(data, &branding); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal branding: %w", err)
+	}
+	
+	return &branding, nil
+}
+
+// SaveBranding stores the report branding
+func (s *FileStorage) SaveBranding(ctx context.Context, branding *models.Branding) error {
+	data, err := encodeDocument(branding)
+	if err != nil {
+		return fmt.Errorf("failed to marshal branding: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "config", "branding.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write branding file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetReviewChecklist retrieves the stored review checklist, or nil if none was saved
+func (s *FileStorage) GetReviewChecklist(ctx context.Context) (*models.ReviewChecklist, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "config", "review-checklist.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read review checklist file: %w", err)
+	}
+	
+	var checklist models.ReviewChecklist
+	if err := decodeDocument(data, &checklist); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal review checklist: %w", err)
+	}
+	
+	return &checklist, nil
+}
+
+// SaveReviewChecklist stores the review checklist
+func (s *FileStorage) SaveReviewChecklist(ctx context.Context, checklist *models.ReviewChecklist) error {
+	data, err := encodeDocument(checklist)
+	if err != nil {
+		return fmt.Errorf("failed to marshal review checklist: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "config", "review-checklist.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write review checklist file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetPortfolioSummary retrieves the materialized portfolio summary, or nil if it has not been
+// built or was invalidated
+func (s *FileStorage) GetPortfolioSummary(ctx context.Context) (*models.PortfolioSummarySnapshot, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "views", "portfolio-summary.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read portfolio summary file: %w", err)
+	}
+	
+	var snapshot models.PortfolioSummarySnapshot
+	if err := decodeDocument(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal portfolio summary: %w", err)
+	}
+	
+	return &snapshot, nil
+}
+
+// SavePortfolioSummary stores the materialized portfolio summary
+func (s *FileStorage) SavePortfolioSummary(ctx context.Context, snapshot *models.PortfolioSummarySnapshot) error {
+	data, err := encodeDocument(snapshot)
+	if err != nil {
+		return fmt.Errorf("failed to marshal portfolio summary: %w", err)
+	}
+	
+	// Readers on other replicas must never see a partially written summary
+	path := filepath.Join(s.basePath, "views", "portfolio-summary.json")
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return fmt.Errorf("failed to write portfolio summary file: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to replace portfolio summary file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeletePortfolioSummary invalidates the materialized portfolio summary; deleting a missing
+// summary is not an error
+func (s *FileStorage) DeletePortfolioSummary(ctx context.Context) error {
+	path := filepath.Join(s.basePath, "views", "portfolio-summary.json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete portfolio summary file: %w", err)
+	}
+	
+	return nil
+}
+
+// subscriptionPath returns the file of a user's digest subscription; user names are escaped
+// since they may contain path separators
+func (s *FileStorage) subscriptionPath(user string) string {
+	return filepath.Join(s.basePath, "subscriptions", url.PathEscape(user)+".json")
+}
+
+// GetDigestSubscription retrieves a user's digest subscription
+func (s *FileStorage) GetDigestSubscription(ctx context.Context, user string) (*models.DigestSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	data, err := os.ReadFile(s.subscriptionPath(user))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subscription file: %w", err)
+	}
+	
+	var subscription models.DigestSubscription
+	if err := decodeDocument(data, &subscription); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal subscription: %w", err)
+	}
+	
+	return &subscription, nil
+}
+
+// ListDigestSubscriptions returns all digest subscriptions
+func (s *FileStorage) ListDigestSubscriptions(ctx context.Context) ([]*models.DigestSubscription, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "subscriptions")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subscriptions directory: %w", err)
+	}
+	
+	var subscriptions []*models.DigestSubscription
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read subscription file %s: %w", file.Name(), err)
+		}
+		
+		var subscription models.DigestSubscription
+		if err := decodeDocument(data, &subscription); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal subscription %s: %w", file.Name(), err)
+		}
+		
+		subscriptions = append(subscriptions, &subscription)
+	}
+	
+	return subscriptions, nil
+}
+
+// SaveDigestSubscription creates or replaces a user's digest subscription
+func (s *FileStorage) SaveDigestSubscription(ctx context.Context, subscription *models.DigestSubscription) error {
+	data, err := encodeDocument(subscription)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subscription: %w", err)
+	}
+	
+	if err := os.WriteFile(s.subscriptionPath(subscription.User), data, 0644); err != nil {
+		return fmt.Errorf("failed to write subscription file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteDigestSubscription removes a user's digest subscription; deleting a missing
+// subscription is not an error
+func (s *FileStorage) DeleteDigestSubscription(ctx context.Context, user string) error {
+	if err := os.Remove(s.subscriptionPath(user)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete subscription file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetDigestState retrieves the digest schedule state, or nil if no digest was sent yet
+func (s *FileStorage) GetDigestState(ctx context.Context) (*models.DigestState, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "config", "digest-state.json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest state file: %w", err)
+	}
+	
+	var state models.DigestState
+	if err := decodeDocument(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal digest state: %w", err)
+	}
+	
+	return &state, nil
+}
+
+// SaveDigestState stores the digest schedule state
+func (s *FileStorage) SaveDigestState(ctx context.Context, state *models.DigestState) error {
+	data, err := encodeDocument(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal digest state: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "config", "digest-state.json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write digest state file: %w", err)
+	}
+	
+	return nil
+}
+
+// categoryPath returns the file of a category; names are escaped since they are free text
+func (s *FileStorage) categoryPath(name string) string {
+	return filepath.Join(s.basePath, "categories", url.PathEscape(name)+".json")
+}
+
+// GetCategory retrieves a category by name
+func (s *FileStorage) GetCategory(ctx context.Context, name string) (*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	data, err := os.ReadFile(s.categoryPath(name))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read category file: %w", err)
+	}
+	
+	var category models.Category
+	if err := decodeDocument(data, &category); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal category: %w", err)
+	}
+	
+	return &category, nil
+}
+
+// ListCategories returns all categories
+func (s *FileStorage) ListCategories(ctx context.Context) ([]*models.Category, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "categories")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read categories directory: %w", err)
+	}
+	
+	var categories []*models.Category
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read category file %s: %w", file.Name(), err)
+		}
+		
+		var category models.Category
+		if err := decodeDocument(data, &category); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal category %s: %w", file.Name(), err)
+		}
+		
+		categories = append(categories, &category)
+	}
+	
+	return categories, nil
+}
+
+// SaveCategory creates or replaces a category
+func (s *FileStorage) SaveCategory(ctx context.Context, category *models.Category) error {
+	data, err := encodeDocument(category)
+	if err != nil {
+		return fmt.Errorf("failed to marshal category: %w", err)
+	}
+	
+	if err := os.WriteFile(s.categoryPath(category.Name), data, 0644); err != nil {
+		return fmt.Errorf("failed to write category file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteCategory removes a category; deleting a missing category is not an error
+func (s *FileStorage) DeleteCategory(ctx context.Context, name string) error {
+	if err := os.Remove(s.categoryPath(name)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete category file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetSection retrieves a section by ID
+func (s *FileStorage) GetSection(ctx context.Context, id string) (*models.Section, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "sections", id+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read section file: %w", err)
+	}
+	
+	var section models.Section
+	if err := decodeDocument(data, &section); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal section: %w", err)
+	}
+	
+	return &section, nil
+}
+
+// ListSections returns all sections
+func (s *FileStorage) ListSections(ctx context.Context) ([]*models.Section, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "sections")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sections directory: %w", err)
+	}
+	
+	var sections []*models.Section
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read section file %s: %w", file.Name(), err)
+		}
+		
+		var section models.Section
+		if err := decodeDocument(data, &section); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal section %s: %w", file.Name(), err)
+		}
+		
+		sections = append(sections, &section)
+	}
+	
+	return sections, nil
+}
+
+// SaveSection creates or replaces a section
+func (s *FileStorage) SaveSection(ctx context.Context, section *models.Section) error {
+	data, err := encodeDocument(section)
+	if err != nil {
+		return fmt.Errorf("failed to marshal section: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "sections", section.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write section file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteSection removes a section; deleting a missing section is not an error
+func (s *FileStorage) DeleteSection(ctx context.Context, id string) error {
+	path := filepath.Join(s.basePath, "sections", id+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete section file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetArchetype retrieves an archetype by ID
+func (s *FileStorage) GetArchetype(ctx context.Context, id string) (*models.Archetype, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "archetypes", id+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archetype file: %w", err)
+	}
+	
+	var archetype models.Archetype
+	if err := decodeDocument(data, &archetype); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archetype: %w", err)
+	}
+	
+	return &archetype, nil
+}
+
+// ListArchetypes returns all archetypes
+func (s *FileStorage) ListArchetypes(ctx context.Context) ([]*models.Archetype, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "archetypes")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archetypes directory: %w", err)
+	}
+	
+	var archetypes []*models.Archetype
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archetype file %s: %w", file.Name(), err)
+		}
+		
+		var archetype models.Archetype
+		if err := decodeDocument(data, &archetype); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal archetype %s: %w", file.Name(), err)
+		}
+		
+		archetypes = append(archetypes, &archetype)
+	}
+	
+	return archetypes, nil
+}
+
+// SaveArchetype creates or replaces an archetype
+func (s *FileStorage) SaveArchetype(ctx context.Context, archetype *models.Archetype) error {
+	data, err := encodeDocument(archetype)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archetype: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "archetypes", archetype.ID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write archetype file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteArchetype removes an archetype; deleting a missing archetype is not an error
+func (s *FileStorage) DeleteArchetype(ctx context.Context, id string) error {
+	path := filepath.Join(s.basePath, "archetypes", id+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete archetype file: %w", err)
+	}
+	
+	return nil
+}
+
+// GetMessageCatalog retrieves the message catalog of a locale
+func (s *FileStorage) GetMessageCatalog(ctx context.Context, locale string) (*models.MessageCatalog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "translations", locale+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message catalog file: %w", err)
+	}
+	
+	var catalog models.MessageCatalog
+	if err := decodeDocument(data, &catalog); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message catalog: %w", err)
+	}
+	
+	return &catalog, nil
+}
+
+// ListMessageCatalogs returns the message catalogs of all locales
+func (s *FileStorage) ListMessageCatalogs(ctx context.Context) ([]*models.MessageCatalog, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "translations")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read translations directory: %w", err)
+	}
+	
+	var catalogs []*models.MessageCatalog
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read message catalog file %s: %w", file.Name(), err)
+		}
+		
+		var catalog models.MessageCatalog
+		if err := decodeDocument(data, &catalog); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal message catalog %s: %w", file.Name(), err)
+		}
+		
+		catalogs = append(catalogs, &catalog)
+	}
+	
+	return catalogs, nil
+}
+
+// SaveMessageCatalog creates or replaces the message catalog of a locale
+func (s *FileStorage) SaveMessageCatalog(ctx context.Context, catalog *models.MessageCatalog) error {
+	data, err := encodeDocument(catalog)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message catalog: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "translations", catalog.Locale+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write message catalog file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteMessageCatalog removes the message catalog of a locale; deleting a missing catalog is
+// not an error
+func (s *FileStorage) DeleteMessageCatalog(ctx context.Context, locale string) error {
+	path := filepath.Join(s.basePath, "translations", locale+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete message catalog file: %w", err)
+	}
+	
+	return nil
+}
+
+// Size returns the bytes used by all files below the base path
+func (s *FileStorage) Size(ctx context.Context) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(s.basePath, func(path string, entry os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		// Files replaced while walking, such as temporary outbox files, are skipped
+		info, err := entry.Info()
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to measure storage size: %w", err)
+	}
+	
+	return size, nil
+}
+
+// GetWorkshop retrieves the workshop of an assessment
+func (s *FileStorage) GetWorkshop(ctx context.Context, assessmentID string) (*models.Workshop, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	path := filepath.Join(s.basePath, "workshops", assessmentID+".json")
+	
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workshop file: %w", err)
+	}
+	
+	var workshop models.Workshop
+	if err := decodeDocument(data, &workshop); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workshop: %w", err)
+	}
+	
+	return &workshop, nil
+}
+
+// ListWorkshops returns all workshops
+func (s *FileStorage) ListWorkshops(ctx context.Context) ([]*models.Workshop, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	
+	dir := filepath.Join(s.basePath, "workshops")
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workshops directory: %w", err)
+	}
+	
+	var workshops []*models.Workshop
+	for _, file := range files {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		
+		if filepath.Ext(file.Name()) != ".json" {
+			continue
+		}
+		
+		path := filepath.Join(dir, file.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read workshop file %s: %w", file.Name(), err)
+		}
+		
+		var workshop models.Workshop
+		if err := decodeDocument(data, &workshop); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal workshop %s: %w", file.Name(), err)
+		}
+		
+		workshops = append(workshops, &workshop)
+	}
+	
+	return workshops, nil
+}
+
+// SaveWorkshop creates or replaces the workshop of an assessment
+func (s *FileStorage) SaveWorkshop(ctx context.Context, workshop *models.Workshop) error {
+	data, err := encodeDocument(workshop)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workshop: %w", err)
+	}
+	
+	path := filepath.Join(s.basePath, "workshops", workshop.AssessmentID+".json")
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write workshop file: %w", err)
+	}
+	
+	return nil
+}
+
+// DeleteWorkshop removes the workshop of an assessment; deleting a missing workshop is not an error
+func (s *FileStorage) DeleteWorkshop(ctx context.Context, assessmentID string) error {
+	path := filepath.Join(s.basePath, "workshops", assessmentID+".json")
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to delete workshop file: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,18 @@
+package storage_test
+
+import (
+	"testing"
+	
+	"questionnaire-app/internal/storage"
+	"questionnaire-app/internal/storage/storagetest"
+)
+
+func TestFileStorage(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.Storage {
+		store, err := storage.NewFileStorage(t.TempDir())
+		if err != nil {
+			t.Fatalf("NewFileStorage: %v", err)
+		}
+		return store
+	})
+}
@@ -0,0 +1,758 @@
+// Package storagetest is a conformance suite for storage backends. A backend passes when it
+// behaves like FileStorage:
+//
+//	func TestMemoryStorage(t *testing.T) {
+//		storagetest.Run(t, func(t *testing.T) storage.Storage {
+//			return NewMemoryStorage()
+//		})
+//	}
+//
+// Backends implementing only some repositories can run the matching functions directly.
+package storagetest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"questionnaire-app/internal/models"
+	"questionnaire-app/internal/storage"
+	"sort"
+	"testing"
+	"time"
+)
+
+// Run verifies every repository of a storage backend. newStorage must return an empty backend
+// on each call; cleanup can be registered with t.Cleanup.
+func Run(t *testing.T, newStorage func(t *testing.T) storage.Storage) {
+	t.Run("Applications", func(t *testing.T) { Applications(t, newStorage(t)) })
+	t.Run("Questions", func(t *testing.T) { Questions(t, newStorage(t)) })
+	t.Run("Categories", func(t *testing.T) { Categories(t, newStorage(t)) })
+	t.Run("Sections", func(t *testing.T) { Sections(t, newStorage(t)) })
+	t.Run("Archetypes", func(t *testing.T) { Archetypes(t, newStorage(t)) })
+	t.Run("Translations", func(t *testing.T) { Translations(t, newStorage(t)) })
+	t.Run("Assessments", func(t *testing.T) { Assessments(t, newStorage(t)) })
+	t.Run("Events", func(t *testing.T) { Events(t, newStorage(t)) })
+	t.Run("Reports", func(t *testing.T) { Reports(t, newStorage(t)) })
+	t.Run("Outbox", func(t *testing.T) { Outbox(t, newStorage(t)) })
+	t.Run("Jobs", func(t *testing.T) { Jobs(t, newStorage(t)) })
+	t.Run("Campaigns", func(t *testing.T) { Campaigns(t, newStorage(t)) })
+	t.Run("CatalogReleases", func(t *testing.T) { CatalogReleases(t, newStorage(t)) })
+	t.Run("Config", func(t *testing.T) { Config(t, newStorage(t)) })
+	t.Run("Views", func(t *testing.T) { Views(t, newStorage(t)) })
+	t.Run("Digests", func(t *testing.T) { Digests(t, newStorage(t)) })
+	t.Run("Workshops", func(t *testing.T) { Workshops(t, newStorage(t)) })
+	t.Run("CanceledContext", func(t *testing.T) { CanceledContext(t, newStorage(t)) })
+}
+
+// Applications verifies an application repository
+func Applications(t *testing.T, repo storage.ApplicationRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetApplication(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetApplication of a missing application = %+v, want nil", missing)
+	}
+	
+	apps := []*models.Application{
+		{ID: "app-a", Name: "A", Description: "First", Tags: map[string]string{"owner": "team-a"}, Repository: "https://example.com/a.git", DependsOn: []string{"app-b"}},
+		{ID: "app-b", Name: "B", Tags: map[string]string{}},
+	}
+	for _, app := range apps {
+		must(t, repo.SaveApplication(ctx, app))
+	}
+	
+	got, err := repo.GetApplication(ctx, "app-a")
+	must(t, err)
+	assertSame(t, "GetApplication", apps[0], got)
+	
+	apps[1].Name = "B renamed"
+	must(t, repo.SaveApplication(ctx, apps[1]))
+	list, err := repo.ListApplications(ctx)
+	must(t, err)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	assertSame(t, "ListApplications", apps, list)
+	
+	must(t, repo.DeleteApplication(ctx, "app-a"))
+	must(t, repo.DeleteApplication(ctx, "app-a"))
+	list, err = repo.ListApplications(ctx)
+	must(t, err)
+	assertSame(t, "ListApplications after delete", apps[1:], list)
+}
+
+// Questions verifies a question repository
+func Questions(t *testing.T, repo storage.QuestionRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetQuestion(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetQuestion of a missing question = %+v, want nil", missing)
+	}
+	
+	question := &models.Question{
+		ID:       "q1",
+		Text:     "Is the application stateless?",
+		Category: "Architecture",
+		Weight:   5,
+		Help:     "Sessions kept **in memory** count as state.",
+		Options: []models.Option{
+			{ID: "q1_a1", Text: "Yes", Points: 10},
+			{ID: "q1_a2", Text: "No", Points: 0},
+		},
+		VisibleWhen: []models.Condition{{QuestionID: "q0", OptionIDs: []string{"q0_a1"}}},
+	}
+	must(t, repo.SaveQuestion(ctx, question))
+	
+	got, err := repo.GetQuestion(ctx, "q1")
+	must(t, err)
+	assertSame(t, "GetQuestion", question, got)
+	
+	question.Text = "Is the application fully stateless?"
+	must(t, repo.SaveQuestion(ctx, question))
+	list, err := repo.GetQuestions(ctx)
+	must(t, err)
+	assertSame(t, "GetQuestions", []*models.Question{question}, list)
+}
+
+// Assessments verifies an assessment repository
+func Assessments(t *testing.T, repo storage.AssessmentRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetAssessment(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetAssessment of a missing assessment = %+v, want nil", missing)
+	}
+	
+	assessments := []*models.Assessment{
+		{ID: "as-1", ApplicationID: "app-a", CreatedAt: timestamp("2026-01-01T00:00:00Z"), Answers: map[string]string{"q1": "q1_a1"}, Status: "in_progress", StartedBy: "alice"},
+		{ID: "as-2", ApplicationID: "app-a", CreatedAt: timestamp("2026-01-02T00:00:00Z"), Answers: map[string]string{}, Status: "in_progress"},
+		{ID: "as-3", ApplicationID: "app-b", CreatedAt: timestamp("2026-01-03T00:00:00Z"), Answers: map[string]string{}, Status: "in_progress"},
+	}
+	for _, assessment := range assessments {
+		must(t, repo.CreateAssessment(ctx, assessment))
+	}
+	
+	assessments[0].Status = "completed"
+	completedAt := timestamp("2026-01-04T00:00:00Z")
+	assessments[0].CompletedAt = &completedAt
+	must(t, repo.UpdateAssessment(ctx, assessments[0]))
+	got, err := repo.GetAssessment(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "GetAssessment after update", assessments[0], got)
+	
+	list, err := repo.ListAssessments(ctx, "app-a")
+	must(t, err)
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	assertSame(t, "ListAssessments of an application", assessments[:2], list)
+	
+	all, err := repo.ListAssessments(ctx, "")
+	must(t, err)
+	if len(all) != 3 {
+		t.Errorf("ListAssessments of all applications returned %d assessments, want 3", len(all))
+	}
+	
+	must(t, repo.DeleteAssessment(ctx, "as-2"))
+	must(t, repo.DeleteAssessment(ctx, "as-2"))
+	list, err = repo.ListAssessments(ctx, "app-a")
+	must(t, err)
+	assertSame(t, "ListAssessments after delete", assessments[:1], list)
+}
+
+// Events verifies an event repository
+func Events(t *testing.T, repo storage.EventRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.ListEvents(ctx, "missing")
+	must(t, err)
+	if len(missing) != 0 {
+		t.Errorf("ListEvents of an assessment without events returned %d events, want none", len(missing))
+	}
+	
+	events := []*models.AssessmentEvent{
+		{AssessmentID: "as-1", Sequence: 1, Type: models.EventAssessmentStarted, OccurredAt: timestamp("2026-01-01T00:00:00Z"), User: "alice",
+			Assessment: &models.Assessment{ID: "as-1", ApplicationID: "app-a", Answers: map[string]string{}, Status: "in_progress"}},
+		{AssessmentID: "as-1", Sequence: 2, Type: models.EventAnswerSaved, OccurredAt: timestamp("2026-01-01T00:01:00Z"), QuestionID: "q1", OptionID: "q1_a1"},
+		{AssessmentID: "as-1", Sequence: 3, Type: models.EventAssessmentCompleted, OccurredAt: timestamp("2026-01-01T00:02:00Z")},
+	}
+	for _, event := range events {
+		must(t, repo.AppendEvent(ctx, event))
+	}
+	must(t, repo.AppendEvent(ctx, &models.AssessmentEvent{AssessmentID: "as-2", Sequence: 1, Type: models.EventAssessmentStarted}))
+	
+	list, err := repo.ListEvents(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "ListEvents keeps append order", events, list)
+	
+	events[0].User = models.AnonymizedUser
+	must(t, repo.ReplaceEvents(ctx, "as-1", events[:2]))
+	list, err = repo.ListEvents(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "ListEvents after replace", events[:2], list)
+	
+	must(t, repo.DeleteEvents(ctx, "as-1"))
+	must(t, repo.DeleteEvents(ctx, "as-1"))
+	list, err = repo.ListEvents(ctx, "as-1")
+	must(t, err)
+	if len(list) != 0 {
+		t.Errorf("ListEvents after delete returned %d events, want none", len(list))
+	}
+	
+	other, err := repo.ListEvents(ctx, "as-2")
+	must(t, err)
+	if len(other) != 1 {
+		t.Errorf("deleting one event log changed another: got %d events, want 1", len(other))
+	}
+}
+
+// Reports verifies a report repository
+func Reports(t *testing.T, repo storage.ReportRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetReport(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetReport of a missing report = %+v, want nil", missing)
+	}
+	
+	reports := []*models.Report{
+		{AssessmentID: "as-1", ApplicationID: "app-a", GeneratedAt: timestamp("2026-01-01T00:00:00Z"), TotalScore: 40, MaxPossibleScore: 50,
+			CategoryScores: map[string]int{"Architecture": 40}, Band: models.BandHigh, Grade: "B",
+			Recommendations: []models.Recommendation{{Category: "Architecture", Description: "Keep it up", Priority: "Low"}},
+			Risks:           []models.Risk{},
+			ModernizationPlan: []models.ModernizationStep{{Order: 1, Description: "Containerize", Effort: "Medium",
+				Estimate: &models.StepEstimate{MinHours: 40, MaxHours: 160, MinCost: 4000, MaxCost: 16000}}},
+			Annotations: []models.Annotation{{ID: "n1", Section: "category", Target: "Architecture", Author: "bob", Text: "Checked", CreatedAt: timestamp("2026-01-02T00:00:00Z")}},
+		},
+		{AssessmentID: "as-2", ApplicationID: "app-b", GeneratedAt: timestamp("2026-01-03T00:00:00Z"), CategoryScores: map[string]int{},
+			Recommendations: []models.Recommendation{}, Risks: []models.Risk{}, ModernizationPlan: []models.ModernizationStep{}},
+		{AssessmentID: "as-3", ApplicationID: "app-b", GeneratedAt: timestamp("2026-01-04T00:00:00Z"), CategoryScores: map[string]int{},
+			Recommendations: []models.Recommendation{}, Risks: []models.Risk{}, ModernizationPlan: []models.ModernizationStep{}},
+	}
+	for _, report := range reports {
+		must(t, repo.SaveReport(ctx, report))
+	}
+	
+	got, err := repo.GetReport(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "GetReport", reports[0], got)
+	
+	list, err := repo.ListReports(ctx)
+	must(t, err)
+	sort.Slice(list, func(i, j int) bool { return list[i].AssessmentID < list[j].AssessmentID })
+	assertSame(t, "ListReports", reports, list)
+	
+	must(t, repo.DeleteReport(ctx, "as-2"))
+	must(t, repo.DeleteReport(ctx, "as-2"))
+	must(t, repo.ArchiveReport(ctx, "as-3"))
+	
+	archived, err := repo.GetReport(ctx, "as-3")
+	must(t, err)
+	if archived != nil {
+		t.Errorf("GetReport of an archived report = %+v, want nil", archived)
+	}
+	list, err = repo.ListReports(ctx)
+	must(t, err)
+	assertSame(t, "ListReports after delete and archive", reports[:1], list)
+	
+	// Superseded versions are kept apart from the current report
+	none, err := repo.ListReportVersions(ctx, "as-1")
+	must(t, err)
+	if len(none) != 0 {
+		t.Errorf("ListReportVersions without versions = %+v, want none", none)
+	}
+	
+	second := *reports[0]
+	second.Version = 2
+	first := *reports[0]
+	first.Version = 1
+	must(t, repo.SaveReportVersion(ctx, &second))
+	must(t, repo.SaveReportVersion(ctx, &first))
+	versions, err := repo.ListReportVersions(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "ListReportVersions", []*models.Report{&first, &second}, versions)
+	
+	got, err = repo.GetReport(ctx, "as-1")
+	must(t, err)
+	assertSame(t, "GetReport after saving versions", reports[0], got)
+	
+	must(t, repo.DeleteReport(ctx, "as-1"))
+	versions, err = repo.ListReportVersions(ctx, "as-1")
+	must(t, err)
+	if len(versions) != 0 {
+		t.Errorf("ListReportVersions after DeleteReport = %+v, want none", versions)
+	}
+}
+
+// Outbox verifies an outbox repository
+func Outbox(t *testing.T, repo storage.OutboxRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetOutboxMessage(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetOutboxMessage of a missing message = %+v, want nil", missing)
+	}
+	
+	messages := []*models.OutboxMessage{
+		{ID: "m1", Target: "hook", Event: models.EventTypeAssessmentCompleted, Payload: map[string]interface{}{"assessmentId": "as-1"},
+			Status: models.OutboxPending, CreatedAt: timestamp("2026-01-01T00:00:00Z"), NextAttemptAt: timestamp("2026-01-01T00:00:00Z")},
+		{ID: "m2", Target: "hook", Event: models.EventTypeAssessmentCompleted, Payload: map[string]interface{}{},
+			Status: models.OutboxDead, Attempts: 5, CreatedAt: timestamp("2026-01-01T00:00:00Z"), NextAttemptAt: timestamp("2026-01-01T01:00:00Z"), LastError: "timeout"},
+	}
+	for _, message := range messages {
+		must(t, repo.SaveOutboxMessage(ctx, message))
+	}
+	
+	got, err := repo.GetOutboxMessage(ctx, "m1")
+	must(t, err)
+	assertSame(t, "GetOutboxMessage", messages[0], got)
+	
+	dead, err := repo.ListOutboxMessages(ctx, models.OutboxDead)
+	must(t, err)
+	assertSame(t, "ListOutboxMessages by status", messages[1:], dead)
+	
+	all, err := repo.ListOutboxMessages(ctx, "")
+	must(t, err)
+	if len(all) != 2 {
+		t.Errorf("ListOutboxMessages of all statuses returned %d messages, want 2", len(all))
+	}
+	
+	must(t, repo.DeleteOutboxMessage(ctx, "m1"))
+	must(t, repo.DeleteOutboxMessage(ctx, "m1"))
+	pending, err := repo.ListOutboxMessages(ctx, models.OutboxPending)
+	must(t, err)
+	if len(pending) != 0 {
+		t.Errorf("ListOutboxMessages after delete returned %d pending messages, want none", len(pending))
+	}
+}
+
+// Jobs verifies a job repository
+func Jobs(t *testing.T, repo storage.JobRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetJob(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetJob of a missing job = %+v, want nil", missing)
+	}
+	
+	job := &models.Job{ID: "j1", Type: "retention", Status: models.JobQueued, Params: json.RawMessage(`{"dryRun":true}`), CreatedAt: timestamp("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveJob(ctx, job))
+	
+	job.Status = models.JobSucceeded
+	job.Result = json.RawMessage(`{"actions":[]}`)
+	job.FinishedAt = timestampRef("2026-01-01T00:01:00Z")
+	must(t, repo.SaveJob(ctx, job))
+	
+	got, err := repo.GetJob(ctx, "j1")
+	must(t, err)
+	assertSame(t, "GetJob after update", job, got)
+	
+	list, err := repo.ListJobs(ctx)
+	must(t, err)
+	assertSame(t, "ListJobs", []*models.Job{job}, list)
+}
+
+// Campaigns verifies a campaign repository
+func Campaigns(t *testing.T, repo storage.CampaignRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetCampaign(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetCampaign of a missing campaign = %+v, want nil", missing)
+	}
+	
+	campaign := &models.Campaign{ID: "c1", Name: "Q3 review", Owner: "carol", DueDate: "2026-09-30",
+		ApplicationIDs: []string{"app-a", "app-b"}, CreatedAt: timestamp("2026-07-01T00:00:00Z"), CreatedBy: "carol"}
+	must(t, repo.SaveCampaign(ctx, campaign))
+	
+	got, err := repo.GetCampaign(ctx, "c1")
+	must(t, err)
+	assertSame(t, "GetCampaign", campaign, got)
+	
+	list, err := repo.ListCampaigns(ctx)
+	must(t, err)
+	assertSame(t, "ListCampaigns", []*models.Campaign{campaign}, list)
+}
+
+// CatalogReleases verifies a catalog release repository
+func CatalogReleases(t *testing.T, repo storage.CatalogReleaseRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetCatalogRelease(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetCatalogRelease of a missing release = %+v, want nil", missing)
+	}
+	
+	releases := []*models.CatalogRelease{
+		{Version: "2026.1", Notes: "Initial catalog", Source: "central", PublishedBy: "carol", PublishedAt: timestamp("2026-07-01T00:00:00Z"),
+			Checksum: "sha256:0a", QuestionCount: 1, Questions: []*models.Question{{ID: "q1", Text: "Stateless?", Category: "Runtime", Weight: 2,
+				Options: []models.Option{{ID: "q1_a1", Text: "Yes", Points: 10}}}},
+			Categories: []*models.Category{{Name: "Runtime", Order: 1}}},
+		{Version: "2026.2", PublishedAt: timestamp("2026-08-01T00:00:00Z"), Checksum: "sha256:0b", ReceivedAt: timestampRef("2026-08-02T00:00:00Z")},
+	}
+	for _, release := range releases {
+		must(t, repo.SaveCatalogRelease(ctx, release))
+	}
+	
+	got, err := repo.GetCatalogRelease(ctx, "2026.1")
+	must(t, err)
+	assertSame(t, "GetCatalogRelease", releases[0], got)
+	
+	list, err := repo.ListCatalogReleases(ctx)
+	must(t, err)
+	assertSame(t, "ListCatalogReleases", releases, list)
+}
+
+// Config verifies a configuration repository
+func Config(t *testing.T, repo storage.ConfigRepository) {
+	ctx := context.Background()
+	
+	scoring, err := repo.GetScoringConfig(ctx)
+	must(t, err)
+	if scoring != nil {
+		t.Errorf("GetScoringConfig before saving = %+v, want nil", scoring)
+	}
+	estimation, err := repo.GetEstimationConfig(ctx)
+	must(t, err)
+	if estimation != nil {
+		t.Errorf("GetEstimationConfig before saving = %+v, want nil", estimation)
+	}
+	branding, err := repo.GetBranding(ctx)
+	must(t, err)
+	if branding != nil {
+		t.Errorf("GetBranding before saving = %+v, want nil", branding)
+	}
+	checklist, err := repo.GetReviewChecklist(ctx)
+	must(t, err)
+	if checklist != nil {
+		t.Errorf("GetReviewChecklist before saving = %+v, want nil", checklist)
+	}
+	
+	wantScoring := &models.ScoringConfig{
+		Bands:     []models.ScoreBand{{Level: models.BandLow, Label: "Not ready", MinRatio: 0}, {Level: models.BandHigh, Label: "Ready", MinRatio: 0.7}},
+		Grades:    []models.GradeThreshold{{Grade: "A", MinRatio: 0.9}, {Grade: "F", MinRatio: 0}},
+		UpdatedAt: timestampRef("2026-01-01T00:00:00Z"),
+	}
+	must(t, repo.SaveScoringConfig(ctx, wantScoring))
+	scoring, err = repo.GetScoringConfig(ctx)
+	must(t, err)
+	assertSame(t, "GetScoringConfig", wantScoring, scoring)
+	
+	wantEstimation := &models.EstimationConfig{Currency: "EUR", HourlyRate: 90,
+		Efforts: []models.EffortRange{{Label: "Low", MinHours: 4, MaxHours: 16}}, UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveEstimationConfig(ctx, wantEstimation))
+	estimation, err = repo.GetEstimationConfig(ctx)
+	must(t, err)
+	assertSame(t, "GetEstimationConfig", wantEstimation, estimation)
+	
+	wantBranding := &models.Branding{Name: "Example Consulting", LogoURL: "https://example.com/logo.png",
+		PrimaryColor: "#123456", Footer: "Confidential", UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveBranding(ctx, wantBranding))
+	branding, err = repo.GetBranding(ctx)
+	must(t, err)
+	assertSame(t, "GetBranding", wantBranding, branding)
+	
+	wantChecklist := &models.ReviewChecklist{Items: []models.ChecklistItem{{ID: "evidence-reviewed", Text: "Evidence reviewed"}},
+		UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveReviewChecklist(ctx, wantChecklist))
+	checklist, err = repo.GetReviewChecklist(ctx)
+	must(t, err)
+	assertSame(t, "GetReviewChecklist", wantChecklist, checklist)
+}
+
+// Views verifies a materialized view repository
+func Views(t *testing.T, repo storage.ViewRepository) {
+	ctx := context.Background()
+	
+	summary, err := repo.GetPortfolioSummary(ctx)
+	must(t, err)
+	if summary != nil {
+		t.Errorf("GetPortfolioSummary before saving = %+v, want nil", summary)
+	}
+	
+	snapshot := &models.PortfolioSummarySnapshot{
+		Summary: models.PortfolioSummary{Applications: 2, Assessed: 1, AverageScorePercent: 80,
+			Bands: map[string]int{models.BandHigh: 1}, Grades: map[string]int{"B": 1},
+			CategoryAverages: map[string]float64{"Architecture": 40}, BuiltAt: timestamp("2026-01-01T00:00:00Z"), UpdatedAt: timestamp("2026-01-01T00:00:00Z")},
+		Entries: map[string]models.SummaryEntry{"app-a": {
+			LatestScore:    models.LatestScore{AssessmentID: "as-1", GeneratedAt: timestamp("2026-01-01T00:00:00Z"), ScorePercent: 80, Band: models.BandHigh, Grade: "B"},
+			CategoryScores: map[string]int{"Architecture": 40},
+		}},
+	}
+	must(t, repo.SavePortfolioSummary(ctx, snapshot))
+	summary, err = repo.GetPortfolioSummary(ctx)
+	must(t, err)
+	assertSame(t, "GetPortfolioSummary", snapshot, summary)
+	
+	must(t, repo.DeletePortfolioSummary(ctx))
+	must(t, repo.DeletePortfolioSummary(ctx))
+	summary, err = repo.GetPortfolioSummary(ctx)
+	must(t, err)
+	if summary != nil {
+		t.Errorf("GetPortfolioSummary after delete = %+v, want nil", summary)
+	}
+}
+
+// Categories verifies a category repository
+func Categories(t *testing.T, repo storage.CategoryRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetCategory(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetCategory of a missing category = %+v, want nil", missing)
+	}
+	
+	// Category names are free text and may contain path separators
+	category := &models.Category{Name: "CI/CD", Description: "Build and release automation", Order: 2,
+		Icon: "rocket", Weight: 2, UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveCategory(ctx, category))
+	
+	got, err := repo.GetCategory(ctx, "CI/CD")
+	must(t, err)
+	assertSame(t, "GetCategory", category, got)
+	
+	list, err := repo.ListCategories(ctx)
+	must(t, err)
+	assertSame(t, "ListCategories", []*models.Category{category}, list)
+	
+	must(t, repo.DeleteCategory(ctx, "CI/CD"))
+	must(t, repo.DeleteCategory(ctx, "CI/CD"))
+	got, err = repo.GetCategory(ctx, "CI/CD")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetCategory after delete = %+v, want nil", got)
+	}
+}
+
+// Sections verifies a section repository
+func Sections(t *testing.T, repo storage.SectionRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetSection(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetSection of a missing section = %+v, want nil", missing)
+	}
+	
+	section := &models.Section{ID: "runtime", Title: "Runtime", Intro: "How the application **runs**", Order: 1,
+		QuestionIDs: []string{"q2", "q1"}, UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveSection(ctx, section))
+	
+	got, err := repo.GetSection(ctx, "runtime")
+	must(t, err)
+	assertSame(t, "GetSection", section, got)
+	
+	list, err := repo.ListSections(ctx)
+	must(t, err)
+	assertSame(t, "ListSections", []*models.Section{section}, list)
+	
+	must(t, repo.DeleteSection(ctx, "runtime"))
+	must(t, repo.DeleteSection(ctx, "runtime"))
+	got, err = repo.GetSection(ctx, "runtime")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetSection after delete = %+v, want nil", got)
+	}
+}
+
+// Archetypes verifies an archetype repository
+func Archetypes(t *testing.T, repo storage.ArchetypeRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetArchetype(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetArchetype of a missing archetype = %+v, want nil", missing)
+	}
+	
+	archetype := &models.Archetype{ID: "spring-boot", Name: "Spring Boot web service", Description: "Stateless REST service",
+		Answers: []models.ArchetypeAnswer{{QuestionID: "q1", OptionID: "q1_a1"}}, UpdatedAt: timestampRef("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveArchetype(ctx, archetype))
+	
+	got, err := repo.GetArchetype(ctx, "spring-boot")
+	must(t, err)
+	assertSame(t, "GetArchetype", archetype, got)
+	
+	list, err := repo.ListArchetypes(ctx)
+	must(t, err)
+	assertSame(t, "ListArchetypes", []*models.Archetype{archetype}, list)
+	
+	must(t, repo.DeleteArchetype(ctx, "spring-boot"))
+	must(t, repo.DeleteArchetype(ctx, "spring-boot"))
+	got, err = repo.GetArchetype(ctx, "spring-boot")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetArchetype after delete = %+v, want nil", got)
+	}
+}
+
+// Translations verifies a message catalog repository
+func Translations(t *testing.T, repo storage.TranslationRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetMessageCatalog(ctx, "fr")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetMessageCatalog of a missing locale = %+v, want nil", missing)
+	}
+	
+	catalog := &models.MessageCatalog{Locale: "pt-BR", UpdatedAt: timestampRef("2026-01-01T00:00:00Z"),
+		Messages: map[string]string{"Implement health checks": "Implementar verificações de saúde"}}
+	must(t, repo.SaveMessageCatalog(ctx, catalog))
+	
+	got, err := repo.GetMessageCatalog(ctx, "pt-BR")
+	must(t, err)
+	assertSame(t, "GetMessageCatalog", catalog, got)
+	
+	list, err := repo.ListMessageCatalogs(ctx)
+	must(t, err)
+	assertSame(t, "ListMessageCatalogs", []*models.MessageCatalog{catalog}, list)
+	
+	must(t, repo.DeleteMessageCatalog(ctx, "pt-BR"))
+	must(t, repo.DeleteMessageCatalog(ctx, "pt-BR"))
+	got, err = repo.GetMessageCatalog(ctx, "pt-BR")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetMessageCatalog after delete = %+v, want nil", got)
+	}
+}
+
+// Digests verifies a digest subscription repository
+func Digests(t *testing.T, repo storage.DigestRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetDigestSubscription(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetDigestSubscription of a missing subscription = %+v, want nil", missing)
+	}
+	state, err := repo.GetDigestState(ctx)
+	must(t, err)
+	if state != nil {
+		t.Errorf("GetDigestState before saving = %+v, want nil", state)
+	}
+	
+	// User names come from a proxy header and may contain path separators
+	subscription := &models.DigestSubscription{User: "org/carol", Email: "carol@example.com",
+		Sections: []string{models.DigestSectionOverdue}, Tags: map[string]string{"team": "payments"}, UpdatedAt: timestamp("2026-01-01T00:00:00Z")}
+	must(t, repo.SaveDigestSubscription(ctx, subscription))
+	
+	got, err := repo.GetDigestSubscription(ctx, "org/carol")
+	must(t, err)
+	assertSame(t, "GetDigestSubscription", subscription, got)
+	
+	list, err := repo.ListDigestSubscriptions(ctx)
+	must(t, err)
+	assertSame(t, "ListDigestSubscriptions", []*models.DigestSubscription{subscription}, list)
+	
+	must(t, repo.DeleteDigestSubscription(ctx, "org/carol"))
+	must(t, repo.DeleteDigestSubscription(ctx, "org/carol"))
+	got, err = repo.GetDigestSubscription(ctx, "org/carol")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetDigestSubscription after delete = %+v, want nil", got)
+	}
+	
+	wantState := &models.DigestState{LastSentAt: timestamp("2026-01-05T08:00:00Z")}
+	must(t, repo.SaveDigestState(ctx, wantState))
+	state, err = repo.GetDigestState(ctx)
+	must(t, err)
+	assertSame(t, "GetDigestState", wantState, state)
+}
+
+// Workshops verifies a workshop repository
+func Workshops(t *testing.T, repo storage.WorkshopRepository) {
+	ctx := context.Background()
+	
+	missing, err := repo.GetWorkshop(ctx, "missing")
+	must(t, err)
+	if missing != nil {
+		t.Errorf("GetWorkshop of a missing workshop = %+v, want nil", missing)
+	}
+	
+	workshop := &models.Workshop{AssessmentID: "a1", Facilitator: "carol", Status: models.WorkshopOpen,
+		QuestionIDs: []string{"q1", "q2"}, Current: 1, StartedAt: timestamp("2026-01-01T00:00:00Z"),
+		Votes: map[string]map[string]string{"q2": {"dave": "q2_a1"}},
+		Decisions: map[string]models.WorkshopDecision{"q1": {OptionID: "q1_a2", Votes: map[string]int{"q1_a2": 3, "q1_a1": 1},
+			Participants: 4, Agreement: 75, DecidedBy: "carol", DecidedAt: timestamp("2026-01-01T00:10:00Z")}}}
+	must(t, repo.SaveWorkshop(ctx, workshop))
+	
+	got, err := repo.GetWorkshop(ctx, "a1")
+	must(t, err)
+	assertSame(t, "GetWorkshop", workshop, got)
+	
+	list, err := repo.ListWorkshops(ctx)
+	must(t, err)
+	assertSame(t, "ListWorkshops", []*models.Workshop{workshop}, list)
+	
+	must(t, repo.DeleteWorkshop(ctx, "a1"))
+	must(t, repo.DeleteWorkshop(ctx, "a1"))
+	got, err = repo.GetWorkshop(ctx, "a1")
+	must(t, err)
+	if got != nil {
+		t.Errorf("GetWorkshop after delete = %+v, want nil", got)
+	}
+}
+
+// CanceledContext verifies that list operations give up with the context's error
+func CanceledContext(t *testing.T, store storage.Storage) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	
+	checks := map[string]func() error{
+		"ListApplications":    func() error { _, err := store.ListApplications(ctx); return err },
+		"ListAssessments":     func() error { _, err := store.ListAssessments(ctx, ""); return err },
+		"ListReports":         func() error { _, err := store.ListReports(ctx); return err },
+		"ListOutboxMessages":  func() error { _, err := store.ListOutboxMessages(ctx, ""); return err },
+		"ListJobs":            func() error { _, err := store.ListJobs(ctx); return err },
+		"ListCampaigns":       func() error { _, err := store.ListCampaigns(ctx); return err },
+		"ListCatalogReleases": func() error { _, err := store.ListCatalogReleases(ctx); return err },
+		"ListWorkshops":       func() error { _, err := store.ListWorkshops(ctx); return err },
+	}
+	for name, check := range checks {
+		if err := check(); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s with a canceled context returned %v, want context.Canceled", name, err)
+		}
+	}
+}
+
+// must stops the test on unexpected errors
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// assertSame compares entities by their JSON encoding, which is all a backend has to preserve
+func assertSame(t *testing.T, what string, want, got interface{}) {
+	t.Helper()
+	wantJSON, err := json.Marshal(want)
+	must(t, err)
+	gotJSON, err := json.Marshal(got)
+	must(t, err)
+	if string(wantJSON) != string(gotJSON) {
+		t.Errorf("%s:\n got %s\nwant %s", what, gotJSON, wantJSON)
+	}
+}
+
+// timestamp parses an RFC3339 timestamp of a fixture
+func timestamp(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// timestampRef parses an RFC3339 timestamp of a fixture for an optional field
+func timestampRef(value string) *time.Time {
+	t := timestamp(value)
+	return &t
+}